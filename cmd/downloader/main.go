@@ -57,6 +57,7 @@ func main() {
 
 var (
 	webseeds                       string
+	objectStores                   string
 	datadirCli, chain              string
 	filePath                       string
 	forceRebuild                   bool
@@ -83,6 +84,7 @@ func init() {
 	withDataDir(rootCmd)
 	rootCmd.Flags().StringVar(&chain, utils.ChainFlag.Name, utils.ChainFlag.Value, utils.ChainFlag.Usage)
 	rootCmd.Flags().StringVar(&webseeds, utils.WebSeedsFlag.Name, utils.WebSeedsFlag.Value, utils.WebSeedsFlag.Usage)
+	rootCmd.Flags().StringVar(&objectStores, utils.ObjectStoreFlag.Name, utils.ObjectStoreFlag.Value, utils.ObjectStoreFlag.Usage)
 	rootCmd.Flags().StringVar(&natSetting, "nat", utils.NATFlag.Value, utils.NATFlag.Usage)
 	rootCmd.Flags().StringVar(&downloaderApiAddr, "downloader.api.addr", "127.0.0.1:9093", "external downloader api network address, for example: 127.0.0.1:9093 serves remote downloader interface")
 	rootCmd.Flags().StringVar(&downloadRateStr, "torrent.download.rate", utils.TorrentDownloadRateFlag.Value, utils.TorrentDownloadRateFlag.Usage)
@@ -191,6 +193,7 @@ func Downloader(ctx context.Context, logger log.Logger) error {
 		return err
 	}
 
+	cfg.ObjectStores = common.CliString2Array(objectStores)
 	cfg.ClientConfig.PieceHashersPerTorrent = 32 * runtime.NumCPU()
 	cfg.ClientConfig.DisableIPv6 = disableIPV6
 	cfg.ClientConfig.DisableIPv4 = disableIPV4
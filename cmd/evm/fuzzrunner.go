@@ -0,0 +1,107 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/ledgerwatch/erigon-lib/kv/memdb"
+	"github.com/ledgerwatch/log/v3"
+	"github.com/urfave/cli/v2"
+
+	"github.com/ledgerwatch/erigon/tests"
+)
+
+var (
+	FuzzIterationsFlag = cli.IntFlag{
+		Name:  "iterations",
+		Usage: "number of generated state tests",
+		Value: 1000,
+	}
+	FuzzSeedFlag = cli.Int64Flag{
+		Name:  "seed",
+		Usage: "seed of the generator, current time if 0",
+	}
+	FuzzForkFlag = cli.StringFlag{
+		Name:  "fork",
+		Usage: "fork of the generated state tests",
+		Value: "Cancun",
+	}
+	FuzzOutputFlag = cli.StringFlag{
+		Name:  "output",
+		Usage: "directory for failing cases, which are exported as state tests",
+		Value: ".",
+	}
+)
+
+var fuzzCommand = cli.Command{
+	Action: fuzzCmd,
+	Name:   "fuzz",
+	Usage:  "executes generated state tests with variants of vm config and compares post-states",
+	Flags: []cli.Flag{
+		&FuzzIterationsFlag,
+		&FuzzSeedFlag,
+		&FuzzForkFlag,
+		&FuzzOutputFlag,
+	},
+}
+
+// fuzzMaxInputSize is the max size of random generator input, longer input is unused by the generator
+const fuzzMaxInputSize = 1024
+
+func fuzzCmd(ctx *cli.Context) error {
+	log.Root().SetHandler(log.LvlFilterHandler(log.LvlInfo, log.StderrHandler))
+	seed := ctx.Int64(FuzzSeedFlag.Name)
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	var (
+		iterations = ctx.Int(FuzzIterationsFlag.Name)
+		fork       = ctx.String(FuzzForkFlag.Name)
+		outDir     = ctx.String(FuzzOutputFlag.Name)
+		rnd        = rand.New(rand.NewSource(seed))
+		subtest    = tests.StateSubtest{Fork: fork}
+		mismatches int
+	)
+	db := memdb.New("")
+	defer db.Close()
+
+	log.Info("Fuzzing", "seed", seed, "iterations", iterations, "fork", fork)
+	for i := 0; i < iterations; i++ {
+		data := make([]byte, rnd.Intn(fuzzMaxInputSize))
+		rnd.Read(data)
+		test, err := tests.GenerateStateTest(data, fork)
+		if err != nil {
+			return err
+		}
+		results, diffErr := test.RunDifferential(db, subtest, tests.DifferentialVariants)
+		if diffErr == nil {
+			continue
+		}
+		if !errors.Is(diffErr, tests.ErrDifferentialMismatch) {
+			return fmt.Errorf("iteration %d: %w", i, diffErr)
+		}
+		mismatches++
+		// expected post-state is the one of the reference variant, so the case fails for the diverging variants
+		name := fmt.Sprintf("fuzz-%d-%d", seed, i)
+		test.SetPost(subtest, results[0].Root, results[0].Logs)
+		out, err := json.MarshalIndent(map[string]*tests.StateTest{name: test}, "", "  ")
+		if err != nil {
+			return err
+		}
+		path := filepath.Join(outDir, name+".json")
+		if err = os.WriteFile(path, out, 0644); err != nil {
+			return err
+		}
+		log.Warn("Post-state mismatch", "iteration", i, "case", path, "err", diffErr)
+	}
+	log.Info("Fuzzing done", "seed", seed, "iterations", iterations, "mismatches", mismatches)
+	if mismatches > 0 {
+		return fmt.Errorf("%d mismatches of post-state", mismatches)
+	}
+	return nil
+}
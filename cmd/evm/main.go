@@ -46,6 +46,20 @@ var (
 		Name:  "cpuprofile",
 		Usage: "creates a CPU profile at the given path",
 	}
+	GasProfileFlag = cli.StringFlag{
+		Name:  "gasprofile",
+		Usage: "creates an opcode gas profile at the given path",
+	}
+	GasProfileFormatFlag = cli.StringFlag{
+		Name:  "gasprofile.format",
+		Usage: "format of the gas profile: pprof, folded (flame graph stacks) or summary (json)",
+		Value: "pprof",
+	}
+	GasProfileWeightFlag = cli.StringFlag{
+		Name:  "gasprofile.weight",
+		Usage: "value of folded stacks of the gas profile: gas or time",
+		Value: "gas",
+	}
 	StatDumpFlag = cli.BoolFlag{
 		Name:  "statdump",
 		Usage: "displays stack and heap memory information",
@@ -170,6 +184,9 @@ func init() {
 		&InputFileFlag,
 		&MemProfileFlag,
 		&CPUProfileFlag,
+		&GasProfileFlag,
+		&GasProfileFormatFlag,
+		&GasProfileWeightFlag,
 		&StatDumpFlag,
 		&GenesisFlag,
 		&MachineFlag,
@@ -183,6 +200,7 @@ func init() {
 	app.Commands = []*cli.Command{
 		&compileCommand,
 		&disasmCommand,
+		&fuzzCommand,
 		&runCommand,
 		&stateTestCommand,
 		&stateTransitionCommand,
@@ -20,6 +20,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"math/big"
@@ -47,7 +48,9 @@ import (
 	"github.com/ledgerwatch/erigon/core/state"
 	"github.com/ledgerwatch/erigon/core/vm"
 	"github.com/ledgerwatch/erigon/core/vm/runtime"
+	"github.com/ledgerwatch/erigon/eth/tracers"
 	"github.com/ledgerwatch/erigon/eth/tracers/logger"
+	_ "github.com/ledgerwatch/erigon/eth/tracers/native"
 	"github.com/ledgerwatch/erigon/params"
 )
 
@@ -150,6 +153,22 @@ func runCmd(ctx *cli.Context) error {
 	} else {
 		debugLogger = logger.NewStructLogger(logconfig)
 	}
+	var gasProfiler tracers.Tracer
+	if ctx.String(GasProfileFlag.Name) != "" {
+		if tracer != nil {
+			return errors.New("--gasprofile can't be used together with --json or --debug")
+		}
+		cfg, err := json.Marshal(map[string]string{
+			"format": ctx.String(GasProfileFormatFlag.Name),
+			"weight": ctx.String(GasProfileWeightFlag.Name),
+		})
+		if err != nil {
+			return err
+		}
+		if gasProfiler, err = tracers.New("gasProfiler", &tracers.Context{}, cfg); err != nil {
+			return err
+		}
+	}
 	db := memdb.New("")
 	defer db.Close()
 	if ctx.String(GenesisFlag.Name) != "" {
@@ -242,6 +261,11 @@ func runCmd(ctx *cli.Context) error {
 		},
 	}
 
+	if gasProfiler != nil {
+		runtimeConfig.EVMConfig.Tracer = gasProfiler
+		runtimeConfig.EVMConfig.Debug = true
+	}
+
 	if cpuProfilePath := ctx.String(CPUProfileFlag.Name); cpuProfilePath != "" {
 		f, err := os.Create(cpuProfilePath)
 		if err != nil {
@@ -324,6 +348,13 @@ func runCmd(ctx *cli.Context) error {
 		}
 	}
 
+	if gasProfilePath := ctx.String(GasProfileFlag.Name); gasProfilePath != "" {
+		if err := writeGasProfile(gasProfilePath, ctx.String(GasProfileFormatFlag.Name), gasProfiler); err != nil {
+			fmt.Println("could not write gas profile: ", err)
+			os.Exit(1)
+		}
+	}
+
 	if ctx.Bool(DebugFlag.Name) {
 		if debugLogger != nil {
 			_, printErr := fmt.Fprintln(os.Stderr, "#### TRACE ####")
@@ -358,3 +389,27 @@ allocated bytes: %d
 
 	return nil
 }
+
+// writeGasProfile writes the result of the gas profiler to the file: pprof profile and folded stacks
+// are unwrapped from their json encoding, so they can be passed to `go tool pprof` and flamegraph.pl
+func writeGasProfile(path, format string, profiler tracers.Tracer) error {
+	res, err := profiler.GetResult()
+	if err != nil {
+		return err
+	}
+	var data []byte
+	switch format {
+	case "pprof":
+		err = json.Unmarshal(res, &data)
+	case "folded":
+		var folded string
+		err = json.Unmarshal(res, &folded)
+		data = []byte(folded)
+	default:
+		data = res
+	}
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
@@ -0,0 +1,74 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	common2 "github.com/ledgerwatch/erigon-lib/common"
+	"github.com/ledgerwatch/erigon-lib/common/datadir"
+	"github.com/ledgerwatch/erigon-lib/kv"
+	"github.com/spf13/cobra"
+
+	"github.com/ledgerwatch/erigon/turbo/backup"
+	"github.com/ledgerwatch/erigon/turbo/debug"
+)
+
+var backupDir string
+
+var cmdBackup = &cobra.Command{
+	Use:   "backup",
+	Short: "online backup of '--datadir' (node may run) to '--backup.dir': consistent copy of chaindata, links of snapshot files and manifest",
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx, _ := common2.RootContext()
+		logger := debug.SetupCobra(cmd, "integration")
+		if err := os.MkdirAll(filepath.Join(backupDir, backup.ChaindataDirName), 0755); err != nil {
+			logger.Error(err.Error())
+			return
+		}
+		from, to := backup.OpenPair(chaindata, filepath.Join(backupDir, backup.ChaindataDirName), kv.ChainDB, 0, logger)
+		defer from.Close()
+		defer to.Close()
+		if _, err := backup.Online(ctx, from, to, datadir.New(datadirCli), backupDir, backup.ReadAheadThreads/8, logger); err != nil {
+			if !errors.Is(err, context.Canceled) {
+				logger.Error(err.Error())
+			}
+			return
+		}
+	},
+}
+
+var cmdRestore = &cobra.Command{
+	Use:   "restore",
+	Short: "validate backup in '--backup.dir' by its manifest, then replace chaindata and snapshots of '--datadir' (node must be stopped)",
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx, _ := common2.RootContext()
+		logger := debug.SetupCobra(cmd, "integration")
+		m, err := backup.Restore(ctx, backupDir, datadir.New(datadirCli), logger)
+		if err != nil {
+			if !errors.Is(err, context.Canceled) {
+				logger.Error(err.Error())
+			}
+			return
+		}
+		fmt.Printf("restored backup of %s, stage progress: %v\n", m.CreatedAt, m.Stages)
+	},
+}
+
+func withBackupDir(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&backupDir, "backup.dir", "", "dir of backup: chaindata, snapshots and manifest.json")
+	must(cmd.MarkFlagRequired("backup.dir"))
+	must(cmd.MarkFlagDirname("backup.dir"))
+}
+
+func init() {
+	withDataDir(cmdBackup)
+	withBackupDir(cmdBackup)
+	rootCmd.AddCommand(cmdBackup)
+
+	withDataDir(cmdRestore)
+	withBackupDir(cmdRestore)
+	rootCmd.AddCommand(cmdRestore)
+}
@@ -0,0 +1,106 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"github.com/c2h5oh/datasize"
+	common2 "github.com/ledgerwatch/erigon-lib/common"
+	"github.com/ledgerwatch/erigon-lib/kv"
+	"github.com/ledgerwatch/erigon-lib/kv/mdbx"
+	"github.com/spf13/cobra"
+
+	"github.com/ledgerwatch/erigon/diagnostics"
+	"github.com/ledgerwatch/erigon/eth/stagedsync/stages"
+	"github.com/ledgerwatch/erigon/turbo/debug"
+)
+
+var dbSizeGrowth time.Duration
+
+var cmdDbSize = &cobra.Command{
+	Use:   "db_size",
+	Short: "Size of chaindata tables (pages, entries), free-list and reclaimable space. With --growth: growth of tables by samples stored by node (--diagnostics.dbsize.interval)",
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx, _ := common2.RootContext()
+		logger := debug.SetupCobra(cmd, "integration")
+		db := dbCfg(kv.ChainDB, chaindata).Readonly().MustOpen()
+		defer db.Close()
+		report, err := diagnostics.DbSizeReport(ctx, db)
+		if err != nil {
+			logger.Error(err.Error())
+			return
+		}
+		if bucket != "" {
+			t := report.Table(bucket)
+			if t == nil {
+				logger.Error("table not found", "table", bucket)
+				return
+			}
+			report.Tables = []mdbx.TableSize{*t}
+		}
+		printDbSize(report)
+		if dbSizeGrowth == 0 {
+			return
+		}
+		samples, err := diagnostics.NewDbSizeHistory(datadirCli).Read(bucket, time.Now().Add(-dbSizeGrowth))
+		if err != nil {
+			logger.Error(err.Error())
+			return
+		}
+		if len(samples) == 0 {
+			fmt.Printf("\nno samples for last %s\n", dbSizeGrowth)
+			return
+		}
+		printDbGrowth(samples[0], report)
+	},
+}
+
+func printDbSize(r *mdbx.SizeReport) {
+	sort.Slice(r.Tables, func(i, j int) bool { return r.Tables[i].Size > r.Tables[j].Size })
+	w := tabwriter.NewWriter(os.Stdout, 8, 8, 1, ' ', tabwriter.AlignRight)
+	defer w.Flush()
+	fmt.Fprintf(w, "table\t size\t entries\t leaf\t branch\t overflow\t\n")
+	for _, t := range r.Tables {
+		fmt.Fprintf(w, "%s\t %s\t %d\t %d\t %d\t %d\t\n", t.Name, datasize.ByteSize(t.Size).HR(), t.Entries, t.LeafPages, t.BranchPages, t.OverflowPages)
+	}
+	fmt.Fprintf(w, "\nfile size: %s, used: %s, free-list: %s (%d pages), reclaimable: %s, txId: %d\n",
+		datasize.ByteSize(r.FileSize).HR(), datasize.ByteSize(r.UsedSize).HR(), datasize.ByteSize(r.FreeSize).HR(), r.FreePages, datasize.ByteSize(r.Reclaimable).HR(), r.TxID)
+}
+
+func printDbGrowth(from, to *mdbx.SizeReport) {
+	w := tabwriter.NewWriter(os.Stdout, 8, 8, 1, ' ', tabwriter.AlignRight)
+	defer w.Flush()
+	fmt.Fprintf(w, "\ngrowth since %s\n", from.Time.Format(time.RFC3339))
+	fmt.Fprintf(w, "table\t size\t entries\t\n")
+	for _, t := range to.Tables {
+		var size, entries int64 = int64(t.Size), int64(t.Entries)
+		if prev := from.Table(t.Name); prev != nil {
+			size, entries = size-int64(prev.Size), entries-int64(prev.Entries)
+		}
+		if size == 0 && entries == 0 {
+			continue
+		}
+		sign := "+"
+		if size < 0 {
+			sign, size = "-", -size
+		}
+		fmt.Fprintf(w, "%s\t %s%s\t %+d\t\n", t.Name, sign, datasize.ByteSize(size).HR(), entries)
+	}
+	fmt.Fprintf(w, "\nstage\t progress\t\n")
+	for _, stage := range stages.AllStages {
+		progress, prev := to.Stages[string(stage)], from.Stages[string(stage)]
+		if prev != progress {
+			fmt.Fprintf(w, "%s\t %+d\t\n", stage, int64(progress)-int64(prev))
+		}
+	}
+}
+
+func init() {
+	withDataDir(cmdDbSize)
+	withBucket(cmdDbSize)
+	cmdDbSize.Flags().DurationVar(&dbSizeGrowth, "growth", 0, "print growth of tables and progress of stages for this period, e.g. 24h")
+	rootCmd.AddCommand(cmdDbSize)
+}
@@ -95,6 +95,19 @@ func withPruneTo(cmd *cobra.Command) {
 	cmd.Flags().Uint64Var(&pruneTo, "prune.to", 0, "how much blocks unwind on each iteration")
 }
 
+func withPrune(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&pruneFlag, "prune", "hrtc", "")
+	cmd.Flags().Uint64Var(&pruneH, "prune.h.older", 0, "")
+	cmd.Flags().Uint64Var(&pruneR, "prune.r.older", 0, "")
+	cmd.Flags().Uint64Var(&pruneT, "prune.t.older", 0, "")
+	cmd.Flags().Uint64Var(&pruneC, "prune.c.older", 0, "")
+	cmd.Flags().Uint64Var(&pruneHBefore, "prune.h.before", 0, "")
+	cmd.Flags().Uint64Var(&pruneRBefore, "prune.r.before", 0, "")
+	cmd.Flags().Uint64Var(&pruneTBefore, "prune.t.before", 0, "")
+	cmd.Flags().Uint64Var(&pruneCBefore, "prune.c.before", 0, "")
+	cmd.Flags().StringSliceVar(&experiments, "experiments", nil, "Storage mode to override database")
+}
+
 func withUnwindEvery(cmd *cobra.Command) {
 	cmd.Flags().Uint64Var(&unwindEvery, "unwind.every", 0, "each iteration test will move forward `--unwind.every` blocks, then unwind `--unwind` blocks")
 }
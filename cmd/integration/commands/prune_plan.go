@@ -0,0 +1,85 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/c2h5oh/datasize"
+	common2 "github.com/ledgerwatch/erigon-lib/common"
+	"github.com/ledgerwatch/erigon-lib/kv"
+	"github.com/spf13/cobra"
+
+	"github.com/ledgerwatch/erigon/cmd/hack/tool/fromdb"
+	"github.com/ledgerwatch/erigon/eth/stagedsync"
+	"github.com/ledgerwatch/erigon/ethdb/prune"
+	"github.com/ledgerwatch/erigon/turbo/debug"
+)
+
+var cmdPrunePlan = &cobra.Command{
+	Use:   "prune_plan",
+	Short: "Dry-run of --prune flags: what stages would delete from each table, how much space it frees and which RPC methods lose history. Doesn't modify db",
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx, _ := common2.RootContext()
+		logger := debug.SetupCobra(cmd, "integration")
+		db := dbCfg(kv.ChainDB, chaindata).Readonly().MustOpen()
+		defer db.Close()
+		pm, err := prune.FromCli(fromdb.ChainConfig(db).ChainID.Uint64(), pruneFlag, pruneH, pruneR, pruneT, pruneC,
+			pruneHBefore, pruneRBefore, pruneTBefore, pruneCBefore, experiments)
+		if err != nil {
+			logger.Error(err.Error())
+			return
+		}
+		var plan *stagedsync.PrunePlan
+		if err := db.View(ctx, func(tx kv.Tx) (err error) {
+			plan, err = stagedsync.PlanPrune(ctx, tx, pm)
+			return err
+		}); err != nil {
+			if !errors.Is(err, context.Canceled) {
+				logger.Error(err.Error())
+			}
+			return
+		}
+		printPrunePlan(plan)
+	},
+}
+
+func printPrunePlan(plan *stagedsync.PrunePlan) {
+	w := tabwriter.NewWriter(os.Stdout, 8, 8, 1, ' ', 0)
+	defer w.Flush()
+	fmt.Fprintf(w, "current:  %s\nproposed: %s\n", plan.Current.String(), plan.Proposed.String())
+	if plan.Refused {
+		fmt.Fprintf(w, "WARNING: node will not start with proposed flags (prune mode can't be changed), apply it by `integration force_set_prune` first\n")
+	}
+	if plan.HistoryV3 {
+		fmt.Fprintf(w, "NOTE: history.v3 - history, receipts and call traces are not pruned by --prune flags, only tx index is\n")
+	}
+
+	fmt.Fprintf(w, "\nrule\tstage\ttable\tprune_to\tentries\tdelete\treclaim\t\n")
+	for _, t := range plan.Tables {
+		approx := ""
+		if !t.Exact {
+			approx = "~"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%d\t%s%d\t%s%s\t\n", t.Rule, t.Stage, t.Table, t.PruneTo, t.Entries, approx, t.Delete, approx, datasize.ByteSize(t.Reclaim).HR())
+	}
+	fmt.Fprintf(w, "\nreclaim: ~%s (pages go to free-list and are re-used by db, file doesn't shrink)\n", datasize.ByteSize(plan.Reclaim).HR())
+
+	if len(plan.Coverage) == 0 {
+		fmt.Fprintf(w, "\nRPC: no methods lose history\n")
+		return
+	}
+	fmt.Fprintf(w, "\nRPC methods which lose history:\n")
+	for _, c := range plan.Coverage {
+		fmt.Fprintf(w, "  --prune=%s: blocks [%d, %d) - %s\n", c.Rule, c.PrevOldestBlock, c.OldestBlock, strings.Join(c.Methods, ", "))
+	}
+}
+
+func init() {
+	withDataDir(cmdPrunePlan)
+	withPrune(cmdPrunePlan)
+	rootCmd.AddCommand(cmdPrunePlan)
+}
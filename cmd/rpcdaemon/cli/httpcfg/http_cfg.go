@@ -37,8 +37,9 @@ type HttpCfg struct {
 	HttpsCertfile      string
 	HttpsKeyFile       string
 
-	AuthRpcPort    int
-	PrivateApiAddr string
+	AuthRpcPort     int
+	PrivateApiAddr  string
+	PrivateApiToken string // sent as `authorization: Bearer <token>`, see --private.api.acl of Erigon
 
 	API                  []string
 	Gascap               uint64
@@ -74,10 +75,11 @@ type HttpCfg struct {
 	LogDirVerbosity string
 	LogDirPath      string
 
-	BatchLimit                  int  // Maximum number of requests in a batch
-	ReturnDataLimit             int  // Maximum number of bytes returned from calls (like eth_call)
-	AllowUnprotectedTxs         bool // Whether to allow non EIP-155 protected transactions  txs over RPC
-	MaxGetProofRewindBlockCount int  //Max GetProof rewind block count
+	BatchLimit                  int    // Maximum number of requests in a batch
+	ReturnDataLimit             int    // Maximum number of bytes returned from calls (like eth_call)
+	AllowUnprotectedTxs         bool   // Whether to allow non EIP-155 protected transactions  txs over RPC
+	MaxGetProofRewindBlockCount int    //Max GetProof rewind block count
+	GetProofRecomputeLimit      uint64 // Max number of state items traversed to re-compute the commitment for GetProof, 0 - disabled
 	// Ots API
 	OtsMaxPageSize uint64
 
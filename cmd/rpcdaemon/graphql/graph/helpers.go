@@ -4,15 +4,21 @@ import (
 	"encoding/hex"
 	"fmt"
 	hexutil2 "github.com/ledgerwatch/erigon-lib/common/hexutil"
+	"math/big"
 	"reflect"
 	"strconv"
+	"strings"
 
 	"github.com/holiman/uint256"
 
 	libcommon "github.com/ledgerwatch/erigon-lib/common"
 	"github.com/ledgerwatch/erigon-lib/common/hexutility"
+	types2 "github.com/ledgerwatch/erigon-lib/types"
 
+	"github.com/ledgerwatch/erigon/cmd/rpcdaemon/graphql/graph/model"
 	"github.com/ledgerwatch/erigon/core/types"
+	"github.com/ledgerwatch/erigon/rpc"
+	"github.com/ledgerwatch/erigon/turbo/adapter/ethapi"
 )
 
 func convertDataToStringP(abstractMap map[string]interface{}, field string) *string {
@@ -134,3 +140,245 @@ func convertStrHexToDec(hexString *string) *string {
 
 	return &result
 }
+
+// convertBlockDetails converts the block details returned by the GraphQL API into the block model.
+func convertBlockDetails(res map[string]interface{}) *model.Block {
+	block := &model.Block{}
+	absBlk := res["block"]
+
+	if absBlk != nil {
+		blk := absBlk.(map[string]interface{})
+
+		block.Difficulty = *convertDataToStringP(blk, "difficulty")
+		block.ExtraData = *convertDataToStringP(blk, "extraData")
+		block.GasLimit = uint64(*convertDataToUint64P(blk, "gasLimit"))
+		block.GasUsed = *convertDataToUint64P(blk, "gasUsed")
+		block.Hash = *convertDataToStringP(blk, "hash")
+		block.Number = *convertDataToUint64P(blk, "number")
+		blockNrOrHash := rpc.BlockNumberOrHashWithNumber(rpc.BlockNumber(block.Number))
+		block.Miner = &model.Account{Block: blockNrOrHash}
+		address := convertDataToStringP(blk, "miner")
+		if address != nil {
+			block.Miner.Address = strings.ToLower(*address)
+		}
+		mixHash := convertDataToStringP(blk, "mixHash")
+		if mixHash != nil {
+			block.MixHash = *mixHash
+		}
+		blockNonce := convertDataToStringP(blk, "nonce")
+		if blockNonce != nil {
+			block.Nonce = *blockNonce
+		}
+		block.Ommers = []*model.Block{}
+		block.Parent = &model.Block{}
+		block.Parent.Hash = *convertDataToStringP(blk, "parentHash")
+		block.ReceiptsRoot = *convertDataToStringP(blk, "receiptsRoot")
+		block.StateRoot = *convertDataToStringP(blk, "stateRoot")
+		block.Timestamp = *convertDataToStringP(blk, "timestamp")
+		block.TransactionCount = convertDataToIntP(blk, "transactionCount")
+		block.TransactionsRoot = *convertDataToStringP(blk, "transactionsRoot")
+		block.TotalDifficulty = *convertDataToStringP(blk, "totalDifficulty")
+		block.Transactions = convertTransactions(res, blockNrOrHash)
+		for _, trans := range block.Transactions {
+			trans.Block = block
+		}
+
+		block.LogsBloom = "0x" + *convertDataToStringP(blk, "logsBloom")
+		block.OmmerHash = *convertDataToStringP(blk, "sha3Uncles")
+	}
+
+	return block
+}
+
+// convertTransactions converts the transactions, with their receipt fields if any, returned by
+// the GraphQL API into the transaction models. Accounts are resolved at the given block.
+func convertTransactions(res map[string]interface{}, blockNrOrHash rpc.BlockNumberOrHash) []*model.Transaction {
+	transactions := []*model.Transaction{}
+
+	absRcp := res["receipts"]
+	rcp := absRcp.([]map[string]interface{})
+	for _, transReceipt := range rcp {
+		trans := &model.Transaction{}
+		trans.InputData = *convertDataToStringP(transReceipt, "data")
+		trans.GasPrice = *convertDataToStringP(transReceipt, "gasPrice")
+		trans.MaxFeePerGas = convertOptionalDataToStringP(transReceipt, "maxFeePerGas")
+		trans.MaxPriorityFeePerGas = convertOptionalDataToStringP(transReceipt, "maxPriorityFeePerGas")
+		trans.EffectiveTip = convertOptionalDataToStringP(transReceipt, "effectiveTip")
+		trans.Gas = *convertDataToUint64P(transReceipt, "gas")
+		trans.Hash = *convertDataToStringP(transReceipt, "transactionHash")
+		trans.Index = convertDataToIntP(transReceipt, "transactionIndex")
+		transNonce := convertDataToStringP(transReceipt, "nonce")
+		if transNonce != nil {
+			trans.Nonce = *transNonce
+		}
+		trans.Type = convertDataToIntP(transReceipt, "type")
+		trans.Value = *convertDataToStringP(transReceipt, "value")
+		trans.R = *convertDataToStringP(transReceipt, "r")
+		trans.S = *convertDataToStringP(transReceipt, "s")
+		trans.V = *convertDataToStringP(transReceipt, "v")
+		trans.Raw = *convertDataToStringP(transReceipt, "raw")
+		for _, tuple := range transReceipt["accessList"].(types2.AccessList) {
+			accessTuple := &model.AccessTuple{Address: strings.ToLower(tuple.Address.String()), StorageKeys: []string{}}
+			for _, key := range tuple.StorageKeys {
+				accessTuple.StorageKeys = append(accessTuple.StorageKeys, key.String())
+			}
+			trans.AccessList = append(trans.AccessList, accessTuple)
+		}
+
+		// Receipt fields are not available for pending transactions
+		if _, ok := transReceipt["status"]; ok {
+			trans.CumulativeGasUsed = convertDataToUint64P(transReceipt, "cumulativeGasUsed")
+			trans.EffectiveGasPrice = convertDataToStringP(transReceipt, "effectiveGasPrice")
+			trans.GasUsed = convertDataToUint64P(transReceipt, "gasUsed")
+			trans.Status = convertDataToUint64P(transReceipt, "status")
+			trans.RawReceipt = *convertDataToStringP(transReceipt, "rawReceipt")
+			if contract, ok := transReceipt["contractAddress"].(libcommon.Address); ok {
+				trans.CreatedContract = &model.Account{Address: strings.ToLower(contract.String()), Block: blockNrOrHash}
+			}
+
+			trans.Logs = make([]*model.Log, 0)
+			for _, rlog := range transReceipt["logs"].(types.Logs) {
+				tlog := model.Log{
+					Index:       int(rlog.Index),
+					Data:        "0x" + hex.EncodeToString(rlog.Data),
+					Transaction: trans,
+				}
+				tlog.Account = &model.Account{Block: blockNrOrHash}
+				tlog.Account.Address = strings.ToLower(rlog.Address.String())
+
+				for _, rtopic := range rlog.Topics {
+					tlog.Topics = append(tlog.Topics, rtopic.String())
+				}
+
+				trans.Logs = append(trans.Logs, &tlog)
+			}
+		}
+
+		trans.From = &model.Account{Block: blockNrOrHash}
+		trans.From.Address = strings.ToLower(*convertDataToStringP(transReceipt, "from"))
+
+		// To address could be nil in case of contract creation
+		address := convertDataToStringP(transReceipt, "to")
+		if address != nil {
+			trans.To = &model.Account{Block: blockNrOrHash}
+			trans.To.Address = strings.ToLower(*address)
+		}
+
+		transactions = append(transactions, trans)
+	}
+
+	return transactions
+}
+
+// convertOptionalDataToStringP is convertDataToStringP for the fields which may be missing.
+func convertOptionalDataToStringP(abstractMap map[string]interface{}, field string) *string {
+	if _, ok := abstractMap[field]; !ok {
+		return nil
+	}
+	return convertDataToStringP(abstractMap, field)
+}
+
+// convertStrToBig parses a BigInt scalar, given either in decimal or 0x-prefixed hexadecimal.
+func convertStrToBig(s string) (*hexutil2.Big, error) {
+	if strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X") {
+		v, err := hexutil2.DecodeBig(s)
+		return (*hexutil2.Big)(v), err
+	}
+	v, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		return nil, fmt.Errorf("invalid BigInt %q", s)
+	}
+	return (*hexutil2.Big)(v), nil
+}
+
+// convertCallData converts the CallData input into call arguments.
+func convertCallData(data model.CallData) (ethapi.CallArgs, error) {
+	var args ethapi.CallArgs
+	if data.From != nil {
+		from := libcommon.HexToAddress(*data.From)
+		args.From = &from
+	}
+	if data.To != nil {
+		to := libcommon.HexToAddress(*data.To)
+		args.To = &to
+	}
+	if data.Gas != nil {
+		args.Gas = (*hexutil2.Uint64)(data.Gas)
+	}
+	for _, field := range []struct {
+		value *string
+		arg   **hexutil2.Big
+	}{
+		{data.GasPrice, &args.GasPrice},
+		{data.MaxFeePerGas, &args.MaxFeePerGas},
+		{data.MaxPriorityFeePerGas, &args.MaxPriorityFeePerGas},
+		{data.Value, &args.Value},
+	} {
+		if field.value == nil {
+			continue
+		}
+		v, err := convertStrToBig(*field.value)
+		if err != nil {
+			return args, err
+		}
+		*field.arg = v
+	}
+	if data.Data != nil {
+		input, err := hexutil2.Decode(*data.Data)
+		if err != nil {
+			return args, err
+		}
+		args.Data = (*hexutility.Bytes)(&input)
+	}
+	return args, nil
+}
+
+// convertCallResult converts the call result returned by the GraphQL API into the CallResult model.
+func convertCallResult(res map[string]interface{}) *model.CallResult {
+	if res == nil {
+		return nil
+	}
+	return &model.CallResult{
+		Data:    *convertDataToStringP(res, "data"),
+		GasUsed: *convertDataToUint64P(res, "gasUsed"),
+		Status:  *convertDataToUint64P(res, "status"),
+	}
+}
+
+// filterLogs returns the logs emitted by any of the addresses, and matching the topics with the
+// same semantics as eth_getLogs. Empty addresses or topics match any log.
+func filterLogs(logs []*model.Log, addresses []string, topics [][]string) []*model.Log {
+	filtered := make([]*model.Log, 0, len(logs))
+Logs:
+	for _, log := range logs {
+		if len(addresses) > 0 {
+			found := false
+			for _, address := range addresses {
+				if libcommon.HexToAddress(address) == libcommon.HexToAddress(log.Account.Address) {
+					found = true
+					break
+				}
+			}
+			if !found {
+				continue
+			}
+		}
+		if len(topics) > len(log.Topics) {
+			continue
+		}
+		for i, sub := range topics {
+			match := len(sub) == 0 // empty rule set == wildcard
+			for _, topic := range sub {
+				if libcommon.HexToHash(topic) == libcommon.HexToHash(log.Topics[i]) {
+					match = true
+					break
+				}
+			}
+			if !match {
+				continue Logs
+			}
+		}
+		filtered = append(filtered, log)
+	}
+	return filtered
+}
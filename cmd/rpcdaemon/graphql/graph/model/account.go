@@ -0,0 +1,27 @@
+package model
+
+import (
+	"sync"
+
+	"github.com/ledgerwatch/erigon/rpc"
+)
+
+// Account is an Ethereum account at a particular block. The account state is resolved on the
+// first access to any of its fields, and shared by all of them.
+type Account struct {
+	Address string `json:"address"`
+	// Block is the block at the end of which the account state is read.
+	Block rpc.BlockNumberOrHash `json:"-"`
+
+	once  sync.Once
+	state map[string]interface{}
+	err   error
+}
+
+// State returns the account state, reading it with the given function on first use.
+func (a *Account) State(read func() (map[string]interface{}, error)) (map[string]interface{}, error) {
+	a.once.Do(func() {
+		a.state, a.err = read()
+	})
+	return a.state, a.err
+}
@@ -7,14 +7,6 @@ type AccessTuple struct {
 	StorageKeys []string `json:"storageKeys"`
 }
 
-type Account struct {
-	Address          string `json:"address"`
-	Balance          string `json:"balance"`
-	TransactionCount uint64 `json:"transactionCount"`
-	Code             string `json:"code"`
-	Storage          string `json:"storage"`
-}
-
 type Block struct {
 	Number            uint64         `json:"number"`
 	Hash              string         `json:"hash"`
@@ -41,10 +33,6 @@ type Block struct {
 	OmmerHash         string         `json:"ommerHash"`
 	Transactions      []*Transaction `json:"transactions,omitempty"`
 	TransactionAt     *Transaction   `json:"transactionAt,omitempty"`
-	Logs              []*Log         `json:"logs"`
-	Account           *Account       `json:"account"`
-	Call              *CallResult    `json:"call,omitempty"`
-	EstimateGas       uint64         `json:"estimateGas"`
 	RawHeader         string         `json:"rawHeader"`
 	Raw               string         `json:"raw"`
 }
@@ -89,9 +77,6 @@ type Log struct {
 type Pending struct {
 	TransactionCount int            `json:"transactionCount"`
 	Transactions     []*Transaction `json:"transactions,omitempty"`
-	Account          *Account       `json:"account"`
-	Call             *CallResult    `json:"call,omitempty"`
-	EstimateGas      uint64         `json:"estimateGas"`
 }
 
 type SyncState struct {
@@ -1,7 +1,13 @@
 package graph
 
 import (
+	"context"
+
+	libcommon "github.com/ledgerwatch/erigon-lib/common"
 	"github.com/ledgerwatch/erigon-lib/kv"
+
+	"github.com/ledgerwatch/erigon/cmd/rpcdaemon/graphql/graph/model"
+	"github.com/ledgerwatch/erigon/rpc"
 	"github.com/ledgerwatch/erigon/turbo/jsonrpc"
 	"github.com/ledgerwatch/erigon/turbo/rpchelper"
 	"github.com/ledgerwatch/erigon/turbo/services"
@@ -17,3 +23,32 @@ type Resolver struct {
 	filters     *rpchelper.Filters
 	blockReader services.FullBlockReader
 }
+
+// state returns the balance, nonce and code of the account, which are read together once for
+// all the fields of the account.
+func (r *Resolver) state(ctx context.Context, obj *model.Account) (map[string]interface{}, error) {
+	return obj.State(func() (map[string]interface{}, error) {
+		return r.GraphQLAPI.GetAccount(ctx, libcommon.HexToAddress(obj.Address), obj.Block)
+	})
+}
+
+func (r *Resolver) call(ctx context.Context, data model.CallData, blockNrOrHash rpc.BlockNumberOrHash) (*model.CallResult, error) {
+	args, err := convertCallData(data)
+	if err != nil {
+		return nil, err
+	}
+	res, err := r.GraphQLAPI.Call(ctx, args, blockNrOrHash)
+	if err != nil {
+		return nil, err
+	}
+	return convertCallResult(res), nil
+}
+
+func (r *Resolver) estimateGas(ctx context.Context, data model.CallData, blockNrOrHash rpc.BlockNumberOrHash) (uint64, error) {
+	args, err := convertCallData(data)
+	if err != nil {
+		return 0, err
+	}
+	gas, err := r.GraphQLAPI.EstimateGas(ctx, args, blockNrOrHash)
+	return uint64(gas), err
+}
@@ -6,22 +6,104 @@ package graph
 
 import (
 	"context"
-	"encoding/hex"
 	"fmt"
+	"math/big"
 	"strconv"
 	"strings"
 
+	libcommon "github.com/ledgerwatch/erigon-lib/common"
 	"github.com/ledgerwatch/erigon-lib/common/hexutil"
 	"github.com/ledgerwatch/erigon/cmd/rpcdaemon/graphql/graph/model"
-	"github.com/ledgerwatch/erigon/core/types"
+	"github.com/ledgerwatch/erigon/eth/filters"
 	"github.com/ledgerwatch/erigon/rpc"
 )
 
+// Balance is the resolver for the balance field.
+func (r *accountResolver) Balance(ctx context.Context, obj *model.Account) (string, error) {
+	state, err := r.state(ctx, obj)
+	if err != nil {
+		return "", err
+	}
+	return *convertDataToStringP(state, "balance"), nil
+}
+
+// TransactionCount is the resolver for the transactionCount field.
+func (r *accountResolver) TransactionCount(ctx context.Context, obj *model.Account) (uint64, error) {
+	state, err := r.state(ctx, obj)
+	if err != nil {
+		return 0, err
+	}
+	return *convertDataToUint64P(state, "nonce"), nil
+}
+
+// Code is the resolver for the code field.
+func (r *accountResolver) Code(ctx context.Context, obj *model.Account) (string, error) {
+	state, err := r.state(ctx, obj)
+	if err != nil {
+		return "", err
+	}
+	return *convertDataToStringP(state, "code"), nil
+}
+
+// Storage is the resolver for the storage field.
+func (r *accountResolver) Storage(ctx context.Context, obj *model.Account, slot string) (string, error) {
+	value, err := r.GraphQLAPI.GetStorageAt(ctx, libcommon.HexToAddress(obj.Address), libcommon.HexToHash(slot), obj.Block)
+	if err != nil {
+		return "", err
+	}
+	return value.String(), nil
+}
+
+// Logs is the resolver for the logs field.
+func (r *blockResolver) Logs(ctx context.Context, obj *model.Block, filter model.BlockFilterCriteria) ([]*model.Log, error) {
+	logs := []*model.Log{}
+	for _, trans := range obj.Transactions {
+		logs = append(logs, filterLogs(trans.Logs, filter.Addresses, filter.Topics)...)
+	}
+	return logs, nil
+}
+
+// Account is the resolver for the account field.
+func (r *blockResolver) Account(ctx context.Context, obj *model.Block, address string) (*model.Account, error) {
+	return &model.Account{
+		Address: strings.ToLower(address),
+		Block:   rpc.BlockNumberOrHashWithNumber(rpc.BlockNumber(obj.Number)),
+	}, nil
+}
+
+// Call is the resolver for the call field.
+func (r *blockResolver) Call(ctx context.Context, obj *model.Block, data model.CallData) (*model.CallResult, error) {
+	return r.call(ctx, data, rpc.BlockNumberOrHashWithNumber(rpc.BlockNumber(obj.Number)))
+}
+
+// EstimateGas is the resolver for the estimateGas field.
+func (r *blockResolver) EstimateGas(ctx context.Context, obj *model.Block, data model.CallData) (uint64, error) {
+	return r.estimateGas(ctx, data, rpc.BlockNumberOrHashWithNumber(rpc.BlockNumber(obj.Number)))
+}
+
 // SendRawTransaction is the resolver for the sendRawTransaction field.
 func (r *mutationResolver) SendRawTransaction(ctx context.Context, data string) (string, error) {
 	panic(fmt.Errorf("not implemented: SendRawTransaction - sendRawTransaction"))
 }
 
+// Account is the resolver for the account field.
+func (r *pendingResolver) Account(ctx context.Context, obj *model.Pending, address string) (*model.Account, error) {
+	return &model.Account{
+		Address: strings.ToLower(address),
+		Block:   rpc.BlockNumberOrHashWithNumber(rpc.PendingBlockNumber),
+	}, nil
+}
+
+// Call is the resolver for the call field.
+func (r *pendingResolver) Call(ctx context.Context, obj *model.Pending, data model.CallData) (*model.CallResult, error) {
+	return r.call(ctx, data, rpc.BlockNumberOrHashWithNumber(rpc.PendingBlockNumber))
+}
+
+// EstimateGas is the resolver for the estimateGas field.
+func (r *pendingResolver) EstimateGas(ctx context.Context, obj *model.Pending, data model.CallData) (uint64, error) {
+	return r.estimateGas(ctx, data, rpc.BlockNumberOrHashWithNumber(rpc.PendingBlockNumber))
+}
+
 // Block is the resolver for the block field.
 func (r *queryResolver) Block(ctx context.Context, number *string, hash *string) (*model.Block, error) {
 	var blockNumber rpc.BlockNumber
@@ -68,93 +150,7 @@ func (r *queryResolver) Block(ctx context.Context, number *string, hash *string)
 		return nil, err
 	}
 
-	block := &model.Block{}
-	absBlk := res["block"]
-
-	if absBlk != nil {
-		blk := absBlk.(map[string]interface{})
-
-		block.Difficulty = *convertDataToStringP(blk, "difficulty")
-		block.ExtraData = *convertDataToStringP(blk, "extraData")
-		block.GasLimit = uint64(*convertDataToUint64P(blk, "gasLimit"))
-		block.GasUsed = *convertDataToUint64P(blk, "gasUsed")
-		block.Hash = *convertDataToStringP(blk, "hash")
-		block.Miner = &model.Account{}
-		address := convertDataToStringP(blk, "miner")
-		if address != nil {
-			block.Miner.Address = strings.ToLower(*address)
-		}
-		mixHash := convertDataToStringP(blk, "mixHash")
-		if mixHash != nil {
-			block.MixHash = *mixHash
-		}
-		blockNonce := convertDataToStringP(blk, "nonce")
-		if blockNonce != nil {
-			block.Nonce = *blockNonce
-		}
-		block.Number = *convertDataToUint64P(blk, "number")
-		block.Ommers = []*model.Block{}
-		block.Parent = &model.Block{}
-		block.Parent.Hash = *convertDataToStringP(blk, "parentHash")
-		block.ReceiptsRoot = *convertDataToStringP(blk, "receiptsRoot")
-		block.StateRoot = *convertDataToStringP(blk, "stateRoot")
-		block.Timestamp = *convertDataToStringP(blk, "timestamp")
-		block.TransactionCount = convertDataToIntP(blk, "transactionCount")
-		block.TransactionsRoot = *convertDataToStringP(blk, "transactionsRoot")
-		block.TotalDifficulty = *convertDataToStringP(blk, "totalDifficulty")
-		block.Transactions = []*model.Transaction{}
-
-		block.LogsBloom = "0x" + *convertDataToStringP(blk, "logsBloom")
-		block.OmmerHash = *convertDataToStringP(blk, "sha3Uncles")
-
-		absRcp := res["receipts"]
-		rcp := absRcp.([]map[string]interface{})
-		for _, transReceipt := range rcp {
-			trans := &model.Transaction{}
-			trans.CumulativeGasUsed = convertDataToUint64P(transReceipt, "cumulativeGasUsed")
-			trans.InputData = *convertDataToStringP(transReceipt, "data")
-			trans.EffectiveGasPrice = convertDataToStringP(transReceipt, "effectiveGasPrice")
-			trans.GasPrice = *convertDataToStringP(transReceipt, "effectiveGasPrice")
-			trans.GasUsed = convertDataToUint64P(transReceipt, "gasUsed")
-			trans.Hash = *convertDataToStringP(transReceipt, "transactionHash")
-			trans.Index = convertDataToIntP(transReceipt, "transactionIndex")
-			transNonce := convertDataToStringP(transReceipt, "nonce")
-			if transNonce != nil {
-				trans.Nonce = *transNonce
-			}
-			trans.Status = convertDataToUint64P(transReceipt, "status")
-			trans.Type = convertDataToIntP(transReceipt, "type")
-			trans.Value = *convertDataToStringP(transReceipt, "value")
-
-			trans.Logs = make([]*model.Log, 0)
-			for _, rlog := range transReceipt["logs"].(types.Logs) {
-				tlog := model.Log{
-					Index: int(rlog.Index),
-					Data:  "0x" + hex.EncodeToString(rlog.Data),
-				}
-				tlog.Account = &model.Account{}
-				tlog.Account.Address = strings.ToLower(rlog.Address.String())
-
-				for _, rtopic := range rlog.Topics {
-					tlog.Topics = append(tlog.Topics, rtopic.String())
-				}
-
-				trans.Logs = append(trans.Logs, &tlog)
-			}
-
-			trans.From = &model.Account{}
-			trans.From.Address = strings.ToLower(*convertDataToStringP(transReceipt, "from"))
-
-			trans.To = &model.Account{}
-			address := convertDataToStringP(transReceipt, "to")
-			// To address could be nil in case of contract creation
-			if address != nil {
-				trans.To.Address = strings.ToLower(*convertDataToStringP(transReceipt, "to"))
-			}
-
-			block.Transactions = append(block.Transactions, trans)
-		}
-	}
+	block := convertBlockDetails(res)
 
 	return block, ctx.Err()
 }
@@ -185,17 +181,73 @@ func (r *queryResolver) Blocks(ctx context.Context, from *uint64, to *uint64) ([
 
 // Pending is the resolver for the pending field.
 func (r *queryResolver) Pending(ctx context.Context) (*model.Pending, error) {
-	panic(fmt.Errorf("not implemented: Pending - pending"))
+	res, err := r.GraphQLAPI.GetBlockDetails(ctx, rpc.PendingBlockNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	pending := &model.Pending{Transactions: []*model.Transaction{}}
+	if res != nil {
+		pending.Transactions = convertTransactions(res, rpc.BlockNumberOrHashWithNumber(rpc.PendingBlockNumber))
+	}
+	pending.TransactionCount = len(pending.Transactions)
+
+	return pending, ctx.Err()
 }
 
 // Transaction is the resolver for the transaction field.
 func (r *queryResolver) Transaction(ctx context.Context, hash string) (*model.Transaction, error) {
-	panic(fmt.Errorf("not implemented: Transaction - transaction"))
+	res, err := r.GraphQLAPI.GetTransactionDetails(ctx, libcommon.HexToHash(hash))
+	if err != nil {
+		return nil, err
+	}
+	if res == nil {
+		return nil, ctx.Err()
+	}
+
+	block := convertBlockDetails(res)
+	txnIndex := res["transactionIndex"].(int)
+	if txnIndex >= len(block.Transactions) {
+		return nil, fmt.Errorf("block %d has no transaction %d", block.Number, txnIndex)
+	}
+
+	return block.Transactions[txnIndex], ctx.Err()
 }
 
 // Logs is the resolver for the logs field.
 func (r *queryResolver) Logs(ctx context.Context, filter model.FilterCriteria) ([]*model.Log, error) {
-	panic(fmt.Errorf("not implemented: Logs - logs"))
+	crit := filters.FilterCriteria{}
+	if filter.FromBlock != nil {
+		crit.FromBlock = new(big.Int).SetUint64(*filter.FromBlock)
+	}
+	if filter.ToBlock != nil {
+		crit.ToBlock = new(big.Int).SetUint64(*filter.ToBlock)
+	}
+	for _, address := range filter.Addresses {
+		crit.Addresses = append(crit.Addresses, libcommon.HexToAddress(address))
+	}
+	for _, sub := range filter.Topics {
+		topics := make([]libcommon.Hash, 0, len(sub))
+		for _, topic := range sub {
+			topics = append(topics, libcommon.HexToHash(topic))
+		}
+		crit.Topics = append(crit.Topics, topics)
+	}
+
+	res, err := r.GraphQLAPI.GetLogsDetails(ctx, crit)
+	if err != nil {
+		return nil, err
+	}
+
+	logs := []*model.Log{}
+	for _, blockRes := range res {
+		block := convertBlockDetails(blockRes)
+		for _, trans := range block.Transactions {
+			logs = append(logs, filterLogs(trans.Logs, filter.Addresses, filter.Topics)...)
+		}
+	}
+
+	return logs, ctx.Err()
 }
 
 // GasPrice is the resolver for the gasPrice field.
@@ -220,11 +272,23 @@ func (r *queryResolver) ChainID(ctx context.Context) (string, error) {
 	return "0x" + strconv.FormatUint(chainID.Uint64(), 16), err
 }
 
+// Account returns AccountResolver implementation.
+func (r *Resolver) Account() AccountResolver { return &accountResolver{r} }
+
+// Block returns BlockResolver implementation.
+func (r *Resolver) Block() BlockResolver { return &blockResolver{r} }
+
 // Mutation returns MutationResolver implementation.
 func (r *Resolver) Mutation() MutationResolver { return &mutationResolver{r} }
 
+// Pending returns PendingResolver implementation.
+func (r *Resolver) Pending() PendingResolver { return &pendingResolver{r} }
+
 // Query returns QueryResolver implementation.
 func (r *Resolver) Query() QueryResolver { return &queryResolver{r} }
 
+type accountResolver struct{ *Resolver }
+type blockResolver struct{ *Resolver }
 type mutationResolver struct{ *Resolver }
+type pendingResolver struct{ *Resolver }
 type queryResolver struct{ *Resolver }
@@ -1,9 +1,11 @@
 package graphql
 
 import (
+	"context"
 	"net/http"
 	"strings"
 
+	"github.com/99designs/gqlgen/graphql"
 	"github.com/99designs/gqlgen/graphql/handler"
 	"github.com/99designs/gqlgen/graphql/playground"
 
@@ -33,7 +35,19 @@ func CreateHandler(api []rpc.API) *handler.Server {
 	resolver := graph.Resolver{}
 	resolver.GraphQLAPI = graphqlAPI
 
-	return handler.NewDefaultServer(graph.NewExecutableSchema(graph.Config{Resolvers: &resolver})) // TODO : init resolver.DB here !!!
+	srv := handler.NewDefaultServer(graph.NewExecutableSchema(graph.Config{Resolvers: &resolver})) // TODO : init resolver.DB here !!!
+	if graphqlAPI != nil {
+		// Resolvers of a query share a single database transaction
+		srv.AroundOperations(func(ctx context.Context, next graphql.OperationHandler) graphql.ResponseHandler {
+			ctx, release := graphqlAPI.WithQueryTx(ctx)
+			responses := next(ctx)
+			return func(ctx context.Context) *graphql.Response {
+				defer release()
+				return responses(ctx)
+			}
+		})
+	}
+	return srv
 }
 
 func ProcessGraphQLcheckIfNeeded(
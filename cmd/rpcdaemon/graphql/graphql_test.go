@@ -101,6 +101,11 @@ func TestGraphQLQueryBlock(t *testing.T) {
 				code: 200,
 			},
 		*/
+		{ // unknown transactions are null
+			body: `{"query": "{transaction(hash:\"0x0000000000000000000000000000000000000000000000000000000000000000\"){hash}}"}`,
+			want: `{"data":{"transaction":null}}`,
+			code: 200,
+		},
 	} {
 		resp, err := http.Post("http://localhost:8545/graphql", "application/json", strings.NewReader(tt.body))
 		if err != nil {
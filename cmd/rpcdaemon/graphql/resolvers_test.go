@@ -0,0 +1,88 @@
+package graphql
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ledgerwatch/erigon-lib/kv/kvcache"
+	"github.com/ledgerwatch/log/v3"
+
+	"github.com/ledgerwatch/erigon/cmd/rpcdaemon/rpcdaemontest"
+	"github.com/ledgerwatch/erigon/rpc"
+	"github.com/ledgerwatch/erigon/rpc/rpccfg"
+	"github.com/ledgerwatch/erigon/turbo/jsonrpc"
+)
+
+// TestGraphQLResolvers runs queries against the test chain of rpcdaemontest, see generateChain there.
+func TestGraphQLResolvers(t *testing.T) {
+	m, chain, _ := rpcdaemontest.CreateTestSentry(t)
+	stateCache := kvcache.New(kvcache.DefaultCoherentConfig)
+	base := jsonrpc.NewBaseApi(nil, stateCache, m.BlockReader, m.HistoryV3Components(), false, rpccfg.DefaultEvmCallTimeout, m.Engine, m.Dirs)
+	eth := jsonrpc.NewEthAPI(base, m.DB, nil, nil, nil, 5000000, 100_000, false, 100_000, log.New())
+	srv := httptest.NewServer(CreateHandler([]rpc.API{{Service: jsonrpc.NewGraphQLAPI(base, m.DB, eth)}}))
+	defer srv.Close()
+
+	// Block 1 has a transfer of 0.001 ether to 0x01, block 10 has a call of Poly contract, which emits DeployEvent
+	transfer := chain.Blocks[0].Transactions()[0]
+	deploy := chain.Blocks[9].Transactions()[0]
+	deployLog := chain.Receipts[9][0].Logs[0]
+	poly := strings.ToLower(deployLog.Address.Hex())
+
+	for _, tt := range []struct {
+		name  string
+		query string
+		want  string
+	}{
+		{
+			name:  "chainID",
+			query: `{chainID}`,
+			want:  `{"data":{"chainID":"0x539"}}`,
+		},
+		{
+			name:  "account",
+			query: `{block(number:1){account(address:"0x0100000000000000000000000000000000000000"){balance transactionCount code storage(slot:"0x0000000000000000000000000000000000000000000000000000000000000000")}}}`,
+			want:  `{"data":{"block":{"account":{"balance":"0x38d7ea4c68000","transactionCount":0,"code":"0x","storage":"0x0000000000000000000000000000000000000000000000000000000000000000"}}}}`,
+		},
+		{
+			name:  "call",
+			query: `{block(number:1){call(data:{to:"0x0100000000000000000000000000000000000000"}){data gasUsed status} estimateGas(data:{to:"0x0100000000000000000000000000000000000000"})}}`,
+			want:  `{"data":{"block":{"call":{"data":"0x","gasUsed":21000,"status":1},"estimateGas":21000}}}`,
+		},
+		{
+			name:  "transaction",
+			query: fmt.Sprintf(`{transaction(hash:"%s"){hash status gasUsed}}`, transfer.Hash().Hex()),
+			want:  fmt.Sprintf(`{"data":{"transaction":{"hash":"%s","status":1,"gasUsed":21000}}}`, transfer.Hash().Hex()),
+		},
+		{
+			name:  "unknownTransaction",
+			query: `{transaction(hash:"0x0000000000000000000000000000000000000000000000000000000000000000"){hash}}`,
+			want:  `{"data":{"transaction":null}}`,
+		},
+		{
+			name:  "logs",
+			query: fmt.Sprintf(`{logs(filter:{fromBlock:1,toBlock:11,addresses:["%s"]}){index account{address} topics data transaction{hash}}}`, poly),
+			want: fmt.Sprintf(`{"data":{"logs":[{"index":0,"account":{"address":"%s"},"topics":["%s"],"data":"0x%x","transaction":{"hash":"%s"}}]}}`,
+				poly, deployLog.Topics[0].Hex(), deployLog.Data, deploy.Hash().Hex()),
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			body := fmt.Sprintf(`{"query":%q}`, tt.query)
+			resp, err := http.Post(srv.URL, "application/json", strings.NewReader(body))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer resp.Body.Close()
+			have, err := io.ReadAll(resp.Body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(have) != tt.want {
+				t.Fatalf("have:\n%s\nwant:\n%s", have, tt.want)
+			}
+		})
+	}
+}
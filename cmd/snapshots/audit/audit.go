@@ -0,0 +1,622 @@
+package audit
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strconv"
+
+	"github.com/holiman/uint256"
+	"github.com/ledgerwatch/erigon-lib/chain"
+	libcommon "github.com/ledgerwatch/erigon-lib/common"
+	"github.com/ledgerwatch/erigon-lib/common/datadir"
+	"github.com/ledgerwatch/erigon-lib/compress"
+	"github.com/ledgerwatch/erigon-lib/downloader/snaptype"
+	"github.com/ledgerwatch/erigon-lib/recsplit"
+	types2 "github.com/ledgerwatch/erigon-lib/types"
+	"github.com/ledgerwatch/erigon/cmd/snapshots/flags"
+	"github.com/ledgerwatch/erigon/cmd/snapshots/sync"
+	"github.com/ledgerwatch/erigon/cmd/utils"
+	"github.com/ledgerwatch/erigon/core/types"
+	"github.com/ledgerwatch/erigon/crypto"
+	"github.com/ledgerwatch/erigon/params"
+	"github.com/ledgerwatch/erigon/rlp"
+	"github.com/pelletier/go-toml/v2"
+	"github.com/urfave/cli/v2"
+	"golang.org/x/sync/errgroup"
+)
+
+var (
+	ChecksumsFlag = cli.StringFlag{
+		Name:     "checksums",
+		Usage:    `Verify segment checksums against .toml file created by --checksums.write`,
+		Required: false,
+	}
+	WriteChecksumsFlag = cli.StringFlag{
+		Name:     "checksums.write",
+		Usage:    `Write segment checksums to .toml file`,
+		Required: false,
+	}
+)
+
+var Command = cli.Command{
+	Action:    audit,
+	Name:      "audit",
+	Usage:     "audit local snapshot segments: walk every word and cross-check it against .idx files",
+	ArgsUsage: "<start block> <end block>",
+	Flags: []cli.Flag{
+		&utils.DataDirFlag,
+		&utils.ChainFlag,
+		&flags.SegTypes,
+		&ChecksumsFlag,
+		&WriteChecksumsFlag,
+	},
+	Description: `Opens each headers/bodies/transactions .seg file, walks every word and checks that block numbers,
+hashes and offsets match the recsplit .idx files. Index entries which don't point to any word are reported as orphaned.
+For every segment a merkle root of its words is calculated: it can be stored with --checksums.write and verified later with --checksums.`,
+}
+
+// maxProblemsPerFile - only first problems of each file are listed, all of them are counted
+const maxProblemsPerFile = 16
+
+// Report - result of audit of one segment file
+type Report struct {
+	File     string
+	Words    uint64
+	Root     libcommon.Hash
+	Problems []string
+	Total    int
+}
+
+func (r *Report) problem(format string, args ...interface{}) {
+	r.Total++
+	if len(r.Problems) < maxProblemsPerFile {
+		r.Problems = append(r.Problems, fmt.Sprintf(format, args...))
+	}
+}
+
+func audit(cliCtx *cli.Context) error {
+	logger := sync.Logger(cliCtx.Context)
+
+	dirs := datadir.New(cliCtx.String(utils.DataDirFlag.Name))
+	chainConfig := params.ChainConfigByChainName(cliCtx.String(utils.ChainFlag.Name))
+	if chainConfig == nil {
+		return fmt.Errorf("unknown chain: %s", cliCtx.String(utils.ChainFlag.Name))
+	}
+
+	typeValues := cliCtx.StringSlice(flags.SegTypes.Name)
+	snapTypes := make([]snaptype.Type, 0, len(typeValues))
+
+	for _, val := range typeValues {
+		segType, ok := snaptype.ParseFileType(val)
+
+		if !ok {
+			return fmt.Errorf("unknown file type: %s", val)
+		}
+
+		snapTypes = append(snapTypes, segType)
+	}
+
+	var firstBlock, lastBlock uint64
+	var err error
+
+	if cliCtx.Args().Len() > 0 {
+		if firstBlock, err = strconv.ParseUint(cliCtx.Args().Get(0), 10, 64); err != nil {
+			return err
+		}
+	}
+
+	if cliCtx.Args().Len() > 1 {
+		if lastBlock, err = strconv.ParseUint(cliCtx.Args().Get(1), 10, 64); err != nil {
+			return err
+		}
+	}
+
+	logger.Info("Starting audit", "dir", dirs.Snap)
+
+	reports, err := Segments(cliCtx.Context, dirs.Snap, chainConfig, snapTypes, firstBlock, lastBlock, runtime.NumCPU())
+	if err != nil {
+		return err
+	}
+
+	if path := cliCtx.String(ChecksumsFlag.Name); path != "" {
+		if err := verifyChecksums(path, reports); err != nil {
+			return err
+		}
+	}
+
+	if path := cliCtx.String(WriteChecksumsFlag.Name); path != "" {
+		if err := writeChecksums(path, reports); err != nil {
+			return err
+		}
+	}
+
+	if problems := printReports(os.Stdout, reports); problems > 0 {
+		return fmt.Errorf("audit failed: %d problems found", problems)
+	}
+
+	return nil
+}
+
+// Segments - audits headers, bodies and transactions segments of snapDir in [from, to) range (to == 0 means: all)
+func Segments(ctx context.Context, snapDir string, chainConfig *chain.Config, snapTypes []snaptype.Type, from, to uint64, workers int) ([]*Report, error) {
+	entries, err := os.ReadDir(snapDir)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(snapTypes) == 0 {
+		snapTypes = snaptype.BlockSnapshotTypes
+	}
+
+	var segments []snaptype.FileInfo
+
+	for _, e := range entries {
+		if e.IsDir() || filepath.Ext(e.Name()) != ".seg" {
+			continue
+		}
+
+		fi, ok := snaptype.ParseFileName(snapDir, e.Name())
+		if !ok || fi.From < from || (to > 0 && fi.To > to) {
+			continue
+		}
+
+		for _, t := range snapTypes {
+			if fi.T == t {
+				segments = append(segments, fi)
+				break
+			}
+		}
+	}
+
+	reports := make([]*Report, len(segments))
+
+	g, ctx := errgroup.WithContext(ctx)
+	g.SetLimit(workers)
+
+	for i, fi := range segments {
+		i, fi := i, fi
+
+		g.Go(func() error {
+			report, err := auditSegment(ctx, chainConfig, fi)
+			if err != nil {
+				return fmt.Errorf("%s: %w", fi.Name(), err)
+			}
+			reports[i] = report
+			return nil
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	sort.Slice(reports, func(i, j int) bool { return reports[i].File < reports[j].File })
+
+	return reports, nil
+}
+
+func auditSegment(ctx context.Context, chainConfig *chain.Config, fi snaptype.FileInfo) (report *Report, err error) {
+	report = &Report{File: fi.Name()}
+
+	defer func() {
+		if rec := recover(); rec != nil {
+			// broken compression tables or index can panic inside of decompressor/recsplit
+			report.problem("can't read file: %v", rec)
+			err = nil
+		}
+	}()
+
+	d, err := compress.NewDecompressor(fi.Path)
+	if err != nil {
+		report.problem("can't open segment: %v", err)
+		return report, nil
+	}
+	defer d.Close()
+
+	switch fi.T {
+	case snaptype.Headers:
+		err = auditHeaders(ctx, d, fi, report)
+	case snaptype.Bodies:
+		err = auditBodies(ctx, d, fi, report)
+	case snaptype.Transactions:
+		err = auditTransactions(ctx, chainConfig, d, fi, report)
+	default:
+		err = walk(ctx, d, report, func(i, offset uint64, word []byte) {})
+	}
+
+	return report, err
+}
+
+// openIndex - opens index of segment, reports missing or broken index and base data id mismatch
+func openIndex(fi snaptype.FileInfo, idxType string, baseDataID uint64, report *Report) *recsplit.Index {
+	name := snaptype.IdxFileName(fi.Version, fi.From, fi.To, idxType)
+
+	idx, err := recsplit.OpenIndex(filepath.Join(filepath.Dir(fi.Path), name))
+	if err != nil {
+		report.problem("%s: can't open index: %v", name, err)
+		return nil
+	}
+
+	if idx.BaseDataID() != baseDataID {
+		report.problem("%s: base data id %d, expected %d", name, idx.BaseDataID(), baseDataID)
+	}
+
+	return idx
+}
+
+// checkKeyCount - index must have exactly one entry per word, extra entries are orphaned
+func checkKeyCount(idx *recsplit.Index, words uint64, report *Report) {
+	switch {
+	case idx.KeyCount() > words:
+		report.problem("%s: %d orphaned entries, index has %d keys for %d words", idx.FileName(), idx.KeyCount()-words, idx.KeyCount(), words)
+	case idx.KeyCount() < words:
+		report.problem("%s: %d words are not indexed, index has %d keys for %d words", idx.FileName(), words-idx.KeyCount(), idx.KeyCount(), words)
+	}
+}
+
+// checkOrdinal - i-th entry of index must point to offset of i-th word, and key of this word must resolve to i
+func checkOrdinal(idx *recsplit.Index, reader *recsplit.IndexReader, i, offset uint64, key []byte, report *Report) {
+	if i >= idx.KeyCount() {
+		return
+	}
+
+	if ordinalOffset := idx.OrdinalLookup(i); ordinalOffset != offset {
+		report.problem("%s: entry %d points to offset %d, word is at %d", idx.FileName(), i, ordinalOffset, offset)
+	}
+
+	if ordinal := reader.Lookup(key); ordinal != i {
+		report.problem("%s: key %x of word %d resolves to entry %d", idx.FileName(), key, i, ordinal)
+	}
+}
+
+func auditHeaders(ctx context.Context, d *compress.Decompressor, fi snaptype.FileInfo, report *Report) error {
+	if uint64(d.Count()) != fi.To-fi.From {
+		report.problem("%d headers, expected %d", d.Count(), fi.To-fi.From)
+	}
+
+	idx := openIndex(fi, snaptype.Headers.String(), fi.From, report)
+	if idx != nil {
+		defer idx.Close()
+		checkKeyCount(idx, uint64(d.Count()), report)
+		if idx.Empty() {
+			idx = nil
+		}
+	}
+
+	var reader *recsplit.IndexReader
+	if idx != nil {
+		reader = recsplit.NewIndexReader(idx)
+	}
+
+	var parentHash libcommon.Hash
+
+	return walk(ctx, d, report, func(i, offset uint64, word []byte) {
+		if len(word) == 0 {
+			report.problem("header %d: empty word", fi.From+i)
+			return
+		}
+
+		h := crypto.Keccak256Hash(word[1:])
+		if word[0] != h[0] {
+			report.problem("header %d: first byte %x doesn't match hash %x", fi.From+i, word[0], h)
+		}
+
+		var header types.Header
+		if err := rlp.DecodeBytes(word[1:], &header); err != nil {
+			report.problem("header %d: %v", fi.From+i, err)
+			return
+		}
+
+		if header.Number.Uint64() != fi.From+i {
+			report.problem("header %d: has number %d", fi.From+i, header.Number.Uint64())
+		}
+
+		if i > 0 && header.ParentHash != parentHash {
+			report.problem("header %d: parent hash %x, expected %x", fi.From+i, header.ParentHash, parentHash)
+		}
+		parentHash = h
+
+		if reader != nil {
+			checkOrdinal(idx, reader, i, offset, h[:], report)
+		}
+	})
+}
+
+func auditBodies(ctx context.Context, d *compress.Decompressor, fi snaptype.FileInfo, report *Report) error {
+	if uint64(d.Count()) != fi.To-fi.From {
+		report.problem("%d bodies, expected %d", d.Count(), fi.To-fi.From)
+	}
+
+	idx := openIndex(fi, snaptype.Bodies.String(), fi.From, report)
+	if idx != nil {
+		defer idx.Close()
+		checkKeyCount(idx, uint64(d.Count()), report)
+		if idx.Empty() {
+			idx = nil
+		}
+	}
+
+	var reader *recsplit.IndexReader
+	if idx != nil {
+		reader = recsplit.NewIndexReader(idx)
+	}
+
+	num := make([]byte, binary.MaxVarintLen64)
+	var nextTxID uint64
+
+	return walk(ctx, d, report, func(i, offset uint64, word []byte) {
+		var body types.BodyForStorage
+		if err := rlp.DecodeBytes(word, &body); err != nil {
+			report.problem("body %d: %v", fi.From+i, err)
+			return
+		}
+
+		if i > 0 && body.BaseTxId != nextTxID {
+			report.problem("body %d: base tx id %d, expected %d", fi.From+i, body.BaseTxId, nextTxID)
+		}
+		nextTxID = body.BaseTxId + uint64(body.TxAmount)
+
+		if reader != nil {
+			n := binary.PutUvarint(num, i)
+			checkOrdinal(idx, reader, i, offset, num[:n], report)
+		}
+	})
+}
+
+func auditTransactions(ctx context.Context, chainConfig *chain.Config, d *compress.Decompressor, fi snaptype.FileInfo, report *Report) error {
+	bodiesPath := filepath.Join(filepath.Dir(fi.Path), snaptype.SegmentFileName(fi.Version, fi.From, fi.To, snaptype.Bodies))
+
+	bodiesSegment, err := compress.NewDecompressor(bodiesPath)
+	if err != nil {
+		report.problem("can't open bodies segment: %v", err)
+		return walk(ctx, d, report, func(i, offset uint64, word []byte) {})
+	}
+	defer bodiesSegment.Close()
+
+	bodyGetter := bodiesSegment.MakeGetter()
+	body := &types.BodyForStorage{}
+	var bodyBuf []byte
+	var firstTxID, expectedCount uint64
+
+	for first := true; bodyGetter.HasNext(); first = false {
+		bodyBuf, _ = bodyGetter.Next(bodyBuf[:0])
+		if err := rlp.DecodeBytes(bodyBuf, body); err != nil {
+			report.problem("can't decode bodies segment: %v", err)
+			return walk(ctx, d, report, func(i, offset uint64, word []byte) {})
+		}
+		if first {
+			firstTxID = body.BaseTxId
+		}
+		expectedCount = body.BaseTxId + uint64(body.TxAmount) - firstTxID
+	}
+
+	if uint64(d.Count()) != expectedCount {
+		report.problem("%d transactions, bodies expect %d", d.Count(), expectedCount)
+	}
+
+	txnHashIdx := openIndex(fi, snaptype.Transactions.String(), firstTxID, report)
+	if txnHashIdx != nil {
+		defer txnHashIdx.Close()
+		checkKeyCount(txnHashIdx, uint64(d.Count()), report)
+		if txnHashIdx.Empty() {
+			txnHashIdx = nil
+		}
+	}
+
+	txnHash2BlockNumIdx := openIndex(fi, snaptype.Transactions2Block.String(), fi.From, report)
+	if txnHash2BlockNumIdx != nil {
+		defer txnHash2BlockNumIdx.Close()
+		checkKeyCount(txnHash2BlockNumIdx, uint64(d.Count()), report)
+		if txnHash2BlockNumIdx.Empty() {
+			txnHash2BlockNumIdx = nil
+		}
+	}
+
+	var txnHashReader, txnHash2BlockNumReader *recsplit.IndexReader
+	if txnHashIdx != nil {
+		txnHashReader = recsplit.NewIndexReader(txnHashIdx)
+	}
+	if txnHash2BlockNumIdx != nil {
+		txnHash2BlockNumReader = recsplit.NewIndexReader(txnHash2BlockNumIdx)
+	}
+
+	chainId, _ := uint256.FromBig(chainConfig.ChainID)
+
+	parseCtx := types2.NewTxParseContext(*chainId)
+	parseCtx.WithSender(false)
+	// keys are calculated exactly like freezeblocks.TransactionsIdx does: slot is re-used between words
+	slot := types2.TxSlot{}
+
+	bodyGetter.Reset(0)
+	blockNum := fi.From
+	if bodyGetter.HasNext() {
+		bodyBuf, _ = bodyGetter.Next(bodyBuf[:0])
+		if err := rlp.DecodeBytes(bodyBuf, body); err != nil {
+			return err
+		}
+	}
+
+	return walk(ctx, d, report, func(i, offset uint64, word []byte) {
+		for body.BaseTxId+uint64(body.TxAmount) <= firstTxID+i { // skip empty blocks
+			if !bodyGetter.HasNext() {
+				report.problem("transaction %d: not enough bodies", firstTxID+i)
+				return
+			}
+
+			bodyBuf, _ = bodyGetter.Next(bodyBuf[:0])
+			if err := rlp.DecodeBytes(bodyBuf, body); err != nil {
+				report.problem("transaction %d: %v", firstTxID+i, err)
+				return
+			}
+
+			blockNum++
+		}
+
+		firstTxByteAndlengthOfAddress := 21
+		isSystemTx := len(word) == 0
+		if isSystemTx { // system-txs hash:pad32(txnID)
+			binary.BigEndian.PutUint64(slot.IDHash[:], firstTxID+i)
+		} else {
+			if len(word) <= firstTxByteAndlengthOfAddress {
+				report.problem("transaction %d: word is too short: %d", firstTxID+i, len(word))
+				return
+			}
+			if _, err := parseCtx.ParseTransaction(word[firstTxByteAndlengthOfAddress:], 0, &slot, nil, true /* hasEnvelope */, false /* wrappedWithBlobs */, nil /* validateHash */); err != nil {
+				report.problem("transaction %d: block %d: %v", firstTxID+i, blockNum, err)
+				return
+			}
+			if word[0] != slot.IDHash[0] {
+				report.problem("transaction %d: first byte %x doesn't match hash %x", firstTxID+i, word[0], slot.IDHash)
+			}
+		}
+
+		if txnHashReader != nil {
+			checkOrdinal(txnHashIdx, txnHashReader, i, offset, slot.IDHash[:], report)
+		}
+
+		if txnHash2BlockNumReader != nil && i < txnHash2BlockNumIdx.KeyCount() {
+			if n := txnHash2BlockNumReader.Lookup(slot.IDHash[:]); n != blockNum {
+				report.problem("%s: transaction %x resolves to block %d, expected %d", txnHash2BlockNumIdx.FileName(), slot.IDHash, n, blockNum)
+			}
+		}
+	})
+}
+
+// walk - visits every word of segment and calculates merkle root of words
+func walk(ctx context.Context, d *compress.Decompressor, report *Report, visit func(i, offset uint64, word []byte)) error {
+	defer d.EnableReadAhead().DisableReadAhead()
+
+	var tree merkleTree
+	var i, offset, nextPos uint64
+	word := make([]byte, 0, 4096)
+
+	g := d.MakeGetter()
+	for g.HasNext() {
+		word, nextPos = g.Next(word[:0])
+
+		tree.add(crypto.Keccak256Hash(word))
+		visit(i, offset, word)
+
+		i++
+		offset = nextPos
+
+		if i%1024 == 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			default:
+			}
+		}
+	}
+
+	report.Words = i
+	report.Root = tree.root()
+
+	return nil
+}
+
+// merkleTree - calculates root of binary merkle tree in streaming fashion, keeping only one node per level.
+// Node without sibling is promoted to the next level unchanged.
+type merkleTree struct {
+	levels []*libcommon.Hash
+}
+
+func (t *merkleTree) add(leaf libcommon.Hash) {
+	node := leaf
+	for level := 0; ; level++ {
+		if level == len(t.levels) {
+			t.levels = append(t.levels, nil)
+		}
+		if t.levels[level] == nil {
+			t.levels[level] = &node
+			return
+		}
+		node = crypto.Keccak256Hash(t.levels[level][:], node[:])
+		t.levels[level] = nil
+	}
+}
+
+func (t *merkleTree) root() libcommon.Hash {
+	var root *libcommon.Hash
+	for _, node := range t.levels {
+		switch {
+		case node == nil:
+		case root == nil:
+			root = node
+		default:
+			h := crypto.Keccak256Hash(node[:], root[:])
+			root = &h
+		}
+	}
+	if root == nil {
+		return libcommon.Hash{}
+	}
+	return *root
+}
+
+func writeChecksums(path string, reports []*Report) error {
+	checksums := map[string]string{}
+	for _, r := range reports {
+		checksums[r.File] = r.Root.Hex()
+	}
+
+	data, err := toml.Marshal(checksums)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, data, 0644)
+}
+
+func verifyChecksums(path string, reports []*Report) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	checksums := map[string]string{}
+	if err := toml.Unmarshal(data, &checksums); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+
+	for _, r := range reports {
+		expected, ok := checksums[r.File]
+		if !ok {
+			continue
+		}
+		if expected != r.Root.Hex() {
+			r.problem("checksum %s, expected %s", r.Root.Hex(), expected)
+		}
+	}
+
+	return nil
+}
+
+func printReports(out io.Writer, reports []*Report) (problems int) {
+	for _, r := range reports {
+		status := "ok"
+		if r.Total > 0 {
+			status = fmt.Sprintf("%d problems", r.Total)
+		}
+
+		fmt.Fprintf(out, "%s words=%d root=%s %s\n", r.File, r.Words, r.Root.Hex(), status)
+
+		for _, p := range r.Problems {
+			fmt.Fprintf(out, "    %s\n", p)
+		}
+
+		if r.Total > len(r.Problems) {
+			fmt.Fprintf(out, "    ... and %d more\n", r.Total-len(r.Problems))
+		}
+
+		problems += r.Total
+	}
+
+	return problems
+}
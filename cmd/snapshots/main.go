@@ -8,6 +8,7 @@ import (
 	"path/filepath"
 	"syscall"
 
+	"github.com/ledgerwatch/erigon/cmd/snapshots/audit"
 	"github.com/ledgerwatch/erigon/cmd/snapshots/cmp"
 	"github.com/ledgerwatch/erigon/cmd/snapshots/copy"
 	"github.com/ledgerwatch/erigon/cmd/snapshots/manifest"
@@ -36,6 +37,7 @@ func main() {
 		&verify.Command,
 		&torrents.Command,
 		&manifest.Command,
+		&audit.Command,
 	}
 
 	app.Flags = []cli.Flag{}
@@ -51,6 +51,17 @@ type TxTask struct {
 	TraceTos           map[libcommon.Address]struct{}
 
 	UsedGas uint64
+
+	// BlockReceipts - shared by all tasks of block: receipt (only logs) of tx is collected when tx is applied,
+	// Final task verifies requests of block by them
+	BlockReceipts types.Receipts
+}
+
+// CollectReceipt - must be called when tx is applied (in txNum order)
+func (t *TxTask) CollectReceipt() {
+	if t.TxIndex >= 0 && t.TxIndex < len(t.BlockReceipts) {
+		t.BlockReceipts[t.TxIndex] = &types.Receipt{Logs: t.Logs}
+	}
 }
 
 // TxTaskQueue non-thread-safe priority-queue
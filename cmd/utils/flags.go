@@ -504,6 +504,13 @@ var (
 		Usage: "Max GetProof rewind block count",
 		Value: 100_000,
 	}
+	// Re-computing the commitment traverses the whole historical state, so it is disabled by default
+	// and the traversal is aborted after the given number of accounts and storage slots.
+	RpcGetProofRecomputeLimit = cli.Uint64Flag{
+		Name:  "rpc.getproof.recompute.limit",
+		Usage: "Serve eth_getProof for blocks older than rpc.maxgetproofrewindblockcount.limit by re-computing the commitment over the historical state, aborting after this number of accounts and storage slots. 0 - disabled",
+		Value: 0,
+	}
 	StateCacheFlag = cli.StringFlag{
 		Name:  "state.cache",
 		Value: "0MB",
@@ -772,6 +779,12 @@ var (
 		Value: "",
 	}
 
+	ObjectStoreFlag = cli.StringFlag{
+		Name:  "downloader.objectstore",
+		Usage: "Comma-separated URL's of HTTP file servers or S3-compatible buckets (s3://key:secret@host:port/bucket/prefix) - used to download snapshots instead of BitTorrent. Every piece is verified against .torrent metadata",
+		Value: "",
+	}
+
 	// WithoutHeimdallFlag no heimdall (for testing purpose)
 	WithoutHeimdallFlag = cli.BoolFlag{
 		Name:  "bor.withoutheimdall",
@@ -853,6 +866,12 @@ var (
 		Usage: "Comma separated list of support session ids to connect to",
 	}
 
+	DiagnosticsDbSizeIntervalFlag = cli.DurationFlag{
+		Name:  "diagnostics.dbsize.interval",
+		Usage: "How often to store size report of chaindata tables (to chart growth per table, see /debug/dbsize/history). 0 - disabled",
+		Value: time.Hour,
+	}
+
 	SilkwormExecutionFlag = cli.BoolFlag{
 		Name:  "silkworm.exec",
 		Usage: "Enable Silkworm block execution",
@@ -1642,6 +1661,7 @@ func SetEthConfig(ctx *cli.Context, nodeConfig *nodecfg.Config, cfg *ethconfig.C
 		if err != nil {
 			panic(err)
 		}
+		cfg.Downloader.ObjectStores = libcommon.CliString2Array(ctx.String(ObjectStoreFlag.Name))
 		downloadernat.DoNat(nodeConfig.P2P.NAT, cfg.Downloader.ClientConfig, logger)
 	}
 
@@ -46,4 +46,11 @@ var (
 
 	// ErrUnexpectedWithdrawals is returned if a pre-Shanghai block has withdrawals.
 	ErrUnexpectedWithdrawals = errors.New("unexpected withdrawals")
+
+	// ErrUnexpectedRequests is returned if a pre-Prague block has a requests hash.
+	ErrUnexpectedRequests = errors.New("unexpected requests")
+
+	// ErrMissingReceipts is returned if a block can't be verified without receipts of its
+	// transactions, e.g. requests hash of Prague block (deposit requests are parsed from logs).
+	ErrMissingReceipts = errors.New("missing receipts")
 )
@@ -138,10 +138,33 @@ func (s *Merge) Finalize(config *chain.Config, header *types.Header, state *stat
 		return s.eth1Engine.Finalize(config, header, state, txs, uncles, r, withdrawals, chain, syscall, logger)
 	}
 
-	rewards, err := s.CalculateRewards(config, header, uncles, syscall)
+	outTxs, outReceipts, requests, err := s.finalize(config, header, state, txs, uncles, r, withdrawals, syscall)
 	if err != nil {
 		return nil, nil, err
 	}
+	if requests != nil {
+		if len(r) != len(txs) {
+			return nil, nil, fmt.Errorf("%w: requestsHash can't be verified with %d receipts of %d txs", consensus.ErrMissingReceipts, len(r), len(txs))
+		}
+		if header.RequestsHash == nil {
+			return nil, nil, errors.New("missing requestsHash")
+		}
+		if rh := requests.Hash(); *rh != *header.RequestsHash {
+			return nil, nil, fmt.Errorf("invalid requestsHash: have %x, want %x", *header.RequestsHash, *rh)
+		}
+	}
+	return outTxs, outReceipts, nil
+}
+
+// finalize applies the block rewards and withdrawals, and collects the execution layer requests since Prague.
+func (s *Merge) finalize(config *chain.Config, header *types.Header, state *state.IntraBlockState,
+	txs types.Transactions, uncles []*types.Header, r types.Receipts, withdrawals []*types.Withdrawal,
+	syscall consensus.SystemCall,
+) (types.Transactions, types.Receipts, types.FlatRequests, error) {
+	rewards, err := s.CalculateRewards(config, header, uncles, syscall)
+	if err != nil {
+		return nil, nil, nil, err
+	}
 	for _, r := range rewards {
 		state.AddBalance(r.Beneficiary, &r.Amount)
 	}
@@ -149,7 +172,7 @@ func (s *Merge) Finalize(config *chain.Config, header *types.Header, state *stat
 	if withdrawals != nil {
 		if auraEngine, ok := s.eth1Engine.(*aura.AuRa); ok {
 			if err := auraEngine.ExecuteSystemWithdrawals(withdrawals, syscall); err != nil {
-				return nil, nil, err
+				return nil, nil, nil, err
 			}
 		} else {
 			for _, w := range withdrawals {
@@ -159,7 +182,22 @@ func (s *Merge) Finalize(config *chain.Config, header *types.Header, state *stat
 		}
 	}
 
-	return txs, r, nil
+	if !config.IsPrague(header.Time) {
+		return txs, r, nil, nil
+	}
+	var logs []*types.Log
+	for _, receipt := range r {
+		logs = append(logs, receipt.Logs...)
+	}
+	deposits, err := types.ParseDepositLogs(logs, config.DepositContract)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	withdrawalRequests, err := misc.DequeueWithdrawalRequests7002(syscall)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("dequeue withdrawal requests: %w", err)
+	}
+	return txs, r, types.FlatRequests{*deposits, *withdrawalRequests}, nil
 }
 
 func (s *Merge) FinalizeAndAssemble(config *chain.Config, header *types.Header, state *state.IntraBlockState,
@@ -169,11 +207,14 @@ func (s *Merge) FinalizeAndAssemble(config *chain.Config, header *types.Header,
 	if !misc.IsPoSHeader(header) {
 		return s.eth1Engine.FinalizeAndAssemble(config, header, state, txs, uncles, receipts, withdrawals, chain, syscall, call, logger)
 	}
-	outTxs, outReceipts, err := s.Finalize(config, header, state, txs, uncles, receipts, withdrawals, chain, syscall, logger)
+	outTxs, outReceipts, requests, err := s.finalize(config, header, state, txs, uncles, receipts, withdrawals, syscall)
 	if err != nil {
 		return nil, nil, nil, err
 	}
-	return types.NewBlock(header, outTxs, uncles, outReceipts, withdrawals), outTxs, outReceipts, nil
+	if requests != nil {
+		header.RequestsHash = requests.Hash()
+	}
+	return types.NewBlock(header, outTxs, uncles, outReceipts, withdrawals).WithRequests(requests), outTxs, outReceipts, nil
 }
 
 func (s *Merge) SealHash(header *types.Header) (hash libcommon.Hash) {
@@ -242,6 +283,15 @@ func (s *Merge) verifyHeader(chain consensus.ChainHeaderReader, header, parent *
 		return consensus.ErrUnexpectedWithdrawals
 	}
 
+	// Verify existence / non-existence of requestsHash
+	prague := chain.Config().IsPrague(header.Time)
+	if prague && header.RequestsHash == nil {
+		return fmt.Errorf("missing requestsHash")
+	}
+	if !prague && header.RequestsHash != nil {
+		return consensus.ErrUnexpectedRequests
+	}
+
 	if !chain.Config().IsCancun(header.Time) {
 		return misc.VerifyAbsenceOfCancunHeaderFields(header)
 	}
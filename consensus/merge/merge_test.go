@@ -1,6 +1,7 @@
 package merge
 
 import (
+	"errors"
 	"math/big"
 	"testing"
 
@@ -89,3 +90,34 @@ func TestVerifyHeaderNonce(t *testing.T) {
 		}
 	}
 }
+
+func TestFinalizeMissingReceipts(t *testing.T) {
+	config := &chain.Config{
+		ChainID:                 big.NewInt(1337),
+		TerminalTotalDifficulty: libcommon.Big0,
+		ShanghaiTime:            libcommon.Big0,
+		CancunTime:              libcommon.Big0,
+		PragueTime:              libcommon.Big0,
+	}
+	syscall := func(libcommon.Address, []byte) ([]byte, error) { return nil, nil }
+	requests := types.FlatRequests{{Type: types.DepositRequestType}, {Type: types.WithdrawalRequestType}}
+	header := &types.Header{
+		Difficulty:   big.NewInt(0),
+		Number:       big.NewInt(1),
+		Time:         1,
+		RequestsHash: requests.Hash(),
+	}
+	txs := types.Transactions{types.NewTransaction(0, libcommon.Address{}, nil, 21000, nil, nil)}
+
+	var eth1Engine consensus.Engine
+	mergeEngine := New(eth1Engine)
+
+	// requests hash can't be verified without the deposit logs of txs
+	_, _, err := mergeEngine.Finalize(config, header, nil, txs, nil, nil, nil, nil, syscall, nil)
+	if !errors.Is(err, consensus.ErrMissingReceipts) {
+		t.Fatalf("Merge engine should not finalize Prague block without receipts, got %v", err)
+	}
+	if _, _, err = mergeEngine.Finalize(config, header, nil, txs, nil, types.Receipts{{}}, nil, nil, syscall, nil); err != nil {
+		t.Fatalf("Merge engine should finalize Prague block with receipts, got %s", err.Error())
+	}
+}
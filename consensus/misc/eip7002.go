@@ -0,0 +1,18 @@
+package misc
+
+import (
+	"github.com/ledgerwatch/erigon/consensus"
+	"github.com/ledgerwatch/erigon/core/types"
+	"github.com/ledgerwatch/erigon/params"
+)
+
+// DequeueWithdrawalRequests7002 calls the EIP-7002 withdrawal request contract at the end of the block,
+// which dequeues the withdrawal requests submitted to it. The contract returns the requests already
+// encoded as source_address ++ validator_pubkey ++ amount, so its output is the request data.
+func DequeueWithdrawalRequests7002(syscall consensus.SystemCall) (*types.FlatRequest, error) {
+	res, err := syscall(params.WithdrawalRequestAddress, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &types.FlatRequest{Type: types.WithdrawalRequestType, RequestData: res}, nil
+}
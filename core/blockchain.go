@@ -137,17 +137,31 @@ func ExecuteBlockEphemerally(
 		}
 	}
 
+	return finalizeExecutedBlock(chainConfig, vmConfig, engine, block, stateReader, stateWriter, chainReader, ibs, receipts, includedTxs, rejectedTxs, *usedGas, *usedBlobGas, logger)
+}
+
+// finalizeExecutedBlock verifies receipts, gas used and bloom of the block, which transactions were applied to ibs,
+// finalizes the block execution and makes its result
+func finalizeExecutedBlock(
+	chainConfig *chain.Config, vmConfig *vm.Config,
+	engine consensus.Engine, block *types.Block,
+	stateReader state.StateReader, stateWriter state.WriterWithChangeSets,
+	chainReader consensus.ChainReader, ibs *state.IntraBlockState,
+	receipts types.Receipts, includedTxs types.Transactions, rejectedTxs []*RejectedTx, usedGas, usedBlobGas uint64,
+	logger log.Logger,
+) (*EphemeralExecResult, error) {
+	header := block.Header()
 	receiptSha := types.DeriveSha(receipts)
 	if !vmConfig.StatelessExec && chainConfig.IsByzantium(header.Number.Uint64()) && !vmConfig.NoReceipts && receiptSha != block.ReceiptHash() {
 		return nil, fmt.Errorf("mismatched receipt headers for block %d (%s != %s)", block.NumberU64(), receiptSha.Hex(), block.ReceiptHash().Hex())
 	}
 
-	if !vmConfig.StatelessExec && *usedGas != header.GasUsed {
-		return nil, fmt.Errorf("gas used by execution: %d, in header: %d", *usedGas, header.GasUsed)
+	if !vmConfig.StatelessExec && usedGas != header.GasUsed {
+		return nil, fmt.Errorf("gas used by execution: %d, in header: %d", usedGas, header.GasUsed)
 	}
 
-	if header.BlobGasUsed != nil && *usedBlobGas != *header.BlobGasUsed {
-		return nil, fmt.Errorf("blob gas used by execution: %d, in header: %d", *usedBlobGas, *header.BlobGasUsed)
+	if header.BlobGasUsed != nil && usedBlobGas != *header.BlobGasUsed {
+		return nil, fmt.Errorf("blob gas used by execution: %d, in header: %d", usedBlobGas, *header.BlobGasUsed)
 	}
 
 	var bloom types.Bloom
@@ -171,7 +185,7 @@ func ExecuteBlockEphemerally(
 		LogsHash:    rlpHash(blockLogs),
 		Receipts:    receipts,
 		Difficulty:  (*math.HexOrDecimal256)(header.Difficulty),
-		GasUsed:     math.HexOrDecimal64(*usedGas),
+		GasUsed:     math.HexOrDecimal64(usedGas),
 		Rejected:    rejectedTxs,
 	}
 
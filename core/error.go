@@ -108,3 +108,11 @@ var (
 	// See EIP-3607: Reject transactions from senders with deployed code.
 	ErrSenderNoEOA = errors.New("sender not an eoa")
 )
+
+// EIP-7702 authorization errors. Invalid authorization is skipped, it doesn't invalidate the transaction.
+var (
+	ErrAuthorizationWrongChainID       = errors.New("EIP-7702 authorization chain ID mismatch")
+	ErrAuthorizationNonceOverflow      = errors.New("EIP-7702 authorization nonce overflow")
+	ErrAuthorizationDestinationHasCode = errors.New("EIP-7702 authority has code which is not delegation")
+	ErrAuthorizationNonceMismatch      = errors.New("EIP-7702 authorization nonce does not match authority nonce")
+)
@@ -0,0 +1,293 @@
+package core
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ledgerwatch/log/v3"
+
+	"github.com/ledgerwatch/erigon-lib/chain"
+	libcommon "github.com/ledgerwatch/erigon-lib/common"
+	"github.com/ledgerwatch/erigon-lib/metrics"
+
+	"github.com/ledgerwatch/erigon/consensus"
+	"github.com/ledgerwatch/erigon/core/state"
+	"github.com/ledgerwatch/erigon/core/types"
+	"github.com/ledgerwatch/erigon/core/vm"
+)
+
+var (
+	parallelExecTxs         = metrics.GetOrCreateCounter("chain_execution_parallel_txs")
+	parallelExecReexecuted  = metrics.GetOrCreateCounter("chain_execution_parallel_reexecuted_txs")
+	parallelExecSerialBlock = metrics.GetOrCreateCounter("chain_execution_parallel_serial_blocks")
+)
+
+// ParallelTracer is a tracer of blocks executed by ExecuteBlockParallel: each execution of a transaction is traced
+// by a tracer made by NewTxTracer, which is merged into the block tracer if the execution is committed
+type ParallelTracer interface {
+	vm.EVMLogger
+	NewTxTracer() vm.EVMLogger
+	MergeTxTracer(tracer vm.EVMLogger)
+}
+
+// ParallelExecView is a read-only view of the state and the chain before the block, which is executed by
+// ExecuteBlockParallel. Each worker of ParallelWorkers has its own view.
+type ParallelExecView struct {
+	StateReader state.StateReader
+	GetHeader   func(hash libcommon.Hash, number uint64) *types.Header
+}
+
+// ParallelWorkers are goroutines, which speculatively execute transactions of blocks for ExecuteBlockParallel.
+// Each worker reads by its own view, made by newView in the goroutine of the worker: views must not share db
+// transactions with each other, or with the goroutine of ExecuteBlockParallel. Views are re-made after Reset,
+// e.g. when the db transaction, which state they read, is committed.
+type ParallelWorkers struct {
+	jobs       chan func(view *ParallelExecView, err error)
+	generation atomic.Uint64
+	wg         sync.WaitGroup
+}
+
+func NewParallelWorkers(workers int, newView func() (view *ParallelExecView, closeView func(), err error)) *ParallelWorkers {
+	w := &ParallelWorkers{jobs: make(chan func(view *ParallelExecView, err error), workers)}
+	for i := 0; i < workers; i++ {
+		w.wg.Add(1)
+		go func() {
+			defer w.wg.Done()
+			var (
+				view       *ParallelExecView
+				viewErr    error
+				closeView  func()
+				generation uint64
+			)
+			defer func() {
+				if closeView != nil {
+					closeView()
+				}
+			}()
+			for job := range w.jobs {
+				if g := w.generation.Load(); view == nil || g != generation {
+					if closeView != nil {
+						closeView()
+					}
+					view, closeView, viewErr = newView()
+					generation = g
+				}
+				job(view, viewErr)
+				if viewErr != nil { // try again with the next job
+					view, closeView = nil, nil
+				}
+			}
+		}()
+	}
+	return w
+}
+
+// Workers returns number of workers
+func (w *ParallelWorkers) Workers() int { return cap(w.jobs) }
+
+// Reset makes workers re-make their views before the next job
+func (w *ParallelWorkers) Reset() { w.generation.Add(1) }
+
+// Close stops workers and closes their views
+func (w *ParallelWorkers) Close() {
+	close(w.jobs)
+	w.wg.Wait()
+}
+
+// speculativeTx is the result of execution of a transaction on the state after block initialization
+type speculativeTx struct {
+	done   chan struct{}
+	err    error
+	serial bool // transaction must be executed on the state of the block, e.g. a service transaction
+	msg    types.Message
+	result *ExecutionResult
+	reads  *state.StateKeys
+	writes *state.WriteSet
+	logs   []*types.Log
+	tracer vm.EVMLogger
+}
+
+// ExecuteBlockParallel executes the block like ExecuteBlockEphemerally, but transactions are first speculatively
+// executed by workers in parallel on the state after block initialization: workers read the state before the block
+// by their views, and writes of block initialization. Results are committed in order of
+// transactions: a transaction which read keys written by preceding transactions of the block, or which write set
+// can't be applied, is re-executed on the state of the block. Blocks which can't be executed in parallel, e.g.
+// blocks of Bor and AuRa chains, whose engines hook into transactions, are executed by ExecuteBlockEphemerally.
+func ExecuteBlockParallel(
+	chainConfig *chain.Config, vmConfig *vm.Config,
+	blockHashFunc func(n uint64) libcommon.Hash,
+	engine consensus.Engine, block *types.Block,
+	stateReader state.StateReader, stateWriter state.WriterWithChangeSets,
+	chainReader consensus.ChainReader, getTracer func(txIndex int, txHash libcommon.Hash) (vm.EVMLogger, error),
+	workers *ParallelWorkers, logger log.Logger,
+) (*EphemeralExecResult, error) {
+	txs := block.Transactions()
+	tracer, parallelTracer := vmConfig.Tracer.(ParallelTracer)
+	if workers == nil || len(txs) < 2 || chainConfig.Bor != nil || chainConfig.Aura != nil || vmConfig.StatelessExec ||
+		(vmConfig.Debug && !parallelTracer) {
+		return ExecuteBlockEphemerally(chainConfig, vmConfig, blockHashFunc, engine, block, stateReader, stateWriter, chainReader, getTracer, logger)
+	}
+	header := block.Header()
+	rules := chainConfig.Rules(header.Number.Uint64(), header.Time)
+
+	// transactions are speculatively executed on the state after block initialization
+	initState := state.New(stateReader)
+	if err := InitializeBlockExecution(engine, chainReader, header, chainConfig, initState, logger); err != nil {
+		return nil, err
+	}
+	initWrites := state.NewWriteSet()
+	if err := initState.CommitBlock(rules, initWrites); err != nil {
+		return nil, err
+	}
+	if initWrites.HasDeletes() {
+		parallelExecSerialBlock.Inc()
+		return ExecuteBlockEphemerally(chainConfig, vmConfig, blockHashFunc, engine, block, stateReader, stateWriter, chainReader, getTracer, logger)
+	}
+
+	defer blockExecutionTimer.ObserveDuration(time.Now())
+	ibs := state.New(stateReader)
+	if err := InitializeBlockExecution(engine, chainReader, header, chainConfig, ibs, logger); err != nil {
+		return nil, err
+	}
+
+	signer := types.MakeSigner(chainConfig, header.Number.Uint64(), header.Time)
+	specs := make([]*speculativeTx, len(txs))
+	for i := range txs {
+		specs[i] = &speculativeTx{done: make(chan struct{})}
+	}
+	// jobs, which are not started yet when the block is done (e.g. on error), are skipped
+	var stopped atomic.Bool
+	var jobs sync.WaitGroup
+	jobs.Add(len(txs))
+	defer func() {
+		stopped.Store(true)
+		jobs.Wait()
+	}()
+	go func() {
+		for i := range txs {
+			i := i
+			workers.jobs <- func(view *ParallelExecView, err error) {
+				defer jobs.Done()
+				spec := specs[i]
+				defer close(spec.done)
+				if stopped.Load() {
+					return
+				}
+				if err != nil {
+					spec.err = err
+					return
+				}
+				tx := txs[i]
+				msg, err := tx.AsMessage(*signer, header.BaseFee, rules)
+				if err != nil {
+					spec.err = err
+					return
+				}
+				if msg.FeeCap().IsZero() {
+					// Only zero-gas transactions may be service ones, which are checked by the engine on the state of the block
+					spec.serial = true
+					return
+				}
+				spec.msg = msg
+				ibsReader := state.NewReadSetReader(initWrites.Reader(view.StateReader))
+				txState := state.New(ibsReader)
+				txState.SetTxContext(tx.Hash(), block.Hash(), i)
+				cfg := *vmConfig
+				cfg.SkipAnalysis = SkipAnalysis(chainConfig, header.Number.Uint64())
+				if parallelTracer {
+					spec.tracer = tracer.NewTxTracer()
+					cfg.Tracer = spec.tracer
+				}
+				txContext := NewEVMTxContext(msg)
+				if cfg.TraceJumpDest {
+					txContext.TxHash = tx.Hash()
+				}
+				blockContext := NewEVMBlockContext(header, GetHashFn(header, view.GetHeader), engine, nil)
+				evm := vm.NewEVM(blockContext, txContext, txState, chainConfig, cfg)
+				gp := new(GasPool).AddGas(header.GasLimit).AddBlobGas(chainConfig.GetMaxBlobGasPerBlock())
+				spec.result, spec.err = ApplyMessage(evm, msg, gp, true /* refunds */, false /* gasBailout */)
+				if spec.err == nil {
+					spec.err = txState.Error()
+				}
+				if spec.err == nil {
+					spec.writes = state.NewWriteSet()
+					spec.err = txState.MakeWriteSet(rules, spec.writes)
+					spec.writes.SetBalanceIncreases(txState.BalanceIncreaseSet())
+					spec.reads = ibsReader.Keys()
+					spec.logs = txState.GetLogs(tx.Hash())
+				}
+			}
+		}
+	}()
+
+	usedGas := new(uint64)
+	usedBlobGas := new(uint64)
+	gp := new(GasPool)
+	gp.AddGas(block.GasLimit()).AddBlobGas(chainConfig.GetMaxBlobGasPerBlock())
+
+	var (
+		includedTxs types.Transactions
+		receipts    types.Receipts
+	)
+	written := state.NewStateKeys()
+	noop := state.NewNoopWriter()
+	for i, tx := range txs {
+		spec := specs[i]
+		<-spec.done
+		ibs.SetTxContext(tx.Hash(), block.Hash(), i)
+		var receipt *types.Receipt
+		if spec.err == nil && !spec.serial && !spec.writes.HasDeletes() && !spec.reads.Intersects(written) &&
+			gp.Gas() >= spec.msg.Gas() && gp.BlobGas() >= spec.msg.BlobGas() {
+			ibs.ApplyWriteSet(spec.writes)
+			for _, l := range spec.logs {
+				ibs.AddLog(l)
+			}
+			if err := ibs.FinalizeTx(rules, noop); err != nil {
+				return nil, err
+			}
+			if err := gp.SubGas(spec.result.UsedGas); err != nil {
+				return nil, err
+			}
+			if err := gp.SubBlobGas(spec.msg.BlobGas()); err != nil {
+				return nil, err
+			}
+			*usedGas += spec.result.UsedGas
+			*usedBlobGas += tx.GetBlobGas()
+			if !vmConfig.NoReceipts {
+				receipt = makeReceipt(header, tx, spec.msg, spec.result, *usedGas, ibs)
+			}
+			written.Merge(spec.writes.Keys())
+			if parallelTracer {
+				tracer.MergeTxTracer(spec.tracer)
+			}
+			parallelExecTxs.Inc()
+		} else {
+			// writes of the transaction are recorded as written keys, state is updated by ibs
+			writes := state.NewWriteSet()
+			cfg := *vmConfig
+			var txTracer vm.EVMLogger
+			if parallelTracer {
+				txTracer = tracer.NewTxTracer()
+				cfg.Tracer = txTracer
+			}
+			var err error
+			receipt, _, err = ApplyTransaction(chainConfig, blockHashFunc, engine, nil, gp, ibs, writes, header, tx, usedGas, usedBlobGas, cfg)
+			if err != nil {
+				return nil, fmt.Errorf("could not apply tx %d from block %d [%v]: %w", i, block.NumberU64(), tx.Hash().Hex(), err)
+			}
+			written.Merge(writes.Keys())
+			if parallelTracer {
+				tracer.MergeTxTracer(txTracer)
+			}
+			parallelExecReexecuted.Inc()
+		}
+		includedTxs = append(includedTxs, tx)
+		if !vmConfig.NoReceipts {
+			receipts = append(receipts, receipt)
+		}
+	}
+
+	return finalizeExecutedBlock(chainConfig, vmConfig, engine, block, stateReader, stateWriter, chainReader, ibs, receipts, includedTxs, nil, *usedGas, *usedBlobGas, logger)
+}
@@ -0,0 +1,269 @@
+package core
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+	"testing"
+
+	libcommon "github.com/ledgerwatch/erigon-lib/common"
+	"github.com/ledgerwatch/erigon-lib/common/datadir"
+	"github.com/ledgerwatch/erigon-lib/kv"
+	"github.com/ledgerwatch/erigon-lib/kv/membatch"
+	"github.com/ledgerwatch/log/v3"
+
+	"github.com/ledgerwatch/erigon/common/u256"
+	"github.com/ledgerwatch/erigon/consensus/ethash"
+	"github.com/ledgerwatch/erigon/core/rawdb"
+	"github.com/ledgerwatch/erigon/core/state"
+	"github.com/ledgerwatch/erigon/core/state/temporal"
+	"github.com/ledgerwatch/erigon/core/types"
+	"github.com/ledgerwatch/erigon/core/vm"
+	"github.com/ledgerwatch/erigon/crypto"
+	"github.com/ledgerwatch/erigon/params"
+)
+
+type execTables map[string]map[string]string
+
+// executeBlock executes the block on the state of db, the state and changesets are rolled back
+func executeBlock(t *testing.T, db kv.RwDB, block *types.Block, parallel bool) (*EphemeralExecResult, execTables) {
+	t.Helper()
+	tx, err := db.BeginRw(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tx.Rollback()
+	var (
+		engine      = ethash.NewFaker()
+		vmConfig    = vm.Config{}
+		reader      = state.NewPlainStateReader(tx)
+		writer      = state.NewPlainStateWriter(tx, tx, block.NumberU64())
+		getHash     = func(n uint64) libcommon.Hash { return block.ParentHash() }
+		res         *EphemeralExecResult
+		logger      = log.New()
+		chainConfig = params.TestChainConfig
+	)
+	if parallel {
+		workers := NewParallelWorkers(4, func() (*ParallelExecView, func(), error) {
+			roTx, err := db.BeginRo(context.Background())
+			if err != nil {
+				return nil, nil, err
+			}
+			return &ParallelExecView{
+				StateReader: state.NewPlainStateReader(roTx),
+				GetHeader: func(hash libcommon.Hash, number uint64) *types.Header {
+					return rawdb.ReadHeader(roTx, hash, number)
+				},
+			}, roTx.Rollback, nil
+		})
+		defer workers.Close()
+		res, err = ExecuteBlockParallel(chainConfig, &vmConfig, getHash, engine, block, reader, writer, nil, nil, workers, logger)
+	} else {
+		res, err = ExecuteBlockEphemerally(chainConfig, &vmConfig, getHash, engine, block, reader, writer, nil, nil, logger)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	tables := execTables{}
+	for _, table := range []string{kv.PlainState, kv.Code, kv.PlainContractCode, kv.AccountChangeSet, kv.StorageChangeSet} {
+		tables[table] = map[string]string{}
+		if err = tx.ForEach(table, nil, func(k, v []byte) error {
+			tables[table][string(k)] += string(v)
+			return nil
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return res, tables
+}
+
+func TestExecuteBlockParallel(t *testing.T) {
+	var (
+		keys    = make([]*ecdsa.PrivateKey, 5)
+		alloc   = types.GenesisAlloc{}
+		counter = libcommon.HexToAddress("0x000000000000000000000000000000000000cccc")
+		emitter = libcommon.HexToAddress("0x000000000000000000000000000000000000dddd")
+		to      = libcommon.HexToAddress("0x000000000000000000000000000000000000eeee")
+	)
+	for i := range keys {
+		keys[i], _ = crypto.GenerateKey()
+		alloc[crypto.PubkeyToAddress(keys[i].PublicKey)] = types.GenesisAccount{Balance: big.NewInt(1_000_000_000_000)}
+	}
+	// PUSH1 0 SLOAD PUSH1 1 ADD PUSH1 0 SSTORE STOP
+	alloc[counter] = types.GenesisAccount{Balance: new(big.Int), Code: []byte{0x60, 0x00, 0x54, 0x60, 0x01, 0x01, 0x60, 0x00, 0x55, 0x00}}
+	// CALLER PUSH1 0 MSTORE PUSH1 32 PUSH1 0 LOG0 CALLER CALLER SSTORE STOP
+	alloc[emitter] = types.GenesisAccount{Balance: new(big.Int), Code: []byte{0x33, 0x60, 0x00, 0x52, 0x60, 0x20, 0x60, 0x00, 0xa0, 0x33, 0x33, 0x55, 0x00}}
+	// PUSH1 1 PUSH1 0 SSTORE PUSH1 1 PUSH1 31 RETURN
+	initCode := []byte{0x60, 0x01, 0x60, 0x00, 0x55, 0x60, 0x01, 0x60, 0x1f, 0xf3}
+
+	txs := []struct {
+		key *ecdsa.PrivateKey
+		tx  types.Transaction
+	}{
+		// independent of other transactions
+		{keys[0], types.NewTransaction(0, to, u256.Num1, 21000, u256.Num1, nil)},
+		{keys[1], types.NewTransaction(0, counter, u256.Num0, 100000, u256.Num1, nil)},
+		// reads the counter written by the previous transaction
+		{keys[2], types.NewTransaction(0, counter, u256.Num0, 100000, u256.Num1, nil)},
+		{keys[3], types.NewTransaction(0, emitter, u256.Num0, 100000, u256.Num1, nil)},
+		{keys[4], types.NewContractCreation(0, u256.Num0, 100000, u256.Num1, initCode)},
+		// nonce of the sender is changed by the first transaction
+		{keys[0], types.NewTransaction(1, emitter, u256.Num0, 100000, u256.Num1, nil)},
+	}
+
+	tmpDir := t.TempDir()
+	_, db, _ := temporal.NewTestDB(t, datadir.New(tmpDir), nil)
+	gspec := &types.Genesis{Config: params.TestChainConfig, Alloc: alloc}
+	genesis := MustCommitGenesis(gspec, db, tmpDir, log.New())
+
+	signer := types.LatestSignerForChainID(nil)
+	chain, err := GenerateChain(params.TestChainConfig, genesis, ethash.NewFaker(), db, 1, func(i int, b *BlockGen) {
+		for _, tx := range txs {
+			signed, err := types.SignTx(tx.tx, *signer, tx.key)
+			if err != nil {
+				t.Fatal(err)
+			}
+			b.AddTx(signed)
+		}
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	block := chain.TopBlock
+	serialRes, serialTables := executeBlock(t, db, block, false)
+	committed, reexecuted := parallelExecTxs.GetValueUint64(), parallelExecReexecuted.GetValueUint64()
+	parallelRes, parallelTables := executeBlock(t, db, block, true)
+	// transactions, which read the counter and the first sender, are re-executed
+	committed, reexecuted = parallelExecTxs.GetValueUint64()-committed, parallelExecReexecuted.GetValueUint64()-reexecuted
+	if committed != 4 || reexecuted != 2 {
+		t.Fatalf("committed %d, re-executed %d transactions", committed, reexecuted)
+	}
+	if len(serialRes.Receipts) != len(txs) {
+		t.Fatalf("receipts: want %d got %d", len(txs), len(serialRes.Receipts))
+	}
+	if parallelRes.ReceiptRoot != serialRes.ReceiptRoot || parallelRes.LogsHash != serialRes.LogsHash || parallelRes.GasUsed != serialRes.GasUsed {
+		t.Fatalf("results: serial %+v parallel %+v", serialRes, parallelRes)
+	}
+	for table, serial := range serialTables {
+		parallel := parallelTables[table]
+		if len(parallel) != len(serial) {
+			t.Fatalf("%s: serial %d records, parallel %d records", table, len(serial), len(parallel))
+		}
+		for k, v := range serial {
+			if parallel[k] != v {
+				t.Fatalf("%s: key %x serial %x parallel %x", table, k, v, parallel[k])
+			}
+		}
+	}
+}
+
+// BenchmarkExecuteBlockParallel compares ExecuteBlockEphemerally with ExecuteBlockParallel on a range of blocks
+// with independent transactions, which are executed the way of the execution stage: the state is written into
+// the batch, and workers read the batch and db by their own read-only transactions.
+//
+// go test -run - -bench BenchmarkExecuteBlockParallel -benchtime 10x ./core/
+// (linux/amd64, Intel Xeon, 1 CPU, 16 blocks of 64 transactions):
+//
+//	BenchmarkExecuteBlockParallel/serial       10   1131295631 ns/op
+//	BenchmarkExecuteBlockParallel/workers=4    10   1229942429 ns/op
+//
+// No real chain data is available to this benchmark, and with a single CPU it measures the overhead of
+// speculative execution only, not its gain.
+func BenchmarkExecuteBlockParallel(b *testing.B) {
+	const blocks, txsPerBlock = 16, 64
+	var (
+		keys   = make([]*ecdsa.PrivateKey, txsPerBlock)
+		alloc  = types.GenesisAlloc{}
+		hasher = libcommon.HexToAddress("0x000000000000000000000000000000000000aaaa")
+		logger = log.New()
+		tmpDir = b.TempDir()
+		signer = types.LatestSignerForChainID(nil)
+		config = params.TestChainConfig
+	)
+	for i := range keys {
+		keys[i], _ = crypto.GenerateKey()
+		alloc[crypto.PubkeyToAddress(keys[i].PublicKey)] = types.GenesisAccount{Balance: big.NewInt(1_000_000_000_000)}
+	}
+	// PUSH2 1024 JUMPDEST DUP1 PUSH1 0 MSTORE PUSH1 32 PUSH1 0 SHA3 POP PUSH1 1 SWAP1 SUB DUP1 PUSH1 3 JUMPI
+	// CALLER CALLER SSTORE STOP
+	alloc[hasher] = types.GenesisAccount{Balance: new(big.Int), Code: []byte{
+		0x61, 0x04, 0x00, 0x5b, 0x80, 0x60, 0x00, 0x52, 0x60, 0x20, 0x60, 0x00, 0x20, 0x50,
+		0x60, 0x01, 0x90, 0x03, 0x80, 0x60, 0x03, 0x57, 0x33, 0x33, 0x55, 0x00}}
+
+	_, db, _ := temporal.NewTestDB(b, datadir.New(tmpDir), nil)
+	gspec := &types.Genesis{Config: config, Alloc: alloc, GasLimit: 30_000_000}
+	genesis := MustCommitGenesis(gspec, db, tmpDir, logger)
+	chain, err := GenerateChain(config, genesis, ethash.NewFaker(), db, blocks, func(i int, gen *BlockGen) {
+		for _, key := range keys {
+			signed, err := types.SignTx(types.NewTransaction(uint64(i), hasher, u256.Num0, 200000, u256.Num1, nil), *signer, key)
+			if err != nil {
+				b.Fatal(err)
+			}
+			gen.AddTx(signed)
+		}
+	})
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	for _, workers := range []int{0, 4} {
+		name := "serial"
+		if workers > 0 {
+			name = fmt.Sprintf("workers=%d", workers)
+		}
+		b.Run(name, func(b *testing.B) {
+			for n := 0; n < b.N; n++ {
+				benchExecuteBlocks(b, db, chain.Blocks, workers, logger)
+			}
+		})
+	}
+}
+
+// benchExecuteBlocks executes blocks on the state of db, the state and changesets are rolled back
+func benchExecuteBlocks(b *testing.B, db kv.RwDB, blocks []*types.Block, workers int, logger log.Logger) {
+	b.Helper()
+	tx, err := db.BeginRw(context.Background())
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer tx.Rollback()
+	batch := membatch.NewHashBatch(tx, nil, b.TempDir(), logger)
+	defer batch.Close()
+
+	var parallelWorkers *ParallelWorkers
+	if workers > 0 {
+		parallelWorkers = NewParallelWorkers(workers, func() (*ParallelExecView, func(), error) {
+			roTx, err := db.BeginRo(context.Background())
+			if err != nil {
+				return nil, nil, err
+			}
+			return &ParallelExecView{
+				StateReader: state.NewPlainStateReader(batch.View(roTx)),
+				GetHeader: func(hash libcommon.Hash, number uint64) *types.Header {
+					return rawdb.ReadHeader(roTx, hash, number)
+				},
+			}, roTx.Rollback, nil
+		})
+		defer parallelWorkers.Close()
+	}
+
+	engine := ethash.NewFaker()
+	for _, block := range blocks {
+		var (
+			vmConfig = vm.Config{}
+			reader   = state.NewPlainStateReader(batch)
+			writer   = state.NewPlainStateWriter(batch, tx, block.NumberU64())
+			getHash  = func(n uint64) libcommon.Hash { return block.ParentHash() }
+		)
+		if parallelWorkers != nil {
+			_, err = ExecuteBlockParallel(params.TestChainConfig, &vmConfig, getHash, engine, block, reader, writer, nil, nil, parallelWorkers, logger)
+		} else {
+			_, err = ExecuteBlockEphemerally(params.TestChainConfig, &vmConfig, getHash, engine, block, reader, writer, nil, nil, logger)
+		}
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
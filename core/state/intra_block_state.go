@@ -218,6 +218,11 @@ func (sdb *IntraBlockState) TxIndex() int {
 	return sdb.txIndex
 }
 
+// BlockHash returns the current block hash set by Prepare.
+func (sdb *IntraBlockState) BlockHash() libcommon.Hash {
+	return sdb.bhash
+}
+
 // DESCRIBED: docs/programmers_guide/guide.md#address---identifier-of-an-account
 func (sdb *IntraBlockState) GetCode(addr libcommon.Address) []byte {
 	stateObject := sdb.getStateObject(addr)
@@ -258,6 +263,15 @@ func (sdb *IntraBlockState) GetCodeHash(addr libcommon.Address) libcommon.Hash {
 	return libcommon.BytesToHash(stateObject.CodeHash())
 }
 
+// GetDelegatedDesignation returns the target of EIP-7702 delegation designator, if account has one as code
+func (sdb *IntraBlockState) GetDelegatedDesignation(addr libcommon.Address) (libcommon.Address, bool) {
+	// designator has fixed size, skip reading of code for all other accounts
+	if sdb.GetCodeSize(addr) != types.DelegationLength {
+		return libcommon.Address{}, false
+	}
+	return types.ParseDelegation(sdb.GetCode(addr))
+}
+
 // GetState retrieves a value from the given account's storage trie.
 // DESCRIBED: docs/programmers_guide/guide.md#address---identifier-of-an-account
 func (sdb *IntraBlockState) GetState(addr libcommon.Address, key *libcommon.Hash, value *uint256.Int) {
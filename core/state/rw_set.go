@@ -0,0 +1,277 @@
+package state
+
+import (
+	"github.com/holiman/uint256"
+	"github.com/ledgerwatch/erigon-lib/common"
+
+	"github.com/ledgerwatch/erigon/core/types/accounts"
+)
+
+// Read and write sets of transactions, which are executed in parallel by core.ExecuteBlockParallel:
+// a transaction is executed on its own IntraBlockState, its write set is applied to the state of the block
+// if it didn't read keys written by preceding transactions of the block.
+
+type storageKey struct {
+	address common.Address
+	key     common.Hash
+}
+
+// StateKeys is a set of accounts and storage slots. Incarnations are not distinguished:
+// a slot of any incarnation of an account is the same key.
+type StateKeys struct {
+	accounts map[common.Address]struct{}
+	storage  map[storageKey]struct{}
+}
+
+func NewStateKeys() *StateKeys {
+	return &StateKeys{
+		accounts: map[common.Address]struct{}{},
+		storage:  map[storageKey]struct{}{},
+	}
+}
+
+func (s *StateKeys) addAccount(address common.Address) {
+	s.accounts[address] = struct{}{}
+}
+
+func (s *StateKeys) addStorage(address common.Address, key common.Hash) {
+	s.storage[storageKey{address: address, key: key}] = struct{}{}
+}
+
+// Merge adds keys of other to s
+func (s *StateKeys) Merge(other *StateKeys) {
+	for k := range other.accounts {
+		s.accounts[k] = struct{}{}
+	}
+	for k := range other.storage {
+		s.storage[k] = struct{}{}
+	}
+}
+
+// Intersects returns true if s and other have a common key
+func (s *StateKeys) Intersects(other *StateKeys) bool {
+	small, large := s, other
+	if len(small.accounts)+len(small.storage) > len(large.accounts)+len(large.storage) {
+		small, large = large, small
+	}
+	for k := range small.accounts {
+		if _, ok := large.accounts[k]; ok {
+			return true
+		}
+	}
+	for k := range small.storage {
+		if _, ok := large.storage[k]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// ReadSetReader records keys read through it. Code is addressed by code hash, which is a part of account,
+// so reads of code are not recorded.
+type ReadSetReader struct {
+	reader StateReader
+	keys   *StateKeys
+}
+
+func NewReadSetReader(reader StateReader) *ReadSetReader {
+	return &ReadSetReader{reader: reader, keys: NewStateKeys()}
+}
+
+// Keys returns keys read so far
+func (r *ReadSetReader) Keys() *StateKeys {
+	return r.keys
+}
+
+func (r *ReadSetReader) ReadAccountData(address common.Address) (*accounts.Account, error) {
+	r.keys.addAccount(address)
+	return r.reader.ReadAccountData(address)
+}
+
+func (r *ReadSetReader) ReadAccountStorage(address common.Address, incarnation uint64, key *common.Hash) ([]byte, error) {
+	r.keys.addStorage(address, *key)
+	return r.reader.ReadAccountStorage(address, incarnation, key)
+}
+
+func (r *ReadSetReader) ReadAccountCode(address common.Address, incarnation uint64, codeHash common.Hash) ([]byte, error) {
+	return r.reader.ReadAccountCode(address, incarnation, codeHash)
+}
+
+func (r *ReadSetReader) ReadAccountCodeSize(address common.Address, incarnation uint64, codeHash common.Hash) (int, error) {
+	return r.reader.ReadAccountCodeSize(address, incarnation, codeHash)
+}
+
+func (r *ReadSetReader) ReadAccountIncarnation(address common.Address) (uint64, error) {
+	r.keys.addAccount(address)
+	return r.reader.ReadAccountIncarnation(address)
+}
+
+// WriteSet is a StateWriter which keeps writes in memory, e.g. the writes of IntraBlockState.MakeWriteSet
+// of a transaction. Balance increases of accounts which were not read are kept separately, see SetBalanceIncreases.
+type WriteSet struct {
+	accounts   map[common.Address]*accounts.Account
+	deleted    map[common.Address]struct{}
+	created    map[common.Address]struct{}
+	code       map[common.Address][]byte
+	storage    map[common.Address]map[common.Hash]uint256.Int
+	balanceInc map[common.Address]uint256.Int
+}
+
+func NewWriteSet() *WriteSet {
+	return &WriteSet{
+		accounts: map[common.Address]*accounts.Account{},
+		deleted:  map[common.Address]struct{}{},
+		created:  map[common.Address]struct{}{},
+		code:     map[common.Address][]byte{},
+		storage:  map[common.Address]map[common.Hash]uint256.Int{},
+	}
+}
+
+func (ws *WriteSet) UpdateAccountData(address common.Address, original, account *accounts.Account) error {
+	a := new(accounts.Account)
+	a.Copy(account)
+	a.PrevIncarnation = account.PrevIncarnation
+	ws.accounts[address] = a
+	return nil
+}
+
+func (ws *WriteSet) UpdateAccountCode(address common.Address, incarnation uint64, codeHash common.Hash, code []byte) error {
+	ws.code[address] = code
+	return nil
+}
+
+func (ws *WriteSet) DeleteAccount(address common.Address, original *accounts.Account) error {
+	ws.deleted[address] = struct{}{}
+	delete(ws.accounts, address)
+	delete(ws.code, address)
+	delete(ws.storage, address)
+	return nil
+}
+
+func (ws *WriteSet) WriteAccountStorage(address common.Address, incarnation uint64, key *common.Hash, original, value *uint256.Int) error {
+	m, ok := ws.storage[address]
+	if !ok {
+		m = map[common.Hash]uint256.Int{}
+		ws.storage[address] = m
+	}
+	m[*key] = *value
+	return nil
+}
+
+func (ws *WriteSet) CreateContract(address common.Address) error {
+	ws.created[address] = struct{}{}
+	return nil
+}
+
+// SetBalanceIncreases sets balance increases of accounts, which were not read, see IntraBlockState.BalanceIncreaseSet
+func (ws *WriteSet) SetBalanceIncreases(balanceInc map[common.Address]uint256.Int) {
+	ws.balanceInc = balanceInc
+}
+
+// HasDeletes returns true if accounts were deleted, such write sets can't be applied by IntraBlockState.ApplyWriteSet
+func (ws *WriteSet) HasDeletes() bool {
+	return len(ws.deleted) > 0
+}
+
+// Keys returns written accounts and storage slots, including accounts with balance increases
+func (ws *WriteSet) Keys() *StateKeys {
+	keys := NewStateKeys()
+	for address := range ws.accounts {
+		keys.addAccount(address)
+	}
+	for address := range ws.deleted {
+		keys.addAccount(address)
+	}
+	for address := range ws.balanceInc {
+		keys.addAccount(address)
+	}
+	for address, m := range ws.storage {
+		for key := range m {
+			keys.addStorage(address, key)
+		}
+	}
+	return keys
+}
+
+// Reader returns a StateReader of the state after ws is applied to the state of reader. Deleted accounts are
+// not supported, i.e. ws must not have deletes.
+func (ws *WriteSet) Reader(reader StateReader) StateReader {
+	return &writeSetReader{ws: ws, reader: reader}
+}
+
+type writeSetReader struct {
+	ws     *WriteSet
+	reader StateReader
+}
+
+func (r *writeSetReader) ReadAccountData(address common.Address) (*accounts.Account, error) {
+	if a, ok := r.ws.accounts[address]; ok {
+		account := new(accounts.Account)
+		account.Copy(a)
+		account.PrevIncarnation = a.PrevIncarnation
+		return account, nil
+	}
+	return r.reader.ReadAccountData(address)
+}
+
+func (r *writeSetReader) ReadAccountStorage(address common.Address, incarnation uint64, key *common.Hash) ([]byte, error) {
+	if a, ok := r.ws.accounts[address]; !ok || a.Incarnation == incarnation {
+		if value, ok := r.ws.storage[address][*key]; ok {
+			if value.IsZero() {
+				return nil, nil
+			}
+			return value.Bytes(), nil
+		}
+	}
+	return r.reader.ReadAccountStorage(address, incarnation, key)
+}
+
+func (r *writeSetReader) ReadAccountCode(address common.Address, incarnation uint64, codeHash common.Hash) ([]byte, error) {
+	if code, ok := r.ws.code[address]; ok {
+		if a := r.ws.accounts[address]; a != nil && a.CodeHash == codeHash {
+			return code, nil
+		}
+	}
+	return r.reader.ReadAccountCode(address, incarnation, codeHash)
+}
+
+func (r *writeSetReader) ReadAccountCodeSize(address common.Address, incarnation uint64, codeHash common.Hash) (int, error) {
+	if code, ok := r.ws.code[address]; ok {
+		if a := r.ws.accounts[address]; a != nil && a.CodeHash == codeHash {
+			return len(code), nil
+		}
+	}
+	return r.reader.ReadAccountCodeSize(address, incarnation, codeHash)
+}
+
+func (r *writeSetReader) ReadAccountIncarnation(address common.Address) (uint64, error) {
+	return r.reader.ReadAccountIncarnation(address)
+}
+
+// ApplyWriteSet applies the write set of a transaction, which was executed on another IntraBlockState, as if
+// the transaction was executed on sdb. It must be followed by FinalizeTx. Write sets with deletes are not supported.
+func (sdb *IntraBlockState) ApplyWriteSet(ws *WriteSet) {
+	for addr, account := range ws.accounts {
+		var so *stateObject
+		if _, ok := ws.created[addr]; ok {
+			sdb.CreateAccount(addr, true)
+			so = sdb.getStateObject(addr)
+		} else {
+			so = sdb.GetOrNewStateObject(addr)
+		}
+		if code, ok := ws.code[addr]; ok {
+			so.SetCode(account.CodeHash, code)
+		}
+		for key, value := range ws.storage[addr] {
+			key := key
+			so.SetState(&key, value)
+		}
+		sdb.journal.append(touchChange{account: &so.address})
+		so.data.Copy(account)
+		so.data.PrevIncarnation = account.PrevIncarnation
+	}
+	for addr, increase := range ws.balanceInc {
+		increase := increase
+		sdb.AddBalance(addr, &increase)
+	}
+}
@@ -46,6 +46,10 @@ type StateV3 struct {
 	triggers     map[uint64]*exec22.TxTask
 	senderTxNums map[common.Address]uint64
 	triggerLock  sync.Mutex
+	// finalization of block is triggered by commit of previous txNum: it needs receipts of all txs of block
+	finalTriggers  map[uint64]*exec22.TxTask
+	committed      bool
+	committedTxNum uint64
 
 	tmpdir              string
 	applyPrevAccountBuf []byte // buffer for ApplyState. Doesn't need mutex because Apply is single-threaded
@@ -58,6 +62,7 @@ func NewStateV3(tmpdir string, logger log.Logger) *StateV3 {
 		tmpdir:         tmpdir,
 		triggers:       map[uint64]*exec22.TxTask{},
 		senderTxNums:   map[common.Address]uint64{},
+		finalTriggers:  map[uint64]*exec22.TxTask{},
 		chCode:         map[string][]byte{},
 		chAccs:         map[string][]byte{},
 		chStorage:      btree2.NewMap[string, []byte](128),
@@ -232,6 +237,19 @@ func (rs *StateV3) AddWork(ctx context.Context, txTask *exec22.TxTask, in *exec2
 	rs.resetTxTask(txTask)
 	in.Add(ctx, txTask)
 }
+
+// AddFinalWork - Final task of block is sent to execution when all txs of block are committed, see CommitTxNum
+func (rs *StateV3) AddFinalWork(ctx context.Context, txTask *exec22.TxTask, in *exec22.QueueWithRetry) {
+	rs.triggerLock.Lock()
+	if !rs.committed || rs.committedTxNum+1 < txTask.TxNum {
+		rs.finalTriggers[txTask.TxNum-1] = txTask
+		rs.triggerLock.Unlock()
+		return
+	}
+	rs.triggerLock.Unlock()
+	rs.AddWork(ctx, txTask, in)
+}
+
 func (rs *StateV3) resetTxTask(txTask *exec22.TxTask) {
 	txTask.BalanceIncreaseSet = nil
 	returnReadList(txTask.ReadLists)
@@ -284,6 +302,12 @@ func (rs *StateV3) CommitTxNum(sender *common.Address, txNum uint64, in *exec22.
 		count++
 		delete(rs.triggers, txNum)
 	}
+	rs.committed, rs.committedTxNum = true, txNum
+	if final, ok := rs.finalTriggers[txNum]; ok {
+		in.ReTry(final)
+		count++
+		delete(rs.finalTriggers, txNum)
+	}
 	if sender != nil {
 		if lastTxNum, ok := rs.senderTxNums[*sender]; ok && lastTxNum == txNum {
 			// This is the last transaction so far with this sender, remove
@@ -21,12 +21,13 @@ import (
 
 	"github.com/holiman/uint256"
 	libcommon "github.com/ledgerwatch/erigon-lib/common"
+	"github.com/ledgerwatch/erigon-lib/common/fixedgas"
 	"github.com/ledgerwatch/erigon-lib/txpool/txpoolcfg"
 	types2 "github.com/ledgerwatch/erigon-lib/types"
 
 	cmath "github.com/ledgerwatch/erigon/common/math"
 	"github.com/ledgerwatch/erigon/common/u256"
-	"github.com/ledgerwatch/erigon/consensus/misc"
+	"github.com/ledgerwatch/erigon/core/types"
 	"github.com/ledgerwatch/erigon/core/vm"
 	"github.com/ledgerwatch/erigon/core/vm/evmtypes"
 	"github.com/ledgerwatch/erigon/crypto"
@@ -92,6 +93,7 @@ type Message interface {
 	Data() []byte
 	AccessList() types2.AccessList
 	BlobHashes() []libcommon.Hash
+	Authorizations() []types.Authorization
 
 	IsFree() bool
 }
@@ -132,7 +134,7 @@ func (result *ExecutionResult) Revert() []byte {
 }
 
 // IntrinsicGas computes the 'intrinsic gas' for a message with the given data.
-func IntrinsicGas(data []byte, accessList types2.AccessList, isContractCreation bool, isHomestead, isEIP2028, isEIP3860 bool) (uint64, error) {
+func IntrinsicGas(data []byte, accessList types2.AccessList, authorizationsLen uint64, isContractCreation bool, isHomestead, isEIP2028, isEIP3860 bool) (uint64, error) {
 	// Zero and non-zero bytes are priced differently
 	dataLen := uint64(len(data))
 	dataNonZeroLen := uint64(0)
@@ -142,7 +144,7 @@ func IntrinsicGas(data []byte, accessList types2.AccessList, isContractCreation
 		}
 	}
 
-	gas, status := txpoolcfg.CalcIntrinsicGas(dataLen, dataNonZeroLen, accessList, isContractCreation, isHomestead, isEIP2028, isEIP3860)
+	gas, status := txpoolcfg.CalcIntrinsicGas(dataLen, dataNonZeroLen, authorizationsLen, accessList, isContractCreation, isHomestead, isEIP2028, isEIP3860)
 	if status != txpoolcfg.Success {
 		return 0, ErrGasUintOverflow
 	}
@@ -203,10 +205,10 @@ func (st *StateTransition) buyGas(gasBailout bool) error {
 	// compute blob fee for eip-4844 data blobs if any
 	blobGasVal := new(uint256.Int)
 	if st.evm.ChainRules().IsCancun {
-		if st.evm.Context.ExcessBlobGas == nil {
+		if st.evm.Context.ExcessBlobGas == nil && st.evm.Context.BlobBaseFee == nil {
 			return fmt.Errorf("%w: Cancun is active but ExcessBlobGas is nil", ErrInternalFailure)
 		}
-		blobGasPrice, err := misc.GetBlobGasPrice(st.evm.ChainConfig(), *st.evm.Context.ExcessBlobGas)
+		blobGasPrice, err := st.evm.BlobBaseFee()
 		if err != nil {
 			return err
 		}
@@ -286,8 +288,8 @@ func (st *StateTransition) preCheck(gasBailout bool) error {
 				st.msg.From().Hex(), stNonce)
 		}
 
-		// Make sure the sender is an EOA (EIP-3607)
-		if codeHash := st.state.GetCodeHash(st.msg.From()); codeHash != emptyCodeHash && codeHash != (libcommon.Hash{}) {
+		// Make sure the sender is an EOA (EIP-3607), account with delegation designator (EIP-7702) is still an EOA
+		if codeHash := st.state.GetCodeHash(st.msg.From()); codeHash != emptyCodeHash && codeHash != (libcommon.Hash{}) && !st.isDelegated(st.msg.From()) {
 			// libcommon.Hash{} means that the sender is not in the state.
 			// Historically there were transactions with 0 gas price and non-existing sender,
 			// so we have to allow that.
@@ -306,10 +308,10 @@ func (st *StateTransition) preCheck(gasBailout bool) error {
 		}
 	}
 	if st.msg.BlobGas() > 0 && st.evm.ChainRules().IsCancun {
-		if st.evm.Context.ExcessBlobGas == nil {
+		if st.evm.Context.ExcessBlobGas == nil && st.evm.Context.BlobBaseFee == nil {
 			return fmt.Errorf("%w: Cancun is active but ExcessBlobGas is nil", ErrInternalFailure)
 		}
-		blobGasPrice, err := misc.GetBlobGasPrice(st.evm.ChainConfig(), *st.evm.Context.ExcessBlobGas)
+		blobGasPrice, err := st.evm.BlobBaseFee()
 		if err != nil {
 			return err
 		}
@@ -324,6 +326,58 @@ func (st *StateTransition) preCheck(gasBailout bool) error {
 	return st.buyGas(gasBailout)
 }
 
+func (st *StateTransition) isDelegated(addr libcommon.Address) bool {
+	if !st.evm.ChainRules().IsPrague {
+		return false
+	}
+	_, ok := st.state.GetDelegatedDesignation(addr)
+	return ok
+}
+
+// applyAuthorizations sets code of authorities to EIP-7702 delegation designators (or clears it for
+// zero address). Invalid authorizations are skipped.
+func (st *StateTransition) applyAuthorizations(auths []types.Authorization) {
+	for i := range auths {
+		authority, err := st.validateAuthorization(&auths[i])
+		if err != nil {
+			continue
+		}
+		// PerEmptyAccountCost is charged as intrinsic gas, refund the difference if account exists
+		if st.state.Exist(authority) {
+			st.state.AddRefund(fixedgas.PerEmptyAccountCost - fixedgas.PerAuthBaseCost)
+		}
+		if auths[i].Address == (libcommon.Address{}) {
+			st.state.SetCode(authority, nil)
+		} else {
+			st.state.SetCode(authority, types.AddressToDelegation(auths[i].Address))
+		}
+		st.state.SetNonce(authority, auths[i].Nonce+1)
+	}
+}
+
+func (st *StateTransition) validateAuthorization(auth *types.Authorization) (authority libcommon.Address, err error) {
+	if !auth.ChainID.IsZero() && auth.ChainID.ToBig().Cmp(st.evm.ChainConfig().ChainID) != 0 {
+		return authority, ErrAuthorizationWrongChainID
+	}
+	if auth.Nonce+1 < auth.Nonce {
+		return authority, ErrAuthorizationNonceOverflow
+	}
+	if authority, err = auth.RecoverSigner(); err != nil {
+		return authority, err
+	}
+	// Authority is accessed even if authorization turns out to be invalid below
+	st.state.AddAddressToAccessList(authority)
+	if code := st.state.GetCode(authority); len(code) > 0 {
+		if _, ok := types.ParseDelegation(code); !ok {
+			return authority, ErrAuthorizationDestinationHasCode
+		}
+	}
+	if nonce := st.state.GetNonce(authority); nonce != auth.Nonce {
+		return authority, ErrAuthorizationNonceMismatch
+	}
+	return authority, nil
+}
+
 // TransitionDb will transition the state by applying the current message and
 // returning the evm execution result with following fields.
 //
@@ -376,7 +430,7 @@ func (st *StateTransition) TransitionDb(refunds bool, gasBailout bool) (*Executi
 	isEIP3860 := vmConfig.HasEip3860(rules)
 
 	// Check clauses 4-5, subtract intrinsic gas if everything is correct
-	gas, err := IntrinsicGas(st.data, st.msg.AccessList(), contractCreation, rules.IsHomestead, rules.IsIstanbul, isEIP3860)
+	gas, err := IntrinsicGas(st.data, st.msg.AccessList(), uint64(len(st.msg.Authorizations())), contractCreation, rules.IsHomestead, rules.IsIstanbul, isEIP3860)
 	if err != nil {
 		return nil, err
 	}
@@ -416,6 +470,15 @@ func (st *StateTransition) TransitionDb(refunds bool, gasBailout bool) (*Executi
 	} else {
 		// Increment the nonce for the next transaction
 		st.state.SetNonce(msg.From(), st.state.GetNonce(sender.Address())+1)
+		if rules.IsPrague {
+			// Authorizations are applied after the nonce increment, so sender can authorize with its next nonce
+			st.applyAuthorizations(msg.Authorizations())
+			// Delegation target of the destination is warm, as the destination itself. It's resolved after
+			// authorizations: the delegation may be set by this transaction
+			if target, ok := types.ParseDelegation(st.state.GetCode(*msg.To())); ok {
+				st.state.AddAddressToAccessList(target)
+			}
+		}
 		ret, st.gas, vmerr = st.evm.Call(sender, st.to(), st.data, st.gas, st.value, bailout)
 	}
 	if refunds {
@@ -0,0 +1,71 @@
+package core
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/holiman/uint256"
+	libcommon "github.com/ledgerwatch/erigon-lib/common"
+	"github.com/ledgerwatch/erigon-lib/common/fixedgas"
+	"github.com/ledgerwatch/erigon-lib/kv/memdb"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ledgerwatch/erigon/core/state"
+	"github.com/ledgerwatch/erigon/core/types"
+	"github.com/ledgerwatch/erigon/core/vm"
+	"github.com/ledgerwatch/erigon/crypto"
+	"github.com/ledgerwatch/erigon/params"
+)
+
+// TestSetCodeTxDelegationTargetWarm calls EOA, which is delegated by the same transaction to the code
+// `PUSH20 <target> BALANCE POP STOP`: the target is in access list, BALANCE costs 100 gas, not 2600
+func TestSetCodeTxDelegationTargetWarm(t *testing.T) {
+	_, tx := memdb.NewTestTx(t)
+	ibs := state.New(state.NewPlainStateReader(tx))
+
+	key, _ := crypto.GenerateKey()
+	authorityKey, _ := crypto.GenerateKey()
+	sender, authority := crypto.PubkeyToAddress(key.PublicKey), crypto.PubkeyToAddress(authorityKey.PublicKey)
+	target := libcommon.HexToAddress("0x000000000000000000000000000000000000aaaa")
+	ibs.AddBalance(sender, uint256.NewInt(1_000_000_000_000))
+	ibs.SetCode(target, append(append([]byte{byte(vm.PUSH20)}, target.Bytes()...), byte(vm.BALANCE), byte(vm.POP), byte(vm.STOP)))
+
+	config := *params.TestChainConfig
+	config.ShanghaiTime, config.CancunTime, config.PragueTime = big.NewInt(0), big.NewInt(0), big.NewInt(0)
+	header := &types.Header{
+		Number:        big.NewInt(1),
+		Difficulty:    new(big.Int),
+		GasLimit:      1_000_000,
+		BaseFee:       big.NewInt(1),
+		ExcessBlobGas: new(uint64),
+	}
+	rules := config.Rules(header.Number.Uint64(), header.Time)
+
+	auth, err := types.SignAuthorization(types.Authorization{ChainID: *uint256.MustFromBig(config.ChainID), Address: target}, authorityKey)
+	require.NoError(t, err)
+	signer := types.LatestSignerForChainID(config.ChainID)
+	txn, err := types.SignNewTx(key, *signer, &types.SetCodeTransaction{
+		DynamicFeeTransaction: types.DynamicFeeTransaction{
+			CommonTx: types.CommonTx{To: &authority, Gas: 100_000, Value: uint256.NewInt(0)},
+			ChainID:  uint256.MustFromBig(config.ChainID),
+			Tip:      uint256.NewInt(1),
+			FeeCap:   uint256.NewInt(10),
+		},
+		Authorizations: []types.Authorization{auth},
+	})
+	require.NoError(t, err)
+	msg, err := txn.AsMessage(*signer, header.BaseFee, rules)
+	require.NoError(t, err)
+
+	coinbase := libcommon.Address{}
+	evm := vm.NewEVM(NewEVMBlockContext(header, nil, nil, &coinbase), NewEVMTxContext(msg), ibs, &config, vm.Config{})
+	res, err := ApplyMessage(evm, msg, new(GasPool).AddGas(header.GasLimit), true, false)
+	require.NoError(t, err)
+	require.NoError(t, res.Err)
+	// authority doesn't exist before the transaction: no refund of PerEmptyAccountCost
+	require.Equal(t, fixedgas.TxGas+fixedgas.PerEmptyAccountCost+3+params.WarmStorageReadCostEIP2929+2, res.UsedGas)
+
+	delegation, ok := ibs.GetDelegatedDesignation(authority)
+	require.True(t, ok)
+	require.Equal(t, target, delegation)
+}
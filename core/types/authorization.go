@@ -0,0 +1,231 @@
+package types
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/holiman/uint256"
+	"github.com/ledgerwatch/secp256k1"
+
+	libcommon "github.com/ledgerwatch/erigon-lib/common"
+	"github.com/ledgerwatch/erigon-lib/common/hexutil"
+	rlp2 "github.com/ledgerwatch/erigon-lib/rlp"
+
+	"github.com/ledgerwatch/erigon/common/u256"
+	"github.com/ledgerwatch/erigon/crypto"
+	"github.com/ledgerwatch/erigon/rlp"
+)
+
+// EIP-7702: an authority signs authorization to set code of its account to delegation designator
+// 0xef0100 || Address. Calls to the account execute code of Address in the context of the authority.
+const (
+	authorizationMagic = 0x05 // prefix of authorization signing hash
+	DelegationLength   = 23   // len(DelegationPrefix) + 20
+)
+
+var DelegationPrefix = []byte{0xef, 0x01, 0x00}
+
+var ErrInvalidAuthorizationSig = errors.New("invalid authorization y_parity, r, s values")
+
+type Authorization struct {
+	ChainID uint256.Int // 0 means the authorization is valid on any chain
+	Address libcommon.Address
+	Nonce   uint64
+	YParity uint8
+	R       uint256.Int
+	S       uint256.Int
+}
+
+// SigningHash - keccak256(0x05 || rlp([chain_id, address, nonce]))
+func (a *Authorization) SigningHash() libcommon.Hash {
+	return prefixedRlpHash(authorizationMagic, []interface{}{&a.ChainID, a.Address, a.Nonce})
+}
+
+// RecoverSigner returns the authority: address which signed the authorization
+func (a *Authorization) RecoverSigner() (libcommon.Address, error) {
+	if a.YParity > 1 {
+		return libcommon.Address{}, ErrInvalidAuthorizationSig
+	}
+	var v uint256.Int
+	v.SetUint64(uint64(a.YParity))
+	v.Add(&v, u256.Num27)
+	addr, err := recoverPlain(secp256k1.DefaultContext, a.SigningHash(), &a.R, &a.S, &v, true)
+	if err != nil {
+		return libcommon.Address{}, fmt.Errorf("%w: %s", ErrInvalidAuthorizationSig, err)
+	}
+	return addr, nil
+}
+
+// SignAuthorization signs the authorization (ChainID, Address, Nonce) with the authority's key
+func SignAuthorization(auth Authorization, prv *ecdsa.PrivateKey) (Authorization, error) {
+	h := auth.SigningHash()
+	sig, err := crypto.Sign(h[:], prv)
+	if err != nil {
+		return Authorization{}, err
+	}
+	r, s, v := decodeSignature(sig)
+	auth.R.Set(r)
+	auth.S.Set(s)
+	auth.YParity = uint8(v.Uint64())
+	return auth, nil
+}
+
+func (a *Authorization) payloadSize() int {
+	size := 1 + rlp.Uint256LenExcludingHead(&a.ChainID)
+	size += 21 // Address
+	size += 1 + rlp.IntLenExcludingHead(a.Nonce)
+	size += 1 + rlp.IntLenExcludingHead(uint64(a.YParity))
+	size += 1 + rlp.Uint256LenExcludingHead(&a.R)
+	size += 1 + rlp.Uint256LenExcludingHead(&a.S)
+	return size
+}
+
+func authorizationsSize(auths []Authorization) int {
+	var size int
+	for i := range auths {
+		payloadSize := auths[i].payloadSize()
+		size += rlp2.ListPrefixLen(payloadSize) + payloadSize
+	}
+	return size
+}
+
+func encodeAuthorizations(auths []Authorization, w io.Writer, b []byte) error {
+	for i := range auths {
+		a := &auths[i]
+		if err := EncodeStructSizePrefix(a.payloadSize(), w, b); err != nil {
+			return err
+		}
+		if err := a.ChainID.EncodeRLP(w); err != nil {
+			return err
+		}
+		b[0] = 128 + 20
+		if _, err := w.Write(b[:1]); err != nil {
+			return err
+		}
+		if _, err := w.Write(a.Address[:]); err != nil {
+			return err
+		}
+		if err := rlp.EncodeInt(a.Nonce, w, b); err != nil {
+			return err
+		}
+		if err := rlp.EncodeInt(uint64(a.YParity), w, b); err != nil {
+			return err
+		}
+		if err := a.R.EncodeRLP(w); err != nil {
+			return err
+		}
+		if err := a.S.EncodeRLP(w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func decodeAuthorizations(auths *[]Authorization, s *rlp.Stream) error {
+	_, err := s.List()
+	if err != nil {
+		return fmt.Errorf("open authorizationList: %w", err)
+	}
+	var b []byte
+	i := 0
+	for _, err = s.List(); err == nil; _, err = s.List() {
+		auth := Authorization{}
+		if b, err = s.Uint256Bytes(); err != nil {
+			return fmt.Errorf("read ChainID: %w", err)
+		}
+		auth.ChainID.SetBytes(b)
+		if b, err = s.Bytes(); err != nil {
+			return fmt.Errorf("read Address: %w", err)
+		}
+		if len(b) != 20 {
+			return fmt.Errorf("wrong size for Authorization address: %d", len(b))
+		}
+		copy(auth.Address[:], b)
+		if auth.Nonce, err = s.Uint(); err != nil {
+			return fmt.Errorf("read Nonce: %w", err)
+		}
+		var yParity uint64
+		if yParity, err = s.Uint(); err != nil {
+			return fmt.Errorf("read YParity: %w", err)
+		}
+		if yParity > 0xff {
+			return fmt.Errorf("wrong YParity: %d", yParity)
+		}
+		auth.YParity = uint8(yParity)
+		if b, err = s.Uint256Bytes(); err != nil {
+			return fmt.Errorf("read R: %w", err)
+		}
+		auth.R.SetBytes(b)
+		if b, err = s.Uint256Bytes(); err != nil {
+			return fmt.Errorf("read S: %w", err)
+		}
+		auth.S.SetBytes(b)
+		// end of authorization
+		if err = s.ListEnd(); err != nil {
+			return fmt.Errorf("close Authorization: %w", err)
+		}
+		*auths = append(*auths, auth)
+		i++
+	}
+	if !errors.Is(err, rlp.EOL) {
+		return fmt.Errorf("open authorization: %d %w", i, err)
+	}
+	if err = s.ListEnd(); err != nil {
+		return fmt.Errorf("close authorizationList: %w", err)
+	}
+	return nil
+}
+
+type JsonAuthorization struct {
+	ChainID hexutil.Big       `json:"chainId"`
+	Address libcommon.Address `json:"address"`
+	Nonce   hexutil.Uint64    `json:"nonce"`
+	YParity hexutil.Uint64    `json:"yParity"`
+	R       hexutil.Big       `json:"r"`
+	S       hexutil.Big       `json:"s"`
+}
+
+func (a Authorization) ToJSON() JsonAuthorization {
+	return JsonAuthorization{
+		ChainID: hexutil.Big(*a.ChainID.ToBig()),
+		Address: a.Address,
+		Nonce:   hexutil.Uint64(a.Nonce),
+		YParity: hexutil.Uint64(a.YParity),
+		R:       hexutil.Big(*a.R.ToBig()),
+		S:       hexutil.Big(*a.S.ToBig()),
+	}
+}
+
+func (a JsonAuthorization) ToAuthorization() (Authorization, error) {
+	auth := Authorization{Address: a.Address, Nonce: uint64(a.Nonce)}
+	if a.YParity > 0xff {
+		return auth, fmt.Errorf("wrong yParity: %d", a.YParity)
+	}
+	auth.YParity = uint8(a.YParity)
+	if overflow := auth.ChainID.SetFromBig(a.ChainID.ToInt()); overflow {
+		return auth, errors.New("chainId in authorization does not fit in 256 bits")
+	}
+	if overflow := auth.R.SetFromBig(a.R.ToInt()); overflow {
+		return auth, errors.New("r in authorization does not fit in 256 bits")
+	}
+	if overflow := auth.S.SetFromBig(a.S.ToInt()); overflow {
+		return auth, errors.New("s in authorization does not fit in 256 bits")
+	}
+	return auth, nil
+}
+
+// ParseDelegation returns the delegation target if code is a delegation designator
+func ParseDelegation(code []byte) (libcommon.Address, bool) {
+	if len(code) != DelegationLength || !bytes.HasPrefix(code, DelegationPrefix) {
+		return libcommon.Address{}, false
+	}
+	return libcommon.BytesToAddress(code[len(DelegationPrefix):]), true
+}
+
+// AddressToDelegation returns the delegation designator of the target
+func AddressToDelegation(addr libcommon.Address) []byte {
+	return append(libcommon.CopyBytes(DelegationPrefix), addr.Bytes()...)
+}
@@ -106,6 +106,8 @@ type Header struct {
 
 	ParentBeaconBlockRoot *libcommon.Hash `json:"parentBeaconBlockRoot"` // EIP-4788
 
+	RequestsHash *libcommon.Hash `json:"requestsHash"` // EIP-7685
+
 	// The verkle proof is ignored in legacy headers
 	Verkle        bool
 	VerkleProof   []byte
@@ -162,6 +164,10 @@ func (h *Header) EncodingSize() int {
 		encodingSize += 33
 	}
 
+	if h.RequestsHash != nil {
+		encodingSize += 33
+	}
+
 	if h.Verkle {
 		// Encoding of Verkle Proof
 		encodingSize += rlp2.StringLen(h.VerkleProof)
@@ -311,6 +317,16 @@ func (h *Header) EncodeRLP(w io.Writer) error {
 		}
 	}
 
+	if h.RequestsHash != nil {
+		b[0] = 128 + 32
+		if _, err := w.Write(b[:1]); err != nil {
+			return err
+		}
+		if _, err := w.Write(h.RequestsHash.Bytes()); err != nil {
+			return err
+		}
+	}
+
 	if h.Verkle {
 		if err := rlp.EncodeString(h.VerkleProof, w, b[:]); err != nil {
 			return err
@@ -499,6 +515,23 @@ func (h *Header) DecodeRLP(s *rlp.Stream) error {
 	h.ParentBeaconBlockRoot = new(libcommon.Hash)
 	h.ParentBeaconBlockRoot.SetBytes(b)
 
+	// RequestsHash
+	if b, err = s.Bytes(); err != nil {
+		if errors.Is(err, rlp.EOL) {
+			h.RequestsHash = nil
+			if err := s.ListEnd(); err != nil {
+				return fmt.Errorf("close header struct (no RequestsHash): %w", err)
+			}
+			return nil
+		}
+		return fmt.Errorf("read RequestsHash: %w", err)
+	}
+	if len(b) != 32 {
+		return fmt.Errorf("wrong size for RequestsHash: %d", len(b))
+	}
+	h.RequestsHash = new(libcommon.Hash)
+	h.RequestsHash.SetBytes(b)
+
 	if h.Verkle {
 		if h.VerkleProof, err = s.Bytes(); err != nil {
 			return fmt.Errorf("read VerkleProof: %w", err)
@@ -558,6 +591,9 @@ func (h *Header) Size() common.StorageSize {
 	if h.ParentBeaconBlockRoot != nil {
 		s += common.StorageSize(32)
 	}
+	if h.RequestsHash != nil {
+		s += common.StorageSize(32)
+	}
 	return s
 }
 
@@ -640,6 +676,10 @@ type Block struct {
 	transactions Transactions
 	withdrawals  []*Withdrawal
 
+	// requests are the execution layer requests (EIP-7685) of a block built by this node.
+	// They are not part of the block encoding, the header only commits to their hash.
+	requests FlatRequests
+
 	// caches
 	hash atomic.Value
 	size atomic.Value
@@ -1190,6 +1230,10 @@ func CopyHeader(h *Header) *Header {
 		cpy.ParentBeaconBlockRoot = new(libcommon.Hash)
 		cpy.ParentBeaconBlockRoot.SetBytes(h.ParentBeaconBlockRoot.Bytes())
 	}
+	if h.RequestsHash != nil {
+		cpy.RequestsHash = new(libcommon.Hash)
+		cpy.RequestsHash.SetBytes(h.RequestsHash.Bytes())
+	}
 	return &cpy
 }
 
@@ -1389,6 +1433,8 @@ func (b *Block) BaseFee() *big.Int {
 func (b *Block) WithdrawalsHash() *libcommon.Hash       { return b.header.WithdrawalsHash }
 func (b *Block) Withdrawals() Withdrawals               { return b.withdrawals }
 func (b *Block) ParentBeaconBlockRoot() *libcommon.Hash { return b.header.ParentBeaconBlockRoot }
+func (b *Block) RequestsHash() *libcommon.Hash          { return b.header.RequestsHash }
+func (b *Block) Requests() FlatRequests                 { return b.requests }
 
 // Header returns a deep-copy of the entire block header using CopyHeader()
 func (b *Block) Header() *Header       { return CopyHeader(b.header) }
@@ -1552,11 +1598,20 @@ func (b *Block) Copy() *Block {
 		sizeValue.Store(size)
 	}
 
+	var requests FlatRequests
+	if b.requests != nil {
+		requests = make(FlatRequests, len(b.requests))
+		for i, r := range b.requests {
+			requests[i] = FlatRequest{Type: r.Type, RequestData: libcommon.Copy(r.RequestData)}
+		}
+	}
+
 	return &Block{
 		header:       CopyHeader(b.header),
 		uncles:       uncles,
 		transactions: CopyTxs(b.transactions),
 		withdrawals:  withdrawals,
+		requests:     requests,
 		hash:         hashValue,
 		size:         sizeValue,
 	}
@@ -1572,6 +1627,19 @@ func (b *Block) WithSeal(header *Header) *Block {
 		transactions: b.transactions,
 		uncles:       b.uncles,
 		withdrawals:  b.withdrawals,
+		requests:     b.requests,
+	}
+}
+
+// WithRequests returns a new block with the data from b and the given execution layer requests,
+// as produced by the block builder.
+func (b *Block) WithRequests(requests FlatRequests) *Block {
+	return &Block{
+		header:       b.header,
+		transactions: b.transactions,
+		uncles:       b.uncles,
+		withdrawals:  b.withdrawals,
+		requests:     requests,
 	}
 }
 
@@ -0,0 +1,73 @@
+package types
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+
+	libcommon "github.com/ledgerwatch/erigon-lib/common"
+)
+
+// Sizes of the fields of an EIP-6110 deposit request.
+const (
+	BLSPubKeyLen             = 48
+	WithdrawalCredentialsLen = 32
+	BLSSigLen                = 96
+	DepositRequestDataLen    = BLSPubKeyLen + WithdrawalCredentialsLen + 8 /* amount */ + BLSSigLen + 8 /* index */
+)
+
+// DepositEventSignature is the topic of the deposit contract event
+// DepositEvent(bytes pubkey, bytes withdrawal_credentials, bytes amount, bytes signature, bytes index).
+var DepositEventSignature = libcommon.HexToHash("0x649bbc62d0e31342afea4e5cd82d4049e7e1ee912fc0889aa790803be39038c5")
+
+// The DepositEvent data is the ABI encoding of 5 dynamic byte arrays of fixed sizes:
+// 5 offsets, followed by each array as a length word and the data padded to 32 bytes.
+const depositEventDataLen = 576
+
+// depositEventFields are the offsets and sizes of the fields in the DepositEvent data.
+var depositEventFields = [5]struct{ offset, size int }{
+	{192, BLSPubKeyLen},             // pubkey
+	{288, WithdrawalCredentialsLen}, // withdrawal_credentials
+	{352, 8},                        // amount, little endian gwei
+	{416, BLSSigLen},                // signature
+	{544, 8},                        // index, little endian
+}
+
+// ParseDepositLogs collects the EIP-6110 deposit requests from the DepositEvent logs of the
+// deposit contract. Each deposit is encoded as pubkey ++ withdrawal_credentials ++ amount ++
+// signature ++ index, the data of the returned request is the concatenation of all deposits.
+func ParseDepositLogs(logs []*Log, depositContract libcommon.Address) (*FlatRequest, error) {
+	data := make([]byte, 0)
+	for _, log := range logs {
+		if log.Address != depositContract || len(log.Topics) == 0 || log.Topics[0] != DepositEventSignature {
+			continue
+		}
+		deposit, err := unpackDepositEvent(log.Data)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse deposit data: %w", err)
+		}
+		data = append(data, deposit...)
+	}
+	return &FlatRequest{Type: DepositRequestType, RequestData: data}, nil
+}
+
+func unpackDepositEvent(data []byte) ([]byte, error) {
+	if len(data) != depositEventDataLen {
+		return nil, fmt.Errorf("deposit wrong length: want %d, have %d", depositEventDataLen, len(data))
+	}
+	var word [32]byte
+	deposit := make([]byte, 0, DepositRequestDataLen)
+	for i, field := range depositEventFields {
+		// Check the offset of the field in the head, and its length word that precedes the data.
+		binary.BigEndian.PutUint64(word[24:], uint64(field.offset-32))
+		if !bytes.Equal(data[i*32:(i+1)*32], word[:]) {
+			return nil, fmt.Errorf("deposit field %d: wrong offset", i)
+		}
+		binary.BigEndian.PutUint64(word[24:], uint64(field.size))
+		if !bytes.Equal(data[field.offset-32:field.offset], word[:]) {
+			return nil, fmt.Errorf("deposit field %d: wrong length", i)
+		}
+		deposit = append(deposit, data[field.offset:field.offset+field.size]...)
+	}
+	return deposit, nil
+}
@@ -0,0 +1,76 @@
+package types
+
+import (
+	"crypto/sha256"
+	"fmt"
+
+	libcommon "github.com/ledgerwatch/erigon-lib/common"
+)
+
+// Execution layer request types, see EIP-7685: General purpose execution layer requests.
+const (
+	DepositRequestType    byte = 0x00 // EIP-6110
+	WithdrawalRequestType byte = 0x01 // EIP-7002
+)
+
+// EmptyRequestsHash is the requests hash of a block without requests, i.e. sha256("").
+var EmptyRequestsHash = libcommon.HexToHash("e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855")
+
+// FlatRequest is an execution layer request as defined by EIP-7685: a request type and the
+// request data, which is the concatenation of all requests of that type produced by a block.
+type FlatRequest struct {
+	Type        byte
+	RequestData []byte
+}
+
+// Encode returns the request type followed by the request data.
+func (r *FlatRequest) Encode() []byte {
+	return append([]byte{r.Type}, r.RequestData...)
+}
+
+// FlatRequests are the requests of a block, in ascending order of request type.
+type FlatRequests []FlatRequest
+
+// Hash returns the EIP-7685 requests hash committed to by the block header:
+// sha256(sha256(type_0 ++ data_0) ++ sha256(type_1 ++ data_1) ++ ...), skipping empty requests.
+func (r FlatRequests) Hash() *libcommon.Hash {
+	outer := sha256.New()
+	for i := range r {
+		if len(r[i].RequestData) == 0 {
+			continue
+		}
+		inner := sha256.Sum256(r[i].Encode())
+		outer.Write(inner[:])
+	}
+	var h libcommon.Hash
+	outer.Sum(h[:0])
+	return &h
+}
+
+// Encode returns the non-empty requests, each prefixed by its type, as they are sent over the Engine API.
+func (r FlatRequests) Encode() [][]byte {
+	encoded := make([][]byte, 0, len(r))
+	for i := range r {
+		if len(r[i].RequestData) == 0 {
+			continue
+		}
+		encoded = append(encoded, r[i].Encode())
+	}
+	return encoded
+}
+
+// DecodeFlatRequests is the inverse of [FlatRequests.Encode]. The requests must be non-empty
+// and strictly ordered by request type.
+func DecodeFlatRequests(encoded [][]byte) (FlatRequests, error) {
+	requests := make(FlatRequests, 0, len(encoded))
+	for i, enc := range encoded {
+		if len(enc) <= 1 {
+			return nil, fmt.Errorf("empty request at index %d", i)
+		}
+		if i > 0 && enc[0] <= requests[i-1].Type {
+			return nil, fmt.Errorf("requests not ordered by type at index %d", i)
+		}
+		requests = append(requests, FlatRequest{Type: enc[0], RequestData: libcommon.Copy(enc[1:])})
+	}
+	return requests, nil
+}
@@ -0,0 +1,78 @@
+package types
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	libcommon "github.com/ledgerwatch/erigon-lib/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ledgerwatch/erigon/common"
+)
+
+func TestEmptyRequestsHash(t *testing.T) {
+	t.Parallel()
+	requests := FlatRequests{{Type: DepositRequestType}, {Type: WithdrawalRequestType, RequestData: []byte{}}}
+	assert.Equal(t, EmptyRequestsHash, *requests.Hash())
+	assert.Empty(t, requests.Encode())
+}
+
+func TestRequestsEncoding(t *testing.T) {
+	t.Parallel()
+	requests := FlatRequests{{Type: DepositRequestType, RequestData: []byte{1, 2}}, {Type: WithdrawalRequestType}}
+	encoded := requests.Encode()
+	require.Equal(t, [][]byte{{DepositRequestType, 1, 2}}, encoded)
+	decoded, err := DecodeFlatRequests(encoded)
+	require.NoError(t, err)
+	assert.Equal(t, requests.Hash(), decoded.Hash())
+
+	_, err = DecodeFlatRequests([][]byte{{WithdrawalRequestType, 1}, {DepositRequestType, 1}})
+	assert.Error(t, err)
+	_, err = DecodeFlatRequests([][]byte{{DepositRequestType}})
+	assert.Error(t, err)
+}
+
+// packDepositEvent ABI-encodes the DepositEvent data.
+func packDepositEvent(fields ...[]byte) []byte {
+	var head, tail bytes.Buffer
+	var word [32]byte
+	for _, field := range fields {
+		binary.BigEndian.PutUint64(word[24:], uint64(len(fields)*32+tail.Len()))
+		head.Write(word[:])
+		binary.BigEndian.PutUint64(word[24:], uint64(len(field)))
+		tail.Write(word[:])
+		tail.Write(common.RightPadBytes(field, (len(field)+31)/32*32))
+	}
+	return append(head.Bytes(), tail.Bytes()...)
+}
+
+func TestParseDepositLogs(t *testing.T) {
+	t.Parallel()
+	depositContract := libcommon.HexToAddress("0x00000000219ab540356cBB839Cbe05303d7705Fa")
+	pubkey := bytes.Repeat([]byte{0x01}, BLSPubKeyLen)
+	credentials := bytes.Repeat([]byte{0x02}, WithdrawalCredentialsLen)
+	amount := binary.LittleEndian.AppendUint64(nil, 32_000_000_000)
+	signature := bytes.Repeat([]byte{0x03}, BLSSigLen)
+	index := binary.LittleEndian.AppendUint64(nil, 7)
+	data := packDepositEvent(pubkey, credentials, amount, signature, index)
+
+	logs := []*Log{
+		{Address: depositContract, Topics: []libcommon.Hash{DepositEventSignature}, Data: data},
+		// not the deposit contract
+		{Address: libcommon.HexToAddress("0x01"), Topics: []libcommon.Hash{DepositEventSignature}, Data: data},
+		// not a DepositEvent
+		{Address: depositContract, Topics: []libcommon.Hash{{}}, Data: data},
+	}
+	request, err := ParseDepositLogs(logs, depositContract)
+	require.NoError(t, err)
+	assert.Equal(t, DepositRequestType, request.Type)
+	expected := bytes.Join([][]byte{pubkey, credentials, amount, signature, index}, nil)
+	assert.Len(t, expected, DepositRequestDataLen)
+	assert.Equal(t, expected, request.RequestData)
+
+	logs[0].Data = data[:len(data)-32]
+	_, err = ParseDepositLogs(logs, depositContract)
+	assert.Error(t, err)
+}
@@ -53,6 +53,7 @@ const (
 	AccessListTxType
 	DynamicFeeTxType
 	BlobTxType
+	SetCodeTxType
 )
 
 // Transaction is an Ethereum transaction.
@@ -66,6 +67,7 @@ type Transaction interface {
 	GetFeeCap() *uint256.Int
 	Cost() *uint256.Int
 	GetBlobHashes() []libcommon.Hash
+	GetAuthorizations() []Authorization
 	GetGas() uint64
 	GetBlobGas() uint64
 	GetValue() *uint256.Int
@@ -206,6 +208,13 @@ func UnmarshalTransactionFromBinary(data []byte) (Transaction, error) {
 			return nil, err
 		}
 		return t, nil
+	case SetCodeTxType:
+		s := rlp.NewStream(bytes.NewReader(data[1:]), uint64(len(data)-1))
+		t := &SetCodeTransaction{}
+		if err := t.DecodeRLP(s); err != nil {
+			return nil, err
+		}
+		return t, nil
 	default:
 		if data[0] >= 0x80 {
 			// Tx is type legacy which is RLP encoded
@@ -527,6 +536,7 @@ type Message struct {
 	checkNonce       bool
 	isFree           bool
 	blobHashes       []libcommon.Hash
+	authorizations   []Authorization
 }
 
 func NewMessage(from libcommon.Address, to *libcommon.Address, nonce uint64, amount *uint256.Int, gasLimit uint64,
@@ -602,6 +612,12 @@ func (m Message) MaxFeePerBlobGas() *uint256.Int {
 
 func (m Message) BlobHashes() []libcommon.Hash { return m.blobHashes }
 
+func (m Message) Authorizations() []Authorization { return m.authorizations }
+
+func (m *Message) SetAuthorizations(authorizations []Authorization) {
+	m.authorizations = authorizations
+}
+
 func DecodeSSZ(data []byte, dest codec.Deserializable) error {
 	err := dest.Deserialize(codec.NewDecodingReader(bytes.NewReader(data), uint64(len(data))))
 	return err
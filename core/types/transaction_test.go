@@ -32,6 +32,7 @@ import (
 	"github.com/holiman/uint256"
 	"github.com/stretchr/testify/assert"
 
+	"github.com/ledgerwatch/erigon-lib/chain"
 	libcommon "github.com/ledgerwatch/erigon-lib/common"
 	"github.com/ledgerwatch/erigon-lib/common/fixedgas"
 	types2 "github.com/ledgerwatch/erigon-lib/types"
@@ -816,3 +817,130 @@ func TestBlobTxEncodeDecode(t *testing.T) {
 		}
 	}
 }
+
+func TestSetCodeTxEncodeDecode(t *testing.T) {
+	t.Parallel()
+	key, _ := crypto.GenerateKey()
+	authorityKey, _ := crypto.GenerateKey()
+	var (
+		signer    = LatestSignerForChainID(libcommon.Big1)
+		recipient = libcommon.HexToAddress("095e7baea6a6c7c4c2dfeb977efac326af552d87")
+		target    = libcommon.HexToAddress("0x0000000000000000000000000000000000000aaa")
+	)
+	auth, err := SignAuthorization(Authorization{ChainID: *uint256.NewInt(1), Address: target, Nonce: 7}, authorityKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	authority, err := auth.RecoverSigner()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := crypto.PubkeyToAddress(authorityKey.PublicKey); authority != want {
+		t.Fatalf("wrong authority, want %x, got %x", want, authority)
+	}
+
+	txdata := &SetCodeTransaction{
+		DynamicFeeTransaction: DynamicFeeTransaction{
+			CommonTx: CommonTx{
+				Nonce: 1,
+				To:    &recipient,
+				Gas:   100_000,
+				Value: uint256.NewInt(0),
+				Data:  []byte("abcdef"),
+			},
+			ChainID: uint256.NewInt(1),
+			Tip:     uint256.NewInt(1),
+			FeeCap:  uint256.NewInt(10),
+		},
+		Authorizations: []Authorization{auth},
+	}
+	tx, err := SignNewTx(key, *signer, txdata)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sender, err := tx.Sender(*signer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := crypto.PubkeyToAddress(key.PublicKey); sender != want {
+		t.Fatalf("wrong sender, want %x, got %x", want, sender)
+	}
+
+	for _, encodeDecode := range []func(Transaction) (Transaction, error){encodeDecodeBinary, encodeDecodeJSON} {
+		parsedTx, err := encodeDecode(tx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = assertEqual(tx, parsedTx); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(tx.GetAuthorizations(), parsedTx.GetAuthorizations()) {
+			t.Fatalf("authorizations differ: want %v, got %v", tx.GetAuthorizations(), parsedTx.GetAuthorizations())
+		}
+	}
+
+	var buf bytes.Buffer
+	if err = tx.MarshalBinary(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if buf.Len() != tx.EncodingSize() {
+		t.Fatalf("wrong encoding size, want %d, got %d", buf.Len(), tx.EncodingSize())
+	}
+
+	if delegation, ok := ParseDelegation(AddressToDelegation(target)); !ok || delegation != target {
+		t.Fatalf("wrong delegation: %x", delegation)
+	}
+}
+
+func TestSetCodeTxAsMessage(t *testing.T) {
+	t.Parallel()
+	key, _ := crypto.GenerateKey()
+	authorityKey, _ := crypto.GenerateKey()
+	signer := LatestSignerForChainID(libcommon.Big1)
+	recipient := libcommon.HexToAddress("095e7baea6a6c7c4c2dfeb977efac326af552d87")
+	auth, err := SignAuthorization(Authorization{ChainID: *uint256.NewInt(1), Address: recipient}, authorityKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signed, err := SignNewTx(key, *signer, &SetCodeTransaction{
+		DynamicFeeTransaction: DynamicFeeTransaction{
+			CommonTx: CommonTx{Nonce: 1, To: &recipient, Gas: 100_000, Value: uint256.NewInt(0)},
+			ChainID:  uint256.NewInt(1),
+			Tip:      uint256.NewInt(1),
+			FeeCap:   uint256.NewInt(10),
+		},
+		Authorizations: []Authorization{auth},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// decoded transaction has no cached sender
+	parsed, err := encodeDecodeBinary(signed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx := parsed.(*SetCodeTransaction)
+	if tx.from.Load() != nil {
+		t.Fatal("sender is cached")
+	}
+
+	if _, err = tx.AsMessage(*signer, big.NewInt(5), &chain.Rules{IsLondon: true}); err == nil {
+		t.Fatal("expected error before Prague")
+	}
+	msg, err := tx.AsMessage(*signer, big.NewInt(5), &chain.Rules{IsLondon: true, IsPrague: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := crypto.PubkeyToAddress(key.PublicKey); msg.From() != want {
+		t.Fatalf("wrong sender, want %x, got %x", want, msg.From())
+	}
+	if sc := tx.from.Load(); sc == nil || sc.(libcommon.Address) != msg.From() {
+		t.Fatal("sender is not cached")
+	}
+	if msg.GasPrice().Uint64() != 6 {
+		t.Fatalf("wrong gas price %d", msg.GasPrice().Uint64())
+	}
+	if !reflect.DeepEqual(msg.Authorizations(), tx.Authorizations) {
+		t.Fatalf("wrong authorizations %v", msg.Authorizations())
+	}
+}
@@ -41,3 +41,36 @@ func codeBitmap(code []byte) []uint64 {
 	}
 	return bits
 }
+
+// eofImmediateSize returns the size of the immediate argument of the instruction at pc of EOF code section.
+// For RJUMPV with missing jump table size it counts the size byte only.
+func eofImmediateSize(code []byte, pc int) int {
+	switch op := OpCode(code[pc]); op {
+	case RJUMP, RJUMPI, CALLF, JUMPF, DATALOADN:
+		return 2
+	case RJUMPV:
+		if pc+1 < len(code) {
+			return 1 + 2*(int(code[pc+1])+1)
+		}
+		return 1
+	default:
+		if op >= PUSH1 && op <= PUSH32 {
+			return int(op - PUSH1 + 1)
+		}
+		return 0
+	}
+}
+
+// eofCodeBitmap collects data locations in EOF code section: PUSH data and immediates
+// of EOF instructions (EIP-4200, EIP-4750, EIP-7480). Locations beyond the end of code aren't marked.
+func eofCodeBitmap(code []byte) []uint64 {
+	bits := make([]uint64, (len(code)+63)/64)
+	for pc := 0; pc < len(code); {
+		size := eofImmediateSize(code, pc)
+		pc++
+		for end := pc + size; pc < end && pc < len(code); pc++ {
+			bits[pc/64] |= uint64(1) << (pc & 63)
+		}
+	}
+	return bits
+}
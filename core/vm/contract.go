@@ -47,15 +47,18 @@ type Contract struct {
 	CallerAddress libcommon.Address
 	caller        ContractRef
 	self          libcommon.Address
-	jumpdests     map[libcommon.Hash][]uint64 // Aggregated result of JUMPDEST analysis.
-	analysis      []uint64                    // Locally cached result of JUMPDEST analysis
+	jumpdests     map[libcommon.Hash][]uint64   // Aggregated result of JUMPDEST analysis.
+	analysis      []uint64                      // Locally cached result of JUMPDEST analysis
+	containers    map[libcommon.Hash]*Container // Aggregated result of EOF container parsing.
 	skipAnalysis  bool
 
-	Code     []byte
+	Code     []byte // code being executed: code section of Container for EOF contracts
 	CodeHash libcommon.Hash
 	CodeAddr *libcommon.Address
 	Input    []byte
 
+	Container *Container // parsed EOF container, nil for legacy code
+
 	Gas   uint64
 	value *uint256.Int
 }
@@ -65,10 +68,12 @@ func NewContract(caller ContractRef, addr libcommon.Address, value *uint256.Int,
 	c := &Contract{CallerAddress: caller.Address(), caller: caller, self: addr}
 
 	if parent, ok := caller.(*Contract); ok {
-		// Reuse JUMPDEST analysis from parent context if available.
+		// Reuse JUMPDEST analysis and parsed EOF containers from parent context if available.
 		c.jumpdests = parent.jumpdests
+		c.containers = parent.containers
 	} else {
 		c.jumpdests = make(map[libcommon.Hash][]uint64)
+		c.containers = make(map[libcommon.Hash]*Container)
 	}
 
 	// Gas should be a pointer so it can safely be reduced through the run
@@ -199,3 +204,27 @@ func (c *Contract) SetCodeOptionalHash(addr *libcommon.Address, codeAndHash *cod
 	c.CodeHash = codeAndHash.hash
 	c.CodeAddr = addr
 }
+
+// eofContainer parses code of the contract as EOF container. Same as JUMPDEST analysis, containers of
+// code with known hash are saved in parent context: code is parsed once, not on every call.
+func (c *Contract) eofContainer() (*Container, error) {
+	if c.CodeHash != (libcommon.Hash{}) {
+		if container, ok := c.containers[c.CodeHash]; ok {
+			return container, nil
+		}
+	}
+	container := &Container{}
+	if err := container.UnmarshalBinary(c.Code); err != nil {
+		return nil, err
+	}
+	if c.CodeHash != (libcommon.Hash{}) {
+		c.containers[c.CodeHash] = container
+	}
+	return container, nil
+}
+
+// setEOF switches the contract to execution of EOF container starting from the first code section
+func (c *Contract) setEOF(container *Container) {
+	c.Container = container
+	c.Code = container.Code[0]
+}
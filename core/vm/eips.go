@@ -29,6 +29,7 @@ import (
 )
 
 var activators = map[int]func(*JumpTable){
+	7702: enable7702,
 	7516: enable7516,
 	6780: enable6780,
 	5656: enable5656,
@@ -306,10 +307,17 @@ func enable6780(jt *JumpTable) {
 	}
 }
 
+// BlobBaseFee returns the blob base fee of the block, it's derived from the excess blob gas unless overridden
+func (evm *EVM) BlobBaseFee() (*uint256.Int, error) {
+	if evm.Context.BlobBaseFee != nil {
+		return evm.Context.BlobBaseFee, nil
+	}
+	return misc.GetBlobGasPrice(evm.ChainConfig(), *evm.Context.ExcessBlobGas)
+}
+
 // opBlobBaseFee implements the BLOBBASEFEE opcode
 func opBlobBaseFee(pc *uint64, interpreter *EVMInterpreter, callContext *ScopeContext) ([]byte, error) {
-	excessBlobGas := interpreter.evm.Context.ExcessBlobGas
-	blobBaseFee, err := misc.GetBlobGasPrice(interpreter.evm.ChainConfig(), *excessBlobGas)
+	blobBaseFee, err := interpreter.evm.BlobBaseFee()
 	if err != nil {
 		return nil, err
 	}
@@ -327,3 +335,12 @@ func enable7516(jt *JumpTable) {
 		numPush:     1,
 	}
 }
+
+// enable7702 applies EIP-7702 (Set EOA account code)
+// - Calls to account with delegation designator also pay for access of the delegation target.
+func enable7702(jt *JumpTable) {
+	jt[CALL].dynamicGas = gasCallEIP7702
+	jt[CALLCODE].dynamicGas = gasCallCodeEIP7702
+	jt[STATICCALL].dynamicGas = gasStaticCallEIP7702
+	jt[DELEGATECALL].dynamicGas = gasDelegateCallEIP7702
+}
@@ -0,0 +1,217 @@
+package vm
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// EVM Object Format (EOF) v1 container, EIP-3540:
+//
+//	container := header, body
+//	header    := 0xEF00, version, kind_types, types_size, kind_code, num_code_sections, code_size+, kind_data, data_size, 0x00
+//	body      := types_section, code_section+, data_section
+//
+// Code of EOF contract is validated once at creation (EIP-3670, EIP-4200, EIP-4750, EIP-5450), so execution
+// doesn't need JUMPDEST analysis and stack underflow/overflow checks except of CALLF/JUMPF.
+//
+// This is the early draft of EOF v1, not the current EOF spec: container sections and instructions of
+// EIP-7620 (EOFCREATE/RETURNCONTRACT), EIP-7069 (EXTCALL*, RETURNDATALOAD), EIP-663 (DUPN/SWAPN/EXCHANGE)
+// and EIP-7698 are not supported. EOF contracts are created by CREATE/CREATE2 and CREATE transactions,
+// EOF initcode can deploy only valid EOF code.
+const (
+	eofFormatByte = 0xef
+	eofMagicByte  = 0x00
+	eofVersion1   = 0x01
+
+	eofKindTypes     = 0x01
+	eofKindCode      = 0x02
+	eofKindContainer = 0x03
+	eofKindData      = 0xff
+
+	eofTypeSize          = 4
+	eofNonReturning      = 0x80 // outputs of code section which never returns to the caller
+	eofMaxInputs         = 0x7f
+	eofMaxOutputs        = 0x7f
+	eofMaxStackIncrease  = 0x3ff
+	eofMaxCodeSections   = 1024
+	eofReturnStackLimit  = 1024
+	eofMinContainerSize  = 20 // header with one code section, one type and one byte of code
+	eofStackLimit        = 1024
+	eofDataLoadWordSize  = 32
+	eofRelativeJumpWidth = 2
+)
+
+var (
+	errEOFInvalidMagic            = fmt.Errorf("%w: invalid magic", ErrInvalidEOF)
+	errEOFInvalidVersion          = fmt.Errorf("%w: invalid version", ErrInvalidEOF)
+	errEOFTruncatedHeader         = fmt.Errorf("%w: truncated header", ErrInvalidEOF)
+	errEOFInvalidSectionKind      = fmt.Errorf("%w: invalid section kind", ErrInvalidEOF)
+	errEOFUnsupportedContainers   = fmt.Errorf("%w: container sections are not supported", ErrInvalidEOF)
+	errEOFInvalidTypesSize        = fmt.Errorf("%w: invalid type section size", ErrInvalidEOF)
+	errEOFInvalidCodeSections     = fmt.Errorf("%w: invalid number of code sections", ErrInvalidEOF)
+	errEOFZeroSectionSize         = fmt.Errorf("%w: zero code section size", ErrInvalidEOF)
+	errEOFMissingTerminator       = fmt.Errorf("%w: missing header terminator", ErrInvalidEOF)
+	errEOFInvalidContainerSize    = fmt.Errorf("%w: invalid container size", ErrInvalidEOF)
+	errEOFInvalidFirstSectionType = fmt.Errorf("%w: invalid type of first code section", ErrInvalidEOF)
+	errEOFInvalidSectionType      = fmt.Errorf("%w: invalid type of code section", ErrInvalidEOF)
+)
+
+// eofType - entry of types section: signature of the code section
+type eofType struct {
+	Inputs           uint8
+	Outputs          uint8 // eofNonReturning for code sections which don't return
+	MaxStackIncrease uint16
+}
+
+func (t eofType) returning() bool { return t.Outputs != eofNonReturning }
+
+// Container is a parsed EOF v1 container
+type Container struct {
+	Types []eofType
+	Code  [][]byte
+	Data  []byte
+}
+
+// HasEOFMagic returns true if code starts with EOF magic 0xEF00
+func HasEOFMagic(code []byte) bool {
+	return len(code) >= 2 && code[0] == eofFormatByte && code[1] == eofMagicByte
+}
+
+// UnmarshalBinary parses and checks the header and the types section of EOF container, it doesn't validate code
+func (c *Container) UnmarshalBinary(b []byte) error {
+	if !HasEOFMagic(b) {
+		return errEOFInvalidMagic
+	}
+	if len(b) < 3 || b[2] != eofVersion1 {
+		return errEOFInvalidVersion
+	}
+	if len(b) < eofMinContainerSize {
+		return errEOFTruncatedHeader
+	}
+	pos := 3
+	readKind := func(kind byte) error {
+		if pos >= len(b) {
+			return errEOFTruncatedHeader
+		}
+		if b[pos] != kind {
+			if b[pos] == eofKindContainer {
+				return errEOFUnsupportedContainers
+			}
+			return fmt.Errorf("%w: want %#x got %#x at %d", errEOFInvalidSectionKind, kind, b[pos], pos)
+		}
+		pos++
+		return nil
+	}
+	readUint16 := func() (int, error) {
+		if pos+2 > len(b) {
+			return 0, errEOFTruncatedHeader
+		}
+		v := int(binary.BigEndian.Uint16(b[pos:]))
+		pos += 2
+		return v, nil
+	}
+
+	if err := readKind(eofKindTypes); err != nil {
+		return err
+	}
+	typesSize, err := readUint16()
+	if err != nil {
+		return err
+	}
+	if typesSize == 0 || typesSize%eofTypeSize != 0 || typesSize/eofTypeSize > eofMaxCodeSections {
+		return fmt.Errorf("%w: %d", errEOFInvalidTypesSize, typesSize)
+	}
+
+	if err = readKind(eofKindCode); err != nil {
+		return err
+	}
+	numSections, err := readUint16()
+	if err != nil {
+		return err
+	}
+	if numSections == 0 || numSections != typesSize/eofTypeSize {
+		return fmt.Errorf("%w: %d sections, %d types", errEOFInvalidCodeSections, numSections, typesSize/eofTypeSize)
+	}
+	codeSizes := make([]int, numSections)
+	codeTotal := 0
+	for i := range codeSizes {
+		if codeSizes[i], err = readUint16(); err != nil {
+			return err
+		}
+		if codeSizes[i] == 0 {
+			return fmt.Errorf("%w: section %d", errEOFZeroSectionSize, i)
+		}
+		codeTotal += codeSizes[i]
+	}
+
+	if err = readKind(eofKindData); err != nil {
+		return err
+	}
+	dataSize, err := readUint16()
+	if err != nil {
+		return err
+	}
+	if pos >= len(b) || b[pos] != 0 {
+		return errEOFMissingTerminator
+	}
+	pos++
+
+	if want := pos + typesSize + codeTotal + dataSize; len(b) != want {
+		return fmt.Errorf("%w: want %d got %d", errEOFInvalidContainerSize, want, len(b))
+	}
+
+	c.Types = make([]eofType, numSections)
+	for i := range c.Types {
+		t := eofType{Inputs: b[pos], Outputs: b[pos+1], MaxStackIncrease: binary.BigEndian.Uint16(b[pos+2:])}
+		if t.Inputs > eofMaxInputs || (t.Outputs > eofMaxOutputs && t.Outputs != eofNonReturning) || t.MaxStackIncrease > eofMaxStackIncrease {
+			return fmt.Errorf("%w: section %d inputs %d outputs %d max stack increase %d", errEOFInvalidSectionType, i, t.Inputs, t.Outputs, t.MaxStackIncrease)
+		}
+		c.Types[i] = t
+		pos += eofTypeSize
+	}
+	if c.Types[0].Inputs != 0 || c.Types[0].Outputs != eofNonReturning {
+		return fmt.Errorf("%w: inputs %d outputs %d", errEOFInvalidFirstSectionType, c.Types[0].Inputs, c.Types[0].Outputs)
+	}
+	c.Code = make([][]byte, numSections)
+	for i, size := range codeSizes {
+		c.Code[i] = b[pos : pos+size]
+		pos += size
+	}
+	c.Data = b[pos:]
+	return nil
+}
+
+// MarshalBinary encodes the container
+func (c *Container) MarshalBinary() []byte {
+	b := []byte{eofFormatByte, eofMagicByte, eofVersion1}
+	b = append(b, eofKindTypes)
+	b = binary.BigEndian.AppendUint16(b, uint16(len(c.Types)*eofTypeSize))
+	b = append(b, eofKindCode)
+	b = binary.BigEndian.AppendUint16(b, uint16(len(c.Code)))
+	for _, code := range c.Code {
+		b = binary.BigEndian.AppendUint16(b, uint16(len(code)))
+	}
+	b = append(b, eofKindData)
+	b = binary.BigEndian.AppendUint16(b, uint16(len(c.Data)))
+	b = append(b, 0)
+	for _, t := range c.Types {
+		b = append(b, t.Inputs, t.Outputs)
+		b = binary.BigEndian.AppendUint16(b, t.MaxStackIncrease)
+	}
+	for _, code := range c.Code {
+		b = append(b, code...)
+	}
+	return append(b, c.Data...)
+}
+
+// ParseAndValidateEOF parses EOF container and validates its code sections
+func ParseAndValidateEOF(code []byte) (*Container, error) {
+	c := &Container{}
+	if err := c.UnmarshalBinary(code); err != nil {
+		return nil, err
+	}
+	if err := c.ValidateCode(&eofInstructionSet); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
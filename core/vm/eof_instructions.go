@@ -0,0 +1,190 @@
+package vm
+
+import (
+	"encoding/binary"
+
+	"github.com/holiman/uint256"
+)
+
+// eofReturnContext - frame of EOF return stack pushed by CALLF and popped by RETF
+type eofReturnContext struct {
+	section uint64
+	pc      uint64 // instruction after CALLF
+}
+
+// setCodeSection switches execution to code section of EOF container
+func (scope *ScopeContext) setCodeSection(section uint64) {
+	scope.CodeSection = section
+	scope.Contract.Code = scope.Contract.Container.Code[section]
+}
+
+// enableEOF applies the instructions of EOF code:
+// - EIP-4200: RJUMP, RJUMPI, RJUMPV static relative jumps
+// - EIP-4750, EIP-6206: CALLF, RETF, JUMPF functions
+// - EIP-7480: DATALOAD, DATALOADN, DATASIZE, DATACOPY data section access
+// - EIP-3670, EIP-4750: CALLCODE, SELFDESTRUCT, JUMP, JUMPI and PC are undefined, code introspection by
+// CODESIZE and CODECOPY is undefined too (EIP-3540)
+func enableEOF(jt *JumpTable) {
+	for _, op := range []OpCode{CALLCODE, SELFDESTRUCT, JUMP, JUMPI, PC, CODESIZE, CODECOPY} {
+		jt[op] = &operation{execute: opUndefined, undefined: true}
+	}
+	jt[RJUMP] = &operation{
+		execute:     opRjump,
+		constantGas: GasQuickStep,
+		numPop:      0,
+		numPush:     0,
+	}
+	jt[RJUMPI] = &operation{
+		execute:     opRjumpi,
+		constantGas: 4,
+		numPop:      1,
+		numPush:     0,
+	}
+	jt[RJUMPV] = &operation{
+		execute:     opRjumpv,
+		constantGas: 4,
+		numPop:      1,
+		numPush:     0,
+	}
+	jt[CALLF] = &operation{
+		execute:     opCallf,
+		constantGas: GasFastStep,
+		numPop:      0,
+		numPush:     0,
+	}
+	jt[RETF] = &operation{
+		execute:     opRetf,
+		constantGas: GasFastestStep,
+		numPop:      0,
+		numPush:     0,
+	}
+	jt[JUMPF] = &operation{
+		execute:     opJumpf,
+		constantGas: GasFastStep,
+		numPop:      0,
+		numPush:     0,
+	}
+	jt[DATALOAD] = &operation{
+		execute:     opDataLoad,
+		constantGas: 4,
+		numPop:      1,
+		numPush:     1,
+	}
+	jt[DATALOADN] = &operation{
+		execute:     opDataLoadN,
+		constantGas: GasFastestStep,
+		numPop:      0,
+		numPush:     1,
+	}
+	jt[DATASIZE] = &operation{
+		execute:     opDataSize,
+		constantGas: GasQuickStep,
+		numPop:      0,
+		numPush:     1,
+	}
+	jt[DATACOPY] = &operation{
+		execute:     opDataCopy,
+		constantGas: GasFastestStep,
+		dynamicGas:  gasCodeCopy, // same operands as CODECOPY
+		numPop:      3,
+		numPush:     0,
+		memorySize:  memoryCodeCopy,
+	}
+}
+
+// Immediates of EOF instructions are checked by code validation, so ops don't check code bounds.
+
+func opRjump(pc *uint64, interpreter *EVMInterpreter, scope *ScopeContext) ([]byte, error) {
+	offset := int16(binary.BigEndian.Uint16(scope.Contract.Code[*pc+1:]))
+	// offset is relative to the next instruction, pc will be increased by the interpreter loop
+	*pc = uint64(int64(*pc) + 1 + eofRelativeJumpWidth + int64(offset) - 1)
+	return nil, nil
+}
+
+func opRjumpi(pc *uint64, interpreter *EVMInterpreter, scope *ScopeContext) ([]byte, error) {
+	cond := scope.Stack.Pop()
+	if cond.IsZero() {
+		*pc += eofRelativeJumpWidth
+		return nil, nil
+	}
+	return opRjump(pc, interpreter, scope)
+}
+
+func opRjumpv(pc *uint64, interpreter *EVMInterpreter, scope *ScopeContext) ([]byte, error) {
+	code := scope.Contract.Code
+	count := uint64(code[*pc+1]) + 1
+	// last byte of jump table, pc will be increased by the interpreter loop
+	end := *pc + 1 + count*eofRelativeJumpWidth
+	idx := scope.Stack.Pop()
+	if !idx.IsUint64() || idx.Uint64() >= count {
+		*pc = end
+		return nil, nil
+	}
+	offset := int16(binary.BigEndian.Uint16(code[*pc+2+idx.Uint64()*eofRelativeJumpWidth:]))
+	*pc = uint64(int64(end) + int64(offset))
+	return nil, nil
+}
+
+func opCallf(pc *uint64, interpreter *EVMInterpreter, scope *ScopeContext) ([]byte, error) {
+	section := uint64(binary.BigEndian.Uint16(scope.Contract.Code[*pc+1:]))
+	typ := scope.Contract.Container.Types[section]
+	if len(scope.ReturnStack) >= eofReturnStackLimit {
+		return nil, ErrReturnStackExceeded
+	}
+	if sLen := scope.Stack.Len(); sLen+int(typ.MaxStackIncrease) > eofStackLimit {
+		return nil, &ErrStackOverflow{stackLen: sLen, limit: eofStackLimit - int(typ.MaxStackIncrease)}
+	}
+	scope.ReturnStack = append(scope.ReturnStack, eofReturnContext{section: scope.CodeSection, pc: *pc + 3})
+	scope.setCodeSection(section)
+	*pc = ^uint64(0) // pc will be increased to 0 by the interpreter loop
+	return nil, nil
+}
+
+func opRetf(pc *uint64, interpreter *EVMInterpreter, scope *ScopeContext) ([]byte, error) {
+	last := len(scope.ReturnStack) - 1
+	ret := scope.ReturnStack[last]
+	scope.ReturnStack = scope.ReturnStack[:last]
+	scope.setCodeSection(ret.section)
+	*pc = ret.pc - 1 // pc will be increased by the interpreter loop
+	return nil, nil
+}
+
+func opJumpf(pc *uint64, interpreter *EVMInterpreter, scope *ScopeContext) ([]byte, error) {
+	section := uint64(binary.BigEndian.Uint16(scope.Contract.Code[*pc+1:]))
+	typ := scope.Contract.Container.Types[section]
+	if sLen := scope.Stack.Len(); sLen+int(typ.MaxStackIncrease) > eofStackLimit {
+		return nil, &ErrStackOverflow{stackLen: sLen, limit: eofStackLimit - int(typ.MaxStackIncrease)}
+	}
+	scope.setCodeSection(section)
+	*pc = ^uint64(0) // pc will be increased to 0 by the interpreter loop
+	return nil, nil
+}
+
+func opDataLoad(pc *uint64, interpreter *EVMInterpreter, scope *ScopeContext) ([]byte, error) {
+	offset := scope.Stack.Peek()
+	offset.SetBytes(getDataBig(scope.Contract.Container.Data, offset, eofDataLoadWordSize))
+	return nil, nil
+}
+
+func opDataLoadN(pc *uint64, interpreter *EVMInterpreter, scope *ScopeContext) ([]byte, error) {
+	offset := uint64(binary.BigEndian.Uint16(scope.Contract.Code[*pc+1:]))
+	scope.Stack.Push(new(uint256.Int).SetBytes(scope.Contract.Container.Data[offset : offset+eofDataLoadWordSize]))
+	*pc += 2
+	return nil, nil
+}
+
+func opDataSize(pc *uint64, interpreter *EVMInterpreter, scope *ScopeContext) ([]byte, error) {
+	scope.Stack.Push(new(uint256.Int).SetUint64(uint64(len(scope.Contract.Container.Data))))
+	return nil, nil
+}
+
+func opDataCopy(pc *uint64, interpreter *EVMInterpreter, scope *ScopeContext) ([]byte, error) {
+	var (
+		memOffset  = scope.Stack.Pop()
+		dataOffset = scope.Stack.Pop()
+		length     = scope.Stack.Pop()
+	)
+	dataCopy := getDataBig(scope.Contract.Container.Data, &dataOffset, length.Uint64())
+	scope.Memory.Set(memOffset.Uint64(), length.Uint64(), dataCopy)
+	return nil, nil
+}
@@ -0,0 +1,151 @@
+package vm
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	libcommon "github.com/ledgerwatch/erigon-lib/common"
+	"github.com/ledgerwatch/erigon-lib/common/hexutility"
+)
+
+func TestEOFMarshalling(t *testing.T) {
+	t.Parallel()
+	for i, c := range []*Container{
+		{
+			Types: []eofType{{Inputs: 0, Outputs: eofNonReturning, MaxStackIncrease: 0}},
+			Code:  [][]byte{{byte(STOP)}},
+			Data:  []byte{},
+		},
+		{
+			Types: []eofType{{Inputs: 0, Outputs: eofNonReturning, MaxStackIncrease: 1}, {Inputs: 2, Outputs: 1, MaxStackIncrease: 0}},
+			Code:  [][]byte{{byte(PUSH0), byte(PUSH0), byte(CALLF), 0, 1, byte(STOP)}, {byte(ADD), byte(RETF)}},
+			Data:  []byte{1, 2, 3},
+		},
+	} {
+		var got Container
+		if err := got.UnmarshalBinary(c.MarshalBinary()); err != nil {
+			t.Fatalf("test %d: %v", i, err)
+		}
+		if !bytes.Equal(got.MarshalBinary(), c.MarshalBinary()) {
+			t.Fatalf("test %d: want %x got %x", i, c.MarshalBinary(), got.MarshalBinary())
+		}
+	}
+}
+
+func TestEOFUnmarshalInvalid(t *testing.T) {
+	t.Parallel()
+	for i, test := range []struct {
+		code string
+		err  error
+	}{
+		{"0xef", errEOFInvalidMagic},
+		{"0xef00020100040200010001ff000000008000005b", errEOFInvalidVersion},
+		{"0xef0001010004020001000104", errEOFTruncatedHeader},
+		{"0xef00010100030200010001ff000000008000005b", errEOFInvalidTypesSize},
+		{"0xef000101000402000200010001ff000000008000005b00", errEOFInvalidCodeSections},
+		{"0xef00010100040200010000ff0000000080000000", errEOFZeroSectionSize},
+		{"0xef000101000402000100010300010001ff000000008000005b00", errEOFUnsupportedContainers},
+		{"0xef00010100040200010001ff000001008000005b", errEOFMissingTerminator},
+		{"0xef00010100040200010001ff000000008000005b00", errEOFInvalidContainerSize},
+		{"0xef00010100040200010001ff000100008000005b", errEOFInvalidContainerSize},
+		{"0xef00010100040200010001ff00000000000000fe", errEOFInvalidFirstSectionType},
+	} {
+		var c Container
+		if err := c.UnmarshalBinary(hexutility.MustDecodeHex(test.code)); !errors.Is(err, test.err) {
+			t.Fatalf("test %d: want %v got %v", i, test.err, err)
+		}
+	}
+}
+
+func TestEOFValidation(t *testing.T) {
+	t.Parallel()
+	main := func(maxStackIncrease uint16) eofType {
+		return eofType{Inputs: 0, Outputs: eofNonReturning, MaxStackIncrease: maxStackIncrease}
+	}
+	for i, test := range []struct {
+		types []eofType
+		code  [][]byte
+		data  []byte
+		err   error
+	}{
+		// valid
+		{[]eofType{main(0)}, [][]byte{{byte(STOP)}}, nil, nil},
+		{[]eofType{main(0)}, [][]byte{{byte(JUMPDEST), byte(RJUMP), 0xff, 0xfc}}, nil, nil},
+		{[]eofType{main(1)}, [][]byte{{byte(PUSH0), byte(RJUMPI), 0, 1, byte(INVALID), byte(STOP)}}, nil, nil},
+		{[]eofType{main(1)}, [][]byte{{byte(PUSH0), byte(RJUMPV), 1, 0, 1, 0, 2, byte(INVALID), byte(STOP), byte(STOP)}}, nil, nil},
+		{[]eofType{main(2), {Inputs: 2, Outputs: 1, MaxStackIncrease: 0}}, [][]byte{{byte(PUSH0), byte(PUSH0), byte(CALLF), 0, 1, byte(STOP)}, {byte(ADD), byte(RETF)}}, nil, nil},
+		{[]eofType{main(0), main(0)}, [][]byte{{byte(JUMPF), 0, 1}, {byte(STOP)}}, nil, nil},
+		{[]eofType{main(1)}, [][]byte{{byte(DATALOADN), 0, 0, byte(STOP)}}, make([]byte, 32), nil},
+		// forward jumps merge stack heights: [1, 2] at STOP
+		{[]eofType{main(2)}, [][]byte{{byte(PUSH0), byte(PUSH0), byte(RJUMPI), 0, 1, byte(PUSH0), byte(STOP)}}, nil, nil},
+
+		// invalid
+		{[]eofType{main(0)}, [][]byte{{byte(JUMPDEST)}}, nil, errEOFNoTerminatingInstruction},
+		{[]eofType{main(0)}, [][]byte{{byte(PC), byte(STOP)}}, nil, errEOFUndefinedInstruction},
+		{[]eofType{main(0)}, [][]byte{{byte(SELFDESTRUCT)}}, nil, errEOFUndefinedInstruction},
+		{[]eofType{main(0)}, [][]byte{{0x0c, byte(STOP)}}, nil, errEOFUndefinedInstruction},
+		{[]eofType{main(1)}, [][]byte{{byte(PUSH2), 0}}, nil, errEOFTruncatedImmediate},
+		{[]eofType{main(0)}, [][]byte{{byte(RJUMPV)}}, nil, errEOFTruncatedImmediate},
+		{[]eofType{main(0)}, [][]byte{{byte(RJUMP), 0, 1, byte(PUSH1), 0, byte(STOP)}}, nil, errEOFInvalidJumpDestination},
+		{[]eofType{main(0)}, [][]byte{{byte(RJUMP), 0, 2, byte(STOP)}}, nil, errEOFInvalidRelativeJumpTarget},
+		{[]eofType{main(0)}, [][]byte{{byte(CALLF), 0, 1, byte(STOP)}}, nil, errEOFInvalidSectionIndex},
+		{[]eofType{main(0), main(0)}, [][]byte{{byte(CALLF), 0, 1, byte(STOP)}, {byte(STOP)}}, nil, errEOFCallfToNonReturning},
+		{[]eofType{main(0), {Inputs: 0, Outputs: 0, MaxStackIncrease: 0}}, [][]byte{{byte(JUMPF), 0, 1}, {byte(RETF)}}, nil, errEOFJumpfToReturning},
+		{[]eofType{main(0)}, [][]byte{{byte(RETF)}}, nil, errEOFInvalidNonReturningFlag},
+		{[]eofType{main(0), {Inputs: 0, Outputs: 0, MaxStackIncrease: 0}}, [][]byte{{byte(CALLF), 0, 1, byte(STOP)}, {byte(STOP)}}, nil, errEOFInvalidNonReturningFlag},
+		{[]eofType{main(1)}, [][]byte{{byte(DATALOADN), 0, 1, byte(STOP)}}, make([]byte, 32), errEOFInvalidDataLoadN},
+		{[]eofType{main(0), main(0)}, [][]byte{{byte(STOP)}, {byte(STOP)}}, nil, errEOFUnreachableSection},
+		{[]eofType{main(0)}, [][]byte{{byte(STOP), byte(STOP)}}, nil, errEOFUnreachableCode},
+		{[]eofType{main(0)}, [][]byte{{byte(POP), byte(STOP)}}, nil, errEOFStackUnderflow},
+		{[]eofType{main(0)}, [][]byte{{byte(PUSH0), byte(STOP)}}, nil, errEOFInvalidMaxStackIncrease},
+		{[]eofType{main(1)}, [][]byte{{byte(PUSH0), byte(RJUMP), 0xff, 0xfc}}, nil, errEOFInvalidBackwardJump},
+		{[]eofType{main(1), {Inputs: 0, Outputs: 1, MaxStackIncrease: 2}}, [][]byte{{byte(CALLF), 0, 1, byte(STOP)}, {byte(PUSH0), byte(PUSH0), byte(RETF)}}, nil, errEOFInvalidStackHeight},
+	} {
+		c := &Container{Types: test.types, Code: test.code, Data: test.data}
+		var parsed Container
+		if err := parsed.UnmarshalBinary(c.MarshalBinary()); err != nil {
+			t.Fatalf("test %d: %v", i, err)
+		}
+		if err := parsed.ValidateCode(&eofInstructionSet); !errors.Is(err, test.err) {
+			t.Fatalf("test %d: want %v got %v", i, test.err, err)
+		}
+	}
+}
+
+func TestEOFCodeBitmap(t *testing.T) {
+	t.Parallel()
+	code := []byte{byte(RJUMPV), 1, 0, 0, 0, 0, byte(PUSH2), 0, 0, byte(CALLF), 0, 0, byte(STOP)}
+	bits := eofCodeBitmap(code)
+	for pc, isCode := range []bool{true, false, false, false, false, false, true, false, false, true, false, false, true} {
+		if isCodeFromAnalysis(bits, uint64(pc)) != isCode {
+			t.Fatalf("pc %d: want code %t", pc, isCode)
+		}
+	}
+}
+
+func TestEOFContainerCache(t *testing.T) {
+	t.Parallel()
+	code := hexutility.MustDecodeHex("0xef00010100040200010001ff00000000800000fe")
+	parent := NewContract(AccountRef{}, libcommon.Address{}, nil, 0, false)
+	parent.SetCallCode(&libcommon.Address{1}, libcommon.Hash{1}, code)
+	container, err := parent.eofContainer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// same code hash - parsed once per top level call
+	child := NewContract(parent, libcommon.Address{}, nil, 0, false)
+	child.SetCallCode(&libcommon.Address{2}, libcommon.Hash{1}, code)
+	if cached, err := child.eofContainer(); err != nil || cached != container {
+		t.Fatalf("container is not cached: %p %p %v", container, cached, err)
+	}
+	// without code hash (initcode) - not cached
+	initcode := NewContract(parent, libcommon.Address{}, nil, 0, false)
+	initcode.SetCallCode(nil, libcommon.Hash{}, code)
+	if parsed, err := initcode.eofContainer(); err != nil || parsed == container {
+		t.Fatalf("initcode container is cached: %v", err)
+	}
+	if len(parent.containers) != 1 {
+		t.Fatalf("expected 1 cached container, got %d", len(parent.containers))
+	}
+}
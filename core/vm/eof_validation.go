@@ -0,0 +1,234 @@
+package vm
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/ledgerwatch/erigon-lib/common/cmp"
+)
+
+const eofMaxStackHeight = 1023
+
+var (
+	errEOFUndefinedInstruction      = fmt.Errorf("%w: undefined instruction", ErrInvalidEOF)
+	errEOFTruncatedImmediate        = fmt.Errorf("%w: truncated immediate", ErrInvalidEOF)
+	errEOFInvalidJumpDestination    = fmt.Errorf("%w: invalid relative jump destination", ErrInvalidEOF)
+	errEOFInvalidSectionIndex       = fmt.Errorf("%w: invalid code section index", ErrInvalidEOF)
+	errEOFCallfToNonReturning       = fmt.Errorf("%w: CALLF to non-returning section", ErrInvalidEOF)
+	errEOFJumpfToReturning          = fmt.Errorf("%w: JUMPF from non-returning to returning section", ErrInvalidEOF)
+	errEOFInvalidNonReturningFlag   = fmt.Errorf("%w: invalid non-returning flag", ErrInvalidEOF)
+	errEOFInvalidDataLoadN          = fmt.Errorf("%w: DATALOADN beyond data section", ErrInvalidEOF)
+	errEOFNoTerminatingInstruction  = fmt.Errorf("%w: no terminating instruction", ErrInvalidEOF)
+	errEOFUnreachableSection        = fmt.Errorf("%w: unreachable code section", ErrInvalidEOF)
+	errEOFUnreachableCode           = fmt.Errorf("%w: unreachable code", ErrInvalidEOF)
+	errEOFStackUnderflow            = fmt.Errorf("%w: stack underflow", ErrInvalidEOF)
+	errEOFStackOverflow             = fmt.Errorf("%w: stack overflow", ErrInvalidEOF)
+	errEOFInvalidStackHeight        = fmt.Errorf("%w: invalid stack height", ErrInvalidEOF)
+	errEOFInvalidBackwardJump       = fmt.Errorf("%w: stack height mismatch at backward jump", ErrInvalidEOF)
+	errEOFInvalidMaxStackIncrease   = fmt.Errorf("%w: max stack increase mismatch", ErrInvalidEOF)
+	errEOFInvalidRelativeJumpTarget = fmt.Errorf("%w: relative jump outside of code section", ErrInvalidEOF)
+)
+
+// ValidateCode validates code sections of the container against EOF instruction set jt:
+//   - EIP-3670: only defined instructions, no truncated immediates, section ends with terminating instruction
+//   - EIP-4200: RJUMP/RJUMPI/RJUMPV target instructions of the same section, not immediate data
+//   - EIP-4750: CALLF/JUMPF target existing sections, RETF only in returning sections
+//   - EIP-5450: stack height is known at each instruction, max stack increase matches types section
+//   - EIP-7480: DATALOADN reads within data section
+//
+// All code sections must be reachable from the first one by CALLF/JUMPF. Stack heights are checked by
+// validateStack, not by the abstract interpreter of absint_cfg.go (it resolves JUMP targets of legacy code).
+func (c *Container) ValidateCode(jt *JumpTable) error {
+	visited := make([]bool, len(c.Code))
+	visited[0] = true
+	queue := []int{0}
+	for len(queue) > 0 {
+		section := queue[0]
+		queue = queue[1:]
+		refs, err := c.validateSection(section, jt)
+		if err != nil {
+			return fmt.Errorf("section %d: %w", section, err)
+		}
+		for _, ref := range refs {
+			if !visited[ref] {
+				visited[ref] = true
+				queue = append(queue, ref)
+			}
+		}
+	}
+	for section := range visited {
+		if !visited[section] {
+			return fmt.Errorf("%w: %d", errEOFUnreachableSection, section)
+		}
+	}
+	return nil
+}
+
+// validateSection checks instructions of code section and returns sections it refers by CALLF/JUMPF
+func (c *Container) validateSection(section int, jt *JumpTable) (refs []int, err error) {
+	code, typ := c.Code[section], c.Types[section]
+	// Same bitmap as JUMPDEST analysis of legacy code, but EOF instructions have immediates too
+	analysis := eofCodeBitmap(code)
+	returns := false // section has RETF or JUMPF to returning section
+	var op OpCode
+	for pc := 0; pc < len(code); pc += 1 + eofImmediateSize(code, pc) {
+		op = OpCode(code[pc])
+		if jt[op].undefined && op != INVALID {
+			return nil, fmt.Errorf("%w: %#x at %d", errEOFUndefinedInstruction, byte(op), pc)
+		}
+		if pc+1+eofImmediateSize(code, pc) > len(code) {
+			return nil, fmt.Errorf("%w: %v at %d", errEOFTruncatedImmediate, op, pc)
+		}
+		switch op {
+		case RJUMP, RJUMPI, RJUMPV:
+			for _, target := range relativeJumpTargets(code, pc) {
+				if target < 0 || target >= len(code) {
+					return nil, fmt.Errorf("%w: %v at %d to %d", errEOFInvalidRelativeJumpTarget, op, pc, target)
+				}
+				if !isCodeFromAnalysis(analysis, uint64(target)) {
+					return nil, fmt.Errorf("%w: %v at %d to %d", errEOFInvalidJumpDestination, op, pc, target)
+				}
+			}
+		case CALLF, JUMPF:
+			target := int(binary.BigEndian.Uint16(code[pc+1:]))
+			if target >= len(c.Types) {
+				return nil, fmt.Errorf("%w: %v at %d to %d", errEOFInvalidSectionIndex, op, pc, target)
+			}
+			if op == CALLF && !c.Types[target].returning() {
+				return nil, fmt.Errorf("%w: at %d to %d", errEOFCallfToNonReturning, pc, target)
+			}
+			if op == JUMPF && c.Types[target].returning() {
+				if !typ.returning() {
+					return nil, fmt.Errorf("%w: at %d to %d", errEOFJumpfToReturning, pc, target)
+				}
+				returns = true
+			}
+			refs = append(refs, target)
+		case RETF:
+			if !typ.returning() {
+				return nil, fmt.Errorf("%w: RETF at %d", errEOFInvalidNonReturningFlag, pc)
+			}
+			returns = true
+		case DATALOADN:
+			if offset := int(binary.BigEndian.Uint16(code[pc+1:])); offset+32 > len(c.Data) {
+				return nil, fmt.Errorf("%w: offset %d, data size %d", errEOFInvalidDataLoadN, offset, len(c.Data))
+			}
+		}
+	}
+	if !eofTerminating(op) && op != RJUMP {
+		return nil, fmt.Errorf("%w: last instruction %v", errEOFNoTerminatingInstruction, op)
+	}
+	if typ.returning() != returns {
+		return nil, fmt.Errorf("%w: outputs %d", errEOFInvalidNonReturningFlag, typ.Outputs)
+	}
+	return refs, c.validateStack(section, jt)
+}
+
+// stackBounds - range of stack heights at instruction, min is -1 for instructions not reached yet
+type stackBounds struct {
+	min, max int
+}
+
+// validateStack is single forward pass of abstract interpretation of stack heights (EIP-5450): only forward
+// jumps can reach not visited instructions, backward jumps must keep stack height of their targets.
+func (c *Container) validateStack(section int, jt *JumpTable) error {
+	code, typ := c.Code[section], c.Types[section]
+	heights := make([]stackBounds, len(code))
+	for i := range heights {
+		heights[i].min = -1
+	}
+	heights[0] = stackBounds{int(typ.Inputs), int(typ.Inputs)}
+	maxHeight := int(typ.Inputs)
+	for pc := 0; pc < len(code); {
+		h := heights[pc]
+		if h.min < 0 {
+			return fmt.Errorf("%w: at %d", errEOFUnreachableCode, pc)
+		}
+		op := OpCode(code[pc])
+		next := pc + 1 + eofImmediateSize(code, pc)
+		pop, push := jt[op].numPop, jt[op].numPush
+		switch op {
+		case CALLF, JUMPF:
+			target := c.Types[binary.BigEndian.Uint16(code[pc+1:])]
+			if h.max+int(target.MaxStackIncrease) > eofStackLimit {
+				return fmt.Errorf("%w: %v at %d", errEOFStackOverflow, op, pc)
+			}
+			pop = int(target.Inputs)
+			if op == CALLF {
+				push = int(target.Outputs)
+			} else if target.returning() {
+				// JUMPF to returning section: its outputs are returned to the caller of the current section
+				want := int(typ.Outputs) + int(target.Inputs) - int(target.Outputs)
+				if target.Outputs > typ.Outputs || h.min != want || h.max != want {
+					return fmt.Errorf("%w: JUMPF at %d, want %d have [%d, %d]", errEOFInvalidStackHeight, pc, want, h.min, h.max)
+				}
+			}
+		case RETF:
+			if h.min != int(typ.Outputs) || h.max != int(typ.Outputs) {
+				return fmt.Errorf("%w: RETF at %d, want %d have [%d, %d]", errEOFInvalidStackHeight, pc, typ.Outputs, h.min, h.max)
+			}
+		}
+		if h.min < pop {
+			return fmt.Errorf("%w: %v at %d, need %d have %d", errEOFStackUnderflow, op, pc, pop, h.min)
+		}
+		after := stackBounds{h.min - pop + push, h.max - pop + push}
+		if after.max > maxHeight {
+			maxHeight = after.max
+		}
+
+		var successors []int
+		if op == RJUMP || op == RJUMPI || op == RJUMPV {
+			successors = relativeJumpTargets(code, pc)
+		}
+		if op != RJUMP && !eofTerminating(op) {
+			successors = append(successors, next)
+		}
+		for _, s := range successors {
+			switch {
+			case s >= len(code):
+				return fmt.Errorf("%w: fallthrough at %d", errEOFNoTerminatingInstruction, pc)
+			case s <= pc:
+				if heights[s] != after {
+					return fmt.Errorf("%w: at %d to %d", errEOFInvalidBackwardJump, pc, s)
+				}
+			case heights[s].min < 0:
+				heights[s] = after
+			default:
+				heights[s].min = cmp.Min(heights[s].min, after.min)
+				heights[s].max = cmp.Max(heights[s].max, after.max)
+			}
+		}
+		pc = next
+	}
+	if maxHeight > eofMaxStackHeight {
+		return fmt.Errorf("%w: max stack height %d", errEOFStackOverflow, maxHeight)
+	}
+	if maxHeight-int(typ.Inputs) != int(typ.MaxStackIncrease) {
+		return fmt.Errorf("%w: declared %d, computed %d", errEOFInvalidMaxStackIncrease, typ.MaxStackIncrease, maxHeight-int(typ.Inputs))
+	}
+	return nil
+}
+
+// relativeJumpTargets returns destinations of RJUMP, RJUMPI and RJUMPV at pc: offsets are relative
+// to the next instruction
+func relativeJumpTargets(code []byte, pc int) []int {
+	if OpCode(code[pc]) != RJUMPV {
+		return []int{pc + 1 + eofRelativeJumpWidth + int(int16(binary.BigEndian.Uint16(code[pc+1:])))}
+	}
+	count := int(code[pc+1]) + 1
+	next := pc + 2 + count*eofRelativeJumpWidth
+	targets := make([]int, count)
+	for i := range targets {
+		targets[i] = next + int(int16(binary.BigEndian.Uint16(code[pc+2+i*eofRelativeJumpWidth:])))
+	}
+	return targets
+}
+
+// eofTerminating returns true for instructions after which execution doesn't continue with the next one
+func eofTerminating(op OpCode) bool {
+	switch op {
+	case STOP, RETURN, REVERT, INVALID, RETF, JUMPF:
+		return true
+	}
+	return false
+}
@@ -17,6 +17,7 @@
 package vm
 
 import (
+	"fmt"
 	"sync/atomic"
 
 	"github.com/holiman/uint256"
@@ -178,8 +179,15 @@ func (evm *EVM) call(typ OpCode, caller ContractRef, addr libcommon.Address, inp
 	}
 	p, isPrecompile := evm.precompile(addr)
 	var code []byte
+	codeAddr := addr
 	if !isPrecompile {
-		code = evm.intraBlockState.GetCode(addr)
+		if evm.chainRules.IsPrague {
+			// EIP-7702: account with delegation designator runs code of the delegation target
+			if target, ok := evm.intraBlockState.GetDelegatedDesignation(addr); ok {
+				codeAddr = target
+			}
+		}
+		code = evm.intraBlockState.GetCode(codeAddr)
 	}
 
 	snapshot := evm.intraBlockState.Snapshot()
@@ -245,7 +253,7 @@ func (evm *EVM) call(typ OpCode, caller ContractRef, addr libcommon.Address, inp
 		addrCopy := addr
 		// Initialise a new contract and set the code that is to be used by the EVM.
 		// The contract is a scoped environment for this execution context only.
-		codeHash := evm.intraBlockState.GetCodeHash(addrCopy)
+		codeHash := evm.intraBlockState.GetCodeHash(codeAddr)
 		var contract *Contract
 		if typ == CALLCODE {
 			contract = NewContract(caller, caller.Address(), value, gas, evm.config.SkipAnalysis)
@@ -259,8 +267,18 @@ func (evm *EVM) call(typ OpCode, caller ContractRef, addr libcommon.Address, inp
 		if typ == STATICCALL {
 			readOnly = true
 		}
-		ret, err = run(evm, contract, input, readOnly)
-		gas = contract.Gas
+		if evm.chainRules.IsOsaka && HasEOFMagic(code) {
+			// Code of EOF contract was validated at creation, parsing of container is enough.
+			// Container which fails to parse is an exceptional halt: all gas is consumed below
+			var container *Container
+			if container, err = contract.eofContainer(); err == nil {
+				contract.setEOF(container)
+			}
+		}
+		if err == nil {
+			ret, err = run(evm, contract, input, readOnly)
+			gas = contract.Gas
+		}
 	}
 	// When an error was returned by the EVM or when setting the creation code
 	// above we revert to the snapshot and consume any gas remaining. Additionally
@@ -392,7 +410,18 @@ func (evm *EVM) create(caller ContractRef, codeAndHash *codeAndHash, gas uint64,
 		return nil, address, gas, nil
 	}
 
-	ret, err = run(evm, contract, nil, false)
+	if evm.chainRules.IsOsaka && HasEOFMagic(codeAndHash.code) {
+		// EIP-3540: EOF initcode is validated before execution, invalid initcode fails creation
+		var container *Container
+		if container, err = ParseAndValidateEOF(codeAndHash.code); err == nil {
+			contract.setEOF(container)
+		} else {
+			err = fmt.Errorf("%w: %v", ErrInvalidEOFInitcode, err)
+		}
+	}
+	if err == nil {
+		ret, err = run(evm, contract, nil, false)
+	}
 
 	// EIP-170: Contract code size limit
 	if err == nil && evm.chainRules.IsSpuriousDragon && len(ret) > params.MaxCodeSize {
@@ -403,8 +432,13 @@ func (evm *EVM) create(caller ContractRef, codeAndHash *codeAndHash, gas uint64,
 		}
 	}
 
-	// Reject code starting with 0xEF if EIP-3541 is enabled.
-	if err == nil && evm.chainRules.IsLondon && len(ret) >= 1 && ret[0] == 0xEF {
+	if err == nil && contract.Container != nil {
+		// EIP-3540: EOF initcode deploys only valid EOF code
+		if _, vErr := ParseAndValidateEOF(ret); vErr != nil {
+			err = ErrInvalidCode
+		}
+	} else if err == nil && evm.chainRules.IsLondon && len(ret) >= 1 && ret[0] == 0xEF {
+		// Reject code starting with 0xEF if EIP-3541 is enabled.
 		err = ErrInvalidCode
 	}
 	// if the contract creation ran successfully and no errors were returned
@@ -33,6 +33,7 @@ type BlockContext struct {
 	BaseFee       *uint256.Int   // Provides information for BASEFEE
 	PrevRanDao    *common.Hash   // Provides information for PREVRANDAO
 	ExcessBlobGas *uint64        // Provides information for handling data blobs
+	BlobBaseFee   *uint256.Int   // Overrides the blob base fee derived from ExcessBlobGas (eth_call block overrides)
 }
 
 // TxContext provides the EVM with information about a transaction.
@@ -70,6 +71,8 @@ type IntraBlockState interface {
 	GetCode(common.Address) []byte
 	SetCode(common.Address, []byte)
 	GetCodeSize(common.Address) int
+	// EIP-7702: target of delegation designator if account has one as code
+	GetDelegatedDesignation(common.Address) (common.Address, bool)
 
 	AddRefund(uint64)
 	SubRefund(uint64)
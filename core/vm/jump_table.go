@@ -46,6 +46,8 @@ type operation struct {
 	isSwap  bool
 	isDup   bool
 	opNum   int // only for push, swap, dup
+	// undefined is set for opcodes which execute opUndefined (EOF code validation rejects them)
+	undefined bool
 	// memorySize returns the memory size required for the operation
 	memorySize memorySizeFunc
 }
@@ -65,6 +67,14 @@ var (
 	pragueInstructionSet           = newPragueInstructionSet()
 )
 
+// eofInstructionSet is initialized by init: it is referred by EOF validation of create,
+// which is referred by the instruction sets, so initialization expression would be a cycle
+var eofInstructionSet JumpTable
+
+func init() {
+	eofInstructionSet = newEOFInstructionSet()
+}
+
 // JumpTable contains the EVM opcodes supported at a given fork.
 type JumpTable [256]*operation
 
@@ -86,11 +96,22 @@ func validateAndFillMaxStack(jt *JumpTable) {
 	}
 }
 
+// newEOFInstructionSet returns the instructions of EOF code (EIP-3540) since Osaka: prague instructions
+// without JUMP, JUMPI, PC, CALLCODE, SELFDESTRUCT, CODESIZE, CODECOPY and with EOF instructions.
+// Legacy code keeps executing prague instructions.
+func newEOFInstructionSet() JumpTable {
+	instructionSet := newPragueInstructionSet()
+	enableEOF(&instructionSet)
+	validateAndFillMaxStack(&instructionSet)
+	return instructionSet
+}
+
 // newPragueInstructionSet returns the frontier, homestead, byzantium,
 // constantinople, istanbul, petersburg, berlin, london, paris, shanghai,
 // cancun, and prague instructions.
 func newPragueInstructionSet() JumpTable {
 	instructionSet := newCancunInstructionSet()
+	enable7702(&instructionSet) // Set EOA account code: gas of calls to delegated accounts
 	validateAndFillMaxStack(&instructionSet)
 	return instructionSet
 }
@@ -1204,7 +1225,7 @@ func newFrontierInstructionSet() JumpTable {
 	// Fill all unassigned slots with opUndefined.
 	for i, entry := range tbl {
 		if entry == nil {
-			tbl[i] = &operation{execute: opUndefined}
+			tbl[i] = &operation{execute: opUndefined, undefined: true}
 		}
 	}
 
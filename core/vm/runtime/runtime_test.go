@@ -17,7 +17,9 @@
 package runtime
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"math/big"
 	"os"
@@ -26,6 +28,7 @@ import (
 
 	"github.com/ledgerwatch/erigon-lib/chain"
 	libcommon "github.com/ledgerwatch/erigon-lib/common"
+	"github.com/ledgerwatch/erigon-lib/common/hexutility"
 	"github.com/ledgerwatch/erigon-lib/kv/memdb"
 	"github.com/ledgerwatch/erigon/accounts/abi"
 	"github.com/ledgerwatch/erigon/common"
@@ -108,6 +111,56 @@ func TestExecute(t *testing.T) {
 	}
 }
 
+func TestEOFExecute(t *testing.T) {
+	t.Parallel()
+	osaka := func() *Config {
+		cfg := new(Config)
+		setDefaults(cfg)
+		cfg.ChainConfig.OsakaTime = new(big.Int)
+		return cfg
+	}
+	// section 0: CALLF section 1 with 3 and 4, add last word of data section (1) by DATALOADN, return result;
+	// section 1: ADD, RETF
+	runtimeCode := hexutility.MustDecodeHex("0xef000101000802000200110002ff002000008000020201000060036004e30001d10000015f5260205ff301e40000000000000000000000000000000000000000000000000000000000000001")
+	ret, _, err := Execute(runtimeCode, nil, osaka(), 0)
+	if err != nil {
+		t.Fatal("didn't expect error", err)
+	}
+	if num := new(big.Int).SetBytes(ret); num.Cmp(big.NewInt(8)) != 0 {
+		t.Error("Expected 8, got", num)
+	}
+
+	// EOF initcode returns runtime code from its data section by DATACOPY
+	initcode := append(hexutility.MustDecodeHex("0xef00010100040200010007ff004c0000800003d25f5fd3d25ff3"), runtimeCode...)
+	code, _, _, err := Create(initcode, osaka(), 0)
+	if err != nil {
+		t.Fatal("didn't expect error", err)
+	}
+	if !bytes.Equal(code, runtimeCode) {
+		t.Errorf("Expected deployed code %x, got %x", runtimeCode, code)
+	}
+
+	// PC is undefined in EOF code
+	if _, _, _, err = Create(hexutility.MustDecodeHex("0xef00010100040200010002ff000000008000015800"), osaka(), 0); !errors.Is(err, vm.ErrInvalidEOFInitcode) {
+		t.Errorf("Expected %v, got %v", vm.ErrInvalidEOFInitcode, err)
+	}
+
+	// truncated container can't be deployed: call of it is an exceptional halt, all gas is consumed
+	cfg := osaka()
+	cfg.GasLimit = 100_000
+	_, tx := memdb.NewTestTx(t)
+	cfg.State = state.New(state.NewDbStateReader(tx))
+	address := libcommon.HexToAddress("0xaa")
+	cfg.State.SetCode(address, hexutility.MustDecodeHex("0xef000101000402"))
+	_, leftOverGas, err := Call(address, nil, cfg)
+	if !errors.Is(err, vm.ErrInvalidEOF) {
+		t.Errorf("Expected %v, got %v", vm.ErrInvalidEOF, err)
+	}
+	if leftOverGas != 0 {
+		t.Errorf("Expected no gas left, got %d", leftOverGas)
+	}
+}
+
 func TestCall(t *testing.T) {
 	t.Parallel()
 	_, tx := memdb.NewTestTx(t)
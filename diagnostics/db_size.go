@@ -0,0 +1,236 @@
+package diagnostics
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/ledgerwatch/erigon-lib/common"
+	"github.com/ledgerwatch/erigon-lib/kv"
+	"github.com/ledgerwatch/erigon-lib/kv/mdbx"
+	"github.com/ledgerwatch/erigon/common/paths"
+	"github.com/ledgerwatch/erigon/eth/stagedsync/stages"
+	"github.com/ledgerwatch/log/v3"
+	"github.com/urfave/cli/v2"
+)
+
+const (
+	dbSizeHistoryFile = "db_size.jsonl"
+	maxDbSizeSamples  = 24 * 90 // 90 days of hourly samples
+)
+
+// SetupDbSizeAccess - `/dbsize` returns size report of chaindata tables, `/dbsize/history` - stored periodic reports.
+// History can be filtered: `?table=PlainState&since=2023-11-01T00:00:00Z`
+func SetupDbSizeAccess(ctx *cli.Context, metricsMux *http.ServeMux) {
+	var dataDir string
+	if ctx.IsSet("datadir") {
+		dataDir = ctx.String("datadir")
+	} else {
+		dataDir = paths.DataDirForNetwork(paths.DefaultDataDir(), ctx.String("chain"))
+	}
+	history := NewDbSizeHistory(dataDir)
+	if interval := ctx.Duration("diagnostics.dbsize.interval"); interval > 0 {
+		go history.sampleLoop(dataDir, interval)
+	}
+
+	metricsMux.HandleFunc("/dbsize", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Access-Control-Allow-Origin", "*")
+		db, ok := mdbx.PathDbMap()[filepath.Join(dataDir, "chaindata")]
+		if !ok {
+			http.Error(w, "chaindata is not opened", http.StatusNotFound)
+			return
+		}
+		report, err := DbSizeReport(r.Context(), db)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("failed to build size report: %v", err), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(report)
+	})
+	metricsMux.HandleFunc("/dbsize/history", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Access-Control-Allow-Origin", "*")
+		var since time.Time
+		if s := r.URL.Query().Get("since"); s != "" {
+			var err error
+			if since, err = time.Parse(time.RFC3339, s); err != nil {
+				http.Error(w, fmt.Sprintf(`"since" must be RFC3339 time: %v`, err), http.StatusBadRequest)
+				return
+			}
+		}
+		reports, err := history.Read(r.URL.Query().Get("table"), since)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("failed to read size history: %v", err), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(reports)
+	})
+}
+
+// DbSizeReport - size of all chaindata tables with progress of stages (to correlate growth of tables with stages)
+func DbSizeReport(ctx context.Context, db kv.RoDB) (report *mdbx.SizeReport, err error) {
+	err = db.View(ctx, func(tx kv.Tx) error {
+		if report, err = mdbx.NewSizeReport(tx, kv.ChaindataTables); err != nil {
+			return err
+		}
+		report.Stages = map[string]uint64{}
+		for _, stage := range stages.AllStages {
+			progress, err := stages.GetStageProgress(tx, stage)
+			if err != nil {
+				return err
+			}
+			report.Stages[string(stage)] = progress
+		}
+		return nil
+	})
+	return report, err
+}
+
+// DbSizeHistory - size reports stored as json lines. Keeps last maxDbSizeSamples reports.
+type DbSizeHistory struct {
+	path  string
+	lock  sync.Mutex
+	count int // reports in file, 0 - not counted yet
+}
+
+func NewDbSizeHistory(dataDir string) *DbSizeHistory {
+	return &DbSizeHistory{path: filepath.Join(dataDir, "diagnostics", dbSizeHistoryFile)}
+}
+
+func (h *DbSizeHistory) sampleLoop(dataDir string, interval time.Duration) {
+	ctx, _ := common.RootContext()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		db, ok := mdbx.PathDbMap()[filepath.Join(dataDir, "chaindata")]
+		if !ok {
+			continue
+		}
+		report, err := DbSizeReport(ctx, db)
+		if err != nil {
+			log.Warn("[diagnostics] db size report", "err", err)
+			continue
+		}
+		if err := h.Append(report); err != nil {
+			log.Warn("[diagnostics] store db size report", "err", err)
+		}
+	}
+}
+
+// Append - stores report, compacts file if it has too many reports
+func (h *DbSizeHistory) Append(report *mdbx.SizeReport) error {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	if err := os.MkdirAll(filepath.Dir(h.path), 0755); err != nil {
+		return err
+	}
+	line, err := json.Marshal(report)
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(h.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err = f.Write(append(line, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	if err = f.Close(); err != nil {
+		return err
+	}
+
+	if h.count == 0 {
+		reports, err := h.read("", time.Time{})
+		if err != nil {
+			return err
+		}
+		h.count = len(reports)
+	} else {
+		h.count++
+	}
+	if h.count <= 2*maxDbSizeSamples {
+		return nil
+	}
+	reports, err := h.read("", time.Time{})
+	if err != nil {
+		return err
+	}
+	return h.rewrite(reports[len(reports)-maxDbSizeSamples:])
+}
+
+func (h *DbSizeHistory) rewrite(reports []*mdbx.SizeReport) error {
+	tmpPath := h.path + ".tmp"
+	f, err := os.Create(tmpPath)
+	if err != nil {
+		return err
+	}
+	w := bufio.NewWriter(f)
+	enc := json.NewEncoder(w)
+	for _, r := range reports {
+		if err := enc.Encode(r); err != nil {
+			f.Close()
+			return err
+		}
+	}
+	if err := w.Flush(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	h.count = len(reports)
+	return os.Rename(tmpPath, h.path)
+}
+
+// Read - reports since `since`. If `table` is not empty - reports have only this table.
+func (h *DbSizeHistory) Read(table string, since time.Time) ([]*mdbx.SizeReport, error) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	return h.read(table, since)
+}
+
+func (h *DbSizeHistory) read(table string, since time.Time) ([]*mdbx.SizeReport, error) {
+	f, err := os.Open(h.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+	var reports []*mdbx.SizeReport
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		r := &mdbx.SizeReport{}
+		if err := json.Unmarshal(scanner.Bytes(), r); err != nil {
+			continue // partially written line
+		}
+		if r.Time.Before(since) {
+			continue
+		}
+		if table != "" {
+			t := r.Table(table)
+			if t == nil {
+				continue
+			}
+			r.Tables = []mdbx.TableSize{*t}
+		}
+		reports = append(reports, r)
+	}
+	return reports, scanner.Err()
+}
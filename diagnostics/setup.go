@@ -22,6 +22,7 @@ func Setup(ctx *cli.Context, metricsMux *http.ServeMux, node *node.ErigonNode) {
 
 	SetupLogsAccess(ctx, debugMux)
 	SetupDbAccess(ctx, debugMux)
+	SetupDbSizeAccess(ctx, debugMux)
 	SetupCmdLineAccess(debugMux)
 	SetupFlagsAccess(ctx, debugMux)
 	SetupVersionAccess(debugMux)
@@ -0,0 +1,455 @@
+/*
+   Copyright 2023 The Erigon contributors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package commitment
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math/bits"
+
+	"github.com/ledgerwatch/erigon-lib/common"
+	"github.com/ledgerwatch/erigon-lib/common/length"
+	"github.com/ledgerwatch/erigon-lib/rlp"
+)
+
+// ProveAccount returns RLP-encoded trie nodes on the path from the state root to the account
+// with the given plain key, as defined by EIP-1186. If the account is absent, the returned
+// nodes prove its absence. storageRoot is the root hash of the account storage trie, it is
+// nil if the account is absent.
+// Trie should be folded (no active rows) and branchFn, accountFn and storageFn should be set.
+func (hph *HexPatriciaHashed) ProveAccount(plainKey []byte) (proof [][]byte, storageRoot []byte, err error) {
+	if len(plainKey) != hph.accountKeyLen {
+		return nil, nil, fmt.Errorf("ProveAccount: expected account key of length %d, got %d", hph.accountKeyLen, len(plainKey))
+	}
+	proof, _, storageRoot, err = hph.prove(plainKey)
+	return proof, storageRoot, err
+}
+
+// ProveStorage returns RLP-encoded nodes of the account storage trie on the path from the storage
+// root to the storage item with the given plain key (account key followed by storage location).
+// Proof is empty when the account is absent or its storage is empty.
+func (hph *HexPatriciaHashed) ProveStorage(plainKey []byte) (proof [][]byte, err error) {
+	if len(plainKey) <= hph.accountKeyLen {
+		return nil, fmt.Errorf("ProveStorage: expected storage key longer than %d, got %d", hph.accountKeyLen, len(plainKey))
+	}
+	_, proof, _, err = hph.prove(plainKey)
+	return proof, err
+}
+
+// prove walks the trie from the root towards hashed plainKey using branch nodes provided by branchFn.
+// Every node met on the way is re-encoded from its cells, account and storage trie nodes are
+// collected separately.
+func (hph *HexPatriciaHashed) prove(plainKey []byte) (accountProof, storageProof [][]byte, storageRoot []byte, err error) {
+	if hph.activeRows > 0 {
+		return nil, nil, nil, fmt.Errorf("prove: trie has %d active rows, fold it first", hph.activeRows)
+	}
+	hashedKey, err := hph.nibblizedKey(plainKey)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	isStorage := len(plainKey) > hph.accountKeyLen
+
+	var row [16]Cell
+	cell := hph.root
+	depth := 0
+	proof := &accountProof
+	for {
+		if cell.apl > 0 && depth <= 64 {
+			root, node, err := hph.accountLeafNode(&cell, depth)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			accountProof = append(accountProof, node)
+			if !bytes.Equal(cell.apk[:cell.apl], plainKey[:hph.accountKeyLen]) {
+				return accountProof, nil, nil, nil // account is absent
+			}
+			storageRoot = root
+			if !isStorage {
+				return accountProof, nil, storageRoot, nil
+			}
+			if cell.spl > 0 {
+				// storage trie consists of a single leaf
+				node, err := hph.storageLeafNode(&cell, 64)
+				if err != nil {
+					return nil, nil, nil, err
+				}
+				return accountProof, append(storageProof, node), storageRoot, nil
+			}
+			if cell.hl == 0 {
+				return accountProof, nil, storageRoot, nil // empty storage
+			}
+			// continue from the storage root, which is represented by the extension and hash parts of account cell
+			cell.apl = 0
+			depth = 64
+			proof = &storageProof
+			continue
+		}
+		if cell.spl > 0 {
+			node, err := hph.storageLeafNode(&cell, depth)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			if len(node) >= length.Hash || len(*proof) == 0 {
+				// smaller nodes are embedded into the parent branch node
+				*proof = append(*proof, node)
+			}
+			return accountProof, storageProof, storageRoot, nil
+		}
+		if cell.extLen > 0 {
+			if cell.hl == 0 {
+				return nil, nil, nil, fmt.Errorf("prove: extension without hash at depth %d", depth)
+			}
+			*proof = append(*proof, extensionNode(cell.extension[:cell.extLen], cell.h[:cell.hl]))
+			if !bytes.HasPrefix(hashedKey[depth:], cell.extension[:cell.extLen]) {
+				return accountProof, storageProof, storageRoot, nil
+			}
+			depth += cell.extLen
+		}
+		if cell.hl == 0 {
+			return accountProof, storageProof, storageRoot, nil // empty trie
+		}
+		afterMap, err := hph.loadProofRow(hashedKey[:depth], &row)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		node, err := hph.branchNode(&row, afterMap, depth+1)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		h, err := hph.nodeHash(node)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		if !bytes.Equal(h[:], cell.h[:cell.hl]) {
+			return nil, nil, nil, fmt.Errorf("prove: branch node hash mismatch at prefix [%x]: computed %x, expected %x", hashedKey[:depth], h, cell.h[:cell.hl])
+		}
+		*proof = append(*proof, node)
+
+		nibble := hashedKey[depth]
+		if afterMap&(uint16(1)<<nibble) == 0 {
+			return accountProof, storageProof, storageRoot, nil
+		}
+		cell = row[nibble]
+		depth++
+	}
+}
+
+// nibblizedKey hashes account and storage parts of the plain key and expands them into nibbles
+func (hph *HexPatriciaHashed) nibblizedKey(plainKey []byte) ([]byte, error) {
+	if len(plainKey) < hph.accountKeyLen {
+		return nil, fmt.Errorf("plain key [%x] is shorter than account key length %d", plainKey, hph.accountKeyLen)
+	}
+	hashedKey := make([]byte, 64, 128)
+	if err := hashKey(hph.keccak, plainKey[:hph.accountKeyLen], hashedKey, 0); err != nil {
+		return nil, err
+	}
+	if len(plainKey) > hph.accountKeyLen {
+		hashedKey = hashedKey[:128]
+		if err := hashKey(hph.keccak, plainKey[hph.accountKeyLen:], hashedKey[64:], 0); err != nil {
+			return nil, err
+		}
+	}
+	return hashedKey, nil
+}
+
+func (hph *HexPatriciaHashed) nodeHash(node []byte) (h [length.Hash]byte, err error) {
+	hph.keccak.Reset()
+	if _, err = hph.keccak.Write(node); err != nil {
+		return h, err
+	}
+	_, err = hph.keccak.Read(h[:])
+	return h, err
+}
+
+// loadProofRow fills row with cells of the branch node stored under the given nibble prefix.
+// Leaf cells get their values from accountFn and storageFn.
+func (hph *HexPatriciaHashed) loadProofRow(prefix []byte, row *[16]Cell) (afterMap uint16, err error) {
+	branchData, err := hph.branchFn(hexToCompact(prefix))
+	if err != nil {
+		return 0, err
+	}
+	if len(branchData) < 2 {
+		return 0, fmt.Errorf("prove: missing branch node at prefix [%x]", prefix)
+	}
+	afterMap = binary.BigEndian.Uint16(branchData[0:])
+	pos := 2
+	for bitset := afterMap; bitset != 0; {
+		bit := bitset & -bitset
+		nibble := bits.TrailingZeros16(bit)
+		cell := &row[nibble]
+		cell.fillEmpty()
+		fieldBits := PartFlags(branchData[pos])
+		pos++
+		if pos, err = cell.fillFromFields(branchData, pos, fieldBits); err != nil {
+			return 0, fmt.Errorf("prefix [%x], branchData[%x]: %w", prefix, branchData, err)
+		}
+		if cell.apl > 0 {
+			if err = hph.accountFn(cell.apk[:cell.apl], cell); err != nil {
+				return 0, err
+			}
+		}
+		if cell.spl > 0 {
+			if err = hph.storageFn(cell.spk[:cell.spl], cell); err != nil {
+				return 0, err
+			}
+		}
+		bitset ^= bit
+	}
+	return afterMap, nil
+}
+
+// branchNode encodes full node from the row of cells located at the given depth
+func (hph *HexPatriciaHashed) branchNode(row *[16]Cell, afterMap uint16, depth int) ([]byte, error) {
+	var children [16][]byte
+	totalLen := 1 // value slot is always empty
+	for nibble := 0; nibble < 16; nibble++ {
+		if afterMap&(uint16(1)<<nibble) == 0 {
+			totalLen++
+			continue
+		}
+		cell := row[nibble] // computeCellHash modifies the cell, so hash a copy
+		ref, err := hph.computeCellHash(&cell, depth, nil)
+		if err != nil {
+			return nil, err
+		}
+		children[nibble] = common.Copy(ref)
+		totalLen += len(ref)
+	}
+	node := make([]byte, rlp.ListPrefixLen(totalLen), rlp.ListPrefixLen(totalLen)+totalLen)
+	encodeListPrefix(totalLen, node)
+	for nibble := 0; nibble < 16; nibble++ {
+		if children[nibble] == nil {
+			node = append(node, 0x80)
+			continue
+		}
+		node = append(node, children[nibble]...)
+	}
+	return append(node, 0x80), nil
+}
+
+// accountLeafNode encodes the leaf node for the account cell and returns the root of its storage trie
+func (hph *HexPatriciaHashed) accountLeafNode(cell *Cell, depth int) (storageRoot []byte, node []byte, err error) {
+	var storageRootHash [length.Hash]byte
+	switch {
+	case cell.spl > 0:
+		storageLeaf, err := hph.storageLeafNode(cell, 64)
+		if err != nil {
+			return nil, nil, err
+		}
+		if storageRootHash, err = hph.nodeHash(storageLeaf); err != nil {
+			return nil, nil, err
+		}
+	case cell.extLen > 0:
+		if cell.hl == 0 {
+			return nil, nil, fmt.Errorf("account cell [%x] has extension without hash", cell.apk[:cell.apl])
+		}
+		if storageRootHash, err = hph.extensionHash(cell.extension[:cell.extLen], cell.h[:cell.hl]); err != nil {
+			return nil, nil, err
+		}
+	case cell.hl > 0:
+		storageRootHash = cell.h
+	default:
+		copy(storageRootHash[:], EmptyRootHash)
+	}
+
+	key := make([]byte, 64-depth+1)
+	if err := hashKey(hph.keccak, cell.apk[:cell.apl], key, depth); err != nil {
+		return nil, nil, err
+	}
+	key[64-depth] = 16 // terminator
+
+	var valBuf [128]byte
+	valLen := cell.accountForHashing(valBuf[:], storageRootHash)
+	if node, err = leafNode(key, rlp.RlpEncodedBytes(valBuf[:valLen])); err != nil {
+		return nil, nil, err
+	}
+	return storageRootHash[:], node, nil
+}
+
+// storageLeafNode encodes the leaf node for the storage cell located at the given depth (>= 64)
+func (hph *HexPatriciaHashed) storageLeafNode(cell *Cell, depth int) ([]byte, error) {
+	hashedKeyOffset := depth - 64
+	key := make([]byte, 64-hashedKeyOffset+1)
+	if err := hashKey(hph.keccak, cell.spk[hph.accountKeyLen:cell.spl], key, hashedKeyOffset); err != nil {
+		return nil, err
+	}
+	key[64-hashedKeyOffset] = 16 // terminator
+	return leafNode(key, rlp.RlpSerializableBytes(cell.Storage[:cell.StorageLen]))
+}
+
+// leafNode encodes [compact(key), val] where key is nibbles with terminator
+func leafNode(key []byte, val rlp.RlpSerializable) ([]byte, error) {
+	var prefixBuf [8]byte
+	var valBuf bytes.Buffer
+	if err := val.ToDoubleRLP(&valBuf, prefixBuf[:]); err != nil {
+		return nil, err
+	}
+	return shortNode(hexToCompact(key), valBuf.Bytes()), nil
+}
+
+// extensionNode encodes [compact(key), hash] where key is nibbles without terminator
+func extensionNode(key []byte, hash []byte) []byte {
+	ref := make([]byte, rlp.StringLen(hash)+9)
+	n := rlp.EncodeString(hash, ref)
+	return shortNode(hexToCompact(key), ref[:n])
+}
+
+// shortNode encodes two-item list of compact key and already encoded value
+func shortNode(compactKey []byte, encodedVal []byte) []byte {
+	keyBuf := make([]byte, rlp.StringLen(compactKey)+9)
+	keyLen := rlp.EncodeString(compactKey, keyBuf)
+	totalLen := keyLen + len(encodedVal)
+
+	node := make([]byte, rlp.ListPrefixLen(totalLen), rlp.ListPrefixLen(totalLen)+totalLen)
+	encodeListPrefix(totalLen, node)
+	node = append(node, keyBuf[:keyLen]...)
+	return append(node, encodedVal...)
+}
+
+// encodeListPrefix writes list prefix into the buffer of rlp.ListPrefixLen(dataLen) size
+func encodeListPrefix(dataLen int, to []byte) {
+	var prefix [10]byte // rlp.EncodeListPrefix needs space for 8-byte length
+	n := rlp.EncodeListPrefix(dataLen, prefix[:])
+	copy(to, prefix[:n])
+}
+
+// ProofTrie computes HexPatriciaHashed commitment from scratch over the whole state and keeps
+// only those branch nodes which are required to prove the retained keys. It allows to generate
+// proofs for any historical state without stored commitment at the cost of full state traversal.
+// State items should be supplied in ascending order of their hashed keys.
+type ProofTrie struct {
+	hph        *HexPatriciaHashed
+	branches   map[string]BranchData
+	retain     [][]byte // nibblized keys which are going to be proven
+	plainKeys  [][]byte
+	hashedKeys [][]byte
+	updates    []Update
+	lastKey    []byte
+	batchSize  int
+}
+
+// NewProofTrie creates ProofTrie retaining proofs for given plain keys. accountFn and storageFn
+// should return values of the same state that is supplied via Update.
+func NewProofTrie(accountKeyLen int, retainPlainKeys [][]byte, batchSize int,
+	accountFn func(plainKey []byte, cell *Cell) error,
+	storageFn func(plainKey []byte, cell *Cell) error,
+) (*ProofTrie, error) {
+	t := &ProofTrie{
+		branches:  make(map[string]BranchData),
+		batchSize: batchSize,
+	}
+	t.hph = NewHexPatriciaHashed(accountKeyLen, t.branchFn, accountFn, storageFn)
+	for _, plainKey := range retainPlainKeys {
+		hashedKey, err := t.hph.nibblizedKey(plainKey)
+		if err != nil {
+			return nil, err
+		}
+		t.retain = append(t.retain, hashedKey)
+	}
+	return t, nil
+}
+
+func (t *ProofTrie) branchFn(prefix []byte) ([]byte, error) {
+	if branch, ok := t.branches[string(prefix)]; ok {
+		return branch[2:], nil // Skip touchMap, but keep afterMap
+	}
+	return nil, nil
+}
+
+// Update adds state item to the trie. Items should come in ascending order of hashed keys.
+func (t *ProofTrie) Update(plainKey []byte, update Update) error {
+	hashedKey, err := t.hph.nibblizedKey(plainKey)
+	if err != nil {
+		return err
+	}
+	if t.lastKey != nil && bytes.Compare(t.lastKey, hashedKey) >= 0 {
+		return fmt.Errorf("ProofTrie: key [%x] is not in ascending order of hashed keys", plainKey)
+	}
+	t.lastKey = hashedKey
+	t.plainKeys = append(t.plainKeys, common.Copy(plainKey))
+	t.hashedKeys = append(t.hashedKeys, hashedKey)
+	t.updates = append(t.updates, update)
+	if len(t.updates) >= t.batchSize {
+		return t.flush()
+	}
+	return nil
+}
+
+func (t *ProofTrie) flush() error {
+	if len(t.updates) == 0 {
+		return nil
+	}
+	_, branchNodeUpdates, err := t.hph.ProcessUpdates(t.plainKeys, t.hashedKeys, t.updates)
+	if err != nil {
+		return err
+	}
+	for key, update := range branchNodeUpdates {
+		if pre, ok := t.branches[key]; ok {
+			if update, err = pre.MergeHexBranches(update, nil); err != nil {
+				return err
+			}
+		}
+		t.branches[key] = update
+	}
+	t.plainKeys, t.hashedKeys, t.updates = t.plainKeys[:0], t.hashedKeys[:0], t.updates[:0]
+
+	// Keys come in ascending order, so branch nodes which are not on the path to the last key
+	// are never going to be unfolded again.
+	for key := range t.branches {
+		if !t.retained(CompactedKeyToHex([]byte(key))) {
+			delete(t.branches, key)
+		}
+	}
+	return nil
+}
+
+func (t *ProofTrie) retained(prefix []byte) bool {
+	if bytes.HasPrefix(t.lastKey, prefix) {
+		return true
+	}
+	for _, key := range t.retain {
+		if bytes.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// RootHash processes pending updates and returns the state root
+func (t *ProofTrie) RootHash() ([]byte, error) {
+	if err := t.flush(); err != nil {
+		return nil, err
+	}
+	return t.hph.RootHash()
+}
+
+// ProveAccount see HexPatriciaHashed.ProveAccount. Key should be one of the retained keys.
+func (t *ProofTrie) ProveAccount(plainKey []byte) (proof [][]byte, storageRoot []byte, err error) {
+	if err := t.flush(); err != nil {
+		return nil, nil, err
+	}
+	return t.hph.ProveAccount(plainKey)
+}
+
+// ProveStorage see HexPatriciaHashed.ProveStorage. Key should be one of the retained keys.
+func (t *ProofTrie) ProveStorage(plainKey []byte) (proof [][]byte, err error) {
+	if err := t.flush(); err != nil {
+		return nil, err
+	}
+	return t.hph.ProveStorage(plainKey)
+}
@@ -0,0 +1,226 @@
+/*
+   Copyright 2023 Erigon contributors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package commitment
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/sha3"
+
+	"github.com/ledgerwatch/erigon-lib/rlp"
+)
+
+// verifyProof walks the proof from the root following nibblized key and returns the value
+// stored in the leaf, or nil if the proof shows absence of the key.
+func verifyProof(t *testing.T, root []byte, key []byte, proof [][]byte) []byte {
+	t.Helper()
+	want := root
+	for i, node := range proof {
+		keccak := sha3.NewLegacyKeccak256()
+		keccak.Write(node)
+		require.Equal(t, want, keccak.Sum(nil), "hash of proof node %d", i)
+		for {
+			items := decodeNodeItems(t, node)
+			var ref []byte
+			switch len(items) {
+			case 17:
+				ref = items[key[0]]
+				key = key[1:]
+			case 2:
+				_, _, isList, err := rlp.Prefix(items[0], 0)
+				require.NoError(t, err)
+				require.False(t, isList)
+				compact := rlpStringPayload(t, items[0])
+				nibbles := CompactedKeyToHex(compact)
+				if len(nibbles) > 0 && nibbles[len(nibbles)-1] == 16 {
+					nibbles = nibbles[:len(nibbles)-1] // drop leaf terminator
+				}
+				if !bytes.HasPrefix(key, nibbles) {
+					require.Equal(t, len(proof)-1, i, "proof continues after divergence")
+					return nil
+				}
+				key = key[len(nibbles):]
+				if compact[0]&0x20 != 0 { // leaf
+					require.Empty(t, key)
+					require.Equal(t, len(proof)-1, i, "proof continues after leaf")
+					return rlpStringPayload(t, items[1])
+				}
+				ref = items[1]
+			default:
+				t.Fatalf("unexpected node with %d items", len(items))
+			}
+			_, dataLen, isList, err := rlp.Prefix(ref, 0)
+			require.NoError(t, err)
+			if isList {
+				node = ref // embedded node
+				continue
+			}
+			if dataLen == 0 {
+				require.Equal(t, len(proof)-1, i, "proof continues after empty child")
+				return nil
+			}
+			want = rlpStringPayload(t, ref)
+			break
+		}
+	}
+	require.Empty(t, proof, "proof does not reach a leaf")
+	return nil
+}
+
+func decodeNodeItems(t *testing.T, node []byte) (items [][]byte) {
+	t.Helper()
+	pos, dataLen, err := rlp.List(node, 0)
+	require.NoError(t, err)
+	for end := pos + dataLen; pos < end; {
+		dataPos, l, _, err := rlp.Prefix(node, pos)
+		require.NoError(t, err)
+		items = append(items, node[pos:dataPos+l])
+		pos = dataPos + l
+	}
+	return items
+}
+
+func rlpStringPayload(t *testing.T, enc []byte) []byte {
+	t.Helper()
+	pos, l, err := rlp.String(enc, 0)
+	require.NoError(t, err)
+	return enc[pos : pos+l]
+}
+
+func hexNibbles(t *testing.T, hph *HexPatriciaHashed, plainKey []byte) []byte {
+	t.Helper()
+	hashedKey, err := hph.nibblizedKey(plainKey)
+	require.NoError(t, err)
+	return hashedKey
+}
+
+func Test_HexPatriciaHashed_ProofTrie(t *testing.T) {
+	rnd := rand.New(rand.NewSource(42))
+	ub := NewUpdateBuilder()
+	var accounts, slots [][]byte
+	for i := 0; i < 100; i++ {
+		addr := fmt.Sprintf("%02x", i)
+		// set all account fields, as ProofTrie gets complete account state, like from the state reader
+		ub.Balance(addr, rnd.Uint64()).Nonce(addr, uint64(i)).CodeHash(addr, hex.EncodeToString(EmptyCodeHash))
+		accounts = append(accounts, []byte{byte(i)})
+		storageSize := 0
+		switch {
+		case i%10 == 1:
+			storageSize = 1 // singleton storage
+		case i%5 == 0:
+			storageSize = 1 + rnd.Intn(30)
+		}
+		for j := 0; j < storageSize; j++ {
+			loc := fmt.Sprintf("%02x", j)
+			value := make([]byte, 1+rnd.Intn(31))
+			rnd.Read(value)
+			value[0] |= 1 // no leading zeroes
+			ub.Storage(addr, loc, hex.EncodeToString(value))
+			slots = append(slots, []byte{byte(i), byte(j)})
+		}
+	}
+	plainKeys, hashedKeys, updates := ub.Build()
+
+	ms := NewMockState(t)
+	require.NoError(t, ms.applyPlainUpdates(plainKeys, updates))
+	hph := NewHexPatriciaHashed(1, ms.branchFn, ms.accountFn, ms.storageFn)
+	expectedRoot, branchNodeUpdates, err := hph.ProcessUpdates(plainKeys, hashedKeys, updates)
+	require.NoError(t, err)
+	ms.applyBranchNodeUpdates(branchNodeUpdates)
+
+	retain := [][]byte{
+		{0xaa},       // absent account
+		{0x01, 0x7f}, // absent slot of the account with singleton storage
+		{0x05, 0x7f}, // absent slot of the account with storage
+		{0x07, 0x00}, // slot of the account without storage
+	}
+	retain = append(retain, accounts...)
+	retain = append(retain, slots...)
+
+	for _, batchSize := range []int{1, 7, 1000} {
+		t.Run(fmt.Sprintf("batch%d", batchSize), func(t *testing.T) {
+			pt, err := NewProofTrie(1, retain, batchSize, ms.accountFn, ms.storageFn)
+			require.NoError(t, err)
+			for i, plainKey := range plainKeys {
+				require.NoError(t, pt.Update(plainKey, updates[i]))
+			}
+			root, err := pt.RootHash()
+			require.NoError(t, err)
+			require.Equal(t, expectedRoot, root)
+
+			storageRoots := make(map[byte][]byte)
+			for _, plainKey := range retain {
+				if len(plainKey) != 1 {
+					continue
+				}
+				proof, storageRoot, err := pt.ProveAccount(plainKey)
+				require.NoError(t, err)
+				value := verifyProof(t, root, hexNibbles(t, pt.hph, plainKey), proof)
+				if _, ok := ms.sm[string(plainKey)]; !ok {
+					require.Nil(t, value, "account %x", plainKey)
+					require.Nil(t, storageRoot)
+					continue
+				}
+				require.NotNil(t, value, "account %x", plainKey)
+				items := decodeNodeItems(t, value)
+				require.Len(t, items, 4)
+				require.Equal(t, storageRoot, rlpStringPayload(t, items[2]))
+				storageRoots[plainKey[0]] = storageRoot
+			}
+
+			for _, plainKey := range retain {
+				if len(plainKey) != 2 {
+					continue
+				}
+				proof, err := pt.ProveStorage(plainKey)
+				require.NoError(t, err)
+				storageRoot := storageRoots[plainKey[0]]
+				if bytes.Equal(storageRoot, EmptyRootHash) {
+					require.Empty(t, proof)
+					continue
+				}
+				value := verifyProof(t, storageRoot, hexNibbles(t, pt.hph, plainKey)[64:], proof)
+				exBytes, ok := ms.sm[string(plainKey)]
+				if !ok {
+					require.Nil(t, value, "slot %x", plainKey)
+					continue
+				}
+				var ex Update
+				_, err = ex.Decode(exBytes, 0)
+				require.NoError(t, err)
+				require.Equal(t, ex.CodeHashOrStorage[:ex.ValLength], rlpStringPayload(t, value), "slot %x", plainKey)
+			}
+		})
+	}
+}
+
+func Test_HexPatriciaHashed_ProofTrieOrder(t *testing.T) {
+	ms := NewMockState(t)
+	plainKeys, _, updates := NewUpdateBuilder().
+		Balance("00", 4).
+		Balance("01", 5).
+		Build()
+	pt, err := NewProofTrie(1, nil, 10, ms.accountFn, ms.storageFn)
+	require.NoError(t, err)
+	require.NoError(t, pt.Update(plainKeys[1], updates[1]))
+	require.Error(t, pt.Update(plainKeys[0], updates[0]))
+}
@@ -115,8 +115,8 @@ func (ms MockState) storageFn(plainKey []byte, cell *Cell) error {
 		return nil
 	}
 	if ex.Flags&StorageUpdate != 0 {
-		copy(cell.Storage[:], ex.CodeHashOrStorage[:])
-		cell.StorageLen = len(ex.CodeHashOrStorage)
+		copy(cell.Storage[:], ex.CodeHashOrStorage[:ex.ValLength])
+		cell.StorageLen = ex.ValLength
 	} else {
 		cell.StorageLen = 0
 		cell.Storage = [length.Hash]byte{}
@@ -154,6 +154,7 @@ func (ms *MockState) applyPlainUpdates(plainKeys [][]byte, updates []Update) err
 				if update.Flags&StorageUpdate != 0 {
 					ex.Flags |= StorageUpdate
 					copy(ex.CodeHashOrStorage[:], update.CodeHashOrStorage[:])
+					ex.ValLength = update.ValLength
 				}
 				ms.sm[string(key)] = ex.Encode(nil, ms.numBuf[:])
 			} else {
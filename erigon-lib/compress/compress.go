@@ -72,6 +72,8 @@ type Compressor struct {
 	trace            bool
 	logger           log.Logger
 	noFsync          bool // fsync is enabled by default, but tests can manually disable
+	codec            Codec
+	zstdSampler      *zstdSampler // sample of words to train zstd dictionary, used only by CodecZstd
 }
 
 func NewCompressor(ctx context.Context, logPrefix, outputFile, tmpDir string, minPatternScore uint64, workers int, lvl log.Lvl, logger log.Logger) (*Compressor, error) {
@@ -129,6 +131,15 @@ func (c *Compressor) Close() {
 func (c *Compressor) SetTrace(trace bool) { c.trace = trace }
 func (c *Compressor) Workers() int        { return c.workers }
 
+// SetCodec - must be called before first AddWord. CodecZstd skips building of patterns dictionary - which is the slowest part of compression.
+func (c *Compressor) SetCodec(codec Codec) {
+	c.codec = codec
+	if codec == CodecZstd {
+		c.zstdSampler = &zstdSampler{}
+	}
+}
+func (c *Compressor) Codec() Codec { return c.codec }
+
 func (c *Compressor) Count() int { return int(c.wordsCount) }
 
 func (c *Compressor) AddWord(word []byte) error {
@@ -139,6 +150,10 @@ func (c *Compressor) AddWord(word []byte) error {
 	}
 
 	c.wordsCount++
+	if c.codec == CodecZstd {
+		c.zstdSampler.add(word)
+		return c.uncompressedFile.Append(word)
+	}
 	l := 2*len(word) + 2
 	if c.superstringLen+l > superstringLimit {
 		if c.superstringCount%samplingFactor == 0 {
@@ -181,6 +196,10 @@ func (c *Compressor) Compress() error {
 	close(c.superstrings)
 	c.wg.Wait()
 
+	if c.codec == CodecZstd {
+		return c.compressZstd()
+	}
+
 	if c.lvl < log.LvlTrace {
 		c.logger.Log(c.lvl, fmt.Sprintf("[%s] BuildDict start", c.logPrefix), "workers", c.workers)
 	}
@@ -26,6 +26,7 @@ import (
 	"time"
 	"unsafe"
 
+	"github.com/klauspost/compress/zstd"
 	"github.com/ledgerwatch/erigon-lib/common/dbg"
 	"github.com/ledgerwatch/erigon-lib/mmap"
 	"github.com/ledgerwatch/log/v3"
@@ -111,6 +112,8 @@ type Decompressor struct {
 	modTime         time.Time
 	wordsCount      uint64
 	emptyWordsCount uint64
+	codec           Codec
+	zstdDecoder     *zstd.Decoder
 
 	filePath, fileName string
 }
@@ -179,6 +182,17 @@ func NewDecompressor(compressedFilePath string) (d *Decompressor, err error) {
 	defer d.EnableReadAhead().DisableReadAhead() //speedup opening on slow drives
 
 	d.wordsCount = binary.BigEndian.Uint64(d.data[:8])
+	d.codec, d.wordsCount = Codec(d.wordsCount>>codecShift), d.wordsCount&wordsCountMask
+	switch d.codec {
+	case CodecPatterns:
+	case CodecZstd:
+		if err = d.openZstd(); err != nil {
+			return nil, err
+		}
+		return d, nil
+	default:
+		return nil, fmt.Errorf("unknown codec: %d", d.codec)
+	}
 	d.emptyWordsCount = binary.BigEndian.Uint64(d.data[8:16])
 	dictSize := binary.BigEndian.Uint64(d.data[16:24])
 	data := d.data[24 : 24+dictSize]
@@ -352,6 +366,10 @@ func (d *Decompressor) IsOpen() bool {
 }
 
 func (d *Decompressor) Close() {
+	if d.zstdDecoder != nil {
+		d.zstdDecoder.Close()
+		d.zstdDecoder = nil
+	}
 	if d.f != nil {
 		if err := mmap.Munmap(d.mmapHandle1, d.mmapHandle2); err != nil {
 			log.Log(dbg.FileCloseLogLevel, "unmap", "err", err, "file", d.FileName(), "stack", dbg.Stack())
@@ -364,6 +382,7 @@ func (d *Decompressor) Close() {
 }
 
 func (d *Decompressor) FilePath() string { return d.filePath }
+func (d *Decompressor) Codec() Codec     { return d.codec }
 func (d *Decompressor) FileName() string { return d.fileName }
 
 // WithReadAhead - Expect read in sequential order. (Hence, pages in the given range can be aggressively read ahead, and may be freed soon after they are accessed.)
@@ -409,6 +428,9 @@ func (d *Decompressor) EnableWillNeed() *Decompressor {
 // Getter represent "reader" or "interator" that can move accross the data of the decompressor
 // The full state of the getter can be captured by saving dataP, and dataBit
 type Getter struct {
+	zstd        *zstd.Decoder // not nil for CodecZstd files
+	zstdBuf     []byte
+	zstdFrame   []byte
 	patternDict *patternTable
 	posDict     *posTable
 	fName       string
@@ -516,6 +538,7 @@ func (d *Decompressor) EmptyWordsCount() int { return int(d.emptyWordsCount) }
 // for the same decompressor
 func (d *Decompressor) MakeGetter() *Getter {
 	return &Getter{
+		zstd:        d.zstdDecoder,
 		posDict:     d.posDict,
 		data:        d.data[d.wordsStart:],
 		patternDict: d.dict,
@@ -536,6 +559,9 @@ func (g *Getter) HasNext() bool {
 // and appends it to the given buf, returning the result of appending
 // After extracting next word, it moves to the beginning of the next one
 func (g *Getter) Next(buf []byte) ([]byte, uint64) {
+	if g.zstd != nil {
+		return g.zstdNext(buf)
+	}
 	savePos := g.dataP
 	wordLen := g.nextPos(true)
 	wordLen-- // because when create huffman tree we do ++ , because 0 is terminator
@@ -595,6 +621,9 @@ func (g *Getter) Next(buf []byte) ([]byte, uint64) {
 }
 
 func (g *Getter) NextUncompressed() ([]byte, uint64) {
+	if g.zstd != nil {
+		return g.zstdNextUncompressed()
+	}
 	wordLen := g.nextPos(true)
 	wordLen-- // because when create huffman tree we do ++ , because 0 is terminator
 	if wordLen == 0 {
@@ -616,6 +645,9 @@ func (g *Getter) NextUncompressed() ([]byte, uint64) {
 
 // Skip moves offset to the next word and returns the new offset and the length of the word.
 func (g *Getter) Skip() (uint64, int) {
+	if g.zstd != nil {
+		return g.zstdSkip()
+	}
 	l := g.nextPos(true)
 	l-- // because when create huffman tree we do ++ , because 0 is terminator
 	if l == 0 {
@@ -653,6 +685,9 @@ func (g *Getter) Skip() (uint64, int) {
 }
 
 func (g *Getter) SkipUncompressed() (uint64, int) {
+	if g.zstd != nil {
+		return g.zstdSkip()
+	}
 	wordLen := g.nextPos(true)
 	wordLen-- // because when create huffman tree we do ++ , because 0 is terminator
 	if wordLen == 0 {
@@ -674,6 +709,9 @@ func (g *Getter) SkipUncompressed() (uint64, int) {
 // Match returns true and next offset if the word at current offset fully matches the buf
 // returns false and current offset otherwise.
 func (g *Getter) Match(buf []byte) (bool, uint64) {
+	if g.zstd != nil {
+		return g.zstdMatch(buf)
+	}
 	savePos := g.dataP
 	wordLen := g.nextPos(true)
 	wordLen-- // because when create huffman tree we do ++ , because 0 is terminator
@@ -739,6 +777,9 @@ func (g *Getter) Match(buf []byte) (bool, uint64) {
 
 // MatchPrefix only checks if the word at the current offset has a buf prefix. Does not move offset to the next word.
 func (g *Getter) MatchPrefix(prefix []byte) bool {
+	if g.zstd != nil {
+		return g.zstdMatchPrefix(prefix)
+	}
 	savePos := g.dataP
 	defer func() {
 		g.dataP, g.dataBit = savePos, 0
@@ -822,6 +863,9 @@ func (g *Getter) MatchPrefix(prefix []byte) bool {
 // MatchCmp lexicographically compares given buf with the word at the current offset in the file.
 // returns 0 if buf == word, -1 if buf < word, 1 if buf > word
 func (g *Getter) MatchCmp(buf []byte) int {
+	if g.zstd != nil {
+		return g.zstdMatchCmp(buf)
+	}
 	savePos := g.dataP
 	wordLen := g.nextPos(true)
 	wordLen-- // because when create huffman tree we do ++ , because 0 is terminator
@@ -884,6 +928,9 @@ func (g *Getter) MatchCmp(buf []byte) int {
 // MatchPrefixCmp lexicographically compares given prefix with the word at the current offset in the file.
 // returns 0 if buf == word, -1 if buf < word, 1 if buf > word
 func (g *Getter) MatchPrefixCmp(prefix []byte) int {
+	if g.zstd != nil {
+		return g.zstdMatchPrefixCmp(prefix)
+	}
 	savePos := g.dataP
 	defer func() {
 		g.dataP, g.dataBit = savePos, 0
@@ -950,6 +997,9 @@ func (g *Getter) MatchPrefixCmp(prefix []byte) int {
 }
 
 func (g *Getter) MatchPrefixUncompressed(prefix []byte) int {
+	if g.zstd != nil {
+		return g.zstdMatchPrefixUncompressed(prefix)
+	}
 	savePos := g.dataP
 	defer func() {
 		g.dataP, g.dataBit = savePos, 0
@@ -986,6 +1036,9 @@ func (g *Getter) FastNext(buf []byte) ([]byte, uint64) {
 			panic(fmt.Sprintf("file: %s, %s, %s", g.fName, rec, dbg.Stack()))
 		}
 	}()
+	if g.zstd != nil {
+		return g.zstdDecode(buf[:0]), g.dataP
+	}
 
 	savePos := g.dataP
 	wordLen := g.nextPos(true)
@@ -17,10 +17,13 @@
 package compress
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/ledgerwatch/log/v3"
 	"github.com/stretchr/testify/require"
 )
 
@@ -110,6 +113,76 @@ func BenchmarkDecompressMatchPrefixCmp(b *testing.B) {
 	}
 }
 
+func BenchmarkDecompressZstdNext(b *testing.B) {
+	d := prepareZstdDict(b, 100)
+	defer d.Close()
+	g := d.MakeGetter()
+	for i := 0; i < b.N; i++ {
+		_, _ = g.Next(nil)
+		if !g.HasNext() {
+			g.Reset(0)
+		}
+	}
+}
+
+func BenchmarkDecompressZstdSkip(b *testing.B) {
+	d := prepareZstdDict(b, 100)
+	defer d.Close()
+	g := d.MakeGetter()
+
+	for i := 0; i < b.N; i++ {
+		_, _ = g.Skip()
+		if !g.HasNext() {
+			g.Reset(0)
+		}
+	}
+}
+
+func BenchmarkDecompressZstdMatch(b *testing.B) {
+	d := prepareZstdDict(b, 100)
+	defer d.Close()
+	g := d.MakeGetter()
+	for i := 0; i < b.N; i++ {
+		_, _ = g.Match([]byte("longlongword"))
+	}
+}
+
+func BenchmarkDecompressZstdMatchPrefix(b *testing.B) {
+	d := prepareZstdDict(b, 100)
+	defer d.Close()
+	g := d.MakeGetter()
+
+	for i := 0; i < b.N; i++ {
+		_ = g.MatchPrefix([]byte("longlongword"))
+	}
+}
+
+func BenchmarkCompressCodec(b *testing.B) {
+	words := make([][]byte, 0, 100_000)
+	for i := 0; i < cap(words); i++ {
+		words = append(words, []byte(fmt.Sprintf("%d longlongword %d %s", i, i, loremStrings[i%len(loremStrings)])))
+	}
+	for _, codec := range []Codec{CodecPatterns, CodecZstd} {
+		b.Run(codec.String(), func(b *testing.B) {
+			logger := log.New()
+			tmpDir := b.TempDir()
+			file := filepath.Join(tmpDir, "compressed")
+			for i := 0; i < b.N; i++ {
+				c, err := NewCompressor(context.Background(), b.Name(), file, tmpDir, 1, 2, log.LvlDebug, logger)
+				require.NoError(b, err)
+				c.DisableFsync()
+				c.SetCodec(codec)
+				for _, w := range words {
+					require.NoError(b, c.AddWord(w))
+				}
+				require.NoError(b, c.Compress())
+				b.ReportMetric(float64(c.Ratio), "ratio")
+				c.Close()
+			}
+		})
+	}
+}
+
 func BenchmarkDecompressTorrent(t *testing.B) {
 	t.Skip()
 
@@ -0,0 +1,482 @@
+/*
+   Copyright 2023 Erigon contributors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compress
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/ledgerwatch/erigon-lib/etl"
+	"github.com/ledgerwatch/log/v3"
+	"golang.org/x/sync/errgroup"
+)
+
+// Codec - how words of file are compressed. Recorded in the highest byte of the first header field (words count),
+// files created before codecs were introduced have 0 there - it's CodecPatterns.
+type Codec uint8
+
+const (
+	// CodecPatterns - dictionary of patterns (built by DictionaryBuilder) + huffman codes
+	CodecPatterns Codec = 0
+	// CodecZstd - every word is zstd frame, all frames share one dictionary trained on sample of words.
+	// Much faster to build than CodecPatterns, but files are a bit bigger.
+	CodecZstd Codec = 1
+)
+
+func (c Codec) String() string {
+	switch c {
+	case CodecPatterns:
+		return "patterns"
+	case CodecZstd:
+		return "zstd"
+	default:
+		return fmt.Sprintf("unknown codec %d", uint8(c))
+	}
+}
+
+const (
+	codecShift     = 56
+	wordsCountMask = uint64(1)<<codecShift - 1
+)
+
+// zstd file format (all numbers big-endian):
+//
+//	[8 bytes: codec<<56 | words count][8 bytes: empty words count][8 bytes: dictionary size][1 byte: dictionary kind][7 bytes: reserved][dictionary]
+//	then every word: [uvarint: len(word)<<1 | compressed flag] and either [uvarint: len(frame)][zstd frame] or [word as is]
+//
+// frames are stored without 4 bytes of zstd magic number - they are same for all frames and significant for short words
+const zstdHeaderSize = 32
+
+var zstdMagic = []byte{0x28, 0xb5, 0x2f, 0xfd}
+
+const (
+	zstdDictNone    byte = 0
+	zstdDictTrained byte = 1 // dictionary in zstd format, built by zstd.BuildDict
+	zstdDictRaw     byte = 2 // raw content dictionary, used when samples are not enough to train
+)
+
+const (
+	zstdDictID         = 1
+	zstdDictSize       = 112 * 1024      // size of dictionary history - same as default of `zstd --train`
+	zstdSamplesLimit   = 8 * 1024 * 1024 // total size of samples to train dictionary
+	zstdMaxSampleLen   = 16 * 1024       // also size of chunk of samples passed to zstd.BuildDict
+	zstdMinWordToFrame = 8               // shorter words are stored as is - frame header will not fit
+	zstdBatchWords     = 4096            // words are compressed in parallel by batches
+)
+
+// zstdSampler - keeps evenly distributed sample of words: if samples are over limit - drops every second sample
+// and takes only every second word from now on
+type zstdSampler struct {
+	samples [][]byte
+	size    int
+	stride  uint64
+	n       uint64
+}
+
+func (s *zstdSampler) add(word []byte) {
+	if len(word) < zstdMinWordToFrame {
+		return
+	}
+	if s.stride == 0 {
+		s.stride = 1
+	}
+	s.n++
+	if s.n%s.stride != 0 {
+		return
+	}
+	if len(word) > zstdMaxSampleLen {
+		word = word[:zstdMaxSampleLen]
+	}
+	s.samples = append(s.samples, bytes.Clone(word))
+	s.size += len(word)
+	for s.size > zstdSamplesLimit {
+		s.size = 0
+		kept := s.samples[:0]
+		for i := 1; i < len(s.samples); i += 2 {
+			kept = append(kept, s.samples[i])
+			s.size += len(s.samples[i])
+		}
+		s.samples = kept
+		s.stride *= 2
+	}
+}
+
+// buildZstdDict - trains dictionary on samples. If it's impossible (too few or too random samples) - uses samples as raw dictionary.
+func buildZstdDict(samples [][]byte) (dict []byte, kind byte) {
+	if len(samples) == 0 {
+		return nil, zstdDictNone
+	}
+	// the most recent bytes of history are the cheapest to reference, so history is filled from the end
+	from, size := len(samples), 0
+	for from > 0 && size < zstdDictSize {
+		from--
+		size += len(samples[from])
+	}
+	history := make([]byte, 0, size)
+	for _, sample := range samples[from:] {
+		history = append(history, sample...)
+	}
+	if len(history) > zstdDictSize {
+		history = history[len(history)-zstdDictSize:]
+	}
+	if len(history) < 8 {
+		return nil, zstdDictNone
+	}
+	// BuildDict does reset encoder for each content, it's too expensive for millions of short words - so join them to chunks
+	contents := make([][]byte, 0, len(samples)/16+1)
+	var chunk []byte
+	for _, sample := range samples {
+		if len(chunk)+len(sample) > zstdMaxSampleLen && len(chunk) > 0 {
+			contents = append(contents, chunk)
+			chunk = nil
+		}
+		chunk = append(chunk, sample...)
+	}
+	contents = append(contents, chunk)
+	dict, err := trainZstdDict(contents, history)
+	if err != nil {
+		return history, zstdDictRaw
+	}
+	return dict, zstdDictTrained
+}
+
+func trainZstdDict(samples [][]byte, history []byte) (dict []byte, err error) {
+	defer func() {
+		// zstd.BuildDict panics on some degenerate inputs (for example: when samples have no matches at all)
+		if rec := recover(); rec != nil {
+			err = fmt.Errorf("zstd.BuildDict: %v", rec)
+		}
+	}()
+	return zstd.BuildDict(zstd.BuildDictOptions{
+		ID:       zstdDictID,
+		Contents: samples,
+		History:  history,
+		Offsets:  [3]int{1, 4, 8},
+		Level:    zstd.SpeedFastest,
+	})
+}
+
+func (c *Compressor) compressZstd() error {
+	t := time.Now()
+	dict, dictKind := buildZstdDict(c.zstdSampler.samples)
+	c.zstdSampler = nil
+	if c.lvl < log.LvlTrace {
+		c.logger.Log(c.lvl, fmt.Sprintf("[%s] BuildDict", c.logPrefix), "took", time.Since(t), "codec", CodecZstd, "dict", len(dict))
+	}
+
+	opts := []zstd.EOption{zstd.WithEncoderCRC(false), zstd.WithEncoderConcurrency(c.workers), zstd.WithEncoderLevel(zstd.SpeedFastest)}
+	switch dictKind {
+	case zstdDictTrained:
+		opts = append(opts, zstd.WithEncoderDict(dict))
+	case zstdDictRaw:
+		opts = append(opts, zstd.WithEncoderDictRaw(zstdDictID, dict))
+	}
+	enc, err := zstd.NewWriter(nil, opts...)
+	if err != nil {
+		return err
+	}
+	defer enc.Close()
+
+	defer os.Remove(c.tmpOutFilePath)
+	cf, err := os.Create(c.tmpOutFilePath)
+	if err != nil {
+		return err
+	}
+	defer cf.Close()
+	w := bufio.NewWriterSize(cf, 2*etl.BufIOSize)
+
+	var numBuf [binary.MaxVarintLen64]byte
+	var header [zstdHeaderSize]byte
+	binary.BigEndian.PutUint64(header[:8], uint64(CodecZstd)<<codecShift|c.wordsCount)
+	binary.BigEndian.PutUint64(header[16:24], uint64(len(dict)))
+	header[24] = dictKind
+	if _, err := w.Write(header[:]); err != nil {
+		return err
+	}
+	if _, err := w.Write(dict); err != nil {
+		return err
+	}
+
+	t = time.Now()
+	var emptyWordsCount uint64
+	words := make([][]byte, 0, zstdBatchWords)
+	compressed := make([]bool, 0, zstdBatchWords)
+	bufs, frames := make([][]byte, zstdBatchWords), make([][]byte, zstdBatchWords)
+	flush := func() error {
+		g := errgroup.Group{}
+		g.SetLimit(c.workers)
+		for i := range words {
+			i := i
+			if !compressed[i] || len(words[i]) < zstdMinWordToFrame {
+				frames[i] = frames[i][:0]
+				continue
+			}
+			g.Go(func() error {
+				bufs[i] = enc.EncodeAll(words[i], bufs[i][:0])
+				frames[i] = bufs[i][len(zstdMagic):]
+				return nil
+			})
+		}
+		_ = g.Wait()
+		for i, word := range words {
+			if len(word) == 0 {
+				emptyWordsCount++
+			}
+			// frame is useful only if it's shorter than the word itself
+			useFrame := len(frames[i]) > 0 && len(frames[i])+binary.PutUvarint(numBuf[:], uint64(len(frames[i]))) < len(word)
+			var prefix uint64 = uint64(len(word)) << 1
+			if useFrame {
+				prefix |= 1
+			}
+			n := binary.PutUvarint(numBuf[:], prefix)
+			if _, err := w.Write(numBuf[:n]); err != nil {
+				return err
+			}
+			if useFrame {
+				n = binary.PutUvarint(numBuf[:], uint64(len(frames[i])))
+				if _, err := w.Write(numBuf[:n]); err != nil {
+					return err
+				}
+				if _, err := w.Write(frames[i]); err != nil {
+					return err
+				}
+				continue
+			}
+			if _, err := w.Write(word); err != nil {
+				return err
+			}
+		}
+		words, compressed = words[:0], compressed[:0]
+		return nil
+	}
+
+	if err := c.uncompressedFile.ForEach(func(v []byte, isCompressed bool) error {
+		select {
+		case <-c.ctx.Done():
+			return c.ctx.Err()
+		default:
+		}
+		words = append(words, bytes.Clone(v))
+		compressed = append(compressed, isCompressed)
+		if len(words) == zstdBatchWords {
+			return flush()
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	if err := flush(); err != nil {
+		return err
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	binary.BigEndian.PutUint64(header[8:16], emptyWordsCount)
+	if _, err := cf.WriteAt(header[8:16], 8); err != nil {
+		return err
+	}
+
+	if err = c.fsync(cf); err != nil {
+		return err
+	}
+	if err = cf.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(c.tmpOutFilePath, c.outputFile); err != nil {
+		return fmt.Errorf("renaming: %w", err)
+	}
+
+	c.Ratio, err = Ratio(c.uncompressedFile.filePath, c.outputFile)
+	if err != nil {
+		return fmt.Errorf("ratio: %w", err)
+	}
+
+	_, fName := filepath.Split(c.outputFile)
+	if c.lvl < log.LvlTrace {
+		c.logger.Log(c.lvl, fmt.Sprintf("[%s] Compress", c.logPrefix), "took", time.Since(t), "ratio", c.Ratio, "file", fName, "codec", CodecZstd)
+	}
+	return nil
+}
+
+// openZstd - reads dictionary of zstd file and prepares decoder
+func (d *Decompressor) openZstd() (err error) {
+	d.emptyWordsCount = binary.BigEndian.Uint64(d.data[8:16])
+	dictSize := binary.BigEndian.Uint64(d.data[16:24])
+	dictKind := d.data[24]
+	if zstdHeaderSize+dictSize > uint64(d.size) {
+		return fmt.Errorf("dictionary is invalid: size=%d", dictSize)
+	}
+	dict := d.data[zstdHeaderSize : zstdHeaderSize+dictSize]
+
+	opts := []zstd.DOption{zstd.WithDecoderConcurrency(0)}
+	switch dictKind {
+	case zstdDictNone:
+	case zstdDictTrained:
+		opts = append(opts, zstd.WithDecoderDicts(dict))
+	case zstdDictRaw:
+		opts = append(opts, zstd.WithDecoderDictRaw(zstdDictID, dict))
+	default:
+		return fmt.Errorf("dictionary is invalid: kind=%d", dictKind)
+	}
+	if d.zstdDecoder, err = zstd.NewReader(nil, opts...); err != nil {
+		return err
+	}
+	d.wordsStart = zstdHeaderSize + dictSize
+	return nil
+}
+
+// zstdWordHeader - reads length of word and length of its zstd frame (0 if word is stored as is).
+// Moves offset to the beginning of word data.
+func (g *Getter) zstdWordHeader() (wordLen, frameLen uint64) {
+	prefix, n := binary.Uvarint(g.data[g.dataP:])
+	g.dataP += uint64(n)
+	wordLen = prefix >> 1
+	if prefix&1 == 0 {
+		return wordLen, 0
+	}
+	frameLen, n = binary.Uvarint(g.data[g.dataP:])
+	g.dataP += uint64(n)
+	return wordLen, frameLen
+}
+
+// zstdDecode - appends word at current offset to buf and moves offset to the next word
+func (g *Getter) zstdDecode(buf []byte) []byte {
+	wordLen, frameLen := g.zstdWordHeader()
+	if frameLen == 0 {
+		buf = append(buf, g.data[g.dataP:g.dataP+wordLen]...)
+		g.dataP += wordLen
+		return buf
+	}
+	g.zstdFrame = append(append(g.zstdFrame[:0], zstdMagic...), g.data[g.dataP:g.dataP+frameLen]...)
+	g.dataP += frameLen
+	res, err := g.zstd.DecodeAll(g.zstdFrame, buf)
+	if err != nil {
+		panic(fmt.Sprintf("file: %s, %s", g.fName, err))
+	}
+	if uint64(len(res)-len(buf)) != wordLen {
+		panic(fmt.Sprintf("file: %s, decoded word length %d, expected %d", g.fName, len(res)-len(buf), wordLen))
+	}
+	return res
+}
+
+func (g *Getter) zstdNext(buf []byte) ([]byte, uint64) {
+	if buf == nil { // nil - is the marker of "something not found"
+		buf = []byte{}
+	}
+	return g.zstdDecode(buf), g.dataP
+}
+
+// zstdNextUncompressed - words added by AddUncompressedWord are never stored as frames, so returns slice of file data
+func (g *Getter) zstdNextUncompressed() ([]byte, uint64) {
+	wordLen, frameLen := g.zstdWordHeader()
+	if frameLen != 0 {
+		panic(fmt.Sprintf("file: %s, NextUncompressed called for compressed word", g.fName))
+	}
+	pos := g.dataP
+	g.dataP += wordLen
+	return g.data[pos:g.dataP], g.dataP
+}
+
+func (g *Getter) zstdSkip() (uint64, int) {
+	wordLen, frameLen := g.zstdWordHeader()
+	if frameLen != 0 {
+		g.dataP += frameLen
+	} else {
+		g.dataP += wordLen
+	}
+	return g.dataP, int(wordLen)
+}
+
+// zstdPeek - decodes word at current offset without moving offset. Returns offset of the next word.
+func (g *Getter) zstdPeek() ([]byte, uint64) {
+	savePos := g.dataP
+	g.zstdBuf = g.zstdDecode(g.zstdBuf[:0])
+	nextPos := g.dataP
+	g.dataP = savePos
+	return g.zstdBuf, nextPos
+}
+
+// zstdPeekLen - returns length of word at current offset without moving offset and without decoding
+func (g *Getter) zstdPeekLen() int {
+	savePos := g.dataP
+	wordLen, _ := g.zstdWordHeader()
+	g.dataP = savePos
+	return int(wordLen)
+}
+
+func (g *Getter) zstdMatch(buf []byte) (bool, uint64) {
+	if g.zstdPeekLen() != len(buf) {
+		return false, g.dataP
+	}
+	word, nextPos := g.zstdPeek()
+	if !bytes.Equal(buf, word) {
+		return false, g.dataP
+	}
+	g.dataP = nextPos
+	return true, nextPos
+}
+
+func (g *Getter) zstdMatchPrefix(prefix []byte) bool {
+	if g.zstdPeekLen() < len(prefix) {
+		return false
+	}
+	if len(prefix) == 0 {
+		return true
+	}
+	word, _ := g.zstdPeek()
+	return bytes.HasPrefix(word, prefix)
+}
+
+func (g *Getter) zstdMatchCmp(buf []byte) int {
+	word, nextPos := g.zstdPeek()
+	cmp := bytes.Compare(buf, word)
+	if cmp == 0 {
+		g.dataP = nextPos
+	}
+	return cmp
+}
+
+func (g *Getter) zstdMatchPrefixCmp(prefix []byte) int {
+	if len(prefix) == 0 {
+		return 0
+	}
+	word, _ := g.zstdPeek()
+	if len(word) == 0 {
+		return 1
+	}
+	if len(prefix) > len(word) {
+		return bytes.Compare(prefix, word)
+	}
+	return bytes.Compare(prefix, word[:len(prefix)])
+}
+
+func (g *Getter) zstdMatchPrefixUncompressed(prefix []byte) int {
+	if len(prefix) == 0 {
+		return 0
+	}
+	word, _ := g.zstdPeek()
+	if len(word) == 0 {
+		return 1
+	}
+	return bytes.Compare(prefix, word)
+}
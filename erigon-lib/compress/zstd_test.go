@@ -0,0 +1,138 @@
+/*
+   Copyright 2023 Erigon contributors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package compress
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/ledgerwatch/log/v3"
+	"github.com/stretchr/testify/require"
+)
+
+func prepareZstdDict(t testing.TB, words int) *Decompressor {
+	t.Helper()
+	logger := log.New()
+	tmpDir := t.TempDir()
+	file := filepath.Join(tmpDir, "compressed")
+	c, err := NewCompressor(context.Background(), t.Name(), file, tmpDir, 1, 2, log.LvlDebug, logger)
+	require.NoError(t, err)
+	defer c.Close()
+	c.DisableFsync()
+	c.SetCodec(CodecZstd)
+	for i := 0; i < words; i++ {
+		require.NoError(t, c.AddWord(nil))
+		require.NoError(t, c.AddWord([]byte("long")))
+		require.NoError(t, c.AddWord([]byte(fmt.Sprintf("%d longlongword %d %s", i, i, loremStrings[i%len(loremStrings)]))))
+		require.NoError(t, c.AddUncompressedWord([]byte(fmt.Sprintf("uncompressed %d", i))))
+	}
+	require.NoError(t, c.Compress())
+	d, err := NewDecompressor(file)
+	require.NoError(t, err)
+	return d
+}
+
+func TestCompressZstd(t *testing.T) {
+	d := prepareZstdDict(t, 100)
+	defer d.Close()
+	require.Equal(t, CodecZstd, d.Codec())
+	require.Equal(t, 400, d.Count())
+	require.Equal(t, 100, d.EmptyWordsCount())
+
+	g := d.MakeGetter()
+	i := 0
+	for g.HasNext() {
+		word, _ := g.Next(nil)
+		require.NotNil(t, word)
+		require.Empty(t, word)
+
+		word, _ = g.Next(nil)
+		require.Equal(t, "long", string(word))
+
+		expected := fmt.Sprintf("%d longlongword %d %s", i, i, loremStrings[i%len(loremStrings)])
+		word, _ = g.Next(word[:0])
+		require.Equal(t, expected, string(word))
+
+		word, _ = g.NextUncompressed()
+		require.Equal(t, fmt.Sprintf("uncompressed %d", i), string(word))
+		i++
+	}
+	require.Equal(t, 100, i)
+}
+
+func TestCompressZstdSkipAndMatch(t *testing.T) {
+	d := prepareZstdDict(t, 10)
+	defer d.Close()
+	g := d.MakeGetter()
+
+	offset, l := g.Skip()
+	require.Zero(t, l)
+	offset2, l := g.Skip()
+	require.Equal(t, 4, l)
+	require.Greater(t, offset2, offset)
+
+	expected := []byte(fmt.Sprintf("0 longlongword 0 %s", loremStrings[0]))
+	require.True(t, g.MatchPrefix(expected[:10]))
+	require.False(t, g.MatchPrefix([]byte("1 long")))
+	require.Equal(t, 0, g.MatchPrefixCmp(expected[:10]))
+	require.Equal(t, -1, g.MatchPrefixCmp([]byte("0 a")))
+	require.Equal(t, 1, g.MatchPrefixCmp([]byte("0 z")))
+
+	ok, pos := g.Match(expected[:10])
+	require.False(t, ok)
+	require.Equal(t, offset2, pos)
+	require.Equal(t, 1, g.MatchCmp([]byte("z")))
+	ok, pos = g.Match(expected)
+	require.True(t, ok)
+	require.Greater(t, pos, offset2)
+
+	require.Equal(t, 0, g.MatchPrefixUncompressed([]byte("uncompressed 0")))
+	_, l = g.SkipUncompressed()
+	require.Equal(t, len("uncompressed 0"), l)
+
+	buf := make([]byte, 0, 128)
+	word, _ := g.FastNext(buf)
+	require.Empty(t, word)
+
+	g.Reset(0)
+	for i := 0; g.HasNext(); i++ {
+		g.Skip()
+		if i%4 == 1 {
+			// Next must continue from offset returned by Skip
+			word, _ = g.Next(nil)
+			require.Equal(t, fmt.Sprintf("%d longlongword %d %s", i/4, i/4, loremStrings[(i/4)%len(loremStrings)]), string(word))
+			i++
+		}
+	}
+}
+
+func TestCompressZstdEmpty(t *testing.T) {
+	d := prepareZstdDict(t, 0)
+	defer d.Close()
+	require.Equal(t, CodecZstd, d.Codec())
+	require.Equal(t, 0, d.Count())
+	require.False(t, d.MakeGetter().HasNext())
+}
+
+func TestCompressPatternsCodec(t *testing.T) {
+	d := prepareDict(t)
+	defer d.Close()
+	require.Equal(t, CodecPatterns, d.Codec())
+	require.Equal(t, 400, d.Count())
+}
@@ -324,6 +324,18 @@ func (c *SentryClientDirect) AddPeer(ctx context.Context, in *sentry.AddPeerRequ
 	return c.server.AddPeer(ctx, in)
 }
 
+func (c *SentryClientDirect) RemovePeer(ctx context.Context, in *sentry.RemovePeerRequest, opts ...grpc.CallOption) (*sentry.RemovePeerReply, error) {
+	return c.server.RemovePeer(ctx, in)
+}
+
+func (c *SentryClientDirect) AddTrustedPeer(ctx context.Context, in *sentry.AddPeerRequest, opts ...grpc.CallOption) (*sentry.AddPeerReply, error) {
+	return c.server.AddTrustedPeer(ctx, in)
+}
+
+func (c *SentryClientDirect) RemoveTrustedPeer(ctx context.Context, in *sentry.RemovePeerRequest, opts ...grpc.CallOption) (*sentry.RemovePeerReply, error) {
+	return c.server.RemoveTrustedPeer(ctx, in)
+}
+
 type peersReply struct {
 	r   *sentry.PeerEvent
 	err error
@@ -119,3 +119,7 @@ func (s *TxPoolClient) Status(ctx context.Context, in *txpool_proto.StatusReques
 func (s *TxPoolClient) Nonce(ctx context.Context, in *txpool_proto.NonceRequest, opts ...grpc.CallOption) (*txpool_proto.NonceReply, error) {
 	return s.server.Nonce(ctx, in)
 }
+
+func (s *TxPoolClient) Explain(ctx context.Context, in *txpool_proto.ExplainRequest, opts ...grpc.CallOption) (*txpool_proto.ExplainReply, error) {
+	return s.server.Explain(ctx, in)
+}
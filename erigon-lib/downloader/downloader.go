@@ -34,6 +34,7 @@ import (
 	"github.com/anacrolix/torrent/storage"
 	"github.com/c2h5oh/datasize"
 	"github.com/ledgerwatch/erigon-lib/common"
+	"github.com/ledgerwatch/erigon-lib/common/cmp"
 	"github.com/ledgerwatch/erigon-lib/common/datadir"
 	"github.com/ledgerwatch/erigon-lib/common/dbg"
 	"github.com/ledgerwatch/erigon-lib/common/dir"
@@ -70,8 +71,17 @@ type Downloader struct {
 	verbosity log.Lvl
 
 	torrentFiles *TorrentFiles
+
+	// objectStores - primary source of files if not empty, BitTorrent is used as fallback
+	objectStores     []ObjectStore
+	objectStoreSem   *semaphore.Weighted
+	objectStoreLock  sync.Mutex
+	objectStoreFiles map[string]*objectStoreProgress
 }
 
+// objectStorePieceWorkers - how many pieces of one file download in parallel from object stores
+const objectStorePieceWorkers = 16
+
 type AggStats struct {
 	MetadataReady, FilesTotal int32
 	PeersUnique               int32
@@ -117,6 +127,15 @@ func New(ctx context.Context, cfg *downloadercfg.Cfg, dirs datadir.Dirs, logger
 		torrentFiles:      &TorrentFiles{dir: cfg.Dirs.Snap},
 	}
 	d.webseeds.torrentFiles = d.torrentFiles
+	for _, storeUrl := range cfg.ObjectStores {
+		store, err := NewObjectStore(storeUrl)
+		if err != nil {
+			return nil, err
+		}
+		d.objectStores = append(d.objectStores, store)
+	}
+	d.objectStoreSem = semaphore.NewWeighted(int64(cmp.Max(1, cfg.DownloadSlots)))
+	d.objectStoreFiles = map[string]*objectStoreProgress{}
 	d.ctx, d.stopMainLoop = context.WithCancel(ctx)
 
 	if cfg.AddTorrentsFromDisk {
@@ -429,6 +448,16 @@ func (d *Downloader) ReCalcStats(interval time.Duration) {
 	stats.DownloadRate = (stats.BytesDownload - prevStats.BytesDownload) / uint64(interval.Seconds())
 	stats.UploadRate = (stats.BytesUpload - prevStats.BytesUpload) / uint64(interval.Seconds())
 
+	// files which are downloading from object stores are not in torrent client yet
+	d.objectStoreLock.Lock()
+	for _, p := range d.objectStoreFiles {
+		stats.BytesTotal += p.bytesTotal.Load()
+		stats.BytesCompleted += p.completed.Load()
+		stats.Completed = false
+	}
+	objectStoreFilesAmount := len(d.objectStoreFiles)
+	d.objectStoreLock.Unlock()
+
 	if stats.BytesTotal == 0 {
 		stats.Progress = 0
 	} else {
@@ -438,7 +467,7 @@ func (d *Downloader) ReCalcStats(interval time.Duration) {
 		}
 	}
 	stats.PeersUnique = int32(len(peers))
-	stats.FilesTotal = int32(len(torrents))
+	stats.FilesTotal = int32(len(torrents) + objectStoreFilesAmount)
 
 	d.stats = stats
 }
@@ -624,7 +653,14 @@ func (d *Downloader) AddMagnetLink(ctx context.Context, infoHash metainfo.Hash,
 	if d.newDownloadsAreProhibited() {
 		return nil
 	}
+	if len(d.objectStores) > 0 {
+		d.fetchFromObjectStoresInBackground(infoHash, name)
+		return nil
+	}
+	return d.addMagnetLink(ctx, infoHash, name)
+}
 
+func (d *Downloader) addMagnetLink(ctx context.Context, infoHash metainfo.Hash, name string) error {
 	mi := &metainfo.MetaInfo{AnnounceList: Trackers}
 	magnet := mi.Magnet(&infoHash, &metainfo.Info{Name: name})
 	spec, err := torrent.TorrentSpecFromMagnetUri(magnet.String())
@@ -661,6 +697,82 @@ func (d *Downloader) AddMagnetLink(ctx context.Context, infoHash metainfo.Hash,
 	return nil
 }
 
+// fetchFromObjectStoresInBackground - object stores are primary source of files: download and verify file,
+// then add it to torrent client for seeding. If file is not available in object stores - fallback to BitTorrent.
+func (d *Downloader) fetchFromObjectStoresInBackground(infoHash metainfo.Hash, name string) {
+	progress := &objectStoreProgress{name: name}
+	d.objectStoreLock.Lock()
+	if _, ok := d.objectStoreFiles[name]; ok {
+		d.objectStoreLock.Unlock()
+		return
+	}
+	d.objectStoreFiles[name] = progress
+	d.objectStoreLock.Unlock()
+
+	d.wg.Add(1)
+	go func() {
+		defer d.wg.Done()
+		defer func() {
+			d.objectStoreLock.Lock()
+			defer d.objectStoreLock.Unlock()
+			delete(d.objectStoreFiles, name)
+		}()
+		if err := d.objectStoreSem.Acquire(d.ctx, 1); err != nil {
+			return
+		}
+		defer d.objectStoreSem.Release(1)
+
+		err := d.fetchFromObjectStores(d.ctx, infoHash, name, progress)
+		if err == nil || errors.Is(err, context.Canceled) {
+			return
+		}
+		d.logger.Warn("[snapshots] object store fetch failed, fallback to BitTorrent", "file", name, "err", err)
+		if err := d.addMagnetLink(d.ctx, infoHash, name); err != nil {
+			d.logger.Warn("[snapshots] add magnet link", "file", name, "err", err)
+		}
+	}()
+}
+
+func (d *Downloader) fetchFromObjectStores(ctx context.Context, infoHash metainfo.Hash, name string, progress *objectStoreProgress) error {
+	var mi *metainfo.MetaInfo
+	if d.torrentFiles.Exists(name) {
+		if fromDisk, err := metainfo.LoadFromFile(filepath.Join(d.SnapDir(), name+".torrent")); err == nil && fromDisk.HashInfoBytes() == infoHash {
+			mi = fromDisk
+		}
+	}
+	if mi == nil {
+		fetched, raw, err := fetchTorrentFile(ctx, d.objectStores, name, infoHash)
+		if err != nil {
+			return err
+		}
+		// keep original bytes: re-encoding of info may change info hash
+		if err := d.torrentFiles.Create(filepath.Join(d.SnapDir(), name+".torrent"), raw); err != nil {
+			return err
+		}
+		mi = fetched
+	}
+	info, err := mi.UnmarshalInfo()
+	if err != nil {
+		return err
+	}
+	progress.bytesTotal.Store(uint64(info.TotalLength()))
+
+	started := time.Now()
+	if err := fetchFile(ctx, d.objectStores, d.SnapDir(), &info, objectStorePieceWorkers, func(p metainfo.Piece) {
+		progress.completed.Add(uint64(p.Length()))
+	}); err != nil {
+		return err
+	}
+	// pieces are verified - torrent client doesn't need to check them again
+	for i := 0; i < info.NumPieces(); i++ {
+		if err := d.pieceCompletionDB.Set(metainfo.PieceKey{InfoHash: infoHash, Index: i}, true); err != nil {
+			return err
+		}
+	}
+	d.logger.Log(d.verbosity, "[snapshots] downloaded from object store", "file", name, "size", common.ByteCount(uint64(info.TotalLength())), "took", time.Since(started))
+	return d.AddNewSeedableFile(ctx, name)
+}
+
 func seedableFiles(dirs datadir.Dirs) ([]string, error) {
 	files, err := seedableSegmentFiles(dirs.Snap)
 	if err != nil {
@@ -57,6 +57,9 @@ type Cfg struct {
 	AddTorrentsFromDisk             bool
 	ChainName                       string
 
+	// ObjectStores - urls of HTTP file servers or S3-compatible buckets, used as primary source of files instead of BitTorrent
+	ObjectStores []string
+
 	Dirs datadir.Dirs
 }
 
@@ -0,0 +1,277 @@
+/*
+   Copyright 2023 Erigon contributors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package downloader
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha1"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+
+	"github.com/anacrolix/torrent/metainfo"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/c2h5oh/datasize"
+	"golang.org/x/sync/errgroup"
+)
+
+var ErrPieceHashMismatch = errors.New("piece hash mismatch")
+
+// ObjectStore - source of files which doesn't need BitTorrent: plain HTTP file server or S3-compatible bucket (AWS, MinIO, R2, ...).
+// Data from ObjectStore is not trusted: every piece is verified against .torrent metadata before it's written to disk.
+type ObjectStore interface {
+	// Get - returns [offset, offset+length) bytes of object. length < 0 means: till the end of object
+	Get(ctx context.Context, name string, offset, length int64) (io.ReadCloser, error)
+	String() string
+}
+
+// NewObjectStore - parses object store url:
+//   - http(s)://host/path/ - plain HTTP file server, must support `Range` requests
+//   - s3://accessKey:secret@host:port/bucket/prefix?region=us-east-1&insecure=true - S3-compatible api, path-style addressing
+func NewObjectStore(rawUrl string) (ObjectStore, error) {
+	u, err := url.Parse(rawUrl)
+	if err != nil {
+		return nil, fmt.Errorf("object store url: %w", err)
+	}
+	switch u.Scheme {
+	case "http", "https":
+		return &HttpObjectStore{base: u, client: http.DefaultClient}, nil
+	case "s3":
+		return newS3ObjectStore(u)
+	default:
+		return nil, fmt.Errorf("object store url: unsupported scheme %q", u.Scheme)
+	}
+}
+
+func rangeHeader(offset, length int64) string {
+	if length < 0 {
+		return fmt.Sprintf("bytes=%d-", offset)
+	}
+	return fmt.Sprintf("bytes=%d-%d", offset, offset+length-1)
+}
+
+type HttpObjectStore struct {
+	base   *url.URL
+	client *http.Client
+}
+
+func (s *HttpObjectStore) String() string { return s.base.Redacted() }
+
+func (s *HttpObjectStore) Get(ctx context.Context, name string, offset, length int64) (io.ReadCloser, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, s.base.JoinPath(name).String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	partial := offset > 0 || length >= 0
+	if partial {
+		request.Header.Set("Range", rangeHeader(offset, length))
+	}
+	resp, err := s.client.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case resp.StatusCode == http.StatusPartialContent:
+	case resp.StatusCode == http.StatusOK && (!partial || offset == 0):
+		// server ignored `Range` - caller reads only first `length` bytes
+	default:
+		resp.Body.Close()
+		return nil, fmt.Errorf("object store: file=%s, status=%s", name, resp.Status)
+	}
+	return resp.Body, nil
+}
+
+type S3ObjectStore struct {
+	client *s3.Client
+	bucket string
+	prefix string
+	host   string
+}
+
+func newS3ObjectStore(u *url.URL) (*S3ObjectStore, error) {
+	bucket, prefix, _ := strings.Cut(strings.TrimPrefix(u.Path, "/"), "/")
+	if u.Host == "" || bucket == "" {
+		return nil, fmt.Errorf("object store url: expecting s3://host/bucket/prefix")
+	}
+	q := u.Query()
+	scheme := "https"
+	if q.Get("insecure") == "true" {
+		scheme = "http"
+	}
+	region := q.Get("region")
+	if region == "" {
+		region = "us-east-1"
+	}
+	opts := s3.Options{
+		Region:       region,
+		BaseEndpoint: aws.String(scheme + "://" + u.Host),
+		UsePathStyle: true, // MinIO and most self-hosted stores don't support virtual-hosted buckets
+		Credentials:  aws.AnonymousCredentials{},
+	}
+	if u.User != nil {
+		secret, _ := u.User.Password()
+		opts.Credentials = credentials.NewStaticCredentialsProvider(u.User.Username(), secret, "")
+	}
+	return &S3ObjectStore{client: s3.New(opts), bucket: bucket, prefix: prefix, host: u.Host}, nil
+}
+
+func (s *S3ObjectStore) String() string { return "s3://" + path.Join(s.host, s.bucket, s.prefix) }
+
+func (s *S3ObjectStore) Get(ctx context.Context, name string, offset, length int64) (io.ReadCloser, error) {
+	input := &s3.GetObjectInput{Bucket: aws.String(s.bucket), Key: aws.String(path.Join(s.prefix, name))}
+	if offset > 0 || length >= 0 {
+		input.Range = aws.String(rangeHeader(offset, length))
+	}
+	resp, err := s.client.GetObject(ctx, input)
+	if err != nil {
+		return nil, fmt.Errorf("object store: file=%s, %w", name, err)
+	}
+	return resp.Body, nil
+}
+
+// fetchTorrentFile - downloads `name.torrent` and checks that it matches expected infoHash. Returns raw .torrent bytes
+func fetchTorrentFile(ctx context.Context, stores []ObjectStore, name string, infoHash metainfo.Hash) (mi *metainfo.MetaInfo, raw []byte, err error) {
+	for _, store := range stores {
+		mi, raw, err = fetchTorrentFileFromStore(ctx, store, name, infoHash)
+		if err == nil {
+			return mi, raw, nil
+		}
+	}
+	return nil, nil, err
+}
+
+func fetchTorrentFileFromStore(ctx context.Context, store ObjectStore, name string, infoHash metainfo.Hash) (*metainfo.MetaInfo, []byte, error) {
+	r, err := store.Get(ctx, name+".torrent", 0, -1)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer r.Close()
+	//protect against too big data
+	b, err := io.ReadAll(io.LimitReader(r, int64(128*datasize.MB)))
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", store, err)
+	}
+	mi, err := metainfo.Load(bytes.NewReader(b))
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: file=%s.torrent, %w", store, name, err)
+	}
+	if mi.HashInfoBytes() != infoHash {
+		return nil, nil, fmt.Errorf("%s: file=%s.torrent, unexpected info hash %x, expected %x", store, name, mi.HashInfoBytes(), infoHash)
+	}
+	return mi, b, nil
+}
+
+// fetchFile - downloads file described by `info` into `dir`. Pieces are requested in parallel, each piece is
+// verified against hash from .torrent metadata. Pieces which are already on disk and valid are not downloaded again.
+// Calls `onPiece` for every verified piece.
+func fetchFile(ctx context.Context, stores []ObjectStore, dir string, info *metainfo.Info, workers int, onPiece func(p metainfo.Piece)) error {
+	if len(stores) == 0 {
+		return fmt.Errorf("no object stores")
+	}
+	if info.IsDir() {
+		return fmt.Errorf("multi-file torrents are not supported: %s", info.Name)
+	}
+	fName, err := ensureCantLeaveDir(info.Name, dir)
+	if err != nil {
+		return err
+	}
+	fPath := filepath.Join(dir, fName)
+	if err := os.MkdirAll(filepath.Dir(fPath), 0755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(fPath, os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if err := f.Truncate(info.TotalLength()); err != nil {
+		return err
+	}
+
+	g, ctx := errgroup.WithContext(ctx)
+	g.SetLimit(workers)
+	for i := 0; i < info.NumPieces(); i++ {
+		p := info.Piece(i)
+		g.Go(func() error {
+			buf := make([]byte, p.Length())
+			if _, err := f.ReadAt(buf, p.Offset()); err == nil && pieceHashMatch(p, buf) {
+				onPiece(p)
+				return nil
+			}
+			if err := fetchPiece(ctx, stores, fName, p, buf); err != nil {
+				return err
+			}
+			if _, err := f.WriteAt(buf, p.Offset()); err != nil {
+				return err
+			}
+			onPiece(p)
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return err
+	}
+	return f.Sync()
+}
+
+// fetchPiece - tries stores one-by-one until one of them returns valid piece
+func fetchPiece(ctx context.Context, stores []ObjectStore, name string, p metainfo.Piece, buf []byte) (err error) {
+	for _, store := range stores {
+		if err = fetchPieceFromStore(ctx, store, name, p, buf); err == nil {
+			return nil
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+	}
+	return err
+}
+
+func fetchPieceFromStore(ctx context.Context, store ObjectStore, name string, p metainfo.Piece, buf []byte) error {
+	r, err := store.Get(ctx, name, p.Offset(), p.Length())
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+	if _, err := io.ReadFull(r, buf); err != nil {
+		return fmt.Errorf("%s: file=%s, piece=%d, %w", store, name, p.Index(), err)
+	}
+	if !pieceHashMatch(p, buf) {
+		return fmt.Errorf("%s: file=%s, piece=%d, %w", store, name, p.Index(), ErrPieceHashMismatch)
+	}
+	return nil
+}
+
+func pieceHashMatch(p metainfo.Piece, data []byte) bool {
+	return metainfo.Hash(sha1.Sum(data)) == p.Hash()
+}
+
+// objectStoreProgress - progress of files which are downloading from object stores (they are not in torrent client yet)
+type objectStoreProgress struct {
+	name                  string
+	bytesTotal, completed atomic.Uint64
+}
@@ -0,0 +1,90 @@
+package downloader
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+
+	"github.com/anacrolix/torrent/bencode"
+	"github.com/anacrolix/torrent/metainfo"
+	"github.com/stretchr/testify/require"
+)
+
+// createObjectStoreFile - creates random file and .torrent for it in dir, returns file content and torrent metadata
+func createObjectStoreFile(t *testing.T, dir, name string, size int) ([]byte, *metainfo.MetaInfo) {
+	t.Helper()
+	require := require.New(t)
+	data := make([]byte, size)
+	_, err := rand.Read(data)
+	require.NoError(err)
+	fPath := filepath.Join(dir, name)
+	require.NoError(os.WriteFile(fPath, data, 0644))
+
+	info := &metainfo.Info{PieceLength: 16 * 1024}
+	require.NoError(info.BuildFromFilePath(fPath))
+	mi := &metainfo.MetaInfo{}
+	mi.InfoBytes, err = bencode.Marshal(info)
+	require.NoError(err)
+	buf := bytes.NewBuffer(nil)
+	require.NoError(mi.Write(buf))
+	require.NoError(os.WriteFile(fPath+".torrent", buf.Bytes(), 0644))
+	return data, mi
+}
+
+func TestObjectStoreFetchFile(t *testing.T) {
+	require := require.New(t)
+	src, dst := t.TempDir(), t.TempDir()
+	data, mi := createObjectStoreFile(t, src, "v1-000000-000500-bodies.seg", 100*1024+1)
+	srv := httptest.NewServer(http.FileServer(http.Dir(src)))
+	defer srv.Close()
+	store, err := NewObjectStore(srv.URL)
+	require.NoError(err)
+	stores := []ObjectStore{store}
+	ctx := context.Background()
+
+	fetched, raw, err := fetchTorrentFile(ctx, stores, "v1-000000-000500-bodies.seg", mi.HashInfoBytes())
+	require.NoError(err)
+	require.Equal(mi.HashInfoBytes(), fetched.HashInfoBytes())
+	metaFromRaw, err := metainfo.Load(bytes.NewReader(raw))
+	require.NoError(err)
+	require.Equal(mi.HashInfoBytes(), metaFromRaw.HashInfoBytes())
+
+	info, err := fetched.UnmarshalInfo()
+	require.NoError(err)
+	var completed atomic.Int64
+	err = fetchFile(ctx, stores, dst, &info, 4, func(p metainfo.Piece) { completed.Add(p.Length()) })
+	require.NoError(err)
+	require.Equal(info.TotalLength(), completed.Load())
+	got, err := os.ReadFile(filepath.Join(dst, "v1-000000-000500-bodies.seg"))
+	require.NoError(err)
+	require.Equal(data, got)
+}
+
+func TestObjectStoreHashMismatch(t *testing.T) {
+	require := require.New(t)
+	src, dst := t.TempDir(), t.TempDir()
+	data, mi := createObjectStoreFile(t, src, "a.seg", 64*1024)
+	srv := httptest.NewServer(http.FileServer(http.Dir(src)))
+	defer srv.Close()
+	store, err := NewObjectStore(srv.URL + "/")
+	require.NoError(err)
+	stores := []ObjectStore{store}
+	ctx := context.Background()
+
+	_, _, err = fetchTorrentFile(ctx, stores, "a.seg", metainfo.Hash{1})
+	require.Error(err)
+
+	// corrupt file on server: piece must be rejected
+	data[len(data)-1]++
+	require.NoError(os.WriteFile(filepath.Join(src, "a.seg"), data, 0644))
+	info, err := mi.UnmarshalInfo()
+	require.NoError(err)
+	err = fetchFile(ctx, stores, dst, &info, 4, func(p metainfo.Piece) {})
+	require.ErrorIs(err, ErrPieceHashMismatch)
+}
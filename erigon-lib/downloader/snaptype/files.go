@@ -42,6 +42,7 @@ const (
 	BorEvents
 	BorSpans
 	BeaconBlocks
+	Receipts
 )
 
 func (ft Type) String() string {
@@ -58,6 +59,8 @@ func (ft Type) String() string {
 		return "borspans"
 	case BeaconBlocks:
 		return "beaconblocks"
+	case Receipts:
+		return "receipts"
 	default:
 		panic(fmt.Sprintf("unknown file type: %d", ft))
 	}
@@ -77,6 +80,8 @@ func ParseFileType(s string) (Type, bool) {
 		return BorSpans, true
 	case "beaconblocks":
 		return BeaconBlocks, true
+	case "receipts":
+		return Receipts, true
 	default:
 		return Unknown, false
 	}
@@ -92,6 +97,10 @@ func (it IdxType) String() string { return string(it) }
 
 var BlockSnapshotTypes = []Type{Headers, Bodies, Transactions}
 
+// OptionalBlockSnapshotTypes - segments which may be produced alongside BlockSnapshotTypes,
+// but are not required for a blocks range to be available
+var OptionalBlockSnapshotTypes = []Type{Receipts}
+
 var BorSnapshotTypes = []Type{BorEvents, BorSpans}
 
 var (
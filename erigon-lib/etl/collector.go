@@ -28,6 +28,7 @@ import (
 
 	"github.com/c2h5oh/datasize"
 	"github.com/ledgerwatch/log/v3"
+	"golang.org/x/sync/errgroup"
 
 	"github.com/ledgerwatch/erigon-lib/common"
 	"github.com/ledgerwatch/erigon-lib/kv"
@@ -49,6 +50,10 @@ type Collector struct {
 	allFlushed    bool
 	autoClean     bool
 	logger        log.Logger
+
+	compression  Compression
+	mergeWorkers int
+	quota        *TmpdirQuota
 }
 
 // NewCollectorFromFiles creates collector from existing files (left over from previous unsuccessful loading)
@@ -69,14 +74,14 @@ func NewCollectorFromFiles(logPrefix, tmpdir string, logger log.Logger) (*Collec
 		if err != nil {
 			return nil, fmt.Errorf("collector from files - reading file info %s: %w", dirEntry.Name(), err)
 		}
-		var dataProvider fileDataProvider
+		dataProvider := fileDataProvider{wg: &errgroup.Group{}, compression: compressionByFileName(fileInfo.Name())}
 		dataProvider.file, err = os.Open(filepath.Join(tmpdir, fileInfo.Name()))
 		if err != nil {
 			return nil, fmt.Errorf("collector from files - opening file %s: %w", fileInfo.Name(), err)
 		}
 		dataProviders[i] = &dataProvider
 	}
-	return &Collector{dataProviders: dataProviders, allFlushed: true, autoClean: false, logPrefix: logPrefix}, nil
+	return &Collector{dataProviders: dataProviders, allFlushed: true, autoClean: false, logPrefix: logPrefix, mergeWorkers: 1}, nil
 }
 
 // NewCriticalCollector does not clean up temporary files if loading has failed
@@ -87,7 +92,8 @@ func NewCriticalCollector(logPrefix, tmpdir string, sortableBuffer Buffer, logge
 }
 
 func NewCollector(logPrefix, tmpdir string, sortableBuffer Buffer, logger log.Logger) *Collector {
-	return &Collector{autoClean: true, bufType: getTypeByBuffer(sortableBuffer), buf: sortableBuffer, logPrefix: logPrefix, tmpdir: tmpdir, logLvl: log.LvlInfo, logger: logger}
+	return &Collector{autoClean: true, bufType: getTypeByBuffer(sortableBuffer), buf: sortableBuffer, logPrefix: logPrefix, tmpdir: tmpdir, logLvl: log.LvlInfo, logger: logger,
+		compression: DefaultCompression, mergeWorkers: DefaultMergeWorkers, quota: DefaultTmpdirQuota}
 }
 
 func (c *Collector) extractNextFunc(originalK, k []byte, v []byte) error {
@@ -104,6 +110,15 @@ func (c *Collector) Collect(k, v []byte) error {
 
 func (c *Collector) LogLvl(v log.Lvl) { c.logLvl = v }
 
+// SetCompression - compression of files spilled to tmpdir by next flushes
+func (c *Collector) SetCompression(v Compression) { c.compression = v }
+
+// SetMergeWorkers - amount of goroutines merging spilled files in Load. LoadFunc is still called from 1 goroutine in order of keys.
+func (c *Collector) SetMergeWorkers(v int) { c.mergeWorkers = v }
+
+// SetTmpdirQuota - nil means unlimited
+func (c *Collector) SetTmpdirQuota(v *TmpdirQuota) { c.quota = v }
+
 func (c *Collector) flushBuffer(canStoreInRam bool) error {
 	if c.buf.Len() == 0 {
 		return nil
@@ -119,9 +134,12 @@ func (c *Collector) flushBuffer(canStoreInRam bool) error {
 		prevLen, prevSize := fullBuf.Len(), fullBuf.SizeLimit()
 		c.buf = getBufferByType(c.bufType, datasize.ByteSize(c.buf.SizeLimit()), c.buf)
 
+		if c.quota.exceeded() {
+			return fmt.Errorf("%s: %w: used=%s, limit=%s", c.logPrefix, ErrTmpdirQuotaExceeded, c.quota.Used().HR(), c.quota.Limit().HR())
+		}
 		doFsync := !c.autoClean /* is critical collector */
 		var err error
-		provider, err = FlushToDisk(c.logPrefix, fullBuf, c.tmpdir, doFsync, c.logLvl)
+		provider, err = flushToDisk(c.logPrefix, fullBuf, c.tmpdir, doFsync, c.logLvl, c.compression, c.quota)
 		if err != nil {
 			return err
 		}
@@ -237,7 +255,7 @@ func (c *Collector) Load(db kv.RwTx, toBucket string, loadFunc LoadFunc, args Tr
 	simpleLoad := func(k, v []byte) error {
 		return loadFunc(k, v, currentTable, loadNextFunc)
 	}
-	if err := mergeSortFiles(c.logPrefix, c.dataProviders, simpleLoad, args, c.buf); err != nil {
+	if err := mergeSortFiles(c.logPrefix, c.dataProviders, simpleLoad, args, c.buf, c.mergeWorkers); err != nil {
 		return fmt.Errorf("loadIntoTable %s: %w", toBucket, err)
 	}
 	//logger.Trace(fmt.Sprintf("[%s] ETL Load done", c.logPrefix), "bucket", bucket, "records", i)
@@ -266,35 +284,44 @@ func (c *Collector) Close() {
 // for the next item, which is then added back to the heap.
 // The subsequent iterations pop the heap again and load up the provider associated with it to get the next element after processing LoadFunc.
 // this continues until all providers have reached their EOF.
-func mergeSortFiles(logPrefix string, providers []dataProvider, loadFunc simpleLoadFunc, args TransformArgs, buf Buffer) (err error) {
+//
+// If workers > 1 and files have sparse index - key space is split to ranges, which are merged by workers in parallel
+// (see parallelMerger). LoadFunc is called from current goroutine anyway.
+func mergeSortFiles(logPrefix string, providers []dataProvider, loadFunc simpleLoadFunc, args TransformArgs, buf Buffer, workers int) (err error) {
 	for _, provider := range providers {
 		if err := provider.Wait(); err != nil {
 			return err
 		}
 	}
 
-	h := &Heap{}
-	heapInit(h)
-	for i, provider := range providers {
-		if key, value, err := provider.Next(nil, nil); err == nil {
-			heapPush(h, &HeapElem{key, value, i})
-		} else /* we must have at least one entry per file */ {
-			eee := fmt.Errorf("%s: error reading first readers: n=%d current=%d provider=%s err=%w",
-				logPrefix, len(providers), i, provider, err)
-			panic(eee)
+	var next func() (*HeapElem, error)
+	if ranges := splitToRanges(providers, workers); len(ranges) > 1 {
+		m := newParallelMerger(logPrefix, providers, ranges, workers)
+		defer m.Close()
+		next = m.Next
+	} else {
+		readers := make([]elementReader, len(providers))
+		for i, provider := range providers {
+			readers[i] = provider
 		}
+		next = newHeapMerger(logPrefix, readers, false).Next
 	}
 
 	var prevK, prevV []byte
 
 	// Main loading loop
-	for h.Len() > 0 {
+	for {
 		if err := common.Stopped(args.Quit); err != nil {
 			return err
 		}
 
-		element := heapPop(h)
-		provider := providers[element.TimeIdx]
+		element, err := next()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return err
+		}
 
 		// SortableOldestAppearedBuffer must guarantee that only 1 oldest value of key will appear
 		// but because size of buffer is limited - each flushed file does guarantee "oldest appeared"
@@ -338,12 +365,6 @@ func mergeSortFiles(logPrefix string, providers []dataProvider, loadFunc simpleL
 				return err
 			}
 		}
-
-		if element.Key, element.Value, err = provider.Next(element.Key[:0], element.Value[:0]); err == nil {
-			heapPush(h, element)
-		} else if !errors.Is(err, io.EOF) {
-			return fmt.Errorf("%s: error while reading next element from disk: %w", logPrefix, err)
-		}
 	}
 
 	if args.BufferType == SortableAppendBuffer {
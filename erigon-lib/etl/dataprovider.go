@@ -21,9 +21,11 @@ import (
 	"encoding/binary"
 	"fmt"
 	"io"
+	"math"
 	"os"
 	"path/filepath"
 
+	"github.com/c2h5oh/datasize"
 	"github.com/ledgerwatch/log/v3"
 	"golang.org/x/sync/errgroup"
 )
@@ -35,19 +37,27 @@ type dataProvider interface {
 }
 
 type fileDataProvider struct {
-	file       *os.File
-	reader     io.Reader
-	byteReader io.ByteReader // Different interface to the same object as reader
-	wg         *errgroup.Group
+	file        *os.File
+	reader      io.Reader
+	byteReader  io.ByteReader // Different interface to the same object as reader
+	wg          *errgroup.Group
+	compression Compression
+	blocks      []spillBlock // sparse index, nil for files left over from previous run
+	size        int64        // reserved in quota
+	quota       *TmpdirQuota
 }
 
 // FlushToDisk - `doFsync` is true only for 'critical' collectors (which should not loose).
 func FlushToDisk(logPrefix string, b Buffer, tmpdir string, doFsync bool, lvl log.Lvl) (dataProvider, error) {
+	return flushToDisk(logPrefix, b, tmpdir, doFsync, lvl, CompressionNone, nil)
+}
+
+func flushToDisk(logPrefix string, b Buffer, tmpdir string, doFsync bool, lvl log.Lvl, compression Compression, quota *TmpdirQuota) (dataProvider, error) {
 	if b.Len() == 0 {
 		return nil, nil
 	}
 
-	provider := &fileDataProvider{reader: nil, wg: &errgroup.Group{}}
+	provider := &fileDataProvider{reader: nil, wg: &errgroup.Group{}, compression: compression, quota: quota}
 	provider.wg.Go(func() error {
 		b.Sort()
 
@@ -59,7 +69,7 @@ func FlushToDisk(logPrefix string, b Buffer, tmpdir string, doFsync bool, lvl lo
 			}
 		}
 
-		bufferFile, err := os.CreateTemp(tmpdir, "erigon-sortable-buf-")
+		bufferFile, err := os.CreateTemp(tmpdir, "erigon-sortable-buf-*"+compression.fileExt())
 		if err != nil {
 			return err
 		}
@@ -69,14 +79,18 @@ func FlushToDisk(logPrefix string, b Buffer, tmpdir string, doFsync bool, lvl lo
 			defer bufferFile.Sync() //nolint:errcheck
 		}
 
-		w := bufio.NewWriterSize(bufferFile, BufIOSize)
-		defer w.Flush() //nolint:errcheck
+		w := newSpillWriter(bufio.NewWriterSize(bufferFile, BufIOSize), compression, quota)
+		defer func() { provider.size = w.written }()
 
 		_, fName := filepath.Split(bufferFile.Name())
 		if err = b.Write(w); err != nil {
 			return fmt.Errorf("error writing entries to disk: %w", err)
 		}
-		log.Log(lvl, fmt.Sprintf("[%s] Flushed buffer file", logPrefix), "name", fName)
+		if err = w.Flush(); err != nil {
+			return fmt.Errorf("error writing entries to disk: %w", err)
+		}
+		provider.blocks = w.blocks
+		log.Log(lvl, fmt.Sprintf("[%s] Flushed buffer file", logPrefix), "name", fName, "compression", compression, "size", datasize.ByteSize(w.written).HR())
 		return nil
 	})
 
@@ -85,18 +99,22 @@ func FlushToDisk(logPrefix string, b Buffer, tmpdir string, doFsync bool, lvl lo
 
 func (p *fileDataProvider) Next(keyBuf, valBuf []byte) ([]byte, []byte, error) {
 	if p.reader == nil {
-		_, err := p.file.Seek(0, 0)
-		if err != nil {
-			return nil, nil, err
-		}
-		r := bufio.NewReaderSize(p.file, BufIOSize)
-		p.reader = r
-		p.byteReader = r
-
+		p.reader, p.byteReader = p.readerFrom(0)
 	}
 	return readElementFromDisk(p.reader, p.byteReader, keyBuf, valBuf)
 }
 
+// readerFrom - creates independent reader of file starting at offset. offset must be beginning of entry
+// (for compressed files - beginning of block): 0 or offset from sparse index.
+func (p *fileDataProvider) readerFrom(offset int64) (io.Reader, io.ByteReader) {
+	r := bufio.NewReaderSize(io.NewSectionReader(p.file, offset, math.MaxInt64-offset), BufIOSize)
+	if p.compression == CompressionNone {
+		return r, r
+	}
+	br := &blockReader{r: r, compression: p.compression}
+	return br, br
+}
+
 func (p *fileDataProvider) Wait() error { return p.wg.Wait() }
 func (p *fileDataProvider) Dispose() {
 	if p.file != nil { //invariant: safe to call multiple time
@@ -104,6 +122,8 @@ func (p *fileDataProvider) Dispose() {
 		_ = p.file.Close()
 		_ = os.Remove(p.file.Name())
 		p.file = nil
+		p.quota.release(p.size)
+		p.size = 0
 	}
 }
 
@@ -17,14 +17,19 @@ package etl
 
 import (
 	"bytes"
+	"encoding/binary"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
+	"math/rand"
 	"os"
+	"sort"
 	"strings"
 	"testing"
 
+	"github.com/c2h5oh/datasize"
+	"github.com/ledgerwatch/erigon-lib/common"
 	"github.com/ledgerwatch/erigon-lib/kv"
 	"github.com/ledgerwatch/erigon-lib/kv/memdb"
 	"github.com/ledgerwatch/log/v3"
@@ -645,3 +650,99 @@ func TestSortable(t *testing.T) {
 	require.Equal([][]byte{{1}, {2}, {3}, {4}, {5}, {6}, {7}, {1}, {20}, nil}, vals)
 
 }
+
+func TestCompressedFilesAndParallelMerge(t *testing.T) {
+	type kv struct{ k, v []byte }
+	rnd := rand.New(rand.NewSource(1))
+	var entries []kv
+	for i := 0; i < 200_000; i++ {
+		k := make([]byte, 8)
+		binary.BigEndian.PutUint64(k, rnd.Uint64()%100_000)
+		var v []byte
+		switch {
+		case i%7 == 0:
+			v = nil
+		case i%11 == 0:
+			v = []byte{}
+		default:
+			v = make([]byte, 32)
+			rnd.Read(v)
+		}
+		entries = append(entries, kv{k, v})
+	}
+	less := func(a, b kv) bool {
+		if c := bytes.Compare(a.k, b.k); c != 0 {
+			return c < 0
+		}
+		if (a.v == nil) != (b.v == nil) {
+			return a.v == nil
+		}
+		return bytes.Compare(a.v, b.v) < 0
+	}
+	expected := append([]kv{}, entries...)
+	sort.Slice(expected, func(i, j int) bool { return less(expected[i], expected[j]) })
+
+	for _, compression := range []Compression{CompressionNone, CompressionSnappy, CompressionZstd} {
+		for _, workers := range []int{1, 4} {
+			t.Run(fmt.Sprintf("%s/%d", compression, workers), func(t *testing.T) {
+				require := require.New(t)
+				quota := NewTmpdirQuota(0)
+				collector := NewCollector(t.Name(), t.TempDir(), NewSortableBuffer(3*datasize.MB), log.New())
+				defer collector.Close()
+				collector.SetCompression(compression)
+				collector.SetMergeWorkers(workers)
+				collector.SetTmpdirQuota(quota)
+				for _, e := range entries {
+					require.NoError(collector.Collect(e.k, e.v))
+				}
+				require.NoError(collector.Flush())
+				require.Greater(len(collector.dataProviders), 1)
+				blocks := 0
+				for _, p := range collector.dataProviders {
+					require.NoError(p.Wait())
+					blocks += len(p.(*fileDataProvider).blocks)
+				}
+				require.Greater(blocks, len(collector.dataProviders))
+				if workers > 1 {
+					require.Greater(len(splitToRanges(collector.dataProviders, workers)), 1)
+				}
+				require.Greater(quota.Used(), datasize.ByteSize(0))
+
+				var got []kv
+				require.NoError(collector.Load(nil, "", func(k, v []byte, table CurrentTableReader, next LoadNextFunc) error {
+					if len(got) > 0 {
+						require.True(bytes.Compare(got[len(got)-1].k, k) <= 0)
+					}
+					got = append(got, kv{common.Copy(k), common.Copy(v)})
+					return nil
+				}, TransformArgs{}))
+				sort.Slice(got, func(i, j int) bool { return less(got[i], got[j]) })
+				require.Equal(expected, got)
+				require.Equal(datasize.ByteSize(0), quota.Used())
+			})
+		}
+	}
+}
+
+func TestTmpdirQuota(t *testing.T) {
+	require := require.New(t)
+	quota := NewTmpdirQuota(1 * datasize.KB)
+	collector := NewCollector(t.Name(), t.TempDir(), NewSortableBuffer(1), log.New())
+	defer collector.Close()
+	collector.SetTmpdirQuota(quota)
+	var err error
+	for i := 0; i < 1000 && err == nil; i++ {
+		err = collector.Collect([]byte(fmt.Sprintf("key%d", i)), make([]byte, 100))
+		if err == nil {
+			err = collector.Flush() // wait for background flush, to see quota exceeding on next Collect
+			for _, p := range collector.dataProviders {
+				if err == nil {
+					err = p.Wait()
+				}
+			}
+		}
+	}
+	require.ErrorIs(err, ErrTmpdirQuotaExceeded)
+	collector.Close()
+	require.Equal(datasize.ByteSize(0), quota.Used())
+}
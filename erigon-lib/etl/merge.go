@@ -0,0 +1,348 @@
+/*
+   Copyright 2023 Erigon contributors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package etl
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+
+	"golang.org/x/sync/errgroup"
+)
+
+type elementReader interface {
+	Next(keyBuf, valBuf []byte) ([]byte, []byte, error)
+}
+
+// heapMerger - k-way merge of sorted readers. Returned element is valid until next call of Next.
+type heapMerger struct {
+	logPrefix string
+	readers   []elementReader
+	h         *Heap
+	last      *HeapElem
+	ranged    bool // readers of key range may have no elements at all
+}
+
+func newHeapMerger(logPrefix string, readers []elementReader, ranged bool) *heapMerger {
+	return &heapMerger{logPrefix: logPrefix, readers: readers, ranged: ranged}
+}
+
+func (m *heapMerger) init() error {
+	m.h = &Heap{}
+	heapInit(m.h)
+	for i, reader := range m.readers {
+		key, value, err := reader.Next(nil, nil)
+		if err == nil {
+			heapPush(m.h, &HeapElem{key, value, i})
+			continue
+		}
+		if m.ranged {
+			if errors.Is(err, io.EOF) {
+				continue
+			}
+			return fmt.Errorf("%s: error reading first readers: n=%d current=%d err=%w", m.logPrefix, len(m.readers), i, err)
+		}
+		/* we must have at least one entry per file */
+		eee := fmt.Errorf("%s: error reading first readers: n=%d current=%d provider=%s err=%w",
+			m.logPrefix, len(m.readers), i, reader, err)
+		panic(eee)
+	}
+	return nil
+}
+
+func (m *heapMerger) Next() (*HeapElem, error) {
+	if m.h == nil {
+		if err := m.init(); err != nil {
+			return nil, err
+		}
+	}
+	if element := m.last; element != nil {
+		m.last = nil
+		var err error
+		if element.Key, element.Value, err = m.readers[element.TimeIdx].Next(element.Key[:0], element.Value[:0]); err == nil {
+			heapPush(m.h, element)
+		} else if !errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("%s: error while reading next element from disk: %w", m.logPrefix, err)
+		}
+	}
+	if m.h.Len() == 0 {
+		return nil, io.EOF
+	}
+	m.last = heapPop(m.h)
+	return m.last, nil
+}
+
+// keyRange - [from, to), nil means unbounded
+type keyRange struct {
+	from, to []byte
+}
+
+// rangesPerWorker - more ranges than workers: to keep all workers busy while consumer is waiting for the current range
+const rangesPerWorker = 4
+
+// splitToRanges - splits key space to ranges of roughly equal size by first keys of blocks of spill files.
+// Returns nil if parallel merge is not possible or not needed.
+func splitToRanges(providers []dataProvider, workers int) []keyRange {
+	if workers <= 1 {
+		return nil
+	}
+	var keys [][]byte
+	for _, p := range providers {
+		fp, ok := p.(*fileDataProvider)
+		if !ok || fp.blocks == nil {
+			return nil
+		}
+		for _, b := range fp.blocks {
+			if b.key != nil {
+				keys = append(keys, b.key)
+			}
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool { return bytes.Compare(keys[i], keys[j]) < 0 })
+	parts := workers * rangesPerWorker
+	var bounds [][]byte
+	for i := 1; i < parts; i++ {
+		k := keys[i*len(keys)/parts]
+		if len(k) == 0 || (len(bounds) > 0 && bytes.Equal(bounds[len(bounds)-1], k)) {
+			continue
+		}
+		bounds = append(bounds, k)
+	}
+	if len(bounds) == 0 {
+		return nil
+	}
+	ranges := make([]keyRange, 0, len(bounds)+1)
+	var from []byte
+	for _, to := range bounds {
+		ranges = append(ranges, keyRange{from: from, to: to})
+		from = to
+	}
+	return append(ranges, keyRange{from: from})
+}
+
+// blockOffset - offset of the block from which reading must start to see all keys >= from
+func (p *fileDataProvider) blockOffset(from []byte) int64 {
+	if from == nil {
+		return 0
+	}
+	i := sort.Search(len(p.blocks), func(i int) bool { return bytes.Compare(p.blocks[i].key, from) >= 0 })
+	if i == 0 {
+		return 0
+	}
+	return p.blocks[i-1].offset
+}
+
+// rangeReader - reads elements of file which belong to keyRange
+type rangeReader struct {
+	r       io.Reader
+	br      io.ByteReader
+	kr      keyRange
+	started bool
+}
+
+func (rr *rangeReader) Next(keyBuf, valBuf []byte) ([]byte, []byte, error) {
+	for {
+		k, v, err := readElementFromDisk(rr.r, rr.br, keyBuf, valBuf)
+		if err != nil {
+			return nil, nil, err
+		}
+		if rr.kr.to != nil && bytes.Compare(k, rr.kr.to) >= 0 {
+			return nil, nil, io.EOF
+		}
+		if !rr.started && rr.kr.from != nil && bytes.Compare(k, rr.kr.from) < 0 {
+			keyBuf, valBuf = k[:0], v[:0]
+			continue
+		}
+		rr.started = true
+		return k, v, nil
+	}
+}
+
+const (
+	mergeBatchSize  = 1024 * 1024
+	mergeBatchElems = 16 * 1024
+)
+
+// mergeBatch - merged elements of range, passed from worker to consumer
+type mergeBatch struct {
+	elems []HeapElem
+	lens  []int // key and value length of every element, -1 - nil
+	data  []byte
+}
+
+func newMergeBatch() *mergeBatch {
+	return &mergeBatch{data: make([]byte, 0, mergeBatchSize)}
+}
+
+func (b *mergeBatch) add(e *HeapElem) {
+	b.elems = append(b.elems, HeapElem{TimeIdx: e.TimeIdx})
+	for _, v := range [][]byte{e.Key, e.Value} {
+		if v == nil {
+			b.lens = append(b.lens, -1)
+			continue
+		}
+		b.lens = append(b.lens, len(v))
+		b.data = append(b.data, v...)
+	}
+}
+
+func (b *mergeBatch) full() bool {
+	return len(b.data) >= mergeBatchSize || len(b.elems) >= mergeBatchElems
+}
+
+// seal - sets Key and Value of elements, after all elements are added (data is not re-allocated anymore)
+func (b *mergeBatch) seal() {
+	pos := 0
+	for i := range b.elems {
+		if l := b.lens[2*i]; l >= 0 {
+			b.elems[i].Key = b.data[pos : pos+l : pos+l]
+			pos += l
+		}
+		if l := b.lens[2*i+1]; l >= 0 {
+			b.elems[i].Value = b.data[pos : pos+l : pos+l]
+			pos += l
+		}
+	}
+}
+
+// parallelMerger - every key range is merged by separated worker, consumer reads results of ranges one-by-one
+// (ranges are ordered) - so it sees same sequence of elements as heapMerger over whole files.
+// Memory is limited: worker can't be ahead of consumer more than on few batches.
+type parallelMerger struct {
+	logPrefix string
+	providers []dataProvider
+	ranges    []keyRange
+	results   []chan *mergeBatch
+	errs      []error
+
+	cancel context.CancelFunc
+	done   chan struct{}
+
+	cur   int
+	batch *mergeBatch
+	pos   int
+}
+
+func newParallelMerger(logPrefix string, providers []dataProvider, ranges []keyRange, workers int) *parallelMerger {
+	ctx, cancel := context.WithCancel(context.Background())
+	m := &parallelMerger{
+		logPrefix: logPrefix,
+		providers: providers,
+		ranges:    ranges,
+		results:   make([]chan *mergeBatch, len(ranges)),
+		errs:      make([]error, len(ranges)),
+		cancel:    cancel,
+		done:      make(chan struct{}),
+	}
+	for i := range m.results {
+		m.results[i] = make(chan *mergeBatch, 2)
+	}
+	go func() {
+		defer close(m.done)
+		g := &errgroup.Group{}
+		g.SetLimit(workers)
+		for i := range ranges {
+			i := i
+			g.Go(func() error {
+				m.merge(ctx, i)
+				return nil
+			})
+		}
+		_ = g.Wait()
+	}()
+	return m
+}
+
+func (m *parallelMerger) merge(ctx context.Context, i int) {
+	var err error
+	defer func() {
+		m.errs[i] = err // consumer reads it after close of channel
+		close(m.results[i])
+	}()
+	if err = ctx.Err(); err != nil {
+		return
+	}
+
+	readers := make([]elementReader, len(m.providers))
+	for j, p := range m.providers {
+		fp := p.(*fileDataProvider)
+		r, br := fp.readerFrom(fp.blockOffset(m.ranges[i].from))
+		readers[j] = &rangeReader{r: r, br: br, kr: m.ranges[i]}
+	}
+	h := newHeapMerger(m.logPrefix, readers, true)
+	send := func(b *mergeBatch) error {
+		b.seal()
+		select {
+		case m.results[i] <- b:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	batch := newMergeBatch()
+	for {
+		var e *HeapElem
+		if e, err = h.Next(); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return
+		}
+		batch.add(e)
+		if batch.full() {
+			if err = send(batch); err != nil {
+				return
+			}
+			batch = newMergeBatch()
+		}
+	}
+	err = nil
+	if len(batch.elems) > 0 {
+		err = send(batch)
+	}
+}
+
+func (m *parallelMerger) Next() (*HeapElem, error) {
+	for {
+		if m.batch != nil && m.pos < len(m.batch.elems) {
+			e := &m.batch.elems[m.pos]
+			m.pos++
+			return e, nil
+		}
+		if m.cur >= len(m.results) {
+			return nil, io.EOF
+		}
+		b, ok := <-m.results[m.cur]
+		if !ok {
+			if err := m.errs[m.cur]; err != nil {
+				return nil, err
+			}
+			m.cur++
+			continue
+		}
+		m.batch, m.pos = b, 0
+	}
+}
+
+// Close - stops workers, safe to call before all elements are read
+func (m *parallelMerger) Close() {
+	m.cancel()
+	<-m.done
+}
@@ -0,0 +1,379 @@
+/*
+   Copyright 2023 Erigon contributors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package etl
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/c2h5oh/datasize"
+	"github.com/klauspost/compress/s2"
+	"github.com/klauspost/compress/zstd"
+)
+
+// Compression - codec of files which collector spills to tmpdir
+type Compression uint8
+
+const (
+	CompressionNone   Compression = iota
+	CompressionSnappy             // snappy block format - cheap, ~2x smaller files
+	CompressionZstd               // zstd fastest level - a bit more CPU, smaller files
+)
+
+// DefaultCompression, DefaultMergeWorkers - used by all new collectors.
+// vars because we want to change them from command-line flags (same as BufferOptimalSize)
+var (
+	DefaultCompression  = CompressionNone
+	DefaultMergeWorkers = 1
+	// DefaultTmpdirQuota - shared by all collectors of process, unlimited by default
+	DefaultTmpdirQuota = NewTmpdirQuota(0)
+)
+
+func (c Compression) String() string {
+	switch c {
+	case CompressionNone:
+		return "none"
+	case CompressionSnappy:
+		return "snappy"
+	case CompressionZstd:
+		return "zstd"
+	default:
+		return fmt.Sprintf("unknown compression %d", uint8(c))
+	}
+}
+
+func ParseCompression(s string) (Compression, error) {
+	switch strings.ToLower(s) {
+	case "", "none":
+		return CompressionNone, nil
+	case "snappy":
+		return CompressionSnappy, nil
+	case "zstd":
+		return CompressionZstd, nil
+	default:
+		return CompressionNone, fmt.Errorf("unknown etl compression: %s, supported: none, snappy, zstd", s)
+	}
+}
+
+// fileExt - compressed files have extension, it allows NewCollectorFromFiles to read them
+func (c Compression) fileExt() string {
+	switch c {
+	case CompressionSnappy:
+		return ".snappy"
+	case CompressionZstd:
+		return ".zst"
+	default:
+		return ""
+	}
+}
+
+func compressionByFileName(name string) Compression {
+	for _, c := range []Compression{CompressionSnappy, CompressionZstd} {
+		if strings.HasSuffix(name, c.fileExt()) {
+			return c
+		}
+	}
+	return CompressionNone
+}
+
+var ErrTmpdirQuotaExceeded = errors.New("etl: tmpdir quota exceeded")
+
+// TmpdirQuota - limits total size of files (after compression) which collectors sharing this quota can keep in tmpdir.
+// Exceeding of quota fails the collector instead of filling the disk.
+type TmpdirQuota struct {
+	limit atomic.Int64
+	used  atomic.Int64
+}
+
+// NewTmpdirQuota - 0 means unlimited
+func NewTmpdirQuota(limit datasize.ByteSize) *TmpdirQuota {
+	q := &TmpdirQuota{}
+	q.SetLimit(limit)
+	return q
+}
+
+func (q *TmpdirQuota) SetLimit(limit datasize.ByteSize) { q.limit.Store(int64(limit)) }
+func (q *TmpdirQuota) Limit() datasize.ByteSize         { return datasize.ByteSize(q.limit.Load()) }
+func (q *TmpdirQuota) Used() datasize.ByteSize          { return datasize.ByteSize(q.used.Load()) }
+
+func (q *TmpdirQuota) exceeded() bool {
+	if q == nil {
+		return false
+	}
+	limit := q.limit.Load()
+	return limit > 0 && q.used.Load() >= limit
+}
+
+func (q *TmpdirQuota) reserve(n int64) error {
+	if q == nil {
+		return nil
+	}
+	used := q.used.Add(n)
+	if limit := q.limit.Load(); limit > 0 && used > limit {
+		q.used.Add(-n)
+		return fmt.Errorf("%w: used=%s, limit=%s", ErrTmpdirQuotaExceeded, datasize.ByteSize(used).HR(), datasize.ByteSize(limit).HR())
+	}
+	return nil
+}
+
+func (q *TmpdirQuota) release(n int64) {
+	if q == nil {
+		return
+	}
+	q.used.Add(-n)
+}
+
+// spillBlockSize - granularity of sparse index of spill file (and size of compressed block).
+// Parallel merge can start reading file only from beginning of block.
+const spillBlockSize = 1024 * 1024
+
+// spillBlock - entry of sparse index: first key of block and its offset in file
+type spillBlock struct {
+	key    []byte
+	offset int64
+}
+
+var (
+	zstdEncoderOnce sync.Once
+	zstdEncoder     *zstd.Encoder
+	zstdDecoderOnce sync.Once
+	zstdDecoder     *zstd.Decoder
+)
+
+func spillZstdEncoder() *zstd.Encoder {
+	zstdEncoderOnce.Do(func() {
+		var err error
+		if zstdEncoder, err = zstd.NewWriter(nil, zstd.WithEncoderLevel(zstd.SpeedFastest), zstd.WithEncoderCRC(false)); err != nil {
+			panic(err)
+		}
+	})
+	return zstdEncoder
+}
+
+func spillZstdDecoder() *zstd.Decoder {
+	zstdDecoderOnce.Do(func() {
+		var err error
+		if zstdDecoder, err = zstd.NewReader(nil, zstd.WithDecoderConcurrency(0)); err != nil {
+			panic(err)
+		}
+	})
+	return zstdDecoder
+}
+
+// spillWriter - writes stream of elements produced by Buffer.Write: [varint len(k)][k][varint len(v)][v]...
+// It does parse this stream to find boundaries of entries - to cut it to blocks and build sparse index.
+//
+// Uncompressed file is the same stream (format didn't change). Compressed file is sequence of blocks:
+// [uvarint len(block)][compressed block], every block has only whole entries.
+type spillWriter struct {
+	w           *bufio.Writer
+	compression Compression
+	quota       *TmpdirQuota
+
+	written int64 // bytes written to file
+	block   []byte
+	encoded []byte
+	inBlock int // bytes of stream in current block
+
+	blocks  []spillBlock
+	pending *spillBlock // block which first key is not parsed yet
+
+	// parser state
+	elem    int // number of element in entry: 0 - key, 1 - value
+	need    int // bytes left to read in current element
+	varint  [binary.MaxVarintLen64]byte
+	varintN int
+	key     []byte
+}
+
+func newSpillWriter(w *bufio.Writer, compression Compression, quota *TmpdirQuota) *spillWriter {
+	return &spillWriter{w: w, compression: compression, quota: quota, pending: &spillBlock{}}
+}
+
+func (sw *spillWriter) Write(p []byte) (int, error) {
+	total := len(p)
+	for len(p) > 0 {
+		var n int
+		if sw.need > 0 {
+			n = sw.need
+			if n > len(p) {
+				n = len(p)
+			}
+			if sw.pending != nil && sw.elem == 0 {
+				sw.key = append(sw.key, p[:n]...)
+			}
+			sw.need -= n
+		} else {
+			n = 1
+			sw.varint[sw.varintN] = p[0]
+			sw.varintN++
+			if p[0]&0x80 == 0 {
+				l, _ := binary.Varint(sw.varint[:sw.varintN])
+				sw.varintN = 0
+				if sw.pending != nil && sw.elem == 0 && l >= 0 {
+					sw.key = make([]byte, 0, l)
+				}
+				if l > 0 {
+					sw.need = int(l)
+				}
+			} else if sw.varintN == len(sw.varint) {
+				return 0, fmt.Errorf("spillWriter: invalid varint")
+			}
+		}
+		if err := sw.emit(p[:n]); err != nil {
+			return 0, err
+		}
+		p = p[n:]
+		if sw.need == 0 && sw.varintN == 0 {
+			if err := sw.elementDone(); err != nil {
+				return 0, err
+			}
+		}
+	}
+	return total, nil
+}
+
+func (sw *spillWriter) elementDone() error {
+	if sw.elem == 0 {
+		if sw.pending != nil {
+			sw.pending.key = sw.key
+			sw.blocks = append(sw.blocks, *sw.pending)
+			sw.pending, sw.key = nil, nil
+		}
+		sw.elem = 1
+		return nil
+	}
+	sw.elem = 0
+	if sw.inBlock < spillBlockSize {
+		return nil
+	}
+	if err := sw.cutBlock(); err != nil {
+		return err
+	}
+	sw.pending = &spillBlock{offset: sw.written}
+	return nil
+}
+
+func (sw *spillWriter) emit(p []byte) error {
+	sw.inBlock += len(p)
+	if sw.compression == CompressionNone {
+		return sw.write(p)
+	}
+	sw.block = append(sw.block, p...)
+	return nil
+}
+
+func (sw *spillWriter) write(p []byte) error {
+	if err := sw.quota.reserve(int64(len(p))); err != nil {
+		return err
+	}
+	sw.written += int64(len(p))
+	_, err := sw.w.Write(p)
+	return err
+}
+
+func (sw *spillWriter) cutBlock() error {
+	sw.inBlock = 0
+	if sw.compression == CompressionNone || len(sw.block) == 0 {
+		return nil
+	}
+	switch sw.compression {
+	case CompressionSnappy:
+		sw.encoded = s2.EncodeSnappy(sw.encoded[:cap(sw.encoded)], sw.block)
+	case CompressionZstd:
+		sw.encoded = spillZstdEncoder().EncodeAll(sw.block, sw.encoded[:0])
+	default:
+		return fmt.Errorf("spillWriter: %s", sw.compression)
+	}
+	sw.block = sw.block[:0]
+	var numBuf [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(numBuf[:], uint64(len(sw.encoded)))
+	if err := sw.write(numBuf[:n]); err != nil {
+		return err
+	}
+	return sw.write(sw.encoded)
+}
+
+// Flush - writes last block and flushes underlying writer
+func (sw *spillWriter) Flush() error {
+	if err := sw.cutBlock(); err != nil {
+		return err
+	}
+	return sw.w.Flush()
+}
+
+// blockReader - reads blocks of compressed spill file, implements io.Reader and io.ByteReader to be used by readElementFromDisk
+type blockReader struct {
+	r           *bufio.Reader
+	compression Compression
+	compressed  []byte
+	block       []byte
+	pos         int
+}
+
+func (br *blockReader) fill() error {
+	for br.pos >= len(br.block) {
+		l, err := binary.ReadUvarint(br.r)
+		if err != nil {
+			return err // io.EOF - is normal end of file
+		}
+		if uint64(cap(br.compressed)) < l {
+			br.compressed = make([]byte, l)
+		}
+		br.compressed = br.compressed[:l]
+		if _, err = io.ReadFull(br.r, br.compressed); err != nil {
+			return err
+		}
+		switch br.compression {
+		case CompressionSnappy:
+			br.block, err = s2.Decode(br.block[:cap(br.block)], br.compressed)
+		case CompressionZstd:
+			br.block, err = spillZstdDecoder().DecodeAll(br.compressed, br.block[:0])
+		default:
+			err = fmt.Errorf("blockReader: %s", br.compression)
+		}
+		if err != nil {
+			return err
+		}
+		br.pos = 0
+	}
+	return nil
+}
+
+func (br *blockReader) Read(p []byte) (int, error) {
+	if err := br.fill(); err != nil {
+		return 0, err
+	}
+	n := copy(p, br.block[br.pos:])
+	br.pos += n
+	return n, nil
+}
+
+func (br *blockReader) ReadByte() (byte, error) {
+	if err := br.fill(); err != nil {
+		return 0, err
+	}
+	b := br.block[br.pos]
+	br.pos++
+	return b, nil
+}
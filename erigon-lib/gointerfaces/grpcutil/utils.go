@@ -89,7 +89,22 @@ func NewServer(rateLimit uint32, creds credentials.TransportCredentials) *grpc.S
 	return grpcServer
 }
 
-func Connect(creds credentials.TransportCredentials, dialAddress string) (*grpc.ClientConn, error) {
+// bearerToken - sends metadata `authorization: Bearer <token>` with every call, see remotedbserver.ACL
+type bearerToken string
+
+func (t bearerToken) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return map[string]string{"authorization": "Bearer " + string(t)}, nil
+}
+
+// RequireTransportSecurity - false: private api often runs without TLS on localhost
+func (t bearerToken) RequireTransportSecurity() bool { return false }
+
+// WithBearerToken - option of Connect, identifies client by token. Use TLS if connection leaves the machine.
+func WithBearerToken(token string) grpc.DialOption {
+	return grpc.WithPerRPCCredentials(bearerToken(token))
+}
+
+func Connect(creds credentials.TransportCredentials, dialAddress string, opts ...grpc.DialOption) (*grpc.ClientConn, error) {
 	var dialOpts []grpc.DialOption
 
 	backoffCfg := backoff.DefaultConfig
@@ -105,6 +120,7 @@ func Connect(creds credentials.TransportCredentials, dialAddress string) (*grpc.
 	} else {
 		dialOpts = append(dialOpts, grpc.WithTransportCredentials(creds))
 	}
+	dialOpts = append(dialOpts, opts...)
 
 	//if opts.inMemConn != nil {
 	//	dialOpts = append(dialOpts, grpc.WithContextDialer(func(ctx context.Context, url string) (net.Conn, error) {
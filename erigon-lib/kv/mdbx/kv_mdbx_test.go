@@ -18,6 +18,7 @@ package mdbx
 
 import (
 	"context"
+	"fmt"
 	"testing"
 
 	"github.com/c2h5oh/datasize"
@@ -773,3 +774,30 @@ func TestAutoConversionSeekBothRange(t *testing.T) {
 	require.NoError(t, err)
 	assert.Nil(t, v)
 }
+
+func TestSizeReport(t *testing.T) {
+	db, tx, _ := BaseCase(t)
+	ctx := context.Background()
+	for i := 0; i < 1000; i++ {
+		require.NoError(t, tx.Put(kv.Sequence, []byte(fmt.Sprintf("key%04d", i)), make([]byte, 512)))
+	}
+	require.NoError(t, tx.Commit())
+	// deleted pages go to free-list
+	require.NoError(t, db.Update(ctx, func(tx kv.RwTx) error { return tx.ClearBucket(kv.Sequence) }))
+	require.NoError(t, db.Update(ctx, func(tx kv.RwTx) error { return tx.Put(kv.Sequence, []byte("k"), []byte("v")) }))
+
+	roTx, err := db.BeginRo(ctx)
+	require.NoError(t, err)
+	defer roTx.Rollback()
+	r, err := NewSizeReport(roTx, []string{"Table", kv.Sequence, "NotExistingTable"})
+	require.NoError(t, err)
+	require.Len(t, r.Tables, 2)
+	require.Equal(t, uint64(4), r.Table("Table").Entries)
+	require.Equal(t, uint64(1), r.Table(kv.Sequence).Entries)
+	require.Equal(t, r.Table(kv.Sequence).Size, (r.Table(kv.Sequence).LeafPages+r.Table(kv.Sequence).BranchPages+r.Table(kv.Sequence).OverflowPages)*r.PageSize)
+	require.Nil(t, r.Table("NotExistingTable"))
+	require.NotZero(t, r.FreePages)
+	require.GreaterOrEqual(t, r.FreeSize, r.FreePages*r.PageSize)
+	require.NotZero(t, r.Reclaimable)
+	require.LessOrEqual(t, r.UsedSize, r.FileSize)
+}
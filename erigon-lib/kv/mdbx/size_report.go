@@ -0,0 +1,155 @@
+/*
+   Copyright 2023 Erigon contributors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package mdbx
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/erigontech/mdbx-go/mdbx"
+	"github.com/ledgerwatch/erigon-lib/kv"
+)
+
+// TableSize - space used by table, by MDBX stats
+type TableSize struct {
+	Name          string `json:"name"`
+	Entries       uint64 `json:"entries"`
+	LeafPages     uint64 `json:"leafPages"`
+	BranchPages   uint64 `json:"branchPages"`
+	OverflowPages uint64 `json:"overflowPages"`
+	Size          uint64 `json:"size"` // bytes: all pages of table
+}
+
+// SizeReport - where the space of db file goes. One report is a consistent view - all numbers are of one read transaction.
+type SizeReport struct {
+	Time     time.Time `json:"time"`
+	TxID     uint64    `json:"txId"`
+	PageSize uint64    `json:"pageSize"`
+	FileSize uint64    `json:"fileSize"` // current size of db file
+	UsedSize uint64    `json:"usedSize"` // up to last used page
+
+	FreePages   uint64 `json:"freePages"`   // pages in free-list (GC), db can re-use them. Not counted by write transaction
+	FreeSize    uint64 `json:"freeSize"`    // bytes: free-list tree itself and pages in free-list
+	Reclaimable uint64 `json:"reclaimable"` // bytes: estimated gain of compacting copy of db (file size - pages used by tables)
+
+	Tables []TableSize       `json:"tables"`
+	Stages map[string]uint64 `json:"stages,omitempty"` // optional: progress of stages - to find stage responsible for growth
+}
+
+// Table - nil if table is not in report
+func (r *SizeReport) Table(name string) *TableSize {
+	for i := range r.Tables {
+		if r.Tables[i].Name == name {
+			return &r.Tables[i]
+		}
+	}
+	return nil
+}
+
+// NewSizeReport - reports `tables` (which exist in db), but estimations of free and reclaimable space take into account all tables.
+// Reading of free-list is proportional to its size.
+func NewSizeReport(tx kv.Tx, tables []string) (*SizeReport, error) {
+	mtx, ok := tx.(*MdbxTx)
+	if !ok {
+		return nil, fmt.Errorf("size report: %T is not mdbx transaction", tx)
+	}
+	info, err := mtx.db.env.Info(mtx.tx)
+	if err != nil {
+		return nil, err
+	}
+	txInfo, err := mtx.tx.Info(false)
+	if err != nil {
+		return nil, err
+	}
+	pageSize := uint64(info.PageSize)
+	r := &SizeReport{
+		Time:     time.Now().UTC(),
+		TxID:     txInfo.Id,
+		PageSize: pageSize,
+		FileSize: info.Geo.Current,
+		UsedSize: txInfo.SpaceUsed,
+	}
+
+	sizes := map[string]TableSize{}
+	const metaPages = 3
+	usedPages := uint64(metaPages)
+	names := []string{"root"}
+	for name, cfg := range mtx.db.buckets {
+		if cfg.DBI != NonExistingDBI {
+			names = append(names, name)
+		}
+	}
+	for _, name := range names {
+		st, err := mtx.BucketStat(name)
+		if err != nil {
+			return nil, err
+		}
+		pages := st.LeafPages + st.BranchPages + st.OverflowPages
+		usedPages += pages
+		sizes[name] = TableSize{
+			Name:          name,
+			Entries:       st.Entries,
+			LeafPages:     st.LeafPages,
+			BranchPages:   st.BranchPages,
+			OverflowPages: st.OverflowPages,
+			Size:          pages * pageSize,
+		}
+	}
+	for _, name := range tables {
+		if size, ok := sizes[name]; ok {
+			r.Tables = append(r.Tables, size)
+		}
+	}
+
+	gc, err := mtx.BucketStat("gc")
+	if err != nil {
+		return nil, err
+	}
+	gcPages := gc.LeafPages + gc.BranchPages + gc.OverflowPages
+	if mtx.readOnly { // GC can't be read by write transaction
+		if r.FreePages, err = mtx.freePages(); err != nil {
+			return nil, err
+		}
+	}
+	r.FreeSize = (gcPages + r.FreePages) * pageSize
+	if used := usedPages * pageSize; used < r.FileSize {
+		r.Reclaimable = r.FileSize - used
+	}
+	return r, nil
+}
+
+// freePages - amount of pages listed in GC. Every GC record is list of page numbers: [len][pgno]...[pgno], 4 bytes each
+func (tx *MdbxTx) freePages() (uint64, error) {
+	c, err := tx.tx.OpenCursor(mdbx.DBI(0))
+	if err != nil {
+		return 0, err
+	}
+	defer c.Close()
+	var pages uint64
+	for _, v, err := c.Get(nil, nil, mdbx.First); ; _, v, err = c.Get(nil, nil, mdbx.Next) {
+		if err != nil {
+			if mdbx.IsNotFound(err) {
+				break
+			}
+			return 0, err
+		}
+		if len(v) >= 4 {
+			pages += uint64(len(v)/4 - 1)
+		}
+	}
+	return pages, nil
+}
@@ -119,6 +119,43 @@ func (m *Mapmutation) GetOne(table string, key []byte) ([]byte, error) {
 	return nil, nil
 }
 
+// View returns a reader of the batch, which reads keys not in the batch from tx instead of the tx of the batch.
+// Views with their own read-only transactions can read concurrently, but not while the batch is written.
+func (m *Mapmutation) View(tx kv.Tx) kv.Getter {
+	return &mapmutationView{m: m, tx: tx}
+}
+
+type mapmutationView struct {
+	m  *Mapmutation
+	tx kv.Tx
+}
+
+func (v *mapmutationView) GetOne(table string, key []byte) ([]byte, error) {
+	if value, ok := v.m.getMem(table, key); ok {
+		return value, nil
+	}
+	return v.tx.GetOne(table, key)
+}
+
+func (v *mapmutationView) Has(table string, key []byte) (bool, error) {
+	if _, ok := v.m.getMem(table, key); ok {
+		return ok, nil
+	}
+	return v.tx.Has(table, key)
+}
+
+func (v *mapmutationView) ForEach(bucket string, fromPrefix []byte, walker func(k, v []byte) error) error {
+	return v.tx.ForEach(bucket, fromPrefix, walker)
+}
+
+func (v *mapmutationView) ForPrefix(bucket string, prefix []byte, walker func(k, v []byte) error) error {
+	return v.tx.ForPrefix(bucket, prefix, walker)
+}
+
+func (v *mapmutationView) ForAmount(bucket string, prefix []byte, amount uint32, walker func(k, v []byte) error) error {
+	return v.tx.ForAmount(bucket, prefix, amount, walker)
+}
+
 func (m *Mapmutation) Last(table string) ([]byte, []byte, error) {
 	c, err := m.db.Cursor(table)
 	if err != nil {
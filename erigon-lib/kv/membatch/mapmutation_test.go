@@ -31,3 +31,39 @@ func TestMapmutation_Flush_Close(t *testing.T) {
 	batch.Close()
 	batch.Close()
 }
+
+func TestMapmutation_View(t *testing.T) {
+	db := memdb.NewTestDB(t)
+	table := kv.ChaindataTables[0]
+	require.NoError(t, db.Update(context.Background(), func(tx kv.RwTx) error {
+		return tx.Put(table, []byte{1}, []byte{1})
+	}))
+
+	tx, err := db.BeginRw(context.Background())
+	require.NoError(t, err)
+	defer tx.Rollback()
+	require.NoError(t, tx.Put(table, []byte{3}, []byte{3})) // not committed: not seen by views
+
+	batch := NewHashBatch(tx, nil, os.TempDir(), log.New())
+	defer batch.Close()
+	require.NoError(t, batch.Put(table, []byte{2}, []byte{2}))
+
+	// read-only tx can't be opened by the thread of rw tx
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		roTx, err := db.BeginRo(context.Background())
+		require.NoError(t, err)
+		defer roTx.Rollback()
+		view := batch.View(roTx)
+		for k, want := range map[byte][]byte{1: {1}, 2: {2}, 3: nil} {
+			v, err := view.GetOne(table, []byte{k})
+			require.NoError(t, err)
+			require.Equal(t, want, v)
+			has, err := view.Has(table, []byte{k})
+			require.NoError(t, err)
+			require.Equal(t, want != nil, has)
+		}
+	}()
+	<-done
+}
@@ -0,0 +1,44 @@
+package membatchwithdb
+
+import (
+	"github.com/ledgerwatch/log/v3"
+
+	"github.com/ledgerwatch/erigon-lib/kv"
+	"github.com/ledgerwatch/erigon-lib/kv/iter"
+	"github.com/ledgerwatch/erigon-lib/kv/order"
+)
+
+// TemporalMemoryMutation - MemoryMutation over kv.TemporalTx. Domains and history are not buffered:
+// they are read from the underlying tx as is
+type TemporalMemoryMutation struct {
+	*MemoryMutation
+	ttx kv.TemporalTx
+}
+
+func NewTemporalMemoryBatch(tx kv.TemporalTx, tmpDir string, logger log.Logger) *TemporalMemoryMutation {
+	return &TemporalMemoryMutation{MemoryMutation: NewMemoryBatch(tx, tmpDir, logger), ttx: tx}
+}
+
+func (m *TemporalMemoryMutation) DomainGet(name kv.Domain, k, k2 []byte) (v []byte, ok bool, err error) {
+	return m.ttx.DomainGet(name, k, k2)
+}
+
+func (m *TemporalMemoryMutation) DomainGetAsOf(name kv.Domain, k, k2 []byte, ts uint64) (v []byte, ok bool, err error) {
+	return m.ttx.DomainGetAsOf(name, k, k2, ts)
+}
+
+func (m *TemporalMemoryMutation) HistoryGet(name kv.History, k []byte, ts uint64) (v []byte, ok bool, err error) {
+	return m.ttx.HistoryGet(name, k, ts)
+}
+
+func (m *TemporalMemoryMutation) IndexRange(name kv.InvertedIdx, k []byte, fromTs, toTs int, asc order.By, limit int) (timestamps iter.U64, err error) {
+	return m.ttx.IndexRange(name, k, fromTs, toTs, asc, limit)
+}
+
+func (m *TemporalMemoryMutation) HistoryRange(name kv.History, fromTs, toTs int, asc order.By, limit int) (it iter.KV, err error) {
+	return m.ttx.HistoryRange(name, fromTs, toTs, asc, limit)
+}
+
+func (m *TemporalMemoryMutation) DomainRange(name kv.Domain, fromKey, toKey []byte, ts uint64, asc order.By, limit int) (it iter.KV, err error) {
+	return m.ttx.DomainRange(name, fromKey, toKey, ts, asc, limit)
+}
@@ -0,0 +1,230 @@
+/*
+   Copyright 2023 Erigon contributors
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package remotedbserver
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/ledgerwatch/erigon-lib/kv"
+	"github.com/pelletier/go-toml/v2"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// ACL - which tables every client of KvServer can read.
+//
+// Client identity is:
+//   - name of token, if client did send metadata `authorization: Bearer <token>`
+//   - or CommonName of client's TLS certificate (if server runs with --tls.cacert - certificate is verified by CA)
+//   - or "" - anonymous client
+//
+// Clients without own policy (including anonymous) get `default` policy, no `default` - means no access.
+// Example of file:
+//
+//	[tokens]
+//	"<secret>" = "indexer"
+//
+//	[default]
+//	allow = ["*"]
+//
+//	[clients.indexer]
+//	allow = ["Header", "CanonicalHeader", "HeaderNumber", "TransactionLog"]
+//
+//	[clients.analytics]
+//	allow = ["*"]
+//	deny = ["PlainState", "HashedAccount", "HashedStorage"]
+type ACL struct {
+	Tokens  map[string]string       `toml:"tokens"`  // token -> client name
+	Default *TablePolicy            `toml:"default"` // policy of anonymous and unknown clients
+	Clients map[string]*TablePolicy `toml:"clients"` // client name -> policy
+}
+
+// TablePolicy - table is accessible if it matches `allow` and doesn't match `deny`. "*" - matches all tables.
+// Domains, histories, inverted indices and Erigon3 tables - also require access to Erigon2 tables with same data (see exposedTables).
+type TablePolicy struct {
+	Allow []string `toml:"allow"`
+	Deny  []string `toml:"deny"`
+}
+
+func LoadACL(path string) (*ACL, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	acl, err := ParseACL(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return acl, nil
+}
+
+func ParseACL(data []byte) (*ACL, error) {
+	acl := &ACL{}
+	if err := toml.Unmarshal(data, acl); err != nil {
+		return nil, err
+	}
+	for token, name := range acl.Tokens {
+		if token == "" || name == "" {
+			return nil, fmt.Errorf("acl: empty token or client name")
+		}
+	}
+	return acl, nil
+}
+
+func matchTable(patterns []string, table string) bool {
+	for _, p := range patterns {
+		if p == "*" || p == table {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *TablePolicy) allowed(table string) bool {
+	if p == nil {
+		return false
+	}
+	return matchTable(p.Allow, table) && !matchTable(p.Deny, table)
+}
+
+// exposedTables - Erigon2 tables, which data is also readable by name of domain/history/inverted index (DomainGet, HistoryGet, IndexRange)
+// or by name of Erigon3 table (Range). Access to such name requires access to all its tables: deny of PlainState - must deny AccountsDomain too.
+// kv.TblTracesFromIdx/kv.TblTracesToIdx have same names as kv.TracesFromIdx/kv.TracesToIdx.
+var exposedTables = map[string][]string{
+	string(kv.AccountsDomain): {kv.PlainState},
+	string(kv.StorageDomain):  {kv.PlainState},
+	string(kv.CodeDomain):     {kv.PlainState, kv.PlainContractCode, kv.Code},
+
+	string(kv.AccountsHistory): {kv.PlainState, kv.AccountChangeSet},
+	string(kv.StorageHistory):  {kv.PlainState, kv.StorageChangeSet},
+	string(kv.CodeHistory):     {kv.PlainState, kv.AccountChangeSet, kv.Code},
+
+	string(kv.AccountsHistoryIdx): {kv.E2AccountsHistory},
+	string(kv.StorageHistoryIdx):  {kv.E2StorageHistory},
+	string(kv.CodeHistoryIdx):     {kv.E2AccountsHistory},
+	string(kv.LogTopicIdx):        {kv.LogTopicIndex},
+	string(kv.LogAddrIdx):         {kv.LogAddressIndex},
+	string(kv.TracesFromIdx):      {kv.CallFromIndex},
+	string(kv.TracesToIdx):        {kv.CallToIndex},
+
+	kv.TblAccountKeys:        {kv.PlainState},
+	kv.TblAccountVals:        {kv.PlainState},
+	kv.TblAccountHistoryKeys: {kv.PlainState, kv.AccountChangeSet},
+	kv.TblAccountHistoryVals: {kv.PlainState, kv.AccountChangeSet},
+	kv.TblAccountIdx:         {kv.E2AccountsHistory},
+
+	kv.TblStorageKeys:        {kv.PlainState},
+	kv.TblStorageVals:        {kv.PlainState},
+	kv.TblStorageHistoryKeys: {kv.PlainState, kv.StorageChangeSet},
+	kv.TblStorageHistoryVals: {kv.PlainState, kv.StorageChangeSet},
+	kv.TblStorageIdx:         {kv.E2StorageHistory},
+
+	kv.TblCodeKeys:        {kv.PlainState, kv.PlainContractCode, kv.Code},
+	kv.TblCodeVals:        {kv.PlainState, kv.PlainContractCode, kv.Code},
+	kv.TblCodeHistoryKeys: {kv.PlainState, kv.AccountChangeSet, kv.Code},
+	kv.TblCodeHistoryVals: {kv.PlainState, kv.AccountChangeSet, kv.Code},
+	kv.TblCodeIdx:         {kv.E2AccountsHistory},
+
+	kv.TblCommitmentKeys:        {kv.PlainState, kv.HashedAccounts, kv.HashedStorage},
+	kv.TblCommitmentVals:        {kv.PlainState, kv.HashedAccounts, kv.HashedStorage},
+	kv.TblCommitmentHistoryKeys: {kv.PlainState, kv.HashedAccounts, kv.HashedStorage},
+	kv.TblCommitmentHistoryVals: {kv.PlainState, kv.HashedAccounts, kv.HashedStorage},
+	kv.TblCommitmentIdx:         {kv.E2AccountsHistory},
+
+	kv.TblLogAddressKeys: {kv.LogAddressIndex},
+	kv.TblLogAddressIdx:  {kv.LogAddressIndex},
+	kv.TblLogTopicsKeys:  {kv.LogTopicIndex},
+	kv.TblLogTopicsIdx:   {kv.LogTopicIndex},
+	kv.TblTracesFromKeys: {kv.CallFromIndex},
+	kv.TblTracesToKeys:   {kv.CallToIndex},
+}
+
+func (a *ACL) allowed(client, table string) bool {
+	policy := a.Default
+	if p, ok := a.Clients[client]; ok && client != "" {
+		policy = p
+	}
+	if !policy.allowed(table) {
+		return false
+	}
+	for _, exposed := range exposedTables[table] {
+		if !policy.allowed(exposed) {
+			return false
+		}
+	}
+	return true
+}
+
+// clientIdentity - returns name of client, "" for anonymous client. Unknown token - is error.
+func (a *ACL) clientIdentity(ctx context.Context) (string, error) {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		for _, auth := range md.Get("authorization") {
+			token, ok := strings.CutPrefix(auth, "Bearer ")
+			if !ok {
+				continue
+			}
+			for known, name := range a.Tokens {
+				if subtle.ConstantTimeCompare([]byte(known), []byte(token)) == 1 {
+					return name, nil
+				}
+			}
+			return "", fmt.Errorf("unknown token")
+		}
+	}
+	if p, ok := peer.FromContext(ctx); ok {
+		if tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo); ok {
+			if chains := tlsInfo.State.VerifiedChains; len(chains) > 0 && len(chains[0]) > 0 {
+				return chains[0][0].Subject.CommonName, nil
+			}
+		}
+	}
+	return "", nil
+}
+
+func peerAddr(ctx context.Context) string {
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		return p.Addr.String()
+	}
+	return ""
+}
+
+// SetACL - nil means every client has access to all tables
+func (s *KvServer) SetACL(acl *ACL) { s.acl = acl }
+
+// checkAccess - returns grpc error if client of `ctx` has no access to `table`. Denied accesses are logged (audit).
+func (s *KvServer) checkAccess(ctx context.Context, method, table string) error {
+	if s.acl == nil {
+		return nil
+	}
+	client, err := s.acl.clientIdentity(ctx)
+	if err != nil {
+		s.logger.Warn("[kv_server] access denied", "method", method, "table", table, "peer", peerAddr(ctx), "err", err)
+		return status.Errorf(codes.Unauthenticated, "kvserver: %s", err)
+	}
+	if s.acl.allowed(client, table) {
+		return nil
+	}
+	s.logger.Warn("[kv_server] access denied", "method", method, "table", table, "client", client, "peer", peerAddr(ctx))
+	return status.Errorf(codes.PermissionDenied, "kvserver: client %q has no access to table %s", client, table)
+}
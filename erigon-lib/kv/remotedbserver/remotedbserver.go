@@ -83,6 +83,7 @@ type KvServer struct {
 	trace     bool
 	rangeStep int // make sure `s.with` has limited time
 	logger    log.Logger
+	acl       *ACL // nil - no restrictions
 }
 
 type threadSafeTx struct {
@@ -301,6 +302,12 @@ func (s *KvServer) Tx(stream remote.KV_TxServer) error {
 			c = cInfo.c
 		}
 		switch in.Op {
+		case remote.Op_OPEN, remote.Op_OPEN_DUP_SORT:
+			if err := s.checkAccess(stream.Context(), "Tx", in.BucketName); err != nil {
+				return err
+			}
+		}
+		switch in.Op {
 		case remote.Op_OPEN:
 			CursorID++
 			var err error
@@ -431,6 +438,10 @@ func bytesCopy(b []byte) []byte {
 }
 
 func (s *KvServer) StateChanges(req *remote.StateChangeRequest, server remote.KV_StateChangesServer) error {
+	// stream has changes of accounts and storage
+	if err := s.checkAccess(server.Context(), "StateChanges", kv.PlainState); err != nil {
+		return err
+	}
 	ch, remove := s.stateChangeStreams.Sub()
 	defer remove()
 	for {
@@ -509,6 +520,9 @@ func (s *StateChangePubSub) remove(id uint) {
 
 // Temporal methods
 func (s *KvServer) DomainGet(ctx context.Context, req *remote.DomainGetReq) (reply *remote.DomainGetReply, err error) {
+	if err := s.checkAccess(ctx, "DomainGet", req.Table); err != nil {
+		return nil, err
+	}
 	reply = &remote.DomainGetReply{}
 	if err := s.with(req.TxId, func(tx kv.Tx) error {
 		ttx, ok := tx.(kv.TemporalTx)
@@ -533,6 +547,9 @@ func (s *KvServer) DomainGet(ctx context.Context, req *remote.DomainGetReq) (rep
 	return reply, nil
 }
 func (s *KvServer) HistoryGet(ctx context.Context, req *remote.HistoryGetReq) (reply *remote.HistoryGetReply, err error) {
+	if err := s.checkAccess(ctx, "HistoryGet", req.Table); err != nil {
+		return nil, err
+	}
 	reply = &remote.HistoryGetReply{}
 	if err := s.with(req.TxId, func(tx kv.Tx) error {
 		ttx, ok := tx.(kv.TemporalTx)
@@ -553,6 +570,9 @@ func (s *KvServer) HistoryGet(ctx context.Context, req *remote.HistoryGetReq) (r
 const PageSizeLimit = 4 * 4096
 
 func (s *KvServer) IndexRange(ctx context.Context, req *remote.IndexRangeReq) (*remote.IndexRangeReply, error) {
+	if err := s.checkAccess(ctx, "IndexRange", req.Table); err != nil {
+		return nil, err
+	}
 	reply := &remote.IndexRangeReply{}
 	from, limit := int(req.FromTs), int(req.Limit)
 	if req.PageToken != "" {
@@ -601,6 +621,9 @@ func (s *KvServer) IndexRange(ctx context.Context, req *remote.IndexRangeReq) (*
 }
 
 func (s *KvServer) Range(ctx context.Context, req *remote.RangeReq) (*remote.Pairs, error) {
+	if err := s.checkAccess(ctx, "Range", req.Table); err != nil {
+		return nil, err
+	}
 	from, limit := req.FromPrefix, int(req.Limit)
 	if req.PageToken != "" {
 		var pagination remote.ParisPagination
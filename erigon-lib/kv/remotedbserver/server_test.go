@@ -18,14 +18,26 @@ package remotedbserver
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net"
 	"runtime"
 	"testing"
 
+	"github.com/ledgerwatch/erigon-lib/gointerfaces/grpcutil"
+	"github.com/ledgerwatch/erigon-lib/gointerfaces/remote"
 	"github.com/ledgerwatch/erigon-lib/kv"
 	"github.com/ledgerwatch/erigon-lib/kv/memdb"
 	"github.com/ledgerwatch/log/v3"
 	"github.com/stretchr/testify/require"
 	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
 )
 
 func TestKvServer_renew(t *testing.T) {
@@ -95,3 +107,102 @@ func TestKvServer_renew(t *testing.T) {
 	}
 	require.NoError(g.Wait())
 }
+
+func TestKvServerACL(t *testing.T) {
+	require, ctx, db := require.New(t), context.Background(), memdb.NewTestDB(t)
+	require.NoError(db.Update(ctx, func(tx kv.RwTx) error {
+		require.NoError(tx.Put(kv.Headers, []byte{1}, []byte{1}))
+		return tx.Put(kv.PlainState, []byte{1}, []byte{1})
+	}))
+	acl, err := ParseACL([]byte(`
+[tokens]
+"secret" = "indexer"
+
+[default]
+allow = ["*"]
+deny = ["PlainState"]
+
+[clients.indexer]
+allow = ["Header", "TransactionLog"]
+
+[clients.node]
+allow = ["*"]
+`))
+	require.NoError(err)
+
+	s := NewKvServer(ctx, db, nil, nil, log.New())
+	s.SetACL(acl)
+	id, err := s.begin(ctx)
+	require.NoError(err)
+	defer s.rollback(id)
+
+	withToken := func(token string) context.Context {
+		return metadata.NewIncomingContext(ctx, metadata.Pairs("authorization", "Bearer "+token))
+	}
+	withCert := func(cn string) context.Context {
+		cert := &x509.Certificate{Subject: pkix.Name{CommonName: cn}}
+		authInfo := credentials.TLSInfo{State: tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{cert}}}}
+		return peer.NewContext(ctx, &peer.Peer{Addr: &net.TCPAddr{}, AuthInfo: authInfo})
+	}
+	rangeTable := func(ctx context.Context, table string) codes.Code {
+		_, err := s.Range(ctx, &remote.RangeReq{TxId: id, Table: table, OrderAscend: true, Limit: -1})
+		return status.Code(err)
+	}
+
+	require.Equal(codes.OK, rangeTable(withToken("secret"), kv.Headers))
+	require.Equal(codes.PermissionDenied, rangeTable(withToken("secret"), kv.PlainState))
+	require.Equal(codes.PermissionDenied, rangeTable(withToken("secret"), kv.HeaderCanonical))
+	require.Equal(codes.Unauthenticated, rangeTable(withToken("wrong"), kv.Headers))
+
+	require.Equal(codes.OK, rangeTable(withCert("node"), kv.PlainState))
+	require.Equal(codes.OK, rangeTable(withCert("unknown"), kv.Headers)) // default policy
+	require.Equal(codes.PermissionDenied, rangeTable(withCert("unknown"), kv.PlainState))
+
+	require.Equal(codes.OK, rangeTable(ctx, kv.Headers)) // anonymous
+	require.Equal(codes.PermissionDenied, rangeTable(ctx, kv.PlainState))
+
+	// domains, histories and Erigon3 tables expose PlainState data: denied by same policy.
+	// Access check happens before tx lookup, so allowed requests fail only because memdb is not kv.TemporalTx
+	domainGet := func(ctx context.Context, domain kv.Domain) codes.Code {
+		_, err := s.DomainGet(ctx, &remote.DomainGetReq{TxId: id, Table: string(domain), K: []byte{1}, Latest: true})
+		return status.Code(err)
+	}
+	historyGet := func(ctx context.Context, history kv.History) codes.Code {
+		_, err := s.HistoryGet(ctx, &remote.HistoryGetReq{TxId: id, Table: string(history), K: []byte{1}})
+		return status.Code(err)
+	}
+	indexRange := func(ctx context.Context, idx kv.InvertedIdx) codes.Code {
+		_, err := s.IndexRange(ctx, &remote.IndexRangeReq{TxId: id, Table: string(idx), K: []byte{1}, ToTs: -1, Limit: -1})
+		return status.Code(err)
+	}
+	require.Equal(codes.PermissionDenied, domainGet(ctx, kv.AccountsDomain))
+	require.Equal(codes.PermissionDenied, domainGet(ctx, kv.StorageDomain))
+	require.Equal(codes.PermissionDenied, historyGet(ctx, kv.AccountsHistory))
+	require.Equal(codes.PermissionDenied, historyGet(withCert("unknown"), kv.StorageHistory))
+	require.Equal(codes.PermissionDenied, rangeTable(ctx, kv.TblAccountVals))
+	require.Equal(codes.PermissionDenied, indexRange(withToken("secret"), kv.LogAddrIdx))
+	require.Equal(codes.Unknown, indexRange(ctx, kv.LogAddrIdx))
+	require.Equal(codes.Unknown, domainGet(withCert("node"), kv.AccountsDomain))
+	require.Equal(codes.Unknown, historyGet(withCert("node"), kv.AccountsHistory))
+
+	// client-side token of grpcutil.Connect reaches ACL
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(err)
+	grpcServer := grpc.NewServer()
+	remote.RegisterKVServer(grpcServer, s)
+	go grpcServer.Serve(lis) //nolint:errcheck
+	defer grpcServer.Stop()
+	remoteRange := func(token, table string) codes.Code {
+		conn, err := grpcutil.Connect(nil, lis.Addr().String(), grpcutil.WithBearerToken(token))
+		require.NoError(err)
+		defer conn.Close()
+		_, err = remote.NewKVClient(conn).Range(ctx, &remote.RangeReq{TxId: id, Table: table, OrderAscend: true, Limit: -1})
+		return status.Code(err)
+	}
+	require.Equal(codes.OK, remoteRange("secret", kv.Headers))
+	require.Equal(codes.PermissionDenied, remoteRange("secret", kv.HeaderCanonical)) // allowed for anonymous, not for indexer
+	require.Equal(codes.Unauthenticated, remoteRange("wrong", kv.Headers))
+
+	s.SetACL(nil)
+	require.Equal(codes.OK, rangeTable(ctx, kv.PlainState))
+}
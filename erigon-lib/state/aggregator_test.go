@@ -22,7 +22,9 @@ import (
 	"github.com/ledgerwatch/erigon-lib/common/length"
 	"github.com/ledgerwatch/erigon-lib/compress"
 	"github.com/ledgerwatch/erigon-lib/kv"
+	"github.com/ledgerwatch/erigon-lib/kv/iter"
 	"github.com/ledgerwatch/erigon-lib/kv/mdbx"
+	"github.com/ledgerwatch/erigon-lib/kv/order"
 )
 
 func testDbAndAggregator(t *testing.T, aggStep uint64) (string, kv.RwDB, *Aggregator) {
@@ -635,3 +637,63 @@ func Test_InitBtreeIndex(t *testing.T) {
 	require.EqualValues(t, bt.KeyCount(), keyCount)
 	bt.Close()
 }
+
+func TestAggregatorV3_BuildLogIndexFiles(t *testing.T) {
+	aggStep := uint64(16)
+	path, db, _ := testDbAndAggregator(t, aggStep)
+	ctx := context.Background()
+	dir, tmpdir := filepath.Join(path, "e4v3"), filepath.Join(path, "e4v3tmp")
+	require.NoError(t, os.MkdirAll(dir, 0740))
+	require.NoError(t, os.MkdirAll(tmpdir, 0740))
+	agg, err := NewAggregatorV3(ctx, dir, tmpdir, aggStep, db, log.New())
+	require.NoError(t, err)
+	t.Cleanup(agg.Close)
+
+	// other indices are frozen, log indices - not (for example: were pruned from db)
+	sf, err := agg.buildFiles(ctx, 0, 0, aggStep)
+	require.NoError(t, err)
+	sf.logAddrs.Close()
+	sf.logTopics.Close()
+	agg.accounts.integrateFiles(sf.accounts, 0, aggStep)
+	agg.storage.integrateFiles(sf.storage, 0, aggStep)
+	agg.code.integrateFiles(sf.code, 0, aggStep)
+	agg.tracesFrom.integrateFiles(sf.tracesFrom, 0, aggStep)
+	agg.tracesTo.integrateFiles(sf.tracesTo, 0, aggStep)
+	agg.recalcMaxTxNum()
+	require.Equal(t, []uint64{0}, agg.LogIndexMissedSteps())
+	require.Zero(t, agg.EndTxNumMinimax())
+
+	addr1, addr2 := []byte("addr1"), []byte("addr2")
+	topic1, topic2 := []byte("topic1"), []byte("topic2")
+	walk := func(txFrom, txTo uint64, add func(txNum uint64, addr []byte, topics [][]byte)) error {
+		require.Equal(t, uint64(0), txFrom)
+		require.Equal(t, aggStep, txTo)
+		add(1, addr1, [][]byte{topic1})
+		add(3, addr2, [][]byte{topic1, topic2})
+		add(5, addr1, nil)
+		add(aggStep, addr1, [][]byte{topic2}) // out of step
+		return nil
+	}
+	require.Error(t, agg.BuildLogIndexFiles(ctx, 1, walk))
+	require.NoError(t, agg.BuildLogIndexFiles(ctx, 0, walk))
+	require.Empty(t, agg.LogIndexMissedSteps())
+	require.Equal(t, aggStep, agg.EndTxNumMinimax())
+
+	tx, err := db.BeginRo(ctx)
+	require.NoError(t, err)
+	defer tx.Rollback()
+	ac := agg.MakeContext()
+	defer ac.Close()
+	check := func(idx kv.InvertedIdx, key []byte, expect []uint64) {
+		t.Helper()
+		it, err := ac.IndexRange(idx, key, 0, -1, order.Asc, -1, tx)
+		require.NoError(t, err)
+		txNums, err := iter.ToU64Arr(it)
+		require.NoError(t, err)
+		require.Equal(t, expect, txNums)
+	}
+	check(kv.LogAddrIdx, addr1, []uint64{1, 5})
+	check(kv.LogAddrIdx, addr2, []uint64{3})
+	check(kv.LogTopicIdx, topic1, []uint64{1, 3})
+	check(kv.LogTopicIdx, topic2, []uint64{3})
+}
@@ -658,6 +658,111 @@ func (a *AggregatorV3) integrateFiles(sf AggV3StaticFiles, txNumFrom, txNumTo ui
 	a.tracesTo.integrateFiles(sf.tracesTo, txNumFrom, txNumTo)
 }
 
+// LogsWalker - must call `add` for every log of txs in [txFrom, txTo). Logs of txs out of this range are ignored.
+type LogsWalker func(txFrom, txTo uint64, add func(txNum uint64, addr []byte, topics [][]byte)) error
+
+// frozenTxNumWithoutLogs - end of files of all indices except log indices
+func (a *AggregatorV3) frozenTxNumWithoutLogs() uint64 {
+	return cmp.Min(
+		cmp.Min(cmp.Min(a.accounts.endTxNumMinimax(), a.storage.endTxNumMinimax()), a.code.endTxNumMinimax()),
+		cmp.Min(a.tracesFrom.endTxNumMinimax(), a.tracesTo.endTxNumMinimax()),
+	)
+}
+
+// LogIndexMissedSteps - steps which are already in files of other indices, but have no files of log indices (logaddrs, logtopics).
+// For example: log indices were pruned from db before files were built, or files were not downloaded.
+// BuildLogIndexFiles can build them from receipts snapshots, or from logs of txs re-executed on state history.
+func (a *AggregatorV3) LogIndexMissedSteps() (steps []uint64) {
+	a.filesMutationLock.Lock()
+	defer a.filesMutationLock.Unlock()
+	frozenTo := a.frozenTxNumWithoutLogs()
+	for step := uint64(0); (step+1)*a.aggregationStep <= frozenTo; step++ {
+		txFrom, txTo := step*a.aggregationStep, (step+1)*a.aggregationStep
+		if !a.logAddrs.filesCover(txFrom, txTo) || !a.logTopics.filesCover(txFrom, txTo) {
+			steps = append(steps, step)
+		}
+	}
+	return steps
+}
+
+// BuildLogIndexFiles - builds files of log indices of `step` from logs provided by `walk` (for example: from receipts snapshots).
+// Step must be frozen by other indices - log indices of recent steps are built from db by BuildFiles.
+func (a *AggregatorV3) BuildLogIndexFiles(ctx context.Context, step uint64, walk LogsWalker) error {
+	txFrom, txTo := step*a.aggregationStep, (step+1)*a.aggregationStep
+	a.filesMutationLock.Lock()
+	frozenTo := a.frozenTxNumWithoutLogs()
+	needAddrs, needTopics := !a.logAddrs.filesCover(txFrom, txTo), !a.logTopics.filesCover(txFrom, txTo)
+	a.filesMutationLock.Unlock()
+	if txTo > frozenTo {
+		return fmt.Errorf("BuildLogIndexFiles: step %d is not frozen yet, frozen up to txNum %d", step, frozenTo)
+	}
+	if !needAddrs && !needTopics {
+		return nil
+	}
+
+	addrs, topics := map[string]*roaring64.Bitmap{}, map[string]*roaring64.Bitmap{}
+	defer func() {
+		for _, b := range addrs {
+			bitmapdb.ReturnToPool64(b)
+		}
+		for _, b := range topics {
+			bitmapdb.ReturnToPool64(b)
+		}
+	}()
+	put := func(bitmaps map[string]*roaring64.Bitmap, key []byte, txNum uint64) {
+		bitmap, ok := bitmaps[string(key)]
+		if !ok {
+			bitmap = bitmapdb.NewBitmap64()
+			bitmaps[string(key)] = bitmap
+		}
+		bitmap.Add(txNum)
+	}
+	if err := walk(txFrom, txTo, func(txNum uint64, addr []byte, logTopics [][]byte) {
+		if txNum < txFrom || txNum >= txTo {
+			return
+		}
+		put(addrs, addr, txNum)
+		for _, topic := range logTopics {
+			put(topics, topic, txNum)
+		}
+	}); err != nil {
+		return err
+	}
+
+	var sf AggV3StaticFiles
+	closeFiles := true
+	defer func() {
+		if closeFiles {
+			sf.logAddrs.Close()
+			sf.logTopics.Close()
+		}
+	}()
+	var err error
+	if needAddrs {
+		if sf.logAddrs, err = a.logAddrs.buildFiles(ctx, step, addrs, a.ps); err != nil {
+			return err
+		}
+	}
+	if needTopics {
+		if sf.logTopics, err = a.logTopics.buildFiles(ctx, step, topics, a.ps); err != nil {
+			return err
+		}
+	}
+
+	a.filesMutationLock.Lock()
+	defer a.filesMutationLock.Unlock()
+	defer a.needSaveFilesListInDB.Store(true)
+	defer a.recalcMaxTxNum()
+	if needAddrs {
+		a.logAddrs.integrateFiles(sf.logAddrs, txFrom, txTo)
+	}
+	if needTopics {
+		a.logTopics.integrateFiles(sf.logTopics, txFrom, txTo)
+	}
+	closeFiles = false
+	return nil
+}
+
 func (a *AggregatorV3) HasNewFrozenFiles() bool {
 	if a == nil {
 		return false
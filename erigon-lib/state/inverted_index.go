@@ -1251,6 +1251,20 @@ func (ii *InvertedIndex) integrateFiles(sf InvertedFiles, txNumFrom, txNumTo uin
 	ii.reCalcRoFiles()
 }
 
+// filesCover - true if [txFrom, txTo) is inside of one of files
+func (ii *InvertedIndex) filesCover(txFrom, txTo uint64) (covered bool) {
+	ii.files.Walk(func(items []*filesItem) bool {
+		for _, item := range items {
+			if item.startTxNum <= txFrom && txTo <= item.endTxNum {
+				covered = true
+				return false
+			}
+		}
+		return true
+	})
+	return covered
+}
+
 func (ii *InvertedIndex) warmup(ctx context.Context, txFrom, limit uint64, tx kv.Tx) error {
 	keysCursor, err := tx.CursorDupSort(ii.indexKeysTable)
 	if err != nil {
@@ -54,6 +54,7 @@ import (
 	"github.com/ledgerwatch/erigon-lib/gointerfaces/remote"
 	proto_txpool "github.com/ledgerwatch/erigon-lib/gointerfaces/txpool"
 	"github.com/ledgerwatch/erigon-lib/kv"
+	"github.com/ledgerwatch/erigon-lib/kv/dbutils"
 	"github.com/ledgerwatch/erigon-lib/kv/kvcache"
 	"github.com/ledgerwatch/erigon-lib/kv/mdbx"
 	"github.com/ledgerwatch/erigon-lib/metrics"
@@ -114,6 +115,27 @@ const (
 	BaseFeePoolBits = NoNonceGaps + EnoughBalance + NotTooMuchGas
 )
 
+var subPoolMarkerNames = []struct {
+	bit  SubPoolMarker
+	name string
+}{
+	{NoNonceGaps, "NoNonceGaps"},
+	{EnoughBalance, "EnoughBalance"},
+	{NotTooMuchGas, "NotTooMuchGas"},
+	{EnoughFeeCapBlock, "EnoughFeeCapBlock"},
+}
+
+// Missing returns the names of the bits that keep a transaction out of the pending sub-pool.
+func (m SubPoolMarker) Missing() []string {
+	var missing []string
+	for _, b := range subPoolMarkerNames {
+		if m&b.bit == 0 {
+			missing = append(missing, b.name)
+		}
+	}
+	return missing
+}
+
 // metaTx holds transaction and some metadata
 type metaTx struct {
 	Tx                        *types.TxSlot
@@ -128,6 +150,7 @@ type metaTx struct {
 	currentSubPool            SubPoolType
 	alreadyYielded            bool
 	minedBlockNum             uint64
+	conditionsNotMet          bool // known accounts of a conditional transaction don't match the state of the last seen block
 }
 
 func newMetaTx(slot *types.TxSlot, isLocal bool, timestamp uint64) *metaTx {
@@ -197,6 +220,7 @@ type TxPool struct {
 	unprocessedRemoteTxs    *types.TxSlots
 	unprocessedRemoteByHash map[string]int                                  // to reject duplicates
 	byHash                  map[string]*metaTx                              // tx_hash => tx : only those records not committed to db yet
+	conditionalTxs          map[string]*metaTx                              // tx_hash => tx : transactions with conditions, re-checked on every block
 	discardReasonsLRU       *simplelru.LRU[string, txpoolcfg.DiscardReason] // tx_hash => discard_reason : non-persisted
 	pending                 *PendingPool
 	baseFee                 *SubPool
@@ -222,12 +246,19 @@ type TxPool struct {
 	isPostAgra              atomic.Bool
 	cancunTime              *uint64
 	isPostCancun            atomic.Bool
+	pragueTime              *uint64
+	isPostPrague            atomic.Bool
 	maxBlobsPerBlock        uint64
+	storageRoots            StorageRootReader // nil if storage roots of conditional transactions can't be checked
 	logger                  log.Logger
 }
 
+// StorageRootReader reads the storage root of an account from the state of tx. Storage roots aren't a part of
+// the plain state, so they can't be read through the state cache.
+type StorageRootReader func(tx kv.Tx, addr common.Address) (common.Hash, error)
+
 func New(newTxs chan types.Announcements, coreDB kv.RoDB, cfg txpoolcfg.Config, cache kvcache.Cache,
-	chainID uint256.Int, shanghaiTime, agraBlock, cancunTime *big.Int, maxBlobsPerBlock uint64, logger log.Logger,
+	chainID uint256.Int, shanghaiTime, agraBlock, cancunTime, pragueTime *big.Int, maxBlobsPerBlock uint64, logger log.Logger,
 ) (*TxPool, error) {
 	localsHistory, err := simplelru.NewLRU[string, struct{}](10_000, nil)
 	if err != nil {
@@ -252,6 +283,7 @@ func New(newTxs chan types.Announcements, coreDB kv.RoDB, cfg txpoolcfg.Config,
 	res := &TxPool{
 		lock:                    &sync.Mutex{},
 		byHash:                  map[string]*metaTx{},
+		conditionalTxs:          map[string]*metaTx{},
 		isLocalLRU:              localsHistory,
 		discardReasonsLRU:       discardHistory,
 		all:                     byNonce,
@@ -294,6 +326,13 @@ func New(newTxs chan types.Announcements, coreDB kv.RoDB, cfg txpoolcfg.Config,
 		cancunTimeU64 := cancunTime.Uint64()
 		res.cancunTime = &cancunTimeU64
 	}
+	if pragueTime != nil {
+		if !pragueTime.IsUint64() {
+			return nil, errors.New("pragueTime overflow")
+		}
+		pragueTimeU64 := pragueTime.Uint64()
+		res.pragueTime = &pragueTimeU64
+	}
 
 	return res, nil
 }
@@ -353,7 +392,7 @@ func (p *TxPool) OnNewBlock(ctx context.Context, stateChanges *remote.StateChang
 	if err := p.senders.onNewBlock(stateChanges, unwindTxs, minedTxs, p.logger); err != nil {
 		return err
 	}
-	_, unwindTxs, err = p.validateTxs(&unwindTxs, cacheView)
+	_, unwindTxs, err = p.validateTxs(&unwindTxs, coreTx, cacheView)
 	if err != nil {
 		return err
 	}
@@ -377,6 +416,9 @@ func (p *TxPool) OnNewBlock(ctx context.Context, stateChanges *remote.StateChang
 	if err := removeMined(p.all, minedTxs.Txs, p.pending, p.baseFee, p.queued, p.discardLocked, p.logger); err != nil {
 		return err
 	}
+	if err := p.checkConditionalTxs(coreTx, cacheView, stateChanges); err != nil {
+		return err
+	}
 
 	//p.logger.Debug("[txpool] new block", "unwinded", len(unwindTxs.txs), "mined", len(minedTxs.txs), "baseFee", baseFee, "blockHeight", blockHeight)
 
@@ -440,7 +482,7 @@ func (p *TxPool) processRemoteTxs(ctx context.Context) error {
 		return err
 	}
 
-	_, newTxs, err := p.validateTxs(p.unprocessedRemoteTxs, cacheView)
+	_, newTxs, err := p.validateTxs(p.unprocessedRemoteTxs, coreTx, cacheView)
 	if err != nil {
 		return err
 	}
@@ -492,7 +534,7 @@ func (p *TxPool) AppendLocalAnnouncements(types []byte, sizes []uint32, hashes [
 	p.lock.Lock()
 	defer p.lock.Unlock()
 	for hash, txn := range p.byHash {
-		if txn.subPool&IsLocal == 0 {
+		if txn.subPool&IsLocal == 0 || txn.Tx.Conditions != nil {
 			continue
 		}
 		types = append(types, txn.Tx.Type)
@@ -506,7 +548,7 @@ func (p *TxPool) AppendRemoteAnnouncements(types []byte, sizes []uint32, hashes
 	defer p.lock.Unlock()
 
 	for hash, txn := range p.byHash {
-		if txn.subPool&IsLocal != 0 {
+		if txn.subPool&IsLocal != 0 || txn.Tx.Conditions != nil {
 			continue
 		}
 		types = append(types, txn.Tx.Type)
@@ -607,6 +649,18 @@ func (p *TxPool) IsLocal(idHash []byte) bool {
 	defer p.lock.Unlock()
 	return p.isLocalLRU.Contains(hashS)
 }
+
+// IsConditional tells if the transaction was submitted with conditions, such transactions aren't propagated
+func (p *TxPool) IsConditional(idHash []byte) bool {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	_, ok := p.conditionalTxs[string(idHash)]
+	return ok
+}
+
+// SetStorageRootReader enables storage root conditions, transactions with them are rejected while there is no reader
+func (p *TxPool) SetStorageRootReader(r StorageRootReader) { p.storageRoots = r }
+
 func (p *TxPool) AddNewGoodPeer(peerID types.PeerID) { p.recentlyConnectedPeers.AddPeer(peerID) }
 func (p *TxPool) Started() bool                      { return p.started.Load() }
 
@@ -640,6 +694,11 @@ func (p *TxPool) best(n uint16, txs *types.TxsRlp, tx kv.Tx, onTopOf, availableG
 			continue
 		}
 
+		if mt.Tx.Conditions != nil && (mt.conditionsNotMet || !mt.Tx.Conditions.InBounds(onTopOf+1, uint64(time.Now().Unix()))) {
+			// Skip conditional transactions which can't be included into the next block
+			continue
+		}
+
 		rlpTx, sender, isLocal, err := p.getRlpLocked(tx, mt.Tx.IDHash[:])
 		if err != nil {
 			return false, count, err
@@ -659,7 +718,7 @@ func (p *TxPool) best(n uint16, txs *types.TxsRlp, tx kv.Tx, onTopOf, availableG
 		// make sure we have enough gas in the caller to add this transaction.
 		// not an exact science using intrinsic gas but as close as we could hope for at
 		// this stage
-		intrinsicGas, _ := txpoolcfg.CalcIntrinsicGas(uint64(mt.Tx.DataLen), uint64(mt.Tx.DataNonZeroLen), nil, mt.Tx.Creation, true, true, isShanghai)
+		intrinsicGas, _ := txpoolcfg.CalcIntrinsicGas(uint64(mt.Tx.DataLen), uint64(mt.Tx.DataNonZeroLen), uint64(mt.Tx.AuthorizationLen), nil, mt.Tx.Creation, true, true, isShanghai)
 		if intrinsicGas > availableGas {
 			// we might find another TX with a low enough intrinsic gas to include so carry on
 			continue
@@ -710,6 +769,24 @@ func (p *TxPool) CountContent() (int, int, int) {
 	defer p.lock.Unlock()
 	return p.pending.Len(), p.baseFee.Len(), p.queued.Len()
 }
+
+// Explain returns the sub-pool and the marker of a transaction in the pool. EnoughFeeCapBlock isn't
+// maintained in the marker, so it's set here if the fee caps cover the pending block fees.
+// For transactions not in the pool, the discard reason is returned if it's still remembered.
+func (p *TxPool) Explain(idHash []byte) (subPool SubPoolType, marker SubPoolMarker, reason txpoolcfg.DiscardReason, found bool) {
+	hashS := string(idHash)
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if mt, ok := p.byHash[hashS]; ok {
+		marker = mt.subPool
+		if mt.minFeeCap.CmpUint64(p.pendingBaseFee.Load()) >= 0 && (mt.Tx.Type != types.BlobTxType || mt.Tx.BlobFeeCap.CmpUint64(p.pendingBlobFee.Load()) >= 0) {
+			marker |= EnoughFeeCapBlock
+		}
+		return mt.currentSubPool, marker, txpoolcfg.NotSet, true
+	}
+	reason, _ = p.discardReasonsLRU.Get(hashS)
+	return 0, 0, reason, false
+}
 func (p *TxPool) AddRemoteTxs(_ context.Context, newTxs types.TxSlots) {
 	if p.cfg.NoGossip {
 		// if no gossip, then
@@ -742,7 +819,7 @@ func toBlobs(_blobs [][]byte) []gokzg4844.Blob {
 	return blobs
 }
 
-func (p *TxPool) validateTx(txn *types.TxSlot, isLocal bool, stateCache kvcache.CacheView) txpoolcfg.DiscardReason {
+func (p *TxPool) validateTx(txn *types.TxSlot, isLocal bool, coreTx kv.Tx, stateCache kvcache.CacheView) txpoolcfg.DiscardReason {
 	isShanghai := p.isShanghai() || p.isAgra()
 	if isShanghai {
 		if txn.DataLen > fixedgas.MaxInitCodeSize {
@@ -784,6 +861,17 @@ func (p *TxPool) validateTx(txn *types.TxSlot, isLocal bool, stateCache kvcache.
 			return txpoolcfg.UnmatchedBlobTxExt
 		}
 	}
+	if txn.Type == types.SetCodeTxType {
+		if !p.isPrague() {
+			return txpoolcfg.TypeNotActivated
+		}
+		if txn.Creation {
+			return txpoolcfg.CreateSetCodeTxn
+		}
+		if txn.AuthorizationLen == 0 {
+			return txpoolcfg.NoAuthorizations
+		}
+	}
 
 	// Drop non-local transactions under our own minimal accepted gas price or tip
 	if !isLocal && uint256.NewInt(p.cfg.MinFeeCap).Cmp(&txn.FeeCap) == 1 {
@@ -792,7 +880,7 @@ func (p *TxPool) validateTx(txn *types.TxSlot, isLocal bool, stateCache kvcache.
 		}
 		return txpoolcfg.UnderPriced
 	}
-	gas, reason := txpoolcfg.CalcIntrinsicGas(uint64(txn.DataLen), uint64(txn.DataNonZeroLen), nil, txn.Creation, true, true, isShanghai)
+	gas, reason := txpoolcfg.CalcIntrinsicGas(uint64(txn.DataLen), uint64(txn.DataNonZeroLen), uint64(txn.AuthorizationLen), nil, txn.Creation, true, true, isShanghai)
 	if txn.Traced {
 		p.logger.Info(fmt.Sprintf("TX TRACING: validateTx intrinsic gas idHash=%x gas=%d", txn.IDHash, gas))
 	}
@@ -821,6 +909,12 @@ func (p *TxPool) validateTx(txn *types.TxSlot, isLocal bool, stateCache kvcache.
 		return txpoolcfg.Spammer
 	}
 
+	if txn.Conditions != nil {
+		if reason := p.validateConditions(txn, coreTx, stateCache); reason != txpoolcfg.Success {
+			return reason
+		}
+	}
+
 	// check nonce and balance
 	senderNonce, senderBalance, _ := p.senders.info(stateCache, txn.SenderID)
 	if senderNonce > txn.Nonce {
@@ -840,6 +934,136 @@ func (p *TxPool) validateTx(txn *types.TxSlot, isLocal bool, stateCache kvcache.
 	return txpoolcfg.Success
 }
 
+// validateConditions checks the preconditions of a conditional transaction against the pending block
+func (p *TxPool) validateConditions(txn *types.TxSlot, coreTx kv.Tx, stateCache kvcache.CacheView) txpoolcfg.DiscardReason {
+	if !txn.Conditions.InBounds(p.lastSeenBlock.Load()+1, uint64(time.Now().Unix())) {
+		if txn.Traced {
+			p.logger.Info(fmt.Sprintf("TX TRACING: validateTx conditions out of bounds idHash=%x block=%d", txn.IDHash, p.lastSeenBlock.Load()+1))
+		}
+		return txpoolcfg.ConditionsNotMet
+	}
+	if len(txn.Conditions.StorageRoots) > 0 && p.storageRoots == nil {
+		if txn.Traced {
+			p.logger.Info(fmt.Sprintf("TX TRACING: validateTx storage roots can't be checked idHash=%x", txn.IDHash))
+		}
+		return txpoolcfg.ConditionsNotMet
+	}
+	match, err := p.knownAccountsMatch(txn.Conditions, coreTx, stateCache, map[common.Address]common.Hash{})
+	if err != nil {
+		p.logger.Warn("[txpool] checking known accounts", "idHash", fmt.Sprintf("%x", txn.IDHash), "err", err)
+		return txpoolcfg.ConditionsNotMet
+	}
+	if !match {
+		if txn.Traced {
+			p.logger.Info(fmt.Sprintf("TX TRACING: validateTx known accounts don't match idHash=%x", txn.IDHash))
+		}
+		return txpoolcfg.ConditionsNotMet
+	}
+	return txpoolcfg.Success
+}
+
+// checkConditionalTxs re-checks conditional transactions after a new block: expired ones are discarded,
+// and the ones whose known accounts don't match the new state are skipped by best() until they match again.
+// Known accounts are only re-checked if the block changed them.
+func (p *TxPool) checkConditionalTxs(coreTx kv.Tx, stateCache kvcache.CacheView, stateChanges *remote.StateChangeBatch) error {
+	if len(p.conditionalTxs) == 0 {
+		return nil
+	}
+	changed := map[common.Address]struct{}{}
+	for _, changesList := range stateChanges.ChangeBatch {
+		for _, change := range changesList.Changes {
+			changed[gointerfaces.ConvertH160toAddress(change.Address)] = struct{}{}
+		}
+	}
+	blockNum, now := p.lastSeenBlock.Load()+1, uint64(time.Now().Unix())
+	roots := map[common.Address]common.Hash{} // storage roots are read once per block
+	var toDel []*metaTx
+	for _, mt := range p.conditionalTxs {
+		if mt.Tx.Conditions.Expired(blockNum, now) {
+			toDel = append(toDel, mt)
+			continue
+		}
+		if !accountsChanged(mt.Tx.Conditions, changed) {
+			continue
+		}
+		match, err := p.knownAccountsMatch(mt.Tx.Conditions, coreTx, stateCache, roots)
+		if err != nil {
+			return err
+		}
+		mt.conditionsNotMet = !match
+	}
+	for _, mt := range toDel {
+		switch mt.currentSubPool {
+		case PendingSubPool:
+			p.pending.Remove(mt)
+		case BaseFeeSubPool:
+			p.baseFee.Remove(mt)
+		case QueuedSubPool:
+			p.queued.Remove(mt)
+		}
+		p.discardLocked(mt, txpoolcfg.ConditionsNotMet)
+	}
+	// later nonces of the senders of discarded transactions can't be pending anymore
+	for _, mt := range toDel {
+		nonce, balance, err := p.senders.info(stateCache, mt.Tx.SenderID)
+		if err != nil {
+			return err
+		}
+		onSenderStateChange(mt.Tx.SenderID, nonce, balance, p.all, p.blockGasLimit.Load(), p.pending, p.baseFee, p.queued, p.discardLocked, p.logger)
+	}
+	return nil
+}
+
+func accountsChanged(conditions *types.TxConditions, changed map[common.Address]struct{}) bool {
+	for addr := range changed {
+		if conditions.HasAccount(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// knownAccountsMatch compares the expected storage slot values and storage roots of the known accounts with the state.
+// Slots are read through the state cache, roots are read by the storage root reader and memoized in roots.
+func (p *TxPool) knownAccountsMatch(conditions *types.TxConditions, coreTx kv.Tx, stateCache kvcache.CacheView, roots map[common.Address]common.Hash) (bool, error) {
+	for addr, slots := range conditions.KnownAccounts {
+		enc, err := stateCache.Get(addr.Bytes())
+		if err != nil {
+			return false, err
+		}
+		incarnation, err := types.DecodeIncarnation(enc)
+		if err != nil {
+			return false, err
+		}
+		for key, expected := range slots {
+			v, err := stateCache.Get(dbutils.PlainGenerateCompositeStorageKey(addr.Bytes(), incarnation, key.Bytes()))
+			if err != nil {
+				return false, err
+			}
+			if common.BytesToHash(v) != expected {
+				return false, nil
+			}
+		}
+	}
+	for addr, expected := range conditions.StorageRoots {
+		root, ok := roots[addr]
+		if !ok {
+			if p.storageRoots == nil {
+				return false, nil
+			}
+			var err error
+			if root, err = p.storageRoots(coreTx, addr); err != nil {
+				return false, err
+			}
+			roots[addr] = root
+		}
+		if root != expected {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
 var maxUint256 = new(uint256.Int).SetAllOne()
 
 // Sender should have enough balance for: gasLimit x feeCap + blobGas x blobFeeCap + transferred_value
@@ -959,6 +1183,31 @@ func (p *TxPool) isCancun() bool {
 	return activated
 }
 
+func (p *TxPool) isPrague() bool {
+	// once this flag has been set for the first time we no longer need to check the timestamp
+	set := p.isPostPrague.Load()
+	if set {
+		return true
+	}
+	if p.pragueTime == nil {
+		return false
+	}
+	pragueTime := *p.pragueTime
+
+	// a zero here means Prague is always active
+	if pragueTime == 0 {
+		p.isPostPrague.Swap(true)
+		return true
+	}
+
+	now := time.Now().Unix()
+	activated := uint64(now) >= pragueTime
+	if activated {
+		p.isPostPrague.Swap(true)
+	}
+	return activated
+}
+
 // Check that that the serialized txn should not exceed a certain max size
 func (p *TxPool) ValidateSerializedTxn(serializedTxn []byte) error {
 	const (
@@ -991,7 +1240,7 @@ func (p *TxPool) ValidateSerializedTxn(serializedTxn []byte) error {
 	return nil
 }
 
-func (p *TxPool) validateTxs(txs *types.TxSlots, stateCache kvcache.CacheView) (reasons []txpoolcfg.DiscardReason, goodTxs types.TxSlots, err error) {
+func (p *TxPool) validateTxs(txs *types.TxSlots, coreTx kv.Tx, stateCache kvcache.CacheView) (reasons []txpoolcfg.DiscardReason, goodTxs types.TxSlots, err error) {
 	// reasons is pre-sized for direct indexing, with the default zero
 	// value DiscardReason of NotSet
 	reasons = make([]txpoolcfg.DiscardReason, len(txs.Txs))
@@ -1002,7 +1251,7 @@ func (p *TxPool) validateTxs(txs *types.TxSlots, stateCache kvcache.CacheView) (
 
 	goodCount := 0
 	for i, txn := range txs.Txs {
-		reason := p.validateTx(txn, txs.IsLocal[i], stateCache)
+		reason := p.validateTx(txn, txs.IsLocal[i], coreTx, stateCache)
 		if reason == txpoolcfg.Success {
 			goodCount++
 			// Success here means no DiscardReason yet, so leave it NotSet
@@ -1088,7 +1337,7 @@ func (p *TxPool) AddLocalTxs(ctx context.Context, newTransactions types.TxSlots,
 		return nil, err
 	}
 
-	reasons, newTxs, err := p.validateTxs(&newTransactions, cacheView)
+	reasons, newTxs, err := p.validateTxs(&newTransactions, coreTx, cacheView)
 	if err != nil {
 		return nil, err
 	}
@@ -1335,6 +1584,9 @@ func (p *TxPool) addLocked(mt *metaTx, announcements *types.Announcements) txpoo
 
 	hashStr := string(mt.Tx.IDHash[:])
 	p.byHash[hashStr] = mt
+	if mt.Tx.Conditions != nil {
+		p.conditionalTxs[hashStr] = mt
+	}
 
 	if replaced := p.all.replaceOrInsert(mt); replaced != nil {
 		if assert.Enable {
@@ -1357,6 +1609,7 @@ func (p *TxPool) addLocked(mt *metaTx, announcements *types.Announcements) txpoo
 func (p *TxPool) discardLocked(mt *metaTx, reason txpoolcfg.DiscardReason) {
 	hashStr := string(mt.Tx.IDHash[:])
 	delete(p.byHash, hashStr)
+	delete(p.conditionalTxs, hashStr)
 	p.deletedTxs = append(p.deletedTxs, mt)
 	p.all.delete(mt)
 	p.discardReasonsLRU.Add(hashStr, reason)
@@ -1740,6 +1993,10 @@ func MainLoop(ctx context.Context, db kv.RwDB, coreDB kv.RoDB, p *TxPool, newTxs
 
 						// Empty rlp can happen if a transaction we want to broadcast has just been mined, for example
 						slotsRlp = append(slotsRlp, slotRlp)
+						// conditions aren't a part of the transaction, peers would include it unconditionally
+						if p.IsConditional(hash) {
+							continue
+						}
 						if p.IsLocal(hash) {
 							localTxTypes = append(localTxTypes, t)
 							localTxSizes = append(localTxSizes, size)
@@ -1885,6 +2142,11 @@ func (p *TxPool) flushLocked(tx kv.RwTx) (err error) {
 		if metaTx.Tx.Rlp == nil {
 			continue
 		}
+		if metaTx.Tx.Conditions != nil {
+			// conditions aren't persisted: conditional transactions keep their rlp in memory and are dropped
+			// on restart, the sender has to re-submit them
+			continue
+		}
 		v = common.EnsureEnoughSize(v, 20+len(metaTx.Tx.Rlp))
 
 		addr, ok := p.senders.senderID2Addr[metaTx.Tx.SenderID]
@@ -1988,7 +2250,7 @@ func (p *TxPool) fromDB(ctx context.Context, tx kv.Tx, coreTx kv.Tx) error {
 
 		isLocalTx := p.isLocalLRU.Contains(string(k))
 
-		if reason := p.validateTx(txn, isLocalTx, cacheView); reason != txpoolcfg.NotSet && reason != txpoolcfg.Success {
+		if reason := p.validateTx(txn, isLocalTx, coreTx, cacheView); reason != txpoolcfg.NotSet && reason != txpoolcfg.Success {
 			return nil // TODO: Clarify - if one of the txs has the wrong reason, no pooled txs!
 		}
 		txs.Resize(uint(i + 1))
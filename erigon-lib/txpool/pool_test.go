@@ -26,6 +26,7 @@ import (
 	"testing"
 
 	gokzg4844 "github.com/crate-crypto/go-kzg-4844"
+	mapset "github.com/deckarep/golang-set/v2"
 	"github.com/holiman/uint256"
 	"github.com/ledgerwatch/log/v3"
 	"github.com/stretchr/testify/assert"
@@ -52,7 +53,7 @@ func TestNonceFromAddress(t *testing.T) {
 
 	cfg := txpoolcfg.DefaultConfig
 	sendersCache := kvcache.New(kvcache.DefaultCoherentConfig)
-	pool, err := New(ch, coreDB, cfg, sendersCache, *u256.N1, nil, nil, nil, fixedgas.DefaultMaxBlobsPerBlock, log.New())
+	pool, err := New(ch, coreDB, cfg, sendersCache, *u256.N1, nil, nil, nil, nil, fixedgas.DefaultMaxBlobsPerBlock, log.New())
 	assert.NoError(err)
 	require.True(pool != nil)
 	ctx := context.Background()
@@ -165,6 +166,108 @@ func TestNonceFromAddress(t *testing.T) {
 	}
 }
 
+func TestExplain(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	ch := make(chan types.Announcements, 100)
+	db, coreDB := memdb.NewTestPoolDB(t), memdb.NewTestDB(t)
+
+	cfg := txpoolcfg.DefaultConfig
+	sendersCache := kvcache.New(kvcache.DefaultCoherentConfig)
+	pool, err := New(ch, coreDB, cfg, sendersCache, *u256.N1, nil, nil, nil, nil, fixedgas.DefaultMaxBlobsPerBlock, log.New())
+	assert.NoError(err)
+	require.True(pool != nil)
+	ctx := context.Background()
+	var stateVersionID uint64 = 0
+	pendingBaseFee := uint64(200000)
+	h1 := gointerfaces.ConvertHashToH256([32]byte{})
+	change := &remote.StateChangeBatch{
+		StateVersionId:      stateVersionID,
+		PendingBlockBaseFee: pendingBaseFee,
+		BlockGasLimit:       1000000,
+		ChangeBatch: []*remote.StateChange{
+			{BlockHeight: 0, BlockHash: h1},
+		},
+	}
+	var addr [20]byte
+	addr[0] = 1
+	v := make([]byte, types.EncodeSenderLengthForStorage(2, *uint256.NewInt(1 * common.Ether)))
+	types.EncodeSender(2, *uint256.NewInt(1 * common.Ether), v)
+	change.ChangeBatch[0].Changes = append(change.ChangeBatch[0].Changes, &remote.AccountChange{
+		Action:  remote.Action_UPSERT,
+		Address: gointerfaces.ConvertAddressToH160(addr),
+		Data:    v,
+	})
+	tx, err := db.BeginRw(ctx)
+	require.NoError(err)
+	defer tx.Rollback()
+	err = pool.OnNewBlock(ctx, change, types.TxSlots{}, types.TxSlots{}, tx)
+	assert.NoError(err)
+
+	idHash := func(i byte) []byte {
+		var h [32]byte
+		h[0] = i
+		return h[:]
+	}
+	var txSlots types.TxSlots
+	for i, slot := range []struct{ nonce, feeCap uint64 }{
+		{2, 300000}, // pending
+		{3, 100000}, // fee cap below the pending base fee
+		{5, 300000}, // nonce gap
+	} {
+		txSlot := &types.TxSlot{
+			Tip:    *uint256.NewInt(100000),
+			FeeCap: *uint256.NewInt(slot.feeCap),
+			Gas:    100000,
+			Nonce:  slot.nonce,
+		}
+		txSlot.IDHash[0] = byte(i + 1)
+		txSlots.Append(txSlot, addr[:], true)
+	}
+	reasons, err := pool.AddLocalTxs(ctx, txSlots, tx)
+	assert.NoError(err)
+	for _, reason := range reasons {
+		assert.Equal(txpoolcfg.Success, reason, reason.String())
+	}
+
+	subPool, marker, _, found := pool.Explain(idHash(1))
+	assert.True(found)
+	assert.Equal(PendingSubPool, subPool)
+	assert.Empty(marker.Missing())
+
+	subPool, marker, _, found = pool.Explain(idHash(2))
+	assert.True(found)
+	assert.Equal(BaseFeeSubPool, subPool)
+	assert.Equal([]string{"EnoughFeeCapBlock"}, marker.Missing())
+
+	subPool, marker, _, found = pool.Explain(idHash(3))
+	assert.True(found)
+	assert.Equal(QueuedSubPool, subPool)
+	// the fee cap of a transaction counts only down to the lowest one of the sender's preceding transactions
+	assert.Equal([]string{"NoNonceGaps", "EnoughFeeCapBlock"}, marker.Missing())
+
+	// replace the pending transaction, the replaced one is remembered as discarded
+	txSlots = types.TxSlots{}
+	txSlot := &types.TxSlot{
+		Tip:    *uint256.NewInt(300000),
+		FeeCap: *uint256.NewInt(400000),
+		Gas:    100000,
+		Nonce:  2,
+	}
+	txSlot.IDHash[0] = 4
+	txSlots.Append(txSlot, addr[:], true)
+	reasons, err = pool.AddLocalTxs(ctx, txSlots, tx)
+	assert.NoError(err)
+	assert.Equal(txpoolcfg.Success, reasons[0], reasons[0].String())
+
+	_, _, reason, found := pool.Explain(idHash(1))
+	assert.False(found)
+	assert.Equal(txpoolcfg.ReplacedByHigherTip, reason)
+
+	_, _, reason, found = pool.Explain(idHash(5))
+	assert.False(found)
+	assert.Equal(txpoolcfg.NotSet, reason)
+}
+
 func TestReplaceWithHigherFee(t *testing.T) {
 	assert, require := assert.New(t), require.New(t)
 	ch := make(chan types.Announcements, 100)
@@ -172,7 +275,7 @@ func TestReplaceWithHigherFee(t *testing.T) {
 
 	cfg := txpoolcfg.DefaultConfig
 	sendersCache := kvcache.New(kvcache.DefaultCoherentConfig)
-	pool, err := New(ch, coreDB, cfg, sendersCache, *u256.N1, nil, nil, nil, fixedgas.DefaultMaxBlobsPerBlock, log.New())
+	pool, err := New(ch, coreDB, cfg, sendersCache, *u256.N1, nil, nil, nil, nil, fixedgas.DefaultMaxBlobsPerBlock, log.New())
 	assert.NoError(err)
 	require.NotEqual(nil, pool)
 	ctx := context.Background()
@@ -289,7 +392,7 @@ func TestReverseNonces(t *testing.T) {
 
 	cfg := txpoolcfg.DefaultConfig
 	sendersCache := kvcache.New(kvcache.DefaultCoherentConfig)
-	pool, err := New(ch, coreDB, cfg, sendersCache, *u256.N1, nil, nil, nil, fixedgas.DefaultMaxBlobsPerBlock, log.New())
+	pool, err := New(ch, coreDB, cfg, sendersCache, *u256.N1, nil, nil, nil, nil, fixedgas.DefaultMaxBlobsPerBlock, log.New())
 	assert.NoError(err)
 	require.True(pool != nil)
 	ctx := context.Background()
@@ -416,7 +519,7 @@ func TestTxPoke(t *testing.T) {
 
 	cfg := txpoolcfg.DefaultConfig
 	sendersCache := kvcache.New(kvcache.DefaultCoherentConfig)
-	pool, err := New(ch, coreDB, cfg, sendersCache, *u256.N1, nil, nil, nil, fixedgas.DefaultMaxBlobsPerBlock, log.New())
+	pool, err := New(ch, coreDB, cfg, sendersCache, *u256.N1, nil, nil, nil, nil, fixedgas.DefaultMaxBlobsPerBlock, log.New())
 	assert.NoError(err)
 	require.True(pool != nil)
 	ctx := context.Background()
@@ -627,7 +730,7 @@ func TestShanghaiIntrinsicGas(t *testing.T) {
 
 	for name, c := range cases {
 		t.Run(name, func(t *testing.T) {
-			gas, reason := txpoolcfg.CalcIntrinsicGas(c.dataLen, c.dataNonZeroLen, nil, c.creation, true, true, c.isShanghai)
+			gas, reason := txpoolcfg.CalcIntrinsicGas(c.dataLen, c.dataNonZeroLen, 0, nil, c.creation, true, true, c.isShanghai)
 			if reason != txpoolcfg.Success {
 				t.Errorf("expected success but got reason %v", reason)
 			}
@@ -681,7 +784,7 @@ func TestShanghaiValidateTx(t *testing.T) {
 			}
 
 			cache := &kvcache.DummyCache{}
-			pool, err := New(ch, coreDB, cfg, cache, *u256.N1, shanghaiTime, nil /* agraBlock */, nil /* cancunTime */, fixedgas.DefaultMaxBlobsPerBlock, logger)
+			pool, err := New(ch, coreDB, cfg, cache, *u256.N1, shanghaiTime, nil /* agraBlock */, nil /* cancunTime */, nil /* pragueTime */, fixedgas.DefaultMaxBlobsPerBlock, logger)
 			asrt.NoError(err)
 			ctx := context.Background()
 			tx, err := coreDB.BeginRw(ctx)
@@ -711,7 +814,7 @@ func TestShanghaiValidateTx(t *testing.T) {
 			view, err := cache.View(ctx, tx)
 			asrt.NoError(err)
 
-			reason := pool.validateTx(txn, false, view)
+			reason := pool.validateTx(txn, false, tx, view)
 
 			if reason != test.expected {
 				t.Errorf("expected %v, got %v", test.expected, reason)
@@ -727,7 +830,7 @@ func TestBlobTxReplacement(t *testing.T) {
 	db, coreDB := memdb.NewTestPoolDB(t), memdb.NewTestDB(t)
 	cfg := txpoolcfg.DefaultConfig
 	sendersCache := kvcache.New(kvcache.DefaultCoherentConfig)
-	pool, err := New(ch, coreDB, cfg, sendersCache, *u256.N1, common.Big0, nil, common.Big0, fixedgas.DefaultMaxBlobsPerBlock, log.New())
+	pool, err := New(ch, coreDB, cfg, sendersCache, *u256.N1, common.Big0, nil, common.Big0, nil, fixedgas.DefaultMaxBlobsPerBlock, log.New())
 	assert.NoError(err)
 	require.True(pool != nil)
 	ctx := context.Background()
@@ -952,7 +1055,7 @@ func TestDropRemoteAtNoGossip(t *testing.T) {
 	logger := log.New()
 	sendersCache := kvcache.New(kvcache.DefaultCoherentConfig)
 
-	txPool, err := New(ch, coreDB, cfg, sendersCache, *u256.N1, big.NewInt(0), big.NewInt(0), nil, fixedgas.DefaultMaxBlobsPerBlock, logger)
+	txPool, err := New(ch, coreDB, cfg, sendersCache, *u256.N1, big.NewInt(0), big.NewInt(0), nil, nil, fixedgas.DefaultMaxBlobsPerBlock, logger)
 	assert.NoError(err)
 	require.True(txPool != nil)
 
@@ -1043,3 +1146,186 @@ func TestDropRemoteAtNoGossip(t *testing.T) {
 	// no announcement because unprocessedRemoteTxs is already empty
 	assert.True(checkAnnouncementEmpty())
 }
+
+func TestConditionalTxs(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	ch := make(chan types.Announcements, 100)
+	db, coreDB := memdb.NewTestPoolDB(t), memdb.NewTestDB(t)
+
+	cfg := txpoolcfg.DefaultConfig
+	sendersCache := kvcache.New(kvcache.DefaultCoherentConfig)
+	pool, err := New(ch, coreDB, cfg, sendersCache, *u256.N1, nil, nil, nil, nil, fixedgas.DefaultMaxBlobsPerBlock, log.New())
+	assert.NoError(err)
+	require.True(pool != nil)
+	ctx := context.Background()
+	pendingBaseFee := uint64(200000)
+	var addr, addr2, contract [20]byte
+	addr[0], addr2[0], contract[0] = 1, 3, 2
+	slot, value := common.Hash{1}, common.Hash{31: 7}
+	v := make([]byte, types.EncodeSenderLengthForStorage(2, *uint256.NewInt(1 * common.Ether)))
+	types.EncodeSender(2, *uint256.NewInt(1 * common.Ether), v)
+	contractChange := func(value []byte) *remote.AccountChange {
+		return &remote.AccountChange{
+			Action:      remote.Action_UPSERT,
+			Address:     gointerfaces.ConvertAddressToH160(contract),
+			Incarnation: 1,
+			Data:        []byte{4, 1, 1}, // incarnation 1
+			StorageChanges: []*remote.StorageChange{
+				{Location: gointerfaces.ConvertHashToH256(slot), Data: value},
+			},
+		}
+	}
+	change := &remote.StateChangeBatch{
+		PendingBlockBaseFee: pendingBaseFee,
+		BlockGasLimit:       1000000,
+		ChangeBatch: []*remote.StateChange{
+			{BlockHeight: 0, BlockHash: gointerfaces.ConvertHashToH256([32]byte{}), Changes: []*remote.AccountChange{
+				{Action: remote.Action_UPSERT, Address: gointerfaces.ConvertAddressToH160(addr), Data: v},
+				{Action: remote.Action_UPSERT, Address: gointerfaces.ConvertAddressToH160(addr2), Data: v},
+				contractChange([]byte{7}),
+			}},
+		},
+	}
+	tx, err := db.BeginRw(ctx)
+	require.NoError(err)
+	defer tx.Rollback()
+	err = pool.OnNewBlock(ctx, change, types.TxSlots{}, types.TxSlots{}, tx)
+	assert.NoError(err)
+
+	knownAccounts := func(value common.Hash) map[common.Address]map[common.Hash]common.Hash {
+		return map[common.Address]map[common.Hash]common.Hash{contract: {slot: value}}
+	}
+	var txSlots types.TxSlots
+	for i, conditions := range []*types.TxConditions{
+		{KnownAccounts: knownAccounts(value)},
+		{BlockNumberMax: 1},
+		{KnownAccounts: knownAccounts(common.Hash{31: 8})},
+		{BlockNumberMin: 5},
+	} {
+		sender := addr
+		if i == 1 {
+			sender = addr2
+		}
+		txSlot := &types.TxSlot{
+			Rlp:        []byte{byte(i + 1)},
+			Tip:        *uint256.NewInt(100000),
+			FeeCap:     *uint256.NewInt(300000),
+			Gas:        100000,
+			Nonce:      2,
+			Conditions: conditions,
+		}
+		txSlot.IDHash[0] = byte(i + 1)
+		txSlots.Append(txSlot, sender[:], true)
+	}
+	reasons, err := pool.AddLocalTxs(ctx, txSlots, tx)
+	assert.NoError(err)
+	assert.Equal(txpoolcfg.Success, reasons[0], reasons[0].String())
+	assert.Equal(txpoolcfg.Success, reasons[1], reasons[1].String())
+	assert.Equal(txpoolcfg.ConditionsNotMet, reasons[2], reasons[2].String())
+	assert.Equal(txpoolcfg.ConditionsNotMet, reasons[3], reasons[3].String())
+
+	// storage roots can't be checked without a reader
+	rootTx := func(idHash byte) types.TxSlots {
+		txSlot := &types.TxSlot{
+			Rlp:        []byte{idHash},
+			Tip:        *uint256.NewInt(100000),
+			FeeCap:     *uint256.NewInt(300000),
+			Gas:        100000,
+			Nonce:      3,
+			Conditions: &types.TxConditions{StorageRoots: map[common.Address]common.Hash{contract: {9}}},
+		}
+		txSlot.IDHash[0] = idHash
+		var slots types.TxSlots
+		slots.Append(txSlot, addr2[:], true)
+		return slots
+	}
+	reasons, err = pool.AddLocalTxs(ctx, rootTx(5), tx)
+	assert.NoError(err)
+	assert.Equal(txpoolcfg.ConditionsNotMet, reasons[0], reasons[0].String())
+	rootReads := 0
+	pool.SetStorageRootReader(func(tx kv.Tx, addr common.Address) (common.Hash, error) {
+		rootReads++
+		return common.Hash{9}, nil
+	})
+	rootTxSlots := rootTx(6)
+	reasons, err = pool.AddLocalTxs(ctx, rootTxSlots, tx)
+	assert.NoError(err)
+	assert.Equal(txpoolcfg.Success, reasons[0], reasons[0].String())
+	assert.Equal(1, rootReads)
+
+	// conditional transactions are neither announced nor propagated
+	_, _, hashes := pool.AppendAllAnnouncements(nil, nil, nil)
+	assert.Empty(hashes)
+	assert.True(pool.IsConditional(txSlots.Txs[0].IDHash[:]))
+	assert.True(pool.IsConditional(rootTxSlots.Txs[0].IDHash[:]))
+	assert.Len(pool.conditionalTxs, 3)
+
+	best := func(onTopOf uint64) int {
+		var txs types.TxsRlp
+		_, count, err := pool.YieldBest(10, &txs, tx, onTopOf, 1000000, 0, mapset.NewThreadUnsafeSet[[32]byte]())
+		require.NoError(err)
+		return count
+	}
+	assert.Equal(3, best(0))
+
+	// the known account changes in the next block, so its transaction is kept but not yielded,
+	// and the block number bound of the other one can't hold anymore, so it's discarded
+	coreTx, err := coreDB.BeginRw(ctx)
+	require.NoError(err)
+	defer coreTx.Rollback()
+	require.NoError(coreTx.Put(kv.Sequence, kv.PlainStateVersion, []byte{0, 0, 0, 0, 0, 0, 0, 1}))
+	require.NoError(coreTx.Commit())
+	change = &remote.StateChangeBatch{
+		StateVersionId:      1,
+		PendingBlockBaseFee: pendingBaseFee,
+		BlockGasLimit:       1000000,
+		ChangeBatch: []*remote.StateChange{
+			{BlockHeight: 1, BlockHash: gointerfaces.ConvertHashToH256([32]byte{1}), Changes: []*remote.AccountChange{contractChange([]byte{8})}},
+		},
+	}
+	err = pool.OnNewBlock(ctx, change, types.TxSlots{}, types.TxSlots{}, tx)
+	assert.NoError(err)
+	assert.Equal(0, best(1))
+	_, _, _, found := pool.Explain(txSlots.Txs[0].IDHash[:])
+	assert.True(found)
+	_, _, reason, found := pool.Explain(txSlots.Txs[1].IDHash[:])
+	assert.False(found)
+	assert.Equal(txpoolcfg.ConditionsNotMet, reason)
+	assert.False(pool.IsConditional(txSlots.Txs[1].IDHash[:]))
+	assert.Equal(2, rootReads)
+
+	// known accounts aren't re-checked if the block doesn't change them
+	coreTx, err = coreDB.BeginRw(ctx)
+	require.NoError(err)
+	defer coreTx.Rollback()
+	require.NoError(coreTx.Put(kv.Sequence, kv.PlainStateVersion, []byte{0, 0, 0, 0, 0, 0, 0, 2}))
+	require.NoError(coreTx.Commit())
+	change = &remote.StateChangeBatch{
+		StateVersionId:      2,
+		PendingBlockBaseFee: pendingBaseFee,
+		BlockGasLimit:       1000000,
+		ChangeBatch: []*remote.StateChange{
+			{BlockHeight: 2, BlockHash: gointerfaces.ConvertHashToH256([32]byte{2}), Changes: []*remote.AccountChange{
+				{Action: remote.Action_UPSERT, Address: gointerfaces.ConvertAddressToH160(addr), Data: v},
+			}},
+		},
+	}
+	err = pool.OnNewBlock(ctx, change, types.TxSlots{}, types.TxSlots{}, tx)
+	assert.NoError(err)
+	assert.Equal(2, rootReads)
+
+	// conditions aren't persisted, so conditional transactions stay in memory only and are dropped on restart
+	require.NoError(pool.flushLocked(tx))
+	for _, txn := range []*types.TxSlot{txSlots.Txs[0], rootTxSlots.Txs[0]} {
+		has, err := tx.Has(kv.PoolTransaction, txn.IDHash[:])
+		require.NoError(err)
+		assert.False(has)
+		assert.NotNil(pool.byHash[string(txn.IDHash[:])].Tx.Rlp)
+	}
+	p2, err := New(ch, coreDB, cfg, sendersCache, *u256.N1, nil, nil, nil, nil, fixedgas.DefaultMaxBlobsPerBlock, log.New())
+	require.NoError(err)
+	p2.senders = pool.senders // senders are not persisted
+	require.NoError(coreDB.View(ctx, func(coreTx kv.Tx) error { return p2.fromDB(ctx, tx, coreTx) }))
+	assert.Empty(p2.byHash)
+	assert.Empty(p2.conditionalTxs)
+}
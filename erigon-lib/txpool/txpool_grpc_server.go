@@ -59,6 +59,7 @@ type txPool interface {
 	CountContent() (int, int, int)
 	IdHashKnown(tx kv.Tx, hash []byte) (bool, error)
 	NonceFromAddress(addr [20]byte) (nonce uint64, inPool bool)
+	Explain(idHash []byte) (subPool SubPoolType, marker SubPoolMarker, reason txpoolcfg.DiscardReason, found bool)
 }
 
 var _ txpool_proto.TxpoolServer = (*GrpcServer)(nil)   // compile-time interface check
@@ -97,6 +98,9 @@ func (*GrpcDisabled) Status(ctx context.Context, request *txpool_proto.StatusReq
 func (*GrpcDisabled) Nonce(ctx context.Context, request *txpool_proto.NonceRequest) (*txpool_proto.NonceReply, error) {
 	return nil, ErrPoolDisabled
 }
+func (*GrpcDisabled) Explain(ctx context.Context, request *txpool_proto.ExplainRequest) (*txpool_proto.ExplainReply, error) {
+	return nil, ErrPoolDisabled
+}
 
 type GrpcServer struct {
 	txpool_proto.UnimplementedTxpoolServer
@@ -210,6 +214,9 @@ func (s *GrpcServer) Add(ctx context.Context, in *txpool_proto.AddRequest) (*txp
 			}
 			continue
 		}
+		if i < len(in.Conditions) {
+			slots.Txs[j].Conditions = txConditionsFromProto(in.Conditions[i])
+		}
 		j++
 	}
 
@@ -232,6 +239,39 @@ func (s *GrpcServer) Add(ctx context.Context, in *txpool_proto.AddRequest) (*txp
 	return reply, nil
 }
 
+// txConditionsFromProto returns nil for empty conditions, so that the transaction is treated as an ordinary one
+func txConditionsFromProto(in *txpool_proto.TxConditions) *types.TxConditions {
+	if in == nil || (len(in.KnownAccounts) == 0 && in.BlockNumberMin == 0 && in.BlockNumberMax == 0 && in.TimestampMin == 0 && in.TimestampMax == 0) {
+		return nil
+	}
+	conditions := &types.TxConditions{
+		KnownAccounts:  make(map[common.Address]map[common.Hash]common.Hash, len(in.KnownAccounts)),
+		BlockNumberMin: in.BlockNumberMin,
+		BlockNumberMax: in.BlockNumberMax,
+		TimestampMin:   in.TimestampMin,
+		TimestampMax:   in.TimestampMax,
+	}
+	for _, account := range in.KnownAccounts {
+		addr := gointerfaces.ConvertH160toAddress(account.Address)
+		if account.StorageRoot != nil {
+			if conditions.StorageRoots == nil {
+				conditions.StorageRoots = map[common.Address]common.Hash{}
+			}
+			conditions.StorageRoots[addr] = gointerfaces.ConvertH256ToHash(account.StorageRoot)
+			continue
+		}
+		slots, ok := conditions.KnownAccounts[addr]
+		if !ok {
+			slots = make(map[common.Hash]common.Hash, len(account.Slots))
+			conditions.KnownAccounts[addr] = slots
+		}
+		for _, slot := range account.Slots {
+			slots[gointerfaces.ConvertH256ToHash(slot.Key)] = gointerfaces.ConvertH256ToHash(slot.Value)
+		}
+	}
+	return conditions
+}
+
 func mapDiscardReasonToProto(reason txpoolcfg.DiscardReason) txpool_proto.ImportResult {
 	switch reason {
 	case txpoolcfg.Success:
@@ -240,7 +280,7 @@ func mapDiscardReasonToProto(reason txpoolcfg.DiscardReason) txpool_proto.Import
 		return txpool_proto.ImportResult_ALREADY_EXISTS
 	case txpoolcfg.UnderPriced, txpoolcfg.ReplaceUnderpriced, txpoolcfg.FeeTooLow:
 		return txpool_proto.ImportResult_FEE_TOO_LOW
-	case txpoolcfg.InvalidSender, txpoolcfg.NegativeValue, txpoolcfg.OversizedData, txpoolcfg.InitCodeTooLarge, txpoolcfg.RLPTooLong, txpoolcfg.CreateBlobTxn, txpoolcfg.NoBlobs, txpoolcfg.TooManyBlobs, txpoolcfg.TypeNotActivated, txpoolcfg.UnequalBlobTxExt, txpoolcfg.BlobHashCheckFail, txpoolcfg.UnmatchedBlobTxExt:
+	case txpoolcfg.InvalidSender, txpoolcfg.NegativeValue, txpoolcfg.OversizedData, txpoolcfg.InitCodeTooLarge, txpoolcfg.RLPTooLong, txpoolcfg.CreateBlobTxn, txpoolcfg.NoBlobs, txpoolcfg.TooManyBlobs, txpoolcfg.TypeNotActivated, txpoolcfg.UnequalBlobTxExt, txpoolcfg.BlobHashCheckFail, txpoolcfg.UnmatchedBlobTxExt, txpoolcfg.ConditionsNotMet:
 		// TODO(eip-4844) TypeNotActivated may be transient (e.g. a blob transaction is submitted 1 sec prior to Cancun activation)
 		return txpool_proto.ImportResult_INVALID
 	default:
@@ -304,6 +344,26 @@ func (s *GrpcServer) Nonce(ctx context.Context, in *txpool_proto.NonceRequest) (
 	}, nil
 }
 
+// Explain returns the sub-pool of a transaction and the marker bits it misses to be pending,
+// or the reason the transaction was discarded from the pool
+func (s *GrpcServer) Explain(_ context.Context, in *txpool_proto.ExplainRequest) (*txpool_proto.ExplainReply, error) {
+	hash := gointerfaces.ConvertH256ToHash(in.Hash)
+	subPool, marker, reason, found := s.txPool.Explain(hash[:])
+	if !found {
+		reply := &txpool_proto.ExplainReply{}
+		if reason != txpoolcfg.NotSet {
+			reply.DiscardReason = reason.String()
+		}
+		return reply, nil
+	}
+	return &txpool_proto.ExplainReply{
+		Found:          true,
+		TxnType:        convertSubPoolType(subPool),
+		SubPoolMarker:  uint32(marker),
+		MissingMarkers: marker.Missing(),
+	}, nil
+}
+
 // NewSlotsStreams - it's safe to use this class as non-pointer
 type NewSlotsStreams struct {
 	chans map[uint]txpool_proto.Txpool_OnAddServer
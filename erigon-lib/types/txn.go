@@ -108,6 +108,45 @@ type TxSlot struct {
 	Blobs       [][]byte
 	Commitments []gokzg4844.KZGCommitment
 	Proofs      []gokzg4844.KZGProof
+
+	// EIP-7702: Set EOA account code
+	AuthorizationLen int // Number of tuples in the authorization list (for calculation of intrinsic gas)
+
+	// Preconditions of eth_sendRawTransactionConditional, nil for ordinary transactions
+	Conditions *TxConditions
+}
+
+// TxConditions are the preconditions a transaction is submitted with, it may only be included into
+// a block while all of them hold. Zero block number and timestamp bounds are unset.
+type TxConditions struct {
+	KnownAccounts  map[common.Address]map[common.Hash]common.Hash // address => (storage slot => expected value)
+	StorageRoots   map[common.Address]common.Hash                 // address => expected storage root
+	BlockNumberMin uint64
+	BlockNumberMax uint64
+	TimestampMin   uint64
+	TimestampMax   uint64
+}
+
+// InBounds checks the block number and timestamp bounds against a block
+func (c *TxConditions) InBounds(blockNum, timestamp uint64) bool {
+	if c.Expired(blockNum, timestamp) {
+		return false
+	}
+	return blockNum >= c.BlockNumberMin && timestamp >= c.TimestampMin
+}
+
+// HasAccount tells if the conditions depend on the storage of the account
+func (c *TxConditions) HasAccount(addr common.Address) bool {
+	if _, ok := c.KnownAccounts[addr]; ok {
+		return true
+	}
+	_, ok := c.StorageRoots[addr]
+	return ok
+}
+
+// Expired means that neither the block nor any block after it can satisfy the upper bounds
+func (c *TxConditions) Expired(blockNum, timestamp uint64) bool {
+	return (c.BlockNumberMax != 0 && blockNum > c.BlockNumberMax) || (c.TimestampMax != 0 && timestamp > c.TimestampMax)
 }
 
 const (
@@ -115,6 +154,7 @@ const (
 	AccessListTxType byte = 1 // EIP-2930
 	DynamicFeeTxType byte = 2 // EIP-1559
 	BlobTxType       byte = 3 // EIP-4844
+	SetCodeTxType    byte = 4 // EIP-7702
 )
 
 var ErrParseTxn = fmt.Errorf("%w transaction", rlp.ErrParse)
@@ -184,7 +224,7 @@ func (ctx *TxParseContext) ParseTransaction(payload []byte, pos int, slot *TxSlo
 	// If it is non-legacy transaction, the transaction type follows, and then the the list
 	if !legacy {
 		slot.Type = payload[p]
-		if slot.Type > BlobTxType {
+		if slot.Type > SetCodeTxType {
 			return 0, fmt.Errorf("%w: unknown transaction type: %d", ErrParseTxn, slot.Type)
 		}
 		p++
@@ -465,6 +505,55 @@ func (ctx *TxParseContext) parseTransactionBody(payload []byte, pos, p0 int, slo
 		}
 		p = dataPos + dataLen
 	}
+	if slot.Type == SetCodeTxType {
+		dataPos, dataLen, err = rlp.List(payload, p)
+		if err != nil {
+			return 0, fmt.Errorf("%w: authorization list len: %s", ErrParseTxn, err) //nolint
+		}
+		var authChainID uint256.Int
+		authPos := dataPos
+		for authPos < dataPos+dataLen {
+			var authLen int
+			authPos, authLen, err = rlp.List(payload, authPos)
+			if err != nil {
+				return 0, fmt.Errorf("%w: authorization len: %s", ErrParseTxn, err) //nolint
+			}
+			var fieldPos int
+			fieldPos, err = rlp.U256(payload, authPos, &authChainID)
+			if err != nil {
+				return 0, fmt.Errorf("%w: authorization chainId: %s", ErrParseTxn, err) //nolint
+			}
+			fieldPos, err = rlp.StringOfLen(payload, fieldPos, 20)
+			if err != nil {
+				return 0, fmt.Errorf("%w: authorization address len: %s", ErrParseTxn, err) //nolint
+			}
+			fieldPos, _, err = rlp.U64(payload, fieldPos+20)
+			if err != nil {
+				return 0, fmt.Errorf("%w: authorization nonce: %s", ErrParseTxn, err) //nolint
+			}
+			fieldPos, _, err = rlp.U64(payload, fieldPos)
+			if err != nil {
+				return 0, fmt.Errorf("%w: authorization yParity: %s", ErrParseTxn, err) //nolint
+			}
+			fieldPos, err = rlp.U256(payload, fieldPos, &ctx.R)
+			if err != nil {
+				return 0, fmt.Errorf("%w: authorization R: %s", ErrParseTxn, err) //nolint
+			}
+			fieldPos, err = rlp.U256(payload, fieldPos, &ctx.S)
+			if err != nil {
+				return 0, fmt.Errorf("%w: authorization S: %s", ErrParseTxn, err) //nolint
+			}
+			if fieldPos != authPos+authLen {
+				return 0, fmt.Errorf("%w: extraneous space in the authorization", ErrParseTxn)
+			}
+			slot.AuthorizationLen++
+			authPos += authLen
+		}
+		if authPos != dataPos+dataLen {
+			return 0, fmt.Errorf("%w: extraneous space in the authorization list after all tuples", ErrParseTxn)
+		}
+		p = dataPos + dataLen
+	}
 	// This is where the data for Sighash ends
 	// Next follows V of the signature
 	var vByte byte
@@ -951,6 +1040,42 @@ func DecodeSender(enc []byte) (nonce uint64, balance uint256.Int, err error) {
 	return
 }
 
+// Decode the account's incarnation from encoded byte-slice, it's needed to build the plain storage keys of the account
+func DecodeIncarnation(enc []byte) (incarnation uint64, err error) {
+	if len(enc) == 0 {
+		return
+	}
+
+	var fieldSet = enc[0]
+	var pos = 1
+
+	// skip nonce and balance
+	for _, field := range []byte{1, 2} {
+		if fieldSet&field > 0 {
+			if len(enc) <= pos {
+				return 0, fmt.Errorf("malformed CBOR for Account: %x", enc)
+			}
+			pos += int(enc[pos]) + 1
+		}
+	}
+
+	if fieldSet&4 > 0 {
+		if len(enc) <= pos {
+			return 0, fmt.Errorf("malformed CBOR for Account: %x", enc)
+		}
+		decodeLength := int(enc[pos])
+
+		if len(enc) < pos+decodeLength+1 {
+			return 0, fmt.Errorf(
+				"malformed CBOR for Account.Incarnation: %s, Length %d",
+				enc[pos+1:], decodeLength)
+		}
+
+		incarnation = bytesToUint64(enc[pos+1 : pos+decodeLength+1])
+	}
+	return
+}
+
 func bytesToUint64(buf []byte) (x uint64) {
 	for i, b := range buf {
 		x = x<<8 + uint64(b)
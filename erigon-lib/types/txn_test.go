@@ -292,3 +292,39 @@ func TestBlobTxParsing(t *testing.T) {
 	assert.Equal(t, proof0, fatTx.Proofs[0])
 	assert.Equal(t, proof1, fatTx.Proofs[1])
 }
+
+func TestSetCodeTxParsing(t *testing.T) {
+	// two authorizations: (chainId=5, nonce=3) and (chainId=0, nonce=0)
+	bodyRlpHex := "f9012705078502540be4008506fc23ac00830186a094811a752c8cd697e3cb27279c330ed1ada745a8d7" +
+		"8080c0f8b8f85a0594de0b295669a9fd93d5f28d9ec85e40f4cb697bae0301a036b241b061a36a32ab7fe86c7aa9eb5" +
+		"92dd59018cd0443adc0903590c16b02b0a05edcc541b4741c5cc6dd347c5ed9577ef293a62787b4510465fadbfe39ee" +
+		"4094f85a8094bb9bc244d798123fde783fcc1c72d3bb8c1894138080a036b241b061a36a32ab7fe86c7aa9eb592dd59" +
+		"018cd0443adc0903590c16b02b0a05edcc541b4741c5cc6dd347c5ed9577ef293a62787b4510465fadbfe39ee409401" +
+		"a036b241b061a36a32ab7fe86c7aa9eb592dd59018cd0443adc0903590c16b02b0a05edcc541b4741c5cc6dd347c5ed" +
+		"9577ef293a62787b4510465fadbfe39ee4094"
+	bodyRlp := hexutility.MustDecodeHex(bodyRlpHex)
+
+	bodyEnvelopePrefix := hexutility.MustDecodeHex("b9012b")
+	var bodyEnvelope []byte
+	bodyEnvelope = append(bodyEnvelope, bodyEnvelopePrefix...)
+	bodyEnvelope = append(bodyEnvelope, SetCodeTxType)
+	bodyEnvelope = append(bodyEnvelope, bodyRlp...)
+
+	ctx := NewTxParseContext(*uint256.NewInt(5))
+	ctx.withSender = false
+
+	var tx TxSlot
+	p, err := ctx.ParseTransaction(bodyEnvelope, 0, &tx, nil, true /* hasEnvelope */, false /* wrappedWithBlobs */, nil)
+	require.NoError(t, err)
+	assert.Equal(t, len(bodyEnvelope), p)
+	assert.Equal(t, SetCodeTxType, tx.Type)
+	assert.Equal(t, 2, tx.AuthorizationLen)
+	assert.Equal(t, uint64(7), tx.Nonce)
+	assert.False(t, tx.Creation)
+
+	// authorization list which length doesn't match its tuples must fail
+	broken := append([]byte{}, bodyEnvelope...)
+	broken[3+1+46]-- // length byte of authorization list prefix (f8b8)
+	_, err = ctx.ParseTransaction(broken, 0, &tx, nil, true, false, nil)
+	require.Error(t, err)
+}
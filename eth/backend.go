@@ -21,6 +21,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"io/fs"
 	"math/big"
 	"net"
@@ -62,6 +63,7 @@ import (
 	"github.com/ledgerwatch/erigon/turbo/silkworm"
 	"github.com/ledgerwatch/erigon/turbo/snapshotsync/freezeblocks"
 	"github.com/ledgerwatch/erigon/turbo/snapshotsync/snap"
+	"github.com/ledgerwatch/erigon/turbo/trie"
 
 	"github.com/holiman/uint256"
 	"github.com/ledgerwatch/log/v3"
@@ -343,6 +345,14 @@ func New(ctx context.Context, stack *node.Node, config *ethconfig.Config, logger
 	}
 
 	kvRPC := remotedbserver.NewKvServer(ctx, backend.chainDB, allSnapshots, agg, logger)
+	if aclPath := stack.Config().PrivateApiACL; aclPath != "" {
+		acl, err := remotedbserver.LoadACL(aclPath)
+		if err != nil {
+			return nil, fmt.Errorf("private api acl: %w", err)
+		}
+		kvRPC.SetACL(acl)
+		logger.Info("Remote KV access restricted by ACL", "file", aclPath, "clients", len(acl.Clients))
+	}
 	backend.notifications.StateChangesConsumer = kvRPC
 	backend.kvRPC = kvRPC
 
@@ -610,6 +620,10 @@ func New(ctx context.Context, stack *node.Node, config *ethconfig.Config, logger
 		if err != nil {
 			return nil, err
 		}
+		if !config.HistoryV3 {
+			// storage roots of conditional transactions are computed from intermediate hashes, which Erigon3 doesn't have
+			backend.txPool.SetStorageRootReader(trie.StorageRoot)
+		}
 	}
 
 	backend.notifyMiningAboutNewTxs = make(chan struct{}, 1)
@@ -1309,6 +1323,81 @@ func (s *Ethereum) AddPeer(ctx context.Context, req *remote.AddPeerRequest) (*re
 	return &remote.AddPeerReply{Success: true}, nil
 }
 
+func (s *Ethereum) RemovePeer(ctx context.Context, req *remote.RemovePeerRequest) (*remote.RemovePeerReply, error) {
+	for _, sentryClient := range s.sentriesClient.Sentries() {
+		_, err := sentryClient.RemovePeer(ctx, &proto_sentry.RemovePeerRequest{Url: req.Url})
+		if err != nil {
+			return nil, fmt.Errorf("ethereum backend MultiClient.RemovePeer error: %w", err)
+		}
+	}
+	return &remote.RemovePeerReply{Success: true}, nil
+}
+
+func (s *Ethereum) AddTrustedPeer(ctx context.Context, req *remote.AddPeerRequest) (*remote.AddPeerReply, error) {
+	for _, sentryClient := range s.sentriesClient.Sentries() {
+		_, err := sentryClient.AddTrustedPeer(ctx, &proto_sentry.AddPeerRequest{Url: req.Url})
+		if err != nil {
+			return nil, fmt.Errorf("ethereum backend MultiClient.AddTrustedPeer error: %w", err)
+		}
+	}
+	return &remote.AddPeerReply{Success: true}, nil
+}
+
+func (s *Ethereum) RemoveTrustedPeer(ctx context.Context, req *remote.RemovePeerRequest) (*remote.RemovePeerReply, error) {
+	for _, sentryClient := range s.sentriesClient.Sentries() {
+		_, err := sentryClient.RemoveTrustedPeer(ctx, &proto_sentry.RemovePeerRequest{Url: req.Url})
+		if err != nil {
+			return nil, fmt.Errorf("ethereum backend MultiClient.RemoveTrustedPeer error: %w", err)
+		}
+	}
+	return &remote.RemovePeerReply{Success: true}, nil
+}
+
+// PeerEvents merges the peer events of all sentries into the returned channel. The channel
+// is closed when ctx is cancelled or all the sentry streams have ended.
+func (s *Ethereum) PeerEvents(ctx context.Context) (<-chan *remote.PeerEvent, error) {
+	// streams are closed by cancellation of their context: when opening of one of them fails, or all of them are done
+	ctx, cancel := context.WithCancel(ctx)
+	var streams []proto_sentry.Sentry_PeerEventsClient
+	for _, sentryClient := range s.sentriesClient.Sentries() {
+		stream, err := sentryClient.PeerEvents(ctx, &proto_sentry.PeerEventsRequest{})
+		if err != nil {
+			cancel()
+			return nil, fmt.Errorf("ethereum backend MultiClient.PeerEvents error: %w", err)
+		}
+		streams = append(streams, stream)
+	}
+
+	ch := make(chan *remote.PeerEvent, 16)
+	var wg sync.WaitGroup
+	for _, stream := range streams {
+		wg.Add(1)
+		go func(stream proto_sentry.Sentry_PeerEventsClient) {
+			defer wg.Done()
+			for {
+				event, err := stream.Recv()
+				if err != nil {
+					if !errors.Is(err, io.EOF) && ctx.Err() == nil {
+						s.logger.Debug("[PeerEvents] sentry stream closed", "err", err)
+					}
+					return
+				}
+				select {
+				case ch <- &remote.PeerEvent{PeerId: event.PeerId, EventId: remote.PeerEvent_PeerEventId(event.EventId)}:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}(stream)
+	}
+	go func() {
+		wg.Wait()
+		cancel()
+		close(ch)
+	}()
+	return ch, nil
+}
+
 // Protocols returns all the currently configured
 // network protocols to start.
 func (s *Ethereum) Protocols() []p2p.Protocol {
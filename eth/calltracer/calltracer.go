@@ -60,6 +60,21 @@ func (ct *CallTracer) CaptureEnd(output []byte, usedGas uint64, err error) {
 func (ct *CallTracer) CaptureExit(output []byte, usedGas uint64, err error) {
 }
 
+// NewTxTracer and MergeTxTracer make CallTracer a core.ParallelTracer
+func (ct *CallTracer) NewTxTracer() vm.EVMLogger {
+	return NewCallTracer()
+}
+
+func (ct *CallTracer) MergeTxTracer(tracer vm.EVMLogger) {
+	txTracer := tracer.(*CallTracer)
+	for addr := range txTracer.froms {
+		ct.froms[addr] = struct{}{}
+	}
+	for addr, created := range txTracer.tos {
+		ct.tos[addr] = ct.tos[addr] || created
+	}
+}
+
 func (ct *CallTracer) WriteToDb(tx kv.StatelessWriteTx, block *types.Block, vmConfig vm.Config) error {
 	ct.tos[block.Coinbase()] = false
 	for _, uncle := range block.Uncles() {
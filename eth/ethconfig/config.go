@@ -268,6 +268,9 @@ type Sync struct {
 	LoopThrottle     time.Duration
 	ExecWorkerCount  int
 	ReconWorkerCount int
+	// ParallelExecWorkers - number of workers, which execute transactions of a block in parallel in the Erigon2
+	// execution stage, if it isn't run in external tx, 0 - transactions are executed sequentially
+	ParallelExecWorkers int
 
 	BodyCacheLimit             datasize.ByteSize
 	BodyDownloadTimeoutSeconds int // TODO: change to duration
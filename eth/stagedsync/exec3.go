@@ -572,6 +572,7 @@ Loop:
 		}
 
 		rules := chainConfig.Rules(blockNum, b.Time())
+		blockReceipts := make(types.Receipts, len(txs))
 		var gasUsed uint64
 		for txIndex := -1; txIndex <= len(txs); txIndex++ {
 
@@ -591,6 +592,7 @@ Loop:
 				GetHashFn:       getHashFn,
 				EvmBlockContext: blockContext,
 				Withdrawals:     b.Withdrawals(),
+				BlockReceipts:   blockReceipts,
 			}
 			if txIndex >= 0 && txIndex < len(txs) {
 				txTask.Tx = txs[txIndex]
@@ -616,12 +618,15 @@ Loop:
 					if ok := rs.RegisterSender(txTask); ok {
 						rs.AddWork(ctx, txTask, in)
 					}
+				} else if txTask.Final {
+					rs.AddFinalWork(ctx, txTask, in)
 				} else {
 					rs.AddWork(ctx, txTask, in)
 				}
 			} else {
 				count++
 				applyWorker.RunTxTask(txTask)
+				txTask.CollectReceipt()
 				if err := func() error {
 					if txTask.Final {
 						gasUsed += txTask.UsedGas
@@ -787,6 +792,7 @@ func processResultQueue(in *exec22.QueueWithRetry, rws *exec22.ResultsQueue, out
 		if err := rs.ApplyState(applyTx, txTask, agg); err != nil {
 			return outputTxNum, conflicts, triggers, processedBlockNum, false, fmt.Errorf("StateV3.Apply: %w", err)
 		}
+		txTask.CollectReceipt()
 		triggers += rs.CommitTxNum(txTask.Sender, txTask.TxNum, in)
 		outputTxNum++
 		if backPressure != nil {
@@ -0,0 +1,269 @@
+package stagedsync
+
+import (
+	"context"
+	"encoding/binary"
+	"reflect"
+
+	libcommon "github.com/ledgerwatch/erigon-lib/common"
+	"github.com/ledgerwatch/erigon-lib/common/cmp"
+	"github.com/ledgerwatch/erigon-lib/kv"
+	"github.com/ledgerwatch/erigon-lib/kv/kvcfg"
+	"github.com/ledgerwatch/erigon-lib/kv/mdbx"
+
+	"github.com/ledgerwatch/erigon/eth/stagedsync/stages"
+	"github.com/ledgerwatch/erigon/ethdb/prune"
+)
+
+type prunedTableKind int
+
+const (
+	blockKeyed        prunedTableKind = iota // key starts with bigEndian(blockNum)
+	blockKeyedDupSort                        // same, but dupsort table: all values of key are deleted
+	bitmapIndex32                            // key + bigEndian(uint32 last block of chunk)
+	bitmapIndex64                            // key + bigEndian(uint64 last block of chunk)
+	txHashKeyed                              // key is hash of transaction, can't count without reading of block bodies
+)
+
+type prunedTable struct {
+	stage stages.SyncStage // which stage's PruneAt deletes data of table
+	table string
+	kind  prunedTableKind
+}
+
+// pruneRule - one of `--prune` letters: what it deletes (see stages Prune* functions) and which RPC methods need deleted data
+type pruneRule struct {
+	name   string
+	amount func(m prune.Mode) prune.BlockAmount
+	tables []prunedTable
+	rpc    []string
+}
+
+var pruneRules = []pruneRule{
+	{
+		name:   "h",
+		amount: func(m prune.Mode) prune.BlockAmount { return m.History },
+		tables: []prunedTable{
+			{stages.Execution, kv.AccountChangeSet, blockKeyedDupSort},
+			{stages.Execution, kv.StorageChangeSet, blockKeyedDupSort},
+			{stages.AccountHistoryIndex, kv.E2AccountsHistory, bitmapIndex64},
+			{stages.StorageHistoryIndex, kv.E2StorageHistory, bitmapIndex64},
+		},
+		rpc: []string{"eth_getBalance", "eth_getCode", "eth_getStorageAt", "eth_getTransactionCount", "eth_getProof", "eth_call",
+			"eth_estimateGas", "eth_createAccessList", "debug_traceTransaction", "debug_traceBlockByNumber", "debug_traceBlockByHash",
+			"debug_traceCall", "debug_storageRangeAt", "debug_accountRange", "debug_getModifiedAccountsByNumber", "trace_call",
+			"trace_callMany", "trace_replayTransaction", "trace_replayBlockTransactions", "trace_block", "trace_transaction",
+			"erigon_getBalanceChangesInBlock", "ots_getTransactionBySenderAndNonce"},
+	},
+	{
+		name:   "r",
+		amount: func(m prune.Mode) prune.BlockAmount { return m.Receipts },
+		tables: []prunedTable{
+			{stages.Execution, kv.Receipts, blockKeyed},
+			{stages.Execution, kv.BorReceipts, blockKeyed},
+			{stages.Execution, kv.Log, blockKeyed},
+			{stages.LogIndex, kv.LogTopicIndex, bitmapIndex32},
+			{stages.LogIndex, kv.LogAddressIndex, bitmapIndex32},
+		},
+		rpc: []string{"eth_getLogs", "eth_getFilterLogs", "erigon_getLogs", "erigon_getLatestLogs", "erigon_getLogsByHash"},
+	},
+	{
+		name:   "t",
+		amount: func(m prune.Mode) prune.BlockAmount { return m.TxIndex },
+		tables: []prunedTable{
+			{stages.Senders, kv.Senders, blockKeyed},
+			{stages.TxLookup, kv.TxLookup, txHashKeyed},
+		},
+		rpc: []string{"eth_getTransactionByHash", "eth_getRawTransactionByHash", "eth_getTransactionReceipt", "debug_traceTransaction",
+			"trace_transaction", "trace_replayTransaction", "trace_get", "ots_getTransactionError", "ots_traceTransaction"},
+	},
+	{
+		name:   "c",
+		amount: func(m prune.Mode) prune.BlockAmount { return m.CallTraces },
+		tables: []prunedTable{
+			{stages.Execution, kv.CallTraceSet, blockKeyedDupSort},
+			{stages.CallTraces, kv.CallFromIndex, bitmapIndex64},
+			{stages.CallTraces, kv.CallToIndex, bitmapIndex64},
+		},
+		rpc: []string{"trace_filter", "erigon_getLatestLogs", "ots_searchTransactionsBefore", "ots_searchTransactionsAfter",
+			"ots_getContractCreator", "ots_getTransactionBySenderAndNonce"},
+	},
+}
+
+// PrunePlanTable - what PruneAt of stage would delete from table
+type PrunePlanTable struct {
+	Rule    string
+	Stage   stages.SyncStage
+	Table   string
+	PruneTo uint64 // data of blocks < PruneTo is deleted
+	Entries uint64 // entries in table now
+	Delete  uint64 // entries to delete
+	Exact   bool   // false: Delete is estimation (upper bound for indices, proportional to blocks for tx lookup)
+	Reclaim uint64 // bytes: estimated by share of deleted entries, freed pages are re-used by db, but file doesn't shrink
+}
+
+// PruneCoverage - RPC methods which would not serve blocks < OldestBlock anymore (before: blocks < PrevOldestBlock)
+type PruneCoverage struct {
+	Rule            string
+	PrevOldestBlock uint64
+	OldestBlock     uint64
+	Methods         []string
+}
+
+type PrunePlan struct {
+	Current  prune.Mode
+	Proposed prune.Mode
+	// Refused - node will not start with Proposed mode (prune mode can't be changed after node creation),
+	// it must be set by `integration force_set_prune` first
+	Refused bool
+	// HistoryV3 - history, receipts and call traces are in Erigon3 domains, their tables are not pruned by "h", "r", "c" rules
+	HistoryV3 bool
+	Tables    []PrunePlanTable
+	Coverage  []PruneCoverage
+	Reclaim   uint64
+}
+
+// PlanPrune - dry-run of `proposed` prune mode: counts what stages PruneAt functions would delete on next cycle, doesn't modify db.
+// `tx` must be transaction of chaindata (not temporal db) - to estimate reclaimable bytes by table stats.
+func PlanPrune(ctx context.Context, tx kv.Tx, proposed prune.Mode) (*PrunePlan, error) {
+	current, err := prune.Get(tx)
+	if err != nil {
+		return nil, err
+	}
+	historyV3, err := kvcfg.HistoryV3.Enabled(tx)
+	if err != nil {
+		return nil, err
+	}
+	var tables []string
+	for _, rule := range pruneRules {
+		for _, t := range rule.tables {
+			tables = append(tables, t.table)
+		}
+	}
+	sizes, err := mdbx.NewSizeReport(tx, tables)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &PrunePlan{Current: current, Proposed: proposed, Refused: !reflect.DeepEqual(current, proposed), HistoryV3: historyV3}
+	for _, rule := range pruneRules {
+		amount := rule.amount(proposed)
+		if !amount.Enabled() {
+			continue
+		}
+		if historyV3 && rule.name != "t" {
+			continue
+		}
+		for _, t := range rule.tables {
+			head, err := stages.GetStageProgress(tx, t.stage)
+			if err != nil {
+				return nil, err
+			}
+			row := PrunePlanTable{Rule: rule.name, Stage: t.stage, Table: t.table, PruneTo: amount.PruneTo(head), Exact: true}
+			if size := sizes.Table(t.table); size != nil {
+				row.Entries = size.Entries
+			}
+			if row.Entries == 0 || row.PruneTo == 0 {
+				plan.Tables = append(plan.Tables, row)
+				continue
+			}
+			switch t.kind {
+			case blockKeyed, blockKeyedDupSort:
+				row.Delete, err = countBlockKeyed(ctx, tx, t.table, t.kind == blockKeyedDupSort, row.PruneTo)
+			case bitmapIndex32, bitmapIndex64:
+				row.Exact = false
+				row.Delete, err = countIndexChunks(ctx, tx, t.table, t.kind == bitmapIndex32, row.PruneTo)
+			case txHashKeyed:
+				row.Exact = false
+				var oldest uint64
+				if oldest, err = stages.GetStagePruneProgress(tx, t.stage); err == nil && head > oldest && row.PruneTo > oldest {
+					row.Delete = row.Entries * (cmp.Min(row.PruneTo, head) - oldest) / (head - oldest)
+				}
+			}
+			if err != nil {
+				return nil, err
+			}
+			if size := sizes.Table(t.table); size != nil {
+				row.Reclaim = uint64(float64(size.Size) * float64(row.Delete) / float64(row.Entries))
+			}
+			plan.Reclaim += row.Reclaim
+			plan.Tables = append(plan.Tables, row)
+		}
+
+		head, err := stages.GetStageProgress(tx, rule.tables[0].stage)
+		if err != nil {
+			return nil, err
+		}
+		cov := PruneCoverage{Rule: rule.name, OldestBlock: amount.PruneTo(head), Methods: rule.rpc}
+		if prev := rule.amount(current); prev.Enabled() {
+			cov.PrevOldestBlock = prev.PruneTo(head)
+		}
+		if cov.OldestBlock > cov.PrevOldestBlock {
+			plan.Coverage = append(plan.Coverage, cov)
+		}
+	}
+	return plan, nil
+}
+
+// countBlockKeyed - same walk as rawdb.PruneTable/PruneTableDupSort, but without deletes
+func countBlockKeyed(ctx context.Context, tx kv.Tx, table string, dupSort bool, pruneTo uint64) (uint64, error) {
+	c, err := tx.CursorDupSort(table)
+	if err != nil {
+		return 0, err
+	}
+	defer c.Close()
+	var count uint64
+	for k, _, err := c.First(); k != nil; k, _, err = c.NextNoDup() {
+		if err != nil {
+			return 0, err
+		}
+		if binary.BigEndian.Uint64(k) >= pruneTo {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return 0, libcommon.ErrStopped
+		default:
+		}
+		if !dupSort {
+			count++
+			continue
+		}
+		dups, err := c.CountDuplicates()
+		if err != nil {
+			return 0, err
+		}
+		count += dups
+	}
+	return count, nil
+}
+
+// countIndexChunks - chunks of bitmap index which have only blocks < pruneTo. Prune deletes them for keys found in
+// not pruned change sets (or logs, or call traces) - so it's upper bound.
+func countIndexChunks(ctx context.Context, tx kv.Tx, table string, suffix32 bool, pruneTo uint64) (uint64, error) {
+	c, err := tx.Cursor(table)
+	if err != nil {
+		return 0, err
+	}
+	defer c.Close()
+	var count, lastBlock uint64
+	for k, _, err := c.First(); k != nil; k, _, err = c.Next() {
+		if err != nil {
+			return 0, err
+		}
+		if suffix32 {
+			lastBlock = uint64(binary.BigEndian.Uint32(k[len(k)-4:]))
+		} else {
+			lastBlock = binary.BigEndian.Uint64(k[len(k)-8:])
+		}
+		if lastBlock < pruneTo {
+			count++
+		}
+		select {
+		case <-ctx.Done():
+			return 0, libcommon.ErrStopped
+		default:
+		}
+	}
+	return count, nil
+}
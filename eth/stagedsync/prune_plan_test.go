@@ -0,0 +1,51 @@
+package stagedsync
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ledgerwatch/erigon-lib/kv"
+	"github.com/ledgerwatch/erigon-lib/kv/memdb"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ledgerwatch/erigon/eth/stagedsync/stages"
+	"github.com/ledgerwatch/erigon/ethdb/prune"
+)
+
+func TestPlanPrune(t *testing.T) {
+	require := require.New(t)
+	_, tx := memdb.NewTestTx(t)
+
+	genTestCallTraceSet(t, tx, 30)
+	require.NoError(stages.SaveStageProgress(tx, stages.Execution, 30))
+
+	proposed := prune.DefaultMode
+	proposed.CallTraces = prune.Distance(10)
+	plan, err := PlanPrune(context.Background(), tx, proposed)
+	require.NoError(err)
+	require.True(plan.Refused)
+
+	var callTraces *PrunePlanTable
+	for i := range plan.Tables {
+		require.Equal("c", plan.Tables[i].Rule)
+		if plan.Tables[i].Table == kv.CallTraceSet {
+			callTraces = &plan.Tables[i]
+		}
+	}
+	require.NotNil(callTraces)
+	require.Equal(uint64(20), callTraces.PruneTo)
+	require.Equal(uint64(30), callTraces.Entries)
+	require.Equal(uint64(20), callTraces.Delete)
+	require.True(callTraces.Exact)
+
+	require.Len(plan.Coverage, 1)
+	require.Equal(uint64(0), plan.Coverage[0].PrevOldestBlock)
+	require.Equal(uint64(20), plan.Coverage[0].OldestBlock)
+
+	// nothing to delete: tables are unchanged, no RPC method loses history
+	plan, err = PlanPrune(context.Background(), tx, prune.DefaultMode)
+	require.NoError(err)
+	require.False(plan.Refused)
+	require.Empty(plan.Tables)
+	require.Empty(plan.Coverage)
+}
@@ -146,6 +146,7 @@ func executeBlock(
 	writeCallTraces bool,
 	initialCycle bool,
 	stateStream bool,
+	parallelWorkers *core.ParallelWorkers,
 	logger log.Logger,
 ) error {
 	blockNum := block.NumberU64()
@@ -173,7 +174,11 @@ func executeBlock(
 	var execRs *core.EphemeralExecResult
 	getHashFn := core.GetHashFn(block.Header(), getHeader)
 
-	execRs, err = core.ExecuteBlockEphemerally(cfg.chainConfig, &vmConfig, getHashFn, cfg.engine, block, stateReader, stateWriter, NewChainReaderImpl(cfg.chainConfig, tx, cfg.blockReader, logger), getTracer, logger)
+	if parallelWorkers != nil {
+		execRs, err = core.ExecuteBlockParallel(cfg.chainConfig, &vmConfig, getHashFn, cfg.engine, block, stateReader, stateWriter, NewChainReaderImpl(cfg.chainConfig, tx, cfg.blockReader, logger), getTracer, parallelWorkers, logger)
+	} else {
+		execRs, err = core.ExecuteBlockEphemerally(cfg.chainConfig, &vmConfig, getHashFn, cfg.engine, block, stateReader, stateWriter, NewChainReaderImpl(cfg.chainConfig, tx, cfg.blockReader, logger), getTracer, logger)
+	}
 	if err != nil {
 		return fmt.Errorf("%w: %v", consensus.ErrInvalidBlock, err)
 	}
@@ -413,7 +418,7 @@ func SpawnExecuteBlocksStage(s *StageState, u Unwinder, tx kv.RwTx, toBlock uint
 
 	var stoppedErr error
 
-	var batch kv.PendingMutations
+	var batch *membatch.Mapmutation
 	// state is stored through ethdb batches
 	batch = membatch.NewHashBatch(tx, quit, cfg.dirs.Tmp, logger)
 	// avoids stacking defers within the loop
@@ -421,6 +426,26 @@ func SpawnExecuteBlocksStage(s *StageState, u Unwinder, tx kv.RwTx, toBlock uint
 		batch.Close()
 	}()
 
+	var parallelWorkers *core.ParallelWorkers
+	// workers read state committed to db and the batch: state of external tx isn't visible to them
+	if cfg.syncCfg.ParallelExecWorkers > 1 && !useExternalTx {
+		parallelWorkers = core.NewParallelWorkers(cfg.syncCfg.ParallelExecWorkers, func() (*core.ParallelExecView, func(), error) {
+			roTx, err := cfg.db.BeginRo(ctx)
+			if err != nil {
+				return nil, nil, err
+			}
+			view := &core.ParallelExecView{
+				StateReader: state.NewPlainStateReader(batch.View(roTx)),
+				GetHeader: func(hash common.Hash, number uint64) *types.Header {
+					h, _ := cfg.blockReader.Header(ctx, roTx, hash, number)
+					return h
+				},
+			}
+			return view, roTx.Rollback, nil
+		})
+		defer parallelWorkers.Close()
+	}
+
 	var readAhead chan uint64
 	if initialCycle {
 		// snapshots are often stored on chaper drives. don't expect low-read-latency and manually read-ahead.
@@ -467,7 +492,7 @@ Loop:
 		if cfg.silkworm != nil && !isMemoryMutation {
 			blockNum, err = silkworm.ExecuteBlocks(cfg.silkworm, tx, cfg.chainConfig.ChainID, blockNum, to, uint64(cfg.batchSize), writeChangeSets, writeReceipts, writeCallTraces)
 		} else {
-			err = executeBlock(block, tx, batch, cfg, *cfg.vmConfig, writeChangeSets, writeReceipts, writeCallTraces, initialCycle, stateStream, logger)
+			err = executeBlock(block, tx, batch, cfg, *cfg.vmConfig, writeChangeSets, writeReceipts, writeCallTraces, initialCycle, stateStream, parallelWorkers, logger)
 		}
 
 		if err != nil {
@@ -525,6 +550,9 @@ Loop:
 				defer tx.Rollback()
 			}
 			batch = membatch.NewHashBatch(tx, quit, cfg.dirs.Tmp, logger)
+			if parallelWorkers != nil {
+				parallelWorkers.Reset()
+			}
 		}
 
 		gas = gas + block.GasUsed()
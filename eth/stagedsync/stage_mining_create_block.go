@@ -30,6 +30,7 @@ type MiningBlock struct {
 	Txs         types.Transactions
 	Receipts    types.Receipts
 	Withdrawals []*types.Withdrawal
+	Requests    types.FlatRequests
 	PreparedTxs types.TransactionsStream
 }
 
@@ -0,0 +1,232 @@
+package tracetest
+
+import (
+	"encoding/json"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+
+	libcommon "github.com/ledgerwatch/erigon-lib/common"
+	"github.com/ledgerwatch/erigon-lib/common/hexutil"
+
+	"github.com/ledgerwatch/erigon/common"
+	"github.com/ledgerwatch/erigon/core"
+	"github.com/ledgerwatch/erigon/core/types"
+	"github.com/ledgerwatch/erigon/core/vm"
+	"github.com/ledgerwatch/erigon/core/vm/evmtypes"
+	"github.com/ledgerwatch/erigon/crypto"
+	"github.com/ledgerwatch/erigon/eth/tracers"
+	"github.com/ledgerwatch/erigon/params"
+	"github.com/ledgerwatch/erigon/tests"
+	"github.com/ledgerwatch/erigon/turbo/stages/mock"
+)
+
+// flatCallTrace is the result of a flatCallTracer run.
+type flatCallTrace struct {
+	Action struct {
+		CallType string             `json:"callType"`
+		From     *libcommon.Address `json:"from"`
+		To       *libcommon.Address `json:"to"`
+		Gas      *hexutil.Uint64    `json:"gas"`
+		Address  *libcommon.Address `json:"address"`
+	} `json:"action"`
+	BlockNumber         uint64          `json:"blockNumber"`
+	Error               string          `json:"error,omitempty"`
+	Subtraces           int             `json:"subtraces"`
+	TraceAddress        []int           `json:"traceAddress"`
+	TransactionHash     *libcommon.Hash `json:"transactionHash"`
+	TransactionPosition uint64          `json:"transactionPosition"`
+	Type                string          `json:"type"`
+}
+
+// checkFlatTrace checks that flat traces are the pre-order traversal of the nested call trace.
+func checkFlatTrace(t *testing.T, nested *callTrace, flat []flatCallTrace, traceAddress []int) []flatCallTrace {
+	t.Helper()
+	require.NotEmpty(t, flat)
+	frame := flat[0]
+	require.Equal(t, traceAddress, frame.TraceAddress)
+	require.Equal(t, len(nested.Calls), frame.Subtraces)
+	require.Equal(t, nested.Error, frame.Error)
+	switch nested.Type {
+	case "CREATE", "CREATE2":
+		require.Equal(t, "create", frame.Type)
+		require.Equal(t, nested.From, *frame.Action.From)
+		require.Equal(t, *nested.Gas, *frame.Action.Gas)
+	case "SELFDESTRUCT":
+		require.Equal(t, "suicide", frame.Type)
+		require.Equal(t, nested.From, *frame.Action.Address)
+	default:
+		require.Equal(t, "call", frame.Type)
+		require.Equal(t, strings.ToLower(nested.Type), frame.Action.CallType)
+		require.Equal(t, nested.From, *frame.Action.From)
+		require.Equal(t, nested.To, *frame.Action.To)
+		require.Equal(t, *nested.Gas, *frame.Action.Gas)
+	}
+	flat = flat[1:]
+	for i := range nested.Calls {
+		child := append(append(make([]int, 0, len(traceAddress)+1), traceAddress...), i)
+		flat = checkFlatTrace(t, &nested.Calls[i], flat, child)
+	}
+	return flat
+}
+
+// TestFlatCallTracerNative runs the call tracer test suite through callTracer and
+// flatCallTracer within a single muxTracer, and compares their results.
+func TestFlatCallTracerNative(t *testing.T) {
+	files, err := os.ReadDir(filepath.Join("testdata", "call_tracer"))
+	if err != nil {
+		t.Fatalf("failed to retrieve tracer test suite: %v", err)
+	}
+	for _, file := range files {
+		if !strings.HasSuffix(file.Name(), ".json") {
+			continue
+		}
+		file := file // capture range variable
+		t.Run(camel(strings.TrimSuffix(file.Name(), ".json")), func(t *testing.T) {
+			t.Parallel()
+
+			test := new(callTracerTest)
+			if blob, err := os.ReadFile(filepath.Join("testdata", "call_tracer", file.Name())); err != nil {
+				t.Fatalf("failed to read testcase: %v", err)
+			} else if err := json.Unmarshal(blob, test); err != nil {
+				t.Fatalf("failed to parse testcase: %v", err)
+			}
+			if len(test.TracerConfig) > 0 {
+				t.Skip("flatCallTracer does not support callTracer config")
+			}
+			tx, err := types.UnmarshalTransactionFromBinary(common.FromHex(test.Input))
+			require.NoError(t, err)
+			var (
+				signer    = types.MakeSigner(test.Genesis.Config, uint64(test.Context.Number), uint64(test.Context.Time))
+				origin, _ = signer.Sender(tx)
+				txContext = evmtypes.TxContext{
+					Origin:   origin,
+					GasPrice: tx.GetPrice(),
+				}
+				context = evmtypes.BlockContext{
+					CanTransfer: core.CanTransfer,
+					Transfer:    core.Transfer,
+					Coinbase:    test.Context.Miner,
+					BlockNumber: uint64(test.Context.Number),
+					Time:        uint64(test.Context.Time),
+					Difficulty:  (*big.Int)(test.Context.Difficulty),
+					GasLimit:    uint64(test.Context.GasLimit),
+				}
+				rules = test.Genesis.Config.Rules(context.BlockNumber, context.Time)
+			)
+			m := mock.Mock(t)
+			dbTx, err := m.DB.BeginRw(m.Ctx)
+			require.NoError(t, err)
+			defer dbTx.Rollback()
+			statedb, _ := tests.MakePreState(rules, dbTx, test.Genesis.Alloc, uint64(test.Context.Number))
+			if test.Genesis.BaseFee != nil {
+				context.BaseFee, _ = uint256.FromBig(test.Genesis.BaseFee)
+			}
+			tracerCtx := &tracers.Context{TxHash: tx.Hash(), TxIndex: 3, BlockNumber: new(big.Int).SetUint64(context.BlockNumber)}
+			tracer, err := tracers.New("muxTracer", tracerCtx, json.RawMessage(`{"callTracer":{},"flatCallTracer":{"includePrecompiles":true}}`))
+			require.NoError(t, err)
+			evm := vm.NewEVM(context, txContext, statedb, test.Genesis.Config, vm.Config{Debug: true, Tracer: tracer})
+			msg, err := tx.AsMessage(*signer, test.Genesis.BaseFee, rules)
+			require.NoError(t, err)
+			_, err = core.ApplyMessage(evm, msg, new(core.GasPool).AddGas(tx.GetGas()).AddBlobGas(tx.GetBlobGas()), true /* refunds */, false /* gasBailout */)
+			require.NoError(t, err)
+			res, err := tracer.GetResult()
+			require.NoError(t, err)
+
+			var results struct {
+				Nested *callTrace      `json:"callTracer"`
+				Flat   []flatCallTrace `json:"flatCallTracer"`
+			}
+			require.NoError(t, json.Unmarshal(res, &results))
+			for _, frame := range results.Flat {
+				require.Equal(t, tx.Hash(), *frame.TransactionHash)
+				require.Equal(t, uint64(3), frame.TransactionPosition)
+				require.Equal(t, context.BlockNumber, frame.BlockNumber)
+			}
+			rest := checkFlatTrace(t, results.Nested, results.Flat, []int{})
+			require.Empty(t, rest)
+		})
+	}
+}
+
+// TestFlatCallTracerPrecompiles checks that calls to precompiles are excluded from the flat
+// traces by default, like in Parity traces.
+func TestFlatCallTracerPrecompiles(t *testing.T) {
+	var to = libcommon.HexToAddress("0x00000000000000000000000000000000deadbeef")
+	privkey, err := crypto.HexToECDSA("0000000000000000deadbeef00000000000000000000000000000000deadbeef")
+	require.NoError(t, err)
+	signer := types.LatestSigner(params.MainnetChainConfig)
+	tx, err := types.SignNewTx(privkey, *signer, &types.LegacyTx{
+		GasPrice: uint256.NewInt(0),
+		CommonTx: types.CommonTx{
+			Gas: 50000,
+			To:  &to,
+		},
+	})
+	require.NoError(t, err)
+	origin, _ := signer.Sender(tx)
+	txContext := evmtypes.TxContext{
+		Origin:   origin,
+		GasPrice: uint256.NewInt(1),
+	}
+	context := evmtypes.BlockContext{
+		CanTransfer: core.CanTransfer,
+		Transfer:    core.Transfer,
+		Coinbase:    libcommon.Address{},
+		BlockNumber: 8000000,
+		Time:        5,
+		Difficulty:  big.NewInt(0x30000),
+		GasLimit:    uint64(6000000),
+	}
+	var code = []byte{
+		byte(vm.PUSH1), 0x0, byte(vm.DUP1), byte(vm.DUP1), byte(vm.DUP1), // in and outs zero
+		byte(vm.DUP1), byte(vm.PUSH1), 0x04, byte(vm.GAS), // value=0,address=identity precompile, gas=GAS
+		byte(vm.CALL),
+	}
+	var alloc = types.GenesisAlloc{
+		to: types.GenesisAccount{
+			Nonce: 1,
+			Code:  code,
+		},
+		origin: types.GenesisAccount{
+			Nonce:   0,
+			Balance: big.NewInt(500000000000000),
+		},
+	}
+	rules := params.MainnetChainConfig.Rules(context.BlockNumber, context.Time)
+
+	for _, tc := range []struct {
+		config string
+		traces int
+	}{
+		{config: `{}`, traces: 1},
+		{config: `{"includePrecompiles":true}`, traces: 2},
+	} {
+		m := mock.Mock(t)
+		dbTx, err := m.DB.BeginRw(m.Ctx)
+		require.NoError(t, err)
+		defer dbTx.Rollback()
+
+		statedb, _ := tests.MakePreState(rules, dbTx, alloc, context.BlockNumber)
+		tracer, err := tracers.New("flatCallTracer", new(tracers.Context), json.RawMessage(tc.config))
+		require.NoError(t, err)
+		evm := vm.NewEVM(context, txContext, statedb, params.MainnetChainConfig, vm.Config{Debug: true, Tracer: tracer})
+		msg, err := tx.AsMessage(*signer, nil, rules)
+		require.NoError(t, err)
+		st := core.NewStateTransition(evm, msg, new(core.GasPool).AddGas(tx.GetGas()).AddBlobGas(tx.GetBlobGas()))
+		_, err = st.TransitionDb(true /* refunds */, false /* gasBailout */)
+		require.NoError(t, err)
+		res, err := tracer.GetResult()
+		require.NoError(t, err)
+
+		var flat []flatCallTrace
+		require.NoError(t, json.Unmarshal(res, &flat))
+		require.Len(t, flat, tc.traces, tc.config)
+		require.Equal(t, tc.traces-1, flat[0].Subtraces, tc.config)
+	}
+}
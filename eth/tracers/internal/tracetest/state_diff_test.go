@@ -0,0 +1,200 @@
+package tracetest
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/holiman/uint256"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/stretchr/testify/require"
+
+	libcommon "github.com/ledgerwatch/erigon-lib/common"
+
+	"github.com/ledgerwatch/erigon/core"
+	"github.com/ledgerwatch/erigon/core/types"
+	"github.com/ledgerwatch/erigon/core/vm"
+	"github.com/ledgerwatch/erigon/core/vm/evmtypes"
+	"github.com/ledgerwatch/erigon/crypto"
+	"github.com/ledgerwatch/erigon/eth/tracers"
+	"github.com/ledgerwatch/erigon/params"
+	"github.com/ledgerwatch/erigon/tests"
+	"github.com/ledgerwatch/erigon/turbo/stages/mock"
+)
+
+type stateDiffEntry struct {
+	Type         string            `json:"type"`
+	Address      libcommon.Address `json:"address"`
+	Key          *libcommon.Hash   `json:"key"`
+	Value        *libcommon.Hash   `json:"value"`
+	From         string            `json:"from"`
+	To           string            `json:"to"`
+	Depth        int               `json:"depth"`
+	TraceAddress []int             `json:"traceAddress"`
+	Op           string            `json:"op"`
+}
+
+var (
+	stateDiffTo     = libcommon.HexToAddress("0x00000000000000000000000000000000deadbeef")
+	stateDiffTarget = libcommon.HexToAddress("0x00000000000000000000000000000000000000ff")
+)
+
+// traceStateDiff executes a transaction from a funded account to stateDiffTo, which has the given code,
+// and returns the entries of stateDiffTracer, either collected by the tracer or streamed into the caller's stream.
+func traceStateDiff(t *testing.T, code []byte, alloc types.GenesisAlloc, streaming bool) (libcommon.Address, []stateDiffEntry) {
+	t.Helper()
+	privkey, err := crypto.HexToECDSA("0000000000000000deadbeef00000000000000000000000000000000deadbeef")
+	require.NoError(t, err)
+	signer := types.LatestSigner(params.MainnetChainConfig)
+	tx, err := types.SignNewTx(privkey, *signer, &types.LegacyTx{
+		GasPrice: uint256.NewInt(0),
+		CommonTx: types.CommonTx{
+			Gas: 100000,
+			To:  &stateDiffTo,
+		},
+	})
+	require.NoError(t, err)
+	origin, _ := signer.Sender(tx)
+	txContext := evmtypes.TxContext{
+		Origin:   origin,
+		GasPrice: uint256.NewInt(1),
+	}
+	context := evmtypes.BlockContext{
+		CanTransfer: core.CanTransfer,
+		Transfer:    core.Transfer,
+		Coinbase:    libcommon.Address{},
+		BlockNumber: 8000000,
+		Time:        5,
+		Difficulty:  big.NewInt(0x30000),
+		GasLimit:    uint64(6000000),
+	}
+	alloc[stateDiffTo] = types.GenesisAccount{
+		Nonce:   1,
+		Code:    code,
+		Balance: big.NewInt(10),
+	}
+	alloc[origin] = types.GenesisAccount{
+		Nonce:   0,
+		Balance: big.NewInt(500000000000000),
+	}
+	rules := params.MainnetChainConfig.Rules(context.BlockNumber, context.Time)
+
+	m := mock.Mock(t)
+	dbTx, err := m.DB.BeginRw(m.Ctx)
+	require.NoError(t, err)
+	defer dbTx.Rollback()
+
+	statedb, _ := tests.MakePreState(rules, dbTx, alloc, context.BlockNumber)
+	tracer, err := tracers.New("stateDiffTracer", new(tracers.Context), nil)
+	require.NoError(t, err)
+	var buf bytes.Buffer
+	stream := jsoniter.NewStream(jsoniter.ConfigDefault, &buf, 16)
+	if streaming {
+		require.Equal(t, "stateDiffs", tracer.(tracers.StreamingTracer).SetStream(stream))
+	}
+	evm := vm.NewEVM(context, txContext, statedb, params.MainnetChainConfig, vm.Config{Debug: true, Tracer: tracer})
+	msg, err := tx.AsMessage(*signer, nil, rules)
+	require.NoError(t, err)
+	st := core.NewStateTransition(evm, msg, new(core.GasPool).AddGas(tx.GetGas()).AddBlobGas(tx.GetBlobGas()))
+	res, err := st.TransitionDb(true /* refunds */, false /* gasBailout */)
+	require.NoError(t, err)
+	require.False(t, res.Failed())
+	result, err := tracer.GetResult()
+	require.NoError(t, err)
+	if streaming {
+		require.Nil(t, result)
+		require.NoError(t, stream.Flush())
+		result = append(append([]byte{'['}, buf.Bytes()...), ']')
+	}
+
+	var entries []stateDiffEntry
+	require.NoError(t, json.Unmarshal(result, &entries), string(result))
+	return origin, entries
+}
+
+// TestStateDiffTracer checks attribution of the state changes to the call frames, both with
+// results collected by the tracer and streamed into the caller's stream.
+func TestStateDiffTracer(t *testing.T) {
+	to, target := stateDiffTo, stateDiffTarget
+	var code = []byte{
+		byte(vm.PUSH1), 0x2a, byte(vm.PUSH1), 0x01, byte(vm.SSTORE), // slot 1 = 42
+		byte(vm.PUSH1), 0x01, byte(vm.SLOAD), byte(vm.POP), // read slot 1
+		byte(vm.PUSH1), 0x0, byte(vm.DUP1), byte(vm.DUP1), byte(vm.DUP1), // in and outs zero
+		byte(vm.PUSH1), 0x01, byte(vm.PUSH1), 0xff, byte(vm.GAS), // value=1,address=0xff, gas=GAS
+		byte(vm.CALL),
+		byte(vm.STOP),
+	}
+
+	for _, streaming := range []bool{false, true} {
+		origin, entries := traceStateDiff(t, code, types.GenesisAlloc{}, streaming)
+		require.Len(t, entries, 5)
+
+		require.Equal(t, "nonce", entries[0].Type)
+		require.Equal(t, origin, entries[0].Address)
+		require.Equal(t, "0x0", entries[0].From)
+		require.Equal(t, "0x1", entries[0].To)
+		require.Equal(t, []int{}, entries[0].TraceAddress)
+
+		require.Equal(t, "storage", entries[1].Type)
+		require.Equal(t, to, entries[1].Address)
+		require.Equal(t, libcommon.HexToHash("0x01"), *entries[1].Key)
+		require.Equal(t, libcommon.HexToHash("0x00").Hex(), entries[1].From)
+		require.Equal(t, libcommon.HexToHash("0x2a").Hex(), entries[1].To)
+		require.Equal(t, "SSTORE", entries[1].Op)
+		require.Equal(t, 0, entries[1].Depth)
+
+		require.Equal(t, "storageRead", entries[2].Type)
+		require.Equal(t, libcommon.HexToHash("0x2a"), *entries[2].Value)
+		require.Equal(t, "SLOAD", entries[2].Op)
+
+		for i, addr := range []libcommon.Address{to, target} {
+			entry := entries[3+i]
+			require.Equal(t, "balance", entry.Type)
+			require.Equal(t, addr, entry.Address)
+			require.Equal(t, 1, entry.Depth)
+			require.Equal(t, []int{0}, entry.TraceAddress)
+			require.Empty(t, entry.Op)
+		}
+		require.Equal(t, "0xa", entries[3].From)
+		require.Equal(t, "0x9", entries[3].To)
+		require.Equal(t, "0x0", entries[4].From)
+		require.Equal(t, "0x1", entries[4].To)
+	}
+}
+
+// TestStateDiffTracerRevertedFrame checks that changes made by a reverted subcall are reported
+// again as changes back to the previous values, attributed to the reverted frame.
+func TestStateDiffTracerRevertedFrame(t *testing.T) {
+	to, target := stateDiffTo, stateDiffTarget
+	var code = []byte{
+		byte(vm.PUSH1), 0x0, byte(vm.DUP1), byte(vm.DUP1), byte(vm.DUP1), // in and outs zero
+		byte(vm.PUSH1), 0x01, byte(vm.PUSH1), 0xff, byte(vm.GAS), // value=1,address=0xff, gas=GAS
+		byte(vm.CALL),
+		byte(vm.STOP),
+	}
+	var targetCode = []byte{
+		byte(vm.PUSH1), 0x2a, byte(vm.PUSH1), 0x01, byte(vm.SSTORE), // slot 1 = 42
+		byte(vm.PUSH1), 0x0, byte(vm.DUP1), byte(vm.REVERT),
+	}
+	alloc := types.GenesisAlloc{
+		target: types.GenesisAccount{Code: targetCode, Balance: big.NewInt(0)},
+	}
+	slot, zero, value := libcommon.HexToHash("0x01"), libcommon.HexToHash("0x00").Hex(), libcommon.HexToHash("0x2a").Hex()
+
+	for _, streaming := range []bool{false, true} {
+		origin, entries := traceStateDiff(t, code, alloc, streaming)
+		expected := []stateDiffEntry{
+			{Type: "nonce", Address: origin, From: "0x0", To: "0x1", TraceAddress: []int{}},
+			// changes made by the subcall
+			{Type: "balance", Address: to, From: "0xa", To: "0x9", Depth: 1, TraceAddress: []int{0}},
+			{Type: "balance", Address: target, From: "0x0", To: "0x1", Depth: 1, TraceAddress: []int{0}},
+			{Type: "storage", Address: target, Key: &slot, From: zero, To: value, Depth: 1, TraceAddress: []int{0}, Op: "SSTORE"},
+			// reverted on exit from the subcall
+			{Type: "balance", Address: to, From: "0x9", To: "0xa", Depth: 1, TraceAddress: []int{0}},
+			{Type: "balance", Address: target, From: "0x1", To: "0x0", Depth: 1, TraceAddress: []int{0}},
+			{Type: "storage", Address: target, Key: &slot, From: value, To: zero, Depth: 1, TraceAddress: []int{0}},
+		}
+		require.Equal(t, expected, entries)
+	}
+}
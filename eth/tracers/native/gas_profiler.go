@@ -0,0 +1,394 @@
+package native
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/pprof/profile"
+	"github.com/holiman/uint256"
+	libcommon "github.com/ledgerwatch/erigon-lib/common"
+
+	"github.com/ledgerwatch/erigon/core/vm"
+	"github.com/ledgerwatch/erigon/eth/tracers"
+)
+
+func init() {
+	register("gasProfiler", newGasProfiler)
+}
+
+const (
+	gasProfileFormatSummary = "summary" // json with gas and time per opcode, contract and call stack
+	gasProfileFormatFolded  = "folded"  // folded stacks for flamegraph.pl, inferno, speedscope
+	gasProfileFormatPprof   = "pprof"   // gzipped profile.proto for `go tool pprof`, base64 in json result
+
+	gasProfileWeightGas  = "gas"
+	gasProfileWeightTime = "time"
+
+	// gasProfilePrecompile is the leaf of stacks which end in a precompile: it has no opcodes
+	gasProfilePrecompile = "PRECOMPILE"
+)
+
+// gasProfiler aggregates gas and execution time per opcode within call stack of contracts,
+// it's made for gas optimization, where struct logs of all steps are too big to analyse.
+//
+// Gas of an opcode is its own gas: gas of subcalls is attributed to opcodes of the callees,
+// code deposit of CREATE is attributed to RETURN of the initcode, gas burned on failure to the
+// failed opcode.
+//
+// Example:
+//
+//	> debug.traceTransaction("0x...", {tracer: "gasProfiler", tracerConfig: {format: "folded"}})
+//	"0x5FbDB2315678afecb367f032d93F642f64180aa3;SSTORE 22100\n0x5FbDB2315678afecb367f032d93F642f64180aa3;0xe7f1725E7734CE288F8367e1Bb143E90bb3F0512;CALLER 2\n..."
+type gasProfiler struct {
+	noopTracer
+	config    gasProfilerConfig
+	frames    []*gasProfileFrame
+	stacks    map[string]*gasProfileEntry // keyed by folded stack
+	gasUsed   uint64
+	interrupt uint32 // Atomic flag to signal execution interruption
+	reason    error  // Textual reason for the interruption
+}
+
+type gasProfilerConfig struct {
+	Format string `json:"format"` // summary (default), folded or pprof
+	Weight string `json:"weight"` // value of folded stacks: gas (default) or time in nanoseconds
+}
+
+// gasProfileFrame is a contract in the call stack
+type gasProfileFrame struct {
+	stack      []string // labels of the call stack up to this frame
+	precompile bool
+	accounted  uint64 // gas of flushed opcodes and subcalls
+	start      time.Time
+	step       *gasProfileStep
+}
+
+// gasProfileStep is the last opcode of the frame: its gas and time are known when the next opcode
+// of the frame starts or the frame exits
+type gasProfileStep struct {
+	op        string
+	gas, cost uint64
+	start     time.Time
+	childGas  uint64 // gas used by subcalls of the opcode
+	childTime time.Duration
+}
+
+type gasProfileEntry struct {
+	frames []string
+	op     string
+	count  uint64
+	gas    uint64
+	time   time.Duration
+}
+
+// gasProfileStat is the aggregate of the summary format
+type gasProfileStat struct {
+	Name   string `json:"name"`
+	Count  uint64 `json:"count"`
+	Gas    uint64 `json:"gas"`
+	TimeNs int64  `json:"timeNs"`
+}
+
+type gasProfileSummary struct {
+	GasUsed   uint64           `json:"gasUsed"`
+	Opcodes   []gasProfileStat `json:"opcodes"`
+	Contracts []gasProfileStat `json:"contracts"`
+	Stacks    []gasProfileStat `json:"stacks"`
+}
+
+// newGasProfiler returns a native go tracer which profiles gas and time of opcodes,
+// and implements vm.EVMLogger.
+func newGasProfiler(ctx *tracers.Context, cfg json.RawMessage) (tracers.Tracer, error) {
+	var config gasProfilerConfig
+	if cfg != nil {
+		if err := json.Unmarshal(cfg, &config); err != nil {
+			return nil, err
+		}
+	}
+	switch config.Format {
+	case "":
+		config.Format = gasProfileFormatSummary
+	case gasProfileFormatSummary, gasProfileFormatFolded, gasProfileFormatPprof:
+	default:
+		return nil, fmt.Errorf("unknown gas profile format %q", config.Format)
+	}
+	switch config.Weight {
+	case "":
+		config.Weight = gasProfileWeightGas
+	case gasProfileWeightGas, gasProfileWeightTime:
+	default:
+		return nil, fmt.Errorf("unknown gas profile weight %q", config.Weight)
+	}
+	return &gasProfiler{config: config, stacks: make(map[string]*gasProfileEntry)}, nil
+}
+
+// CaptureStart implements the EVMLogger interface to initialize the tracing operation.
+func (t *gasProfiler) CaptureStart(env *vm.EVM, from libcommon.Address, to libcommon.Address, precompile bool, create bool, input []byte, gas uint64, value *uint256.Int, code []byte) {
+	t.enter(to, precompile, create)
+}
+
+// CaptureEnd is called after the call finishes to finalize the tracing.
+func (t *gasProfiler) CaptureEnd(output []byte, gasUsed uint64, err error) {
+	t.gasUsed += gasUsed
+	t.exit(gasUsed)
+}
+
+// CaptureEnter is called when EVM enters a new scope (via call, create or selfdestruct).
+func (t *gasProfiler) CaptureEnter(typ vm.OpCode, from libcommon.Address, to libcommon.Address, precompile, create bool, input []byte, gas uint64, value *uint256.Int, code []byte) {
+	t.enter(to, precompile, create)
+}
+
+// CaptureExit is called when EVM exits a scope, even if the scope didn't
+// execute any code.
+func (t *gasProfiler) CaptureExit(output []byte, gasUsed uint64, err error) {
+	t.exit(gasUsed)
+}
+
+// CaptureState implements the EVMLogger interface to trace a single step of VM execution.
+func (t *gasProfiler) CaptureState(pc uint64, op vm.OpCode, gas, cost uint64, scope *vm.ScopeContext, rData []byte, depth int, err error) {
+	if atomic.LoadUint32(&t.interrupt) > 0 || len(t.frames) == 0 {
+		return
+	}
+	now := time.Now()
+	frame := t.frames[len(t.frames)-1]
+	if step := frame.step; step != nil {
+		// gas difference between steps includes gas used by subcalls and excludes gas returned by them
+		var used uint64
+		if step.gas > gas {
+			used = step.gas - gas
+		}
+		t.flush(frame, used, now)
+	}
+	frame.step = &gasProfileStep{op: op.String(), gas: gas, cost: cost, start: now}
+}
+
+func (t *gasProfiler) enter(addr libcommon.Address, precompile, create bool) {
+	label := addr.Hex()
+	if create {
+		label = "create:" + label
+	}
+	var stack []string
+	if len(t.frames) > 0 {
+		stack = t.frames[len(t.frames)-1].stack
+	}
+	frame := &gasProfileFrame{stack: make([]string, len(stack), len(stack)+1), precompile: precompile, start: time.Now()}
+	copy(frame.stack, stack)
+	frame.stack = append(frame.stack, label)
+	t.frames = append(t.frames, frame)
+}
+
+func (t *gasProfiler) exit(gasUsed uint64) {
+	if len(t.frames) == 0 {
+		return
+	}
+	now := time.Now()
+	frame := t.frames[len(t.frames)-1]
+	t.frames = t.frames[:len(t.frames)-1]
+	switch {
+	case frame.step != nil:
+		// the rest of gas used by the frame belongs to its last opcode: e.g. code deposit
+		// after RETURN of initcode or gas burned by failed opcode
+		used := frame.step.cost + frame.step.childGas
+		if gasUsed > frame.accounted+used {
+			used = gasUsed - frame.accounted
+		}
+		t.flush(frame, used, now)
+	case frame.precompile && gasUsed > 0:
+		t.add(frame.stack, gasProfilePrecompile, gasUsed, now.Sub(frame.start))
+	}
+	if len(t.frames) > 0 {
+		// attribute gas and time of the subcall to the calling opcode
+		if step := t.frames[len(t.frames)-1].step; step != nil {
+			step.childGas += gasUsed
+			step.childTime += now.Sub(frame.start)
+		}
+	}
+}
+
+// flush adds the last step of the frame to the profile, used is gas of the step including its subcalls
+func (t *gasProfiler) flush(frame *gasProfileFrame, used uint64, now time.Time) {
+	step := frame.step
+	frame.step = nil
+	var gas uint64
+	if used > step.childGas {
+		gas = used - step.childGas
+	}
+	frame.accounted += gas + step.childGas
+	elapsed := now.Sub(step.start) - step.childTime
+	if elapsed < 0 {
+		elapsed = 0
+	}
+	t.add(frame.stack, step.op, gas, elapsed)
+}
+
+func (t *gasProfiler) add(frames []string, op string, gas uint64, elapsed time.Duration) {
+	key := strings.Join(frames, ";") + ";" + op
+	entry, ok := t.stacks[key]
+	if !ok {
+		entry = &gasProfileEntry{frames: frames, op: op}
+		t.stacks[key] = entry
+	}
+	entry.count++
+	entry.gas += gas
+	entry.time += elapsed
+}
+
+// sortedStacks returns folded stacks in lexicographical order
+func (t *gasProfiler) sortedStacks() ([]string, []*gasProfileEntry) {
+	keys := make([]string, 0, len(t.stacks))
+	for key := range t.stacks {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	entries := make([]*gasProfileEntry, len(keys))
+	for i, key := range keys {
+		entries[i] = t.stacks[key]
+	}
+	return keys, entries
+}
+
+// GetResult returns the json-encoded profile in the configured format, and any
+// error arising from the encoding or forceful termination (via `Stop`).
+func (t *gasProfiler) GetResult() (json.RawMessage, error) {
+	var (
+		res []byte
+		err error
+	)
+	switch t.config.Format {
+	case gasProfileFormatFolded:
+		res, err = json.Marshal(t.folded())
+	case gasProfileFormatPprof:
+		var profile []byte
+		if profile, err = t.pprof(); err == nil {
+			res, err = json.Marshal(profile)
+		}
+	default:
+		res, err = json.Marshal(t.summary())
+	}
+	if err != nil {
+		return nil, err
+	}
+	return res, t.reason
+}
+
+// Stop terminates execution of the tracer at the first opportune moment.
+func (t *gasProfiler) Stop(err error) {
+	t.reason = err
+	atomic.StoreUint32(&t.interrupt, 1)
+}
+
+func (t *gasProfiler) summary() *gasProfileSummary {
+	opcodes := map[string]*gasProfileStat{}
+	contracts := map[string]*gasProfileStat{}
+	aggregate := func(stats map[string]*gasProfileStat, name string, entry *gasProfileEntry) {
+		stat, ok := stats[name]
+		if !ok {
+			stat = &gasProfileStat{Name: name}
+			stats[name] = stat
+		}
+		stat.Count += entry.count
+		stat.Gas += entry.gas
+		stat.TimeNs += entry.time.Nanoseconds()
+	}
+	keys, entries := t.sortedStacks()
+	summary := &gasProfileSummary{GasUsed: t.gasUsed, Stacks: make([]gasProfileStat, len(keys))}
+	for i, entry := range entries {
+		aggregate(opcodes, entry.op, entry)
+		aggregate(contracts, entry.frames[len(entry.frames)-1], entry)
+		summary.Stacks[i] = gasProfileStat{Name: keys[i], Count: entry.count, Gas: entry.gas, TimeNs: entry.time.Nanoseconds()}
+	}
+	summary.Opcodes = sortedGasProfileStats(opcodes)
+	summary.Contracts = sortedGasProfileStats(contracts)
+	sortGasProfileStats(summary.Stacks)
+	return summary
+}
+
+func sortedGasProfileStats(stats map[string]*gasProfileStat) []gasProfileStat {
+	res := make([]gasProfileStat, 0, len(stats))
+	for _, stat := range stats {
+		res = append(res, *stat)
+	}
+	sortGasProfileStats(res)
+	return res
+}
+
+// sortGasProfileStats sorts by gas descending, then by name
+func sortGasProfileStats(stats []gasProfileStat) {
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].Gas != stats[j].Gas {
+			return stats[i].Gas > stats[j].Gas
+		}
+		return stats[i].Name < stats[j].Name
+	})
+}
+
+// folded returns stacks in the format of flamegraph.pl: `frame;frame;OPCODE value` per line
+func (t *gasProfiler) folded() string {
+	var b strings.Builder
+	keys, entries := t.sortedStacks()
+	for i, entry := range entries {
+		value := entry.gas
+		if t.config.Weight == gasProfileWeightTime {
+			value = uint64(entry.time.Nanoseconds())
+		}
+		if value == 0 {
+			continue
+		}
+		b.WriteString(keys[i])
+		b.WriteByte(' ')
+		b.WriteString(strconv.FormatUint(value, 10))
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+// pprof returns gzipped profile.proto with gas, count and time samples. Contracts and opcodes are
+// functions, each function has one location.
+func (t *gasProfiler) pprof() ([]byte, error) {
+	p := &profile.Profile{
+		SampleType: []*profile.ValueType{
+			{Type: "gas", Unit: "gas"},
+			{Type: "samples", Unit: "count"},
+			{Type: "time", Unit: "nanoseconds"},
+		},
+		DefaultSampleType: "gas",
+	}
+	locations := map[string]*profile.Location{}
+	location := func(name string) *profile.Location {
+		loc, ok := locations[name]
+		if !ok {
+			id := uint64(len(p.Function) + 1)
+			fn := &profile.Function{ID: id, Name: name, SystemName: name}
+			loc = &profile.Location{ID: id, Line: []profile.Line{{Function: fn}}}
+			p.Function = append(p.Function, fn)
+			p.Location = append(p.Location, loc)
+			locations[name] = loc
+		}
+		return loc
+	}
+
+	_, entries := t.sortedStacks()
+	for _, entry := range entries {
+		// locations of the sample start from the leaf
+		sample := &profile.Sample{
+			Location: []*profile.Location{location(entry.op)},
+			Value:    []int64{int64(entry.gas), int64(entry.count), entry.time.Nanoseconds()},
+		}
+		for i := len(entry.frames) - 1; i >= 0; i-- {
+			sample.Location = append(sample.Location, location(entry.frames[i]))
+		}
+		p.Sample = append(p.Sample, sample)
+	}
+
+	var buf bytes.Buffer
+	if err := p.Write(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,121 @@
+package native
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/pprof/profile"
+	libcommon "github.com/ledgerwatch/erigon-lib/common"
+
+	"github.com/ledgerwatch/erigon/core/vm"
+)
+
+var (
+	gasProfilerCaller = libcommon.HexToAddress("0x000000000000000000000000000000000000aaaa")
+	gasProfilerCallee = libcommon.HexToAddress("0x000000000000000000000000000000000000bbbb")
+)
+
+// runGasProfiler replays steps of a call which calls another contract:
+// caller: ADD, CALL (2600 gas + 40000 forwarded), RETURN; callee: SSTORE, STOP
+func runGasProfiler(t *testing.T, cfg string) json.RawMessage {
+	t.Helper()
+	tracer, err := newGasProfiler(nil, json.RawMessage(cfg))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tracer.CaptureStart(nil, libcommon.Address{}, gasProfilerCaller, false, false, nil, 100000, nil, nil)
+	tracer.CaptureState(0, vm.ADD, 100000, 3, nil, nil, 1, nil)
+	tracer.CaptureState(1, vm.CALL, 99997, 42600, nil, nil, 1, nil)
+	tracer.CaptureEnter(vm.CALL, gasProfilerCaller, gasProfilerCallee, false, false, nil, 40000, nil, nil)
+	tracer.CaptureState(0, vm.SSTORE, 40000, 20000, nil, nil, 2, nil)
+	tracer.CaptureState(1, vm.STOP, 20000, 0, nil, nil, 2, nil)
+	tracer.CaptureExit(nil, 20000, nil)
+	tracer.CaptureState(2, vm.RETURN, 77397, 0, nil, nil, 1, nil)
+	tracer.CaptureEnd(nil, 22603, nil)
+	res, err := tracer.GetResult()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return res
+}
+
+func TestGasProfilerSummary(t *testing.T) {
+	var summary gasProfileSummary
+	if err := json.Unmarshal(runGasProfiler(t, `{}`), &summary); err != nil {
+		t.Fatal(err)
+	}
+	if summary.GasUsed != 22603 {
+		t.Fatalf("gas used: want 22603 got %d", summary.GasUsed)
+	}
+	var total uint64
+	for _, stat := range summary.Stacks {
+		total += stat.Gas
+	}
+	if total != summary.GasUsed {
+		t.Fatalf("gas of stacks: want %d got %d", summary.GasUsed, total)
+	}
+	want := []struct {
+		name string
+		gas  uint64
+	}{{"SSTORE", 20000}, {"CALL", 2600}, {"ADD", 3}}
+	for i, w := range want {
+		if op := summary.Opcodes[i]; op.Name != w.name || op.Gas != w.gas || op.Count != 1 {
+			t.Fatalf("opcode %d: want %s %d got %+v", i, w.name, w.gas, op)
+		}
+	}
+	if c := summary.Contracts[0]; c.Name != gasProfilerCallee.Hex() || c.Gas != 20000 || c.Count != 2 {
+		t.Fatalf("contract: %+v", c)
+	}
+}
+
+func TestGasProfilerFolded(t *testing.T) {
+	var folded string
+	if err := json.Unmarshal(runGasProfiler(t, `{"format":"folded"}`), &folded); err != nil {
+		t.Fatal(err)
+	}
+	caller, callee := gasProfilerCaller.Hex(), gasProfilerCallee.Hex()
+	want := caller + ";" + callee + ";SSTORE 20000\n" + caller + ";ADD 3\n" + caller + ";CALL 2600\n"
+	if folded != want {
+		t.Fatalf("want\n%s\ngot\n%s", want, folded)
+	}
+}
+
+func TestGasProfilerPprof(t *testing.T) {
+	var data []byte
+	if err := json.Unmarshal(runGasProfiler(t, `{"format":"pprof"}`), &data); err != nil {
+		t.Fatal(err)
+	}
+	p, err := profile.Parse(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = p.CheckValid(); err != nil {
+		t.Fatal(err)
+	}
+	// 5 stacks, 2 contracts and 5 opcodes
+	if len(p.SampleType) != 3 || len(p.Sample) != 5 || len(p.Location) != 7 || len(p.Function) != 7 {
+		t.Fatalf("unexpected profile %v", p)
+	}
+	if p.DefaultSampleType != "gas" || p.SampleType[0].Type != "gas" {
+		t.Fatalf("unexpected sample types %v, default %s", p.SampleType, p.DefaultSampleType)
+	}
+	var sstore *profile.Sample
+	for _, s := range p.Sample {
+		if s.Location[0].Line[0].Function.Name == "SSTORE" {
+			sstore = s
+		}
+	}
+	// leaf is the first: SSTORE, callee, caller
+	if sstore == nil || len(sstore.Location) != 3 || sstore.Value[0] != 20000 || sstore.Value[1] != 1 {
+		t.Fatalf("unexpected SSTORE sample %v", sstore)
+	}
+}
+
+func TestGasProfilerConfig(t *testing.T) {
+	for _, cfg := range []string{`{"format":"svg"}`, `{"weight":"calls"}`} {
+		if _, err := newGasProfiler(nil, json.RawMessage(cfg)); err == nil {
+			t.Fatalf("%s: expected error", cfg)
+		}
+	}
+}
@@ -0,0 +1,409 @@
+package native
+
+import (
+	"encoding/json"
+	"sync/atomic"
+
+	"github.com/holiman/uint256"
+	jsoniter "github.com/json-iterator/go"
+
+	libcommon "github.com/ledgerwatch/erigon-lib/common"
+
+	"github.com/ledgerwatch/erigon/core/vm"
+	"github.com/ledgerwatch/erigon/core/vm/evmtypes"
+	"github.com/ledgerwatch/erigon/crypto"
+	"github.com/ledgerwatch/erigon/eth/tracers"
+)
+
+var emptyCodeHash = crypto.Keccak256Hash(nil)
+
+func init() {
+	register("stateDiffTracer", newStateDiffTracer)
+}
+
+// stateDiffTracer reports every storage read and write, balance, nonce and code change
+// together with the call frame (as Parity trace address) and depth where it happened.
+// Changes are written as they occur, so when used via debug_trace* they are streamed to the client.
+// Changes reverted by a failed frame are reported again as changes back to the previous values,
+// attributed to the failed frame. Gas fee payments are not reported.
+type stateDiffTracer struct {
+	noopTracer
+	env       *vm.EVM
+	config    stateDiffTracerConfig
+	stream    *jsoniter.Stream
+	streaming bool // Entries are written into the stream of the caller
+	first     bool
+	frames    []*stateDiffFrame
+	accounts  map[libcommon.Address]*stateDiffAccount              // Last reported values of the watched accounts
+	slots     map[libcommon.Address]map[libcommon.Hash]uint256.Int // Last reported values of the watched storage slots
+	interrupt uint32                                               // Atomic flag to signal execution interruption
+	reason    error                                                // Textual reason for the interruption
+}
+
+type stateDiffTracerConfig struct {
+	DisableStorageReads bool `json:"disableStorageReads"` // If true, SLOADs are not reported
+}
+
+type stateDiffAccount struct {
+	balance  uint256.Int
+	nonce    uint64
+	codeHash libcommon.Hash
+}
+
+type stateDiffSlot struct {
+	address libcommon.Address
+	key     libcommon.Hash
+}
+
+// stateDiffFrame keeps accounts and storage slots touched within the call frame and its subcalls,
+// which have to be checked for changes on exit from the frame.
+type stateDiffFrame struct {
+	traceAddress []int
+	calls        int
+	fresh        bool // No opcode has been executed in the frame yet
+	accounts     []libcommon.Address
+	seen         map[libcommon.Address]struct{}
+	slots        []stateDiffSlot
+}
+
+func (f *stateDiffFrame) addAccount(addr libcommon.Address) {
+	if _, ok := f.seen[addr]; ok {
+		return
+	}
+	if f.seen == nil {
+		f.seen = make(map[libcommon.Address]struct{})
+	}
+	f.seen[addr] = struct{}{}
+	f.accounts = append(f.accounts, addr)
+}
+
+func newStateDiffTracer(ctx *tracers.Context, cfg json.RawMessage) (tracers.Tracer, error) {
+	var config stateDiffTracerConfig
+	if cfg != nil {
+		if err := json.Unmarshal(cfg, &config); err != nil {
+			return nil, err
+		}
+	}
+	return &stateDiffTracer{
+		config:   config,
+		stream:   jsoniter.NewStream(jsoniter.ConfigDefault, nil, 4096),
+		first:    true,
+		accounts: make(map[libcommon.Address]*stateDiffAccount),
+		slots:    make(map[libcommon.Address]map[libcommon.Hash]uint256.Int),
+	}, nil
+}
+
+// SetStream implements the StreamingTracer interface to write changes directly into the stream.
+func (t *stateDiffTracer) SetStream(stream *jsoniter.Stream) string {
+	t.stream = stream
+	t.streaming = true
+	return "stateDiffs"
+}
+
+// CaptureStart implements the EVMLogger interface to initialize the tracing operation.
+func (t *stateDiffTracer) CaptureStart(env *vm.EVM, from libcommon.Address, to libcommon.Address, precompile bool, create bool, input []byte, gas uint64, value *uint256.Int, code []byte) {
+	t.env = env
+	t.frames = append(t.frames[:0], &stateDiffFrame{traceAddress: []int{}, fresh: true})
+	if create {
+		// Nothing is changed before CaptureStart for contract creation
+		t.watchAccount(from)
+		t.watchAccount(to)
+		return
+	}
+	// Nonce of the sender is already incremented and the value is already transferred,
+	// restore the previous values to report the changes
+	t.watchAccount(from)
+	t.watchAccount(to)
+	if value != nil && from != to {
+		sender, recipient := t.accounts[from], t.accounts[to]
+		sender.balance.Add(&sender.balance, value)
+		recipient.balance.Sub(&recipient.balance, value)
+	}
+	if sender := t.accounts[from]; sender.nonce > 0 {
+		sender.nonce--
+	}
+	t.checkAccount(from)
+	t.checkAccount(to)
+}
+
+// CaptureEnd is called after the call finishes to finalize the tracing.
+func (t *stateDiffTracer) CaptureEnd(output []byte, gasUsed uint64, err error) {
+	t.exitFrame(err)
+	_ = t.stream.Flush()
+}
+
+// CaptureState implements the EVMLogger interface to trace a single step of VM execution.
+func (t *stateDiffTracer) CaptureState(pc uint64, op vm.OpCode, gas, cost uint64, scope *vm.ScopeContext, rData []byte, depth int, err error) {
+	if err != nil || atomic.LoadUint32(&t.interrupt) > 0 {
+		return
+	}
+	frame := t.frames[len(t.frames)-1]
+	if frame.fresh {
+		// Report changes made on entering the frame, e.g. by contract creation
+		frame.fresh = false
+		t.checkAccounts(frame.accounts)
+	}
+	stackData := scope.Stack.Data
+	stackLen := len(stackData)
+	caller := scope.Contract.Address()
+	switch op {
+	case vm.SLOAD:
+		if stackLen < 1 || t.config.DisableStorageReads {
+			return
+		}
+		key := libcommon.Hash(stackData[stackLen-1].Bytes32())
+		var value uint256.Int
+		t.env.IntraBlockState().GetState(caller, &key, &value)
+		t.watchSlot(caller, key, &value)
+		t.writeEntry("storageRead", caller, func() {
+			t.writeHashField("key", key)
+			t.writeHashField("value", value.Bytes32())
+			t.writeOpFields(pc, op)
+		})
+	case vm.SSTORE:
+		if stackLen < 2 {
+			return
+		}
+		key := libcommon.Hash(stackData[stackLen-1].Bytes32())
+		value := stackData[stackLen-2]
+		var prev uint256.Int
+		t.env.IntraBlockState().GetState(caller, &key, &prev)
+		t.watchSlot(caller, key, &prev)
+		frame.slots = append(frame.slots, stateDiffSlot{address: caller, key: key})
+		t.slots[caller][key] = value
+		t.writeEntry("storage", caller, func() {
+			t.writeHashField("key", key)
+			t.writeHashField("from", prev.Bytes32())
+			t.writeHashField("to", value.Bytes32())
+			t.writeOpFields(pc, op)
+		})
+	case vm.CALL, vm.CALLCODE:
+		// Value transfers are reported on entering the subcall
+		if stackLen < 2 {
+			return
+		}
+		t.watchAccount(caller)
+		t.watchAccount(libcommon.Address(stackData[stackLen-2].Bytes20()))
+	case vm.SELFDESTRUCT:
+		if stackLen < 1 {
+			return
+		}
+		t.watchAccount(caller)
+		t.watchAccount(libcommon.Address(stackData[stackLen-1].Bytes20()))
+	case vm.CREATE, vm.CREATE2:
+		t.watchAccount(caller)
+	}
+}
+
+// CaptureEnter is called when EVM enters a new scope (via call, create or selfdestruct).
+func (t *stateDiffTracer) CaptureEnter(typ vm.OpCode, from libcommon.Address, to libcommon.Address, precompile, create bool, input []byte, gas uint64, value *uint256.Int, code []byte) {
+	parent := t.frames[len(t.frames)-1]
+	traceAddress := make([]int, len(parent.traceAddress)+1)
+	copy(traceAddress, parent.traceAddress)
+	traceAddress[len(parent.traceAddress)] = parent.calls
+	parent.calls++
+	t.frames = append(t.frames, &stateDiffFrame{traceAddress: traceAddress, fresh: true})
+	if atomic.LoadUint32(&t.interrupt) > 0 {
+		return
+	}
+	t.watchAccount(from)
+	t.watchAccount(to)
+	if !create {
+		// Value is already transferred
+		t.checkAccount(from)
+		t.checkAccount(to)
+	}
+}
+
+// CaptureExit is called when EVM exits a scope, even if the scope didn't
+// execute any code.
+func (t *stateDiffTracer) CaptureExit(output []byte, gasUsed uint64, err error) {
+	if len(t.frames) <= 1 {
+		return
+	}
+	t.exitFrame(err)
+	frame := t.frames[len(t.frames)-1]
+	t.frames = t.frames[:len(t.frames)-1]
+	// Changes of the frame may still be reverted by the parent frames
+	parent := t.frames[len(t.frames)-1]
+	for _, addr := range frame.accounts {
+		parent.addAccount(addr)
+	}
+	parent.slots = append(parent.slots, frame.slots...)
+}
+
+// exitFrame reports changes made on exit from the current frame, or reverted by its failure.
+func (t *stateDiffTracer) exitFrame(err error) {
+	if atomic.LoadUint32(&t.interrupt) > 0 {
+		return
+	}
+	frame := t.frames[len(t.frames)-1]
+	t.checkAccounts(frame.accounts)
+	if err == nil {
+		return
+	}
+	ibs := t.env.IntraBlockState()
+	for _, slot := range frame.slots {
+		var value uint256.Int
+		ibs.GetState(slot.address, &slot.key, &value)
+		prev := t.slots[slot.address][slot.key]
+		if prev.Eq(&value) {
+			continue
+		}
+		t.slots[slot.address][slot.key] = value
+		key := slot.key
+		t.writeEntry("storage", slot.address, func() {
+			t.writeHashField("key", key)
+			t.writeHashField("from", prev.Bytes32())
+			t.writeHashField("to", value.Bytes32())
+		})
+	}
+}
+
+// watchAccount remembers the current state of the account, if it is not watched yet,
+// and adds it to the accounts checked for changes on exit from the current frame.
+func (t *stateDiffTracer) watchAccount(addr libcommon.Address) {
+	t.frames[len(t.frames)-1].addAccount(addr)
+	if _, ok := t.accounts[addr]; ok {
+		return
+	}
+	ibs := t.env.IntraBlockState()
+	acc := &stateDiffAccount{nonce: ibs.GetNonce(addr), codeHash: codeHashOf(ibs, addr)}
+	acc.balance.Set(ibs.GetBalance(addr))
+	t.accounts[addr] = acc
+}
+
+func (t *stateDiffTracer) watchSlot(addr libcommon.Address, key libcommon.Hash, value *uint256.Int) {
+	slots, ok := t.slots[addr]
+	if !ok {
+		slots = make(map[libcommon.Hash]uint256.Int)
+		t.slots[addr] = slots
+	}
+	if _, ok := slots[key]; !ok {
+		slots[key] = *value
+	}
+}
+
+func (t *stateDiffTracer) checkAccounts(addrs []libcommon.Address) {
+	for _, addr := range addrs {
+		t.checkAccount(addr)
+	}
+}
+
+// checkAccount reports changes of the account since the last check.
+func (t *stateDiffTracer) checkAccount(addr libcommon.Address) {
+	acc, ok := t.accounts[addr]
+	if !ok {
+		return
+	}
+	ibs := t.env.IntraBlockState()
+	if balance := ibs.GetBalance(addr); !balance.Eq(&acc.balance) {
+		prev := acc.balance
+		acc.balance.Set(balance)
+		t.writeEntry("balance", addr, func() {
+			t.writeUint256Field("from", &prev)
+			t.writeUint256Field("to", &acc.balance)
+		})
+	}
+	if nonce := ibs.GetNonce(addr); nonce != acc.nonce {
+		prev := acc.nonce
+		acc.nonce = nonce
+		t.writeEntry("nonce", addr, func() {
+			t.writeUint256Field("from", uint256.NewInt(prev))
+			t.writeUint256Field("to", uint256.NewInt(nonce))
+		})
+	}
+	if codeHash := codeHashOf(ibs, addr); codeHash != acc.codeHash {
+		prev := acc.codeHash
+		acc.codeHash = codeHash
+		t.writeEntry("code", addr, func() {
+			t.writeHashField("from", prev)
+			t.writeHashField("to", codeHash)
+		})
+	}
+}
+
+// codeHashOf returns hash of the empty code for accounts which don't exist, so that creation
+// of an account without code is not reported as a code change.
+func codeHashOf(ibs evmtypes.IntraBlockState, addr libcommon.Address) libcommon.Hash {
+	if codeHash := ibs.GetCodeHash(addr); codeHash != (libcommon.Hash{}) {
+		return codeHash
+	}
+	return emptyCodeHash
+}
+
+// writeEntry writes a single change into the stream, fields writes the change specific fields.
+func (t *stateDiffTracer) writeEntry(typ string, addr libcommon.Address, fields func()) {
+	frame := t.frames[len(t.frames)-1]
+	if !t.first {
+		t.stream.WriteMore()
+	} else {
+		t.first = false
+	}
+	t.stream.WriteObjectStart()
+	t.stream.WriteObjectField("type")
+	t.stream.WriteString(typ)
+	t.stream.WriteMore()
+	t.stream.WriteObjectField("address")
+	t.stream.WriteString(addr.Hex())
+	fields()
+	t.stream.WriteMore()
+	t.stream.WriteObjectField("depth")
+	t.stream.WriteInt(len(t.frames) - 1)
+	t.stream.WriteMore()
+	t.stream.WriteObjectField("traceAddress")
+	t.stream.WriteArrayStart()
+	for i, idx := range frame.traceAddress {
+		if i > 0 {
+			t.stream.WriteMore()
+		}
+		t.stream.WriteInt(idx)
+	}
+	t.stream.WriteArrayEnd()
+	t.stream.WriteObjectEnd()
+	if t.streaming {
+		_ = t.stream.Flush()
+	}
+}
+
+func (t *stateDiffTracer) writeHashField(name string, value libcommon.Hash) {
+	t.stream.WriteMore()
+	t.stream.WriteObjectField(name)
+	t.stream.WriteString(value.Hex())
+}
+
+func (t *stateDiffTracer) writeUint256Field(name string, value *uint256.Int) {
+	t.stream.WriteMore()
+	t.stream.WriteObjectField(name)
+	t.stream.WriteString(value.Hex())
+}
+
+// writeOpFields writes the opcode, which made the change directly.
+func (t *stateDiffTracer) writeOpFields(pc uint64, op vm.OpCode) {
+	t.stream.WriteMore()
+	t.stream.WriteObjectField("pc")
+	t.stream.WriteUint64(pc)
+	t.stream.WriteMore()
+	t.stream.WriteObjectField("op")
+	t.stream.WriteString(op.String())
+}
+
+// GetResult returns the json-encoded list of changes, unless they are already written
+// into the stream of the caller, and any error arising from the encoding or forceful
+// termination (via `Stop`).
+func (t *stateDiffTracer) GetResult() (json.RawMessage, error) {
+	if t.streaming {
+		return nil, t.reason
+	}
+	res := make([]byte, 0, len(t.stream.Buffer())+2)
+	res = append(res, '[')
+	res = append(res, t.stream.Buffer()...)
+	res = append(res, ']')
+	return res, t.reason
+}
+
+// Stop terminates execution of the tracer at the first opportune moment.
+func (t *stateDiffTracer) Stop(err error) {
+	t.reason = err
+	atomic.StoreUint32(&t.interrupt, 1)
+}
@@ -20,7 +20,9 @@ package tracers
 import (
 	"encoding/json"
 	"errors"
+	"math/big"
 
+	jsoniter "github.com/json-iterator/go"
 	libcommon "github.com/ledgerwatch/erigon-lib/common"
 
 	"github.com/ledgerwatch/erigon/core/vm"
@@ -29,9 +31,10 @@ import (
 // Context contains some contextual infos for a transaction execution that is not
 // available from within the EVM object.
 type Context struct {
-	BlockHash libcommon.Hash // Hash of the block the tx is contained within (zero if dangling tx or call)
-	TxIndex   int            // Index of the transaction within a block (zero if dangling tx or call)
-	TxHash    libcommon.Hash // Hash of the transaction being traced (zero if dangling call)
+	BlockHash   libcommon.Hash // Hash of the block the tx is contained within (zero if dangling tx or call)
+	BlockNumber *big.Int       // Number of the block the tx is contained within (zero if dangling tx or call)
+	TxIndex     int            // Index of the transaction within a block (zero if dangling tx or call)
+	TxHash      libcommon.Hash // Hash of the transaction being traced (zero if dangling call)
 }
 
 // Tracer interface extends vm.EVMLogger and additionally
@@ -43,6 +46,15 @@ type Tracer interface {
 	Stop(err error)
 }
 
+// StreamingTracer is a Tracer, which writes entries of its result into the json stream
+// during the execution, instead of accumulating them until GetResult is called.
+type StreamingTracer interface {
+	Tracer
+	// SetStream makes the tracer write entries into the given stream. Entries form an array,
+	// which is placed by the caller into the field with the returned name.
+	SetStream(stream *jsoniter.Stream) (field string)
+}
+
 type lookupFunc func(string, *Context, json.RawMessage) (Tracer, error)
 
 var (
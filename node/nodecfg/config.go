@@ -147,6 +147,8 @@ type Config struct {
 	// empty string means not to start the listener
 	PrivateApiAddr      string
 	PrivateApiRateLimit uint32
+	// PrivateApiACL - path to file with per-client table access policy of remote KV (see remotedbserver.ACL)
+	PrivateApiACL string
 
 	staticNodesWarning  bool
 	trustedNodesWarning bool
@@ -60,6 +60,10 @@ func (bt *BlockTest) UnmarshalJSON(in []byte) error {
 	return json.Unmarshal(in, &bt.json)
 }
 
+func (bt BlockTest) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&bt.json)
+}
+
 type btJSON struct {
 	Blocks     []btBlock             `json:"blocks"`
 	Genesis    btHeader              `json:"genesisBlockHeader"`
@@ -236,6 +240,33 @@ func (bt *BlockTest) insertBlocks(m *mock.MockSentry) ([]btBlock, error) {
 	return validBlocks, nil
 }
 
+// newBtHeader is the header of a block test, which passes validateHeader
+func newBtHeader(h *types.Header) btHeader {
+	return btHeader{
+		Bloom:                 h.Bloom,
+		Coinbase:              h.Coinbase,
+		MixHash:               h.MixDigest,
+		Nonce:                 h.Nonce,
+		Number:                h.Number,
+		Hash:                  h.Hash(),
+		ParentHash:            h.ParentHash,
+		ReceiptTrie:           h.ReceiptHash,
+		StateRoot:             h.Root,
+		TransactionsTrie:      h.TxHash,
+		UncleHash:             h.UncleHash,
+		ExtraData:             h.Extra,
+		Difficulty:            h.Difficulty,
+		GasLimit:              h.GasLimit,
+		GasUsed:               h.GasUsed,
+		Timestamp:             h.Time,
+		BaseFeePerGas:         h.BaseFee,
+		WithdrawalsRoot:       h.WithdrawalsHash,
+		BlobGasUsed:           h.BlobGasUsed,
+		ExcessBlobGas:         h.ExcessBlobGas,
+		ParentBeaconBlockRoot: h.ParentBeaconBlockRoot,
+	}
+}
+
 func validateHeader(h *btHeader, h2 *types.Header) error {
 	if h == nil {
 		return fmt.Errorf("validateHeader: h == nil")
@@ -0,0 +1,23 @@
+//go:build integration
+
+package tests
+
+import (
+	"testing"
+)
+
+// TestEOF runs EOFTests of ethereum/tests, if tests/testdata is checked out: the revision of EOFTests
+// isn't pinned, and only vectors of the early EOF v1 draft (see core/vm/eof.go) are expected to pass.
+func TestEOF(t *testing.T) {
+	//t.Parallel()
+	tm := new(testMatcher)
+	// EOF contracts are created by CREATE/CREATE2 and creation transactions: container sections
+	// (EIP-7620 EOFCREATE, RETURNCONTRACT) and instructions of later EIPs are not supported
+	tm.skipLoad(`(?i)(eofcreate|returncontract|subcontainer|container_section)`)
+	tm.skipLoad(`EIP(7620|7069|663)/`)
+	tm.walk(t, eofTestDir, func(t *testing.T, name string, test *EOFTest) {
+		if err := tm.checkFailure(t, test.Run()); err != nil {
+			t.Error(err)
+		}
+	})
+}
@@ -0,0 +1,57 @@
+package tests
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/ledgerwatch/erigon-lib/common/hexutility"
+
+	"github.com/ledgerwatch/erigon/core/vm"
+)
+
+// EOFTest checks validation of EVM Object Format containers (EOFTests of ethereum/tests).
+type EOFTest struct {
+	Vectors map[string]eofVector `json:"vectors"`
+}
+
+type eofVector struct {
+	Code          hexutility.Bytes           `json:"code"`
+	ContainerKind string                     `json:"containerKind"`
+	Results       map[string]eofVectorResult `json:"results"`
+}
+
+type eofVectorResult struct {
+	Result    bool   `json:"result"`
+	Exception string `json:"exception"`
+}
+
+// Run validates code of all vectors for all forks of their results. Forks before Prague are skipped.
+func (t *EOFTest) Run() error {
+	names := make([]string, 0, len(t.Vectors))
+	for name := range t.Vectors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		vector := t.Vectors[name]
+		for fork, result := range vector.Results {
+			config, ok := Forks[fork]
+			if !ok {
+				return UnsupportedForkError{fork}
+			}
+			// EOFTests were written when EOF was scheduled for Prague, here EOF is activated by Osaka:
+			// validation rules are the same, results of Prague are checked too
+			if !config.IsPrague(0) {
+				continue
+			}
+			_, err := vm.ParseAndValidateEOF(vector.Code)
+			switch {
+			case result.Result && err != nil:
+				return fmt.Errorf("%s (%s): unexpected error: %w", name, fork, err)
+			case !result.Result && err == nil:
+				return fmt.Errorf("%s (%s): expected error %s", name, fork, result.Exception)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package tests
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/ledgerwatch/erigon-lib/kv/memdb"
+
+	"github.com/ledgerwatch/erigon/core/vm"
+)
+
+const fuzzFork = "Cancun"
+
+var (
+	// single contract: CALLVALUE PUSH1 1 SSTORE
+	fuzzSeedSstore = []byte{0, 0, 4, byte(vm.CALLVALUE), byte(vm.PUSH1), 1, byte(vm.SSTORE), 0, 0, 0, 0, 0, 0, 0, 42}
+	// single contract: PUSH1 4 JUMP PUSH1 JUMPDEST STOP, JUMP to JUMPDEST in push data
+	fuzzSeedJumpIntoPushData = []byte{0, 0, 6, byte(vm.PUSH1), 4, byte(vm.JUMP), byte(vm.PUSH1), byte(vm.JUMPDEST), byte(vm.STOP)}
+)
+
+func TestGeneratedStateTestExport(t *testing.T) {
+	db := memdb.NewTestDB(t)
+	test, err := GenerateStateTest(fuzzSeedSstore, fuzzFork)
+	if err != nil {
+		t.Fatal(err)
+	}
+	subtest := StateSubtest{Fork: fuzzFork}
+	results, err := test.RunDifferential(db, subtest, DifferentialVariants)
+	if err != nil {
+		t.Fatal(err)
+	}
+	test.SetPost(subtest, results[0].Root, results[0].Logs)
+
+	// exported test is a regular state test, which passes with the reference results
+	out, err := json.Marshal(map[string]*StateTest{"fuzz": test})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var stateTests map[string]StateTest
+	if err = json.Unmarshal(out, &stateTests); err != nil {
+		t.Fatal(err)
+	}
+	exported := stateTests["fuzz"]
+	tx, err := db.BeginRw(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tx.Rollback()
+	if _, err = exported.Run(tx, subtest, vm.Config{}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDifferentialSkipAnalysis(t *testing.T) {
+	db := memdb.NewTestDB(t)
+	// JUMP to JUMPDEST in push data is valid only if jumpdest analysis is skipped
+	test, err := GenerateStateTest(fuzzSeedJumpIntoPushData, fuzzFork)
+	if err != nil {
+		t.Fatal(err)
+	}
+	results, err := test.RunDifferential(db, StateSubtest{Fork: fuzzFork}, DifferentialVariants)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !results[1].InvalidJump || !results[2].Skipped {
+		t.Fatalf("skip analysis variant must be skipped: %+v", results)
+	}
+	if results[0].Root == results[2].Root {
+		t.Fatal("skip analysis variant is expected to diverge")
+	}
+}
+
+func TestGeneratedBlockTestRoundTrip(t *testing.T) {
+	db := memdb.NewTestDB(t)
+	for _, seed := range [][]byte{fuzzSeedSstore, fuzzSeedJumpIntoPushData} {
+		test, err := GenerateStateTest(seed, fuzzFork)
+		if err != nil {
+			t.Fatal(err)
+		}
+		subtest := StateSubtest{Fork: fuzzFork}
+		bt, err := test.RunBlockTestRoundTrip(t, db, subtest)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// the block has the transaction of the state test
+		if len(bt.json.Blocks) != 1 || len(bt.json.Post) == 0 || bt.json.Blocks[0].BlockHeader.GasUsed == 0 {
+			t.Fatalf("unexpected block test %+v", bt.json)
+		}
+	}
+}
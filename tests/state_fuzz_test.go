@@ -0,0 +1,61 @@
+//go:build integration
+
+package tests
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/ledgerwatch/erigon-lib/kv/memdb"
+)
+
+// FuzzStateTestDifferential runs generated state tests with DifferentialVariants, a failing case is
+// logged as a state test, which can be executed by `evm statetest`
+func FuzzStateTestDifferential(f *testing.F) {
+	f.Add([]byte{})
+	f.Add(fuzzSeedSstore)
+	f.Add(fuzzSeedJumpIntoPushData)
+	db := memdb.New("")
+	f.Cleanup(db.Close)
+	f.Fuzz(func(t *testing.T, data []byte) {
+		test, err := GenerateStateTest(data, fuzzFork)
+		if err != nil {
+			t.Fatal(err)
+		}
+		subtest := StateSubtest{Fork: fuzzFork}
+		results, err := test.RunDifferential(db, subtest, DifferentialVariants)
+		if errors.Is(err, ErrDifferentialMismatch) {
+			test.SetPost(subtest, results[0].Root, results[0].Logs)
+			out, _ := json.MarshalIndent(map[string]*StateTest{"fuzz": test}, "", "  ")
+			t.Fatalf("%v\n%s", err, out)
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+	})
+}
+
+// FuzzBlockTestDifferential imports generated state tests converted into block tests, see RunBlockTestRoundTrip,
+// a failing case is logged as a block test
+func FuzzBlockTestDifferential(f *testing.F) {
+	f.Add([]byte{})
+	f.Add(fuzzSeedSstore)
+	f.Add(fuzzSeedJumpIntoPushData)
+	db := memdb.New("")
+	f.Cleanup(db.Close)
+	f.Fuzz(func(t *testing.T, data []byte) {
+		test, err := GenerateStateTest(data, fuzzFork)
+		if err != nil {
+			t.Fatal(err)
+		}
+		bt, err := test.RunBlockTestRoundTrip(t, db, StateSubtest{Fork: fuzzFork})
+		if errors.Is(err, ErrDifferentialMismatch) {
+			out, _ := json.MarshalIndent(map[string]*BlockTest{"fuzz": bt}, "", "  ")
+			t.Fatalf("%v\n%s", err, out)
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+	})
+}
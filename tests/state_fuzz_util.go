@@ -0,0 +1,482 @@
+package tests
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/holiman/uint256"
+	"github.com/ledgerwatch/erigon-lib/chain"
+	libcommon "github.com/ledgerwatch/erigon-lib/common"
+	"github.com/ledgerwatch/erigon-lib/common/hexutility"
+	"github.com/ledgerwatch/erigon-lib/common/length"
+	"github.com/ledgerwatch/erigon-lib/kv"
+	"github.com/ledgerwatch/log/v3"
+
+	"github.com/ledgerwatch/erigon/common"
+	"github.com/ledgerwatch/erigon/common/math"
+	"github.com/ledgerwatch/erigon/consensus/misc"
+	"github.com/ledgerwatch/erigon/core"
+	"github.com/ledgerwatch/erigon/core/state"
+	"github.com/ledgerwatch/erigon/core/types"
+	"github.com/ledgerwatch/erigon/core/types/accounts"
+	"github.com/ledgerwatch/erigon/core/vm"
+	"github.com/ledgerwatch/erigon/crypto"
+	"github.com/ledgerwatch/erigon/eth/ethconsensusconfig"
+	"github.com/ledgerwatch/erigon/params"
+	"github.com/ledgerwatch/erigon/rlp"
+)
+
+// ErrDifferentialMismatch is returned when variants of vm.Config produce different post-states
+var ErrDifferentialMismatch = errors.New("differential execution mismatch")
+
+// DifferentialVariant is a vm.Config which must not change results of execution
+type DifferentialVariant struct {
+	Name   string
+	Config vm.Config
+	// SkipOnInvalidJump excludes the variant from the comparison if a traced variant failed on invalid jump
+	// destination: jumpdest analysis can be skipped only for code which doesn't jump into push data
+	SkipOnInvalidJump bool
+}
+
+// DifferentialVariants are compared by `evm fuzz` and the fuzz target, the first one is the reference.
+// Variants with Debug and without Tracer are traced by a tracer which detects invalid jumps.
+var DifferentialVariants = []DifferentialVariant{
+	{Name: "interpreter"},
+	{Name: "traced", Config: vm.Config{Debug: true}},
+	{Name: "skipAnalysis", Config: vm.Config{SkipAnalysis: true}, SkipOnInvalidJump: true},
+}
+
+// DifferentialResult is the post-state of the subtest executed with a variant
+type DifferentialResult struct {
+	Variant     string
+	Root        libcommon.Hash
+	Logs        libcommon.Hash
+	InvalidJump bool // traced execution failed on invalid jump destination
+	Skipped     bool // excluded from the comparison, see DifferentialVariant.SkipOnInvalidJump
+}
+
+// RunDifferential executes the subtest with each of the variants, it doesn't verify the expected post-state.
+// Post-state roots and logs of all variants are compared with the first one, ErrDifferentialMismatch is
+// returned if they differ. Each variant is executed in its own transaction of db, which is rolled back.
+func (t *StateTest) RunDifferential(db kv.RwDB, subtest StateSubtest, variants []DifferentialVariant) ([]DifferentialResult, error) {
+	results := make([]DifferentialResult, len(variants))
+	invalidJump := false
+	for i, variant := range variants {
+		var tracer *invalidJumpTracer
+		cfg := variant.Config
+		if cfg.Debug && cfg.Tracer == nil {
+			tracer = &invalidJumpTracer{}
+			cfg.Tracer = tracer
+		}
+		root, logs, err := t.runVariant(db, subtest, cfg)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", variant.Name, err)
+		}
+		results[i] = DifferentialResult{Variant: variant.Name, Root: root, Logs: logs}
+		if tracer != nil && tracer.invalidJump {
+			results[i].InvalidJump = true
+			invalidJump = true
+		}
+	}
+	var err error
+	for i := 1; i < len(results); i++ {
+		if variants[i].SkipOnInvalidJump && invalidJump {
+			results[i].Skipped = true
+			continue
+		}
+		ref, res := results[0], results[i]
+		if res.Root != ref.Root || res.Logs != ref.Logs {
+			err = errors.Join(err, fmt.Errorf("%w: %s root %x logs %x, %s root %x logs %x", ErrDifferentialMismatch,
+				ref.Variant, ref.Root, ref.Logs, res.Variant, res.Root, res.Logs))
+		}
+	}
+	return results, err
+}
+
+func (t *StateTest) runVariant(db kv.RwDB, subtest StateSubtest, cfg vm.Config) (root, logs libcommon.Hash, err error) {
+	tx, err := db.BeginRw(context.Background())
+	if err != nil {
+		return root, logs, err
+	}
+	defer tx.Rollback()
+	statedb, root, err := t.RunNoVerify(tx, subtest, cfg)
+	if err != nil {
+		return root, logs, err
+	}
+	return root, rlpHash(statedb.Logs()), nil
+}
+
+// SetPost sets the expected post-state of the subtest, e.g. to export a generated test
+// with the results of the reference execution
+func (t *StateTest) SetPost(subtest StateSubtest, root, logs libcommon.Hash) {
+	post := &t.json.Post[subtest.Fork][subtest.Index]
+	post.Root = common.UnprefixedHash(root)
+	post.Logs = common.UnprefixedHash(logs)
+}
+
+// invalidJumpTracer detects jumps to invalid destinations, which can succeed if jumpdest analysis is skipped
+type invalidJumpTracer struct {
+	invalidJump bool
+}
+
+func (t *invalidJumpTracer) CaptureTxStart(gasLimit uint64) {}
+func (t *invalidJumpTracer) CaptureTxEnd(restGas uint64)    {}
+func (t *invalidJumpTracer) CaptureStart(env *vm.EVM, from libcommon.Address, to libcommon.Address, precompile bool, create bool, input []byte, gas uint64, value *uint256.Int, code []byte) {
+}
+func (t *invalidJumpTracer) CaptureEnd(output []byte, usedGas uint64, err error) {}
+func (t *invalidJumpTracer) CaptureEnter(typ vm.OpCode, from libcommon.Address, to libcommon.Address, precompile bool, create bool, input []byte, gas uint64, value *uint256.Int, code []byte) {
+}
+func (t *invalidJumpTracer) CaptureExit(output []byte, usedGas uint64, err error) {}
+func (t *invalidJumpTracer) CaptureState(pc uint64, op vm.OpCode, gas, cost uint64, scope *vm.ScopeContext, rData []byte, depth int, err error) {
+	t.capture(err)
+}
+func (t *invalidJumpTracer) CaptureFault(pc uint64, op vm.OpCode, gas, cost uint64, scope *vm.ScopeContext, depth int, err error) {
+	t.capture(err)
+}
+
+func (t *invalidJumpTracer) capture(err error) {
+	if errors.Is(err, vm.ErrInvalidJump) {
+		t.invalidJump = true
+	}
+}
+
+const (
+	fuzzMaxContracts = 3
+	fuzzMaxPushes    = 8
+	fuzzMaxCodeSize  = 128
+	fuzzMaxSlots     = 4
+	fuzzMaxDataSize  = 64
+)
+
+var (
+	// fuzzSenderKey is the key of 0xa94f5374fce5edbc8e2a8697c15331677e6ebf0b, the sender of most of the state tests
+	fuzzSenderKey = hexutility.MustDecodeHex("0x45a915e4d060149eb4365960e6a7a45f334393093061116b197e3240065ff2d8")
+	fuzzCoinbase  = libcommon.HexToAddress("0x2adc25665018aa1fe0e6bc666dac8fc2697ff9ba")
+	fuzzBaseFee   = big.NewInt(10)
+)
+
+// fuzzContractAddress is the address of the i-th generated contract: addresses are small,
+// so that generated code can call them with PUSH2
+func fuzzContractAddress(i int) libcommon.Address {
+	return libcommon.BytesToAddress([]byte{0x10, byte(i)})
+}
+
+// fuzzReader reads fuzzer input, it returns zeros when the input is exhausted
+type fuzzReader struct {
+	data []byte
+}
+
+func (r *fuzzReader) byte() byte {
+	if len(r.data) == 0 {
+		return 0
+	}
+	b := r.data[0]
+	r.data = r.data[1:]
+	return b
+}
+
+func (r *fuzzReader) bytes(n int) []byte {
+	b := make([]byte, n)
+	copy(b, r.data)
+	if n > len(r.data) {
+		n = len(r.data)
+	}
+	r.data = r.data[n:]
+	return b
+}
+
+func (r *fuzzReader) uint16() uint16 {
+	return uint16(r.byte())<<8 | uint16(r.byte())
+}
+
+// code returns EVM code: pushes of values which are likely to be meaningful operands (addresses of
+// the generated contracts, word size, max uint256) followed by raw bytes of the input
+func (r *fuzzReader) code() []byte {
+	var code []byte
+	for i := int(r.byte() % (fuzzMaxPushes + 1)); i > 0; i-- {
+		switch v := r.byte(); v % 8 {
+		case 0:
+			code = append(code, byte(vm.PUSH1), 0)
+		case 1:
+			code = append(code, byte(vm.PUSH1), 1)
+		case 2:
+			code = append(code, byte(vm.PUSH1), 32)
+		case 3:
+			addr := fuzzContractAddress(int(v/8) % fuzzMaxContracts)
+			code = append(code, byte(vm.PUSH2), addr[18], addr[19])
+		case 4:
+			code = append(code, byte(vm.PUSH32))
+			code = append(code, bytes.Repeat([]byte{0xff}, 32)...)
+		case 5:
+			code = append(code, byte(vm.GAS))
+		default:
+			code = append(code, byte(vm.PUSH1), r.byte())
+		}
+	}
+	return append(code, r.bytes(int(r.byte())%(fuzzMaxCodeSize+1))...)
+}
+
+// GenerateStateTest deterministically builds a state test from fuzzer input: a pre-state with few contracts
+// and a transaction which calls the first of them or creates a contract. The test has a single subtest of
+// the fork without expected post-state, see SetPost.
+func GenerateStateTest(data []byte, fork string) (*StateTest, error) {
+	if _, _, err := GetChainConfig(fork); err != nil {
+		return nil, err
+	}
+	key, err := crypto.ToECDSA(fuzzSenderKey)
+	if err != nil {
+		return nil, err
+	}
+	r := &fuzzReader{data: data}
+
+	pre := types.GenesisAlloc{
+		crypto.PubkeyToAddress(key.PublicKey): {Balance: new(big.Int).Lsh(big.NewInt(1), 100)},
+	}
+	for i := 0; i < 1+int(r.byte())%fuzzMaxContracts; i++ {
+		account := types.GenesisAccount{
+			Code:    r.code(),
+			Balance: big.NewInt(int64(r.uint16())),
+			Storage: map[libcommon.Hash]libcommon.Hash{},
+		}
+		for j := int(r.byte()) % (fuzzMaxSlots + 1); j > 0; j-- {
+			account.Storage[libcommon.BytesToHash([]byte{r.byte()})] = libcommon.BytesToHash([]byte{r.byte()})
+		}
+		pre[fuzzContractAddress(i)] = account
+	}
+
+	txData := r.bytes(int(r.byte()) % (fuzzMaxDataSize + 1))
+	to := fuzzContractAddress(0).Hex()
+	if r.byte()%8 == 7 {
+		txData, to = r.code(), ""
+	}
+	tx := stTransaction{
+		GasPrice:   math.NewHexOrDecimal256(fuzzBaseFee.Int64()),
+		GasLimit:   []math.HexOrDecimal64{math.HexOrDecimal64(100_000 + 8*uint64(r.uint16()))},
+		PrivateKey: fuzzSenderKey,
+		To:         to,
+		Data:       []string{hexutility.Encode(txData)},
+		Value:      []string{fmt.Sprintf("%#x", r.byte())},
+	}
+
+	return &StateTest{json: stJSON{
+		Env: stEnv{
+			Coinbase:   fuzzCoinbase,
+			Difficulty: big.NewInt(0x20000),
+			Random:     big.NewInt(0x20000),
+			GasLimit:   30_000_000,
+			Number:     1,
+			Timestamp:  1000,
+			BaseFee:    fuzzBaseFee,
+		},
+		Pre:  pre,
+		Tx:   tx,
+		Post: map[string][]stPostState{fork: {{}}},
+	}}, nil
+}
+
+// ToBlockTest converts the subtest into a block test of a single block on top of the genesis with the pre-state.
+// The block has the environment and the transaction of the subtest, its post-state, receipts and gas used are the
+// results of execution of the block by core.ExecuteBlockEphemerally in a transaction of db, which is rolled back.
+// Only proof-of-stake forks before Prague are supported. Unlike the state test, the block can read hash of the
+// genesis by BLOCKHASH, and the beacon roots contract is called before the transaction since Cancun.
+func (t *StateTest) ToBlockTest(db kv.RwDB, subtest StateSubtest) (*BlockTest, error) {
+	config, ok := Forks[subtest.Fork]
+	if !ok {
+		return nil, UnsupportedForkError{subtest.Fork}
+	}
+	env := t.json.Env
+	if config.TerminalTotalDifficulty == nil || config.TerminalTotalDifficulty.Sign() != 0 || config.IsPrague(env.Timestamp) {
+		return nil, UnsupportedForkError{subtest.Fork}
+	}
+	txn, err := t.signedTransaction(config, subtest)
+	if err != nil {
+		return nil, err
+	}
+
+	// gas used of the genesis is the gas target, so that the base fee of the block is the one of the genesis
+	genesis := &types.Genesis{
+		Config:     config,
+		Difficulty: new(big.Int),
+		GasLimit:   env.GasLimit,
+		GasUsed:    env.GasLimit / params.ElasticityMultiplier,
+		BaseFee:    env.BaseFee,
+		Alloc:      t.json.Pre,
+	}
+	genesisBlock, _, err := core.GenesisToBlock(genesis, "", log.Root())
+	if err != nil {
+		return nil, err
+	}
+	parent := genesisBlock.Header()
+	header := &types.Header{
+		ParentHash: parent.Hash(),
+		UncleHash:  types.EmptyUncleHash,
+		Coinbase:   env.Coinbase,
+		Difficulty: new(big.Int),
+		Number:     big.NewInt(1),
+		GasLimit:   env.GasLimit,
+		Time:       env.Timestamp,
+		BaseFee:    misc.CalcBaseFee(config, parent),
+	}
+	if env.Random != nil {
+		header.MixDigest = libcommon.BigToHash(env.Random)
+	}
+	var withdrawals []*types.Withdrawal
+	if config.IsShanghai(header.Time) {
+		withdrawals = []*types.Withdrawal{}
+	}
+	if config.IsCancun(header.Time) {
+		blobGasUsed, excessBlobGas := txn.GetBlobGas(), misc.CalcExcessBlobGas(config, parent)
+		header.BlobGasUsed, header.ExcessBlobGas = &blobGasUsed, &excessBlobGas
+		header.ParentBeaconBlockRoot = &libcommon.Hash{}
+	}
+
+	tx, err := db.BeginRw(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+	if _, err = MakePreState(&chain.Rules{}, tx, t.json.Pre, 0); err != nil {
+		return nil, err
+	}
+	// the header is filled by results of the execution: they are not verified
+	vmConfig := vm.Config{StatelessExec: true}
+	engine := ethconsensusconfig.CreateConsensusEngineBareBones(context.Background(), config, log.Root())
+	getHash := func(n uint64) libcommon.Hash { return parent.Hash() }
+	block := types.NewBlock(header, types.Transactions{txn}, nil, nil, withdrawals)
+	execRs, err := core.ExecuteBlockEphemerally(config, &vmConfig, getHash, engine, block, state.NewPlainStateReader(tx),
+		state.NewPlainStateWriter(tx, tx, 1), &core.FakeChainReader{Cfg: config}, nil, log.Root())
+	if err != nil {
+		return nil, err
+	}
+	if len(execRs.Rejected) > 0 {
+		return nil, fmt.Errorf("invalid transaction: %s", execRs.Rejected[0].Err)
+	}
+	if header.Root, err = calcRootFromPlainState(tx); err != nil {
+		return nil, err
+	}
+	header.GasUsed = uint64(execRs.GasUsed)
+	block = types.NewBlock(header, types.Transactions{txn}, nil, execRs.Receipts, withdrawals)
+	post, err := readPlainStateAlloc(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	blockRlp, err := rlp.EncodeToBytes(block)
+	if err != nil {
+		return nil, err
+	}
+	blockHeader := newBtHeader(block.Header())
+	return &BlockTest{json: btJSON{
+		Blocks:     []btBlock{{BlockHeader: &blockHeader, Rlp: hexutility.Encode(blockRlp)}},
+		Genesis:    newBtHeader(parent),
+		Pre:        t.json.Pre,
+		Post:       post,
+		BestBlock:  common.UnprefixedHash(block.Hash()),
+		Network:    subtest.Fork,
+		SealEngine: "NoProof",
+	}}, nil
+}
+
+// RunBlockTestRoundTrip converts the subtest into a block test, see ToBlockTest, and imports the block test marshalled
+// to JSON and back by the staged sync, which verifies the state root, receipts and gas used of the block:
+// ErrDifferentialMismatch is returned if the import fails. The block test is returned, e.g. to export it.
+func (t *StateTest) RunBlockTestRoundTrip(tb *testing.T, db kv.RwDB, subtest StateSubtest) (*BlockTest, error) {
+	bt, err := t.ToBlockTest(db, subtest)
+	if err != nil {
+		return nil, err
+	}
+	out, err := json.Marshal(bt)
+	if err != nil {
+		return nil, err
+	}
+	var imported BlockTest
+	if err = json.Unmarshal(out, &imported); err != nil {
+		return nil, err
+	}
+	if err = imported.Run(tb, true /* checkStateRoot */); err != nil {
+		return bt, fmt.Errorf("%w: block test: %w", ErrDifferentialMismatch, err)
+	}
+	return bt, nil
+}
+
+// signedTransaction returns the transaction of the subtest signed by its private key
+func (t *StateTest) signedTransaction(config *chain.Config, subtest StateSubtest) (types.Transaction, error) {
+	post := t.json.Post[subtest.Fork][subtest.Index]
+	if len(post.Tx) != 0 {
+		return types.UnmarshalTransactionFromBinary(post.Tx)
+	}
+	stTx := t.json.Tx
+	if stTx.BlobGasFeeCap != nil {
+		return nil, errors.New("blob transactions are not supported")
+	}
+	msg, err := toMessage(stTx, post, t.json.Env.BaseFee)
+	if err != nil {
+		return nil, err
+	}
+	key, err := crypto.ToECDSA(stTx.PrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid private key: %w", err)
+	}
+	// nonce isn't checked by execution of ToBlockTest
+	if nonce := t.json.Pre[msg.From()].Nonce; msg.Nonce() != nonce {
+		return nil, fmt.Errorf("nonce of transaction %d, of sender %d", msg.Nonce(), nonce)
+	}
+	commonTx := types.CommonTx{Nonce: msg.Nonce(), Gas: msg.Gas(), To: msg.To(), Value: msg.Value(), Data: msg.Data()}
+	var txn types.Transaction
+	switch {
+	case stTx.MaxFeePerGas != nil:
+		tip := stTx.MaxPriorityFeePerGas
+		if tip == nil {
+			tip = stTx.MaxFeePerGas
+		}
+		txn = &types.DynamicFeeTransaction{
+			CommonTx:   commonTx,
+			ChainID:    uint256.MustFromBig(config.ChainID),
+			Tip:        uint256.MustFromBig((*big.Int)(tip)),
+			FeeCap:     uint256.MustFromBig((*big.Int)(stTx.MaxFeePerGas)),
+			AccessList: msg.AccessList(),
+		}
+	case stTx.GasPrice == nil:
+		return nil, errors.New("no gas price provided")
+	case len(msg.AccessList()) > 0:
+		txn = &types.AccessListTx{
+			LegacyTx:   types.LegacyTx{CommonTx: commonTx, GasPrice: uint256.MustFromBig((*big.Int)(stTx.GasPrice))},
+			ChainID:    uint256.MustFromBig(config.ChainID),
+			AccessList: msg.AccessList(),
+		}
+	default:
+		txn = &types.LegacyTx{CommonTx: commonTx, GasPrice: uint256.MustFromBig((*big.Int)(stTx.GasPrice))}
+	}
+	return types.SignTx(txn, *types.LatestSigner(config), key)
+}
+
+// readPlainStateAlloc reads accounts of the plain state, e.g. the expected post-state of a block test
+func readPlainStateAlloc(tx kv.Tx) (types.GenesisAlloc, error) {
+	reader := state.NewPlainStateReader(tx)
+	alloc := types.GenesisAlloc{}
+	if err := tx.ForEach(kv.PlainState, nil, func(k, v []byte) error {
+		addr := libcommon.BytesToAddress(k[:length.Addr])
+		if len(k) > length.Addr {
+			alloc[addr].Storage[libcommon.BytesToHash(k[length.Addr+length.Incarnation:])] = libcommon.BytesToHash(v)
+			return nil
+		}
+		var acc accounts.Account
+		if err := acc.DecodeForStorage(v); err != nil {
+			return err
+		}
+		code, err := reader.ReadAccountCode(addr, acc.Incarnation, acc.CodeHash)
+		if err != nil {
+			return err
+		}
+		alloc[addr] = types.GenesisAccount{Balance: acc.Balance.ToBig(), Nonce: acc.Nonce, Code: code, Storage: map[libcommon.Hash]libcommon.Hash{}}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return alloc, nil
+}
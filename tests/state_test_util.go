@@ -65,6 +65,10 @@ func (t *StateTest) UnmarshalJSON(in []byte) error {
 	return json.Unmarshal(in, &t.json)
 }
 
+func (t StateTest) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&t.json)
+}
+
 type stJSON struct {
 	Env  stEnv                    `json:"env"`
 	Pre  types.GenesisAlloc       `json:"pre"`
@@ -76,19 +80,19 @@ type stJSON struct {
 type stPostState struct {
 	Root            common.UnprefixedHash `json:"hash"`
 	Logs            common.UnprefixedHash `json:"logs"`
-	Tx              hexutility.Bytes      `json:"txbytes"`
-	ExpectException string                `json:"expectException"`
+	Tx              hexutility.Bytes      `json:"txbytes,omitempty"`
+	ExpectException string                `json:"expectException,omitempty"`
 	Indexes         struct {
 		Data  int `json:"data"`
 		Gas   int `json:"gas"`
 		Value int `json:"value"`
-	}
+	} `json:"indexes"`
 }
 
 type stTransaction struct {
-	GasPrice             *math.HexOrDecimal256 `json:"gasPrice"`
-	MaxFeePerGas         *math.HexOrDecimal256 `json:"maxFeePerGas"`
-	MaxPriorityFeePerGas *math.HexOrDecimal256 `json:"maxPriorityFeePerGas"`
+	GasPrice             *math.HexOrDecimal256 `json:"gasPrice,omitempty"`
+	MaxFeePerGas         *math.HexOrDecimal256 `json:"maxFeePerGas,omitempty"`
+	MaxPriorityFeePerGas *math.HexOrDecimal256 `json:"maxPriorityFeePerGas,omitempty"`
 	Nonce                math.HexOrDecimal64   `json:"nonce"`
 	GasLimit             []math.HexOrDecimal64 `json:"gasLimit"`
 	PrivateKey           hexutility.Bytes      `json:"secretKey"`
@@ -260,16 +264,24 @@ func (t *StateTest) RunNoVerify(tx kv.RwTx, subtest StateSubtest, vmconfig vm.Co
 	if err = statedb.CommitBlock(evm.ChainRules(), w); err != nil {
 		return nil, libcommon.Hash{}, err
 	}
-	// Generate hashed state
-	c, err := tx.RwCursor(kv.PlainState)
+	root, err := calcRootFromPlainState(tx)
 	if err != nil {
 		return nil, libcommon.Hash{}, err
 	}
+	return statedb, root, nil
+}
+
+// calcRootFromPlainState generates hashed state of the plain state and calculates the state root
+func calcRootFromPlainState(tx kv.RwTx) (libcommon.Hash, error) {
+	c, err := tx.RwCursor(kv.PlainState)
+	if err != nil {
+		return libcommon.Hash{}, err
+	}
 	h := libcommon.NewHasher()
 	defer libcommon.ReturnHasherToPool(h)
 	for k, v, err := c.First(); k != nil; k, v, err = c.Next() {
 		if err != nil {
-			return nil, libcommon.Hash{}, fmt.Errorf("interate over plain state: %w", err)
+			return libcommon.Hash{}, fmt.Errorf("interate over plain state: %w", err)
 		}
 		var newK []byte
 		if len(k) == length.Addr {
@@ -290,11 +302,11 @@ func (t *StateTest) RunNoVerify(tx kv.RwTx, subtest StateSubtest, vmconfig vm.Co
 			//nolint:errcheck
 			h.Sha.Read(newK[length.Hash+length.Incarnation:])
 			if err = tx.Put(kv.HashedStorage, newK, libcommon.CopyBytes(v)); err != nil {
-				return nil, libcommon.Hash{}, fmt.Errorf("insert hashed key: %w", err)
+				return libcommon.Hash{}, fmt.Errorf("insert hashed key: %w", err)
 			}
 		} else {
 			if err = tx.Put(kv.HashedAccounts, newK, libcommon.CopyBytes(v)); err != nil {
-				return nil, libcommon.Hash{}, fmt.Errorf("insert hashed key: %w", err)
+				return libcommon.Hash{}, fmt.Errorf("insert hashed key: %w", err)
 			}
 		}
 	}
@@ -302,9 +314,9 @@ func (t *StateTest) RunNoVerify(tx kv.RwTx, subtest StateSubtest, vmconfig vm.Co
 
 	root, err := trie.CalcRoot("", tx)
 	if err != nil {
-		return nil, libcommon.Hash{}, fmt.Errorf("error calculating state root: %w", err)
+		return libcommon.Hash{}, fmt.Errorf("error calculating state root: %w", err)
 	}
-	return statedb, root, nil
+	return root, nil
 }
 
 func MakePreState(rules *chain.Rules, tx kv.RwTx, accounts types.GenesisAlloc, blockNr uint64) (*state.IntraBlockState, error) {
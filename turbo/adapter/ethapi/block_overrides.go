@@ -0,0 +1,75 @@
+package ethapi
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/holiman/uint256"
+	libcommon "github.com/ledgerwatch/erigon-lib/common"
+	"github.com/ledgerwatch/erigon-lib/common/hexutil"
+
+	"github.com/ledgerwatch/erigon/core/vm/evmtypes"
+)
+
+// BlockOverrides is a set of header fields to override when executing a call on top of a block.
+// BlockHash overrides the results of the BLOCKHASH opcode by block number.
+type BlockOverrides struct {
+	Number      *hexutil.Big              `json:"number"`
+	Difficulty  *hexutil.Big              `json:"difficulty"`
+	Time        *hexutil.Uint64           `json:"time"`
+	GasLimit    *hexutil.Uint64           `json:"gasLimit"`
+	Coinbase    *libcommon.Address        `json:"coinbase"`
+	PrevRandao  *libcommon.Hash           `json:"prevRandao"`
+	BaseFee     *hexutil.Big              `json:"baseFee"`
+	BlobBaseFee *hexutil.Big              `json:"blobBaseFee"`
+	BlockHash   map[uint64]libcommon.Hash `json:"blockHash"`
+}
+
+func (overrides *BlockOverrides) Override(blockCtx *evmtypes.BlockContext) error {
+	if overrides.Number != nil {
+		if !(*big.Int)(overrides.Number).IsUint64() {
+			return fmt.Errorf("block number override higher than 2^64-1")
+		}
+		blockCtx.BlockNumber = (*big.Int)(overrides.Number).Uint64()
+	}
+	if overrides.Difficulty != nil {
+		blockCtx.Difficulty = new(big.Int).Set((*big.Int)(overrides.Difficulty))
+	}
+	if overrides.Time != nil {
+		blockCtx.Time = uint64(*overrides.Time)
+	}
+	if overrides.GasLimit != nil {
+		blockCtx.GasLimit = uint64(*overrides.GasLimit)
+	}
+	if overrides.Coinbase != nil {
+		blockCtx.Coinbase = *overrides.Coinbase
+	}
+	if overrides.PrevRandao != nil {
+		prevRandao := *overrides.PrevRandao
+		blockCtx.PrevRanDao = &prevRandao
+	}
+	if overrides.BaseFee != nil {
+		baseFee, overflow := uint256.FromBig((*big.Int)(overrides.BaseFee))
+		if overflow {
+			return fmt.Errorf("base fee override higher than 2^256-1")
+		}
+		blockCtx.BaseFee = baseFee
+	}
+	if overrides.BlobBaseFee != nil {
+		blobBaseFee, overflow := uint256.FromBig((*big.Int)(overrides.BlobBaseFee))
+		if overflow {
+			return fmt.Errorf("blob base fee override higher than 2^256-1")
+		}
+		blockCtx.BlobBaseFee = blobBaseFee
+	}
+	if len(overrides.BlockHash) > 0 {
+		getHash := blockCtx.GetHash
+		blockCtx.GetHash = func(n uint64) libcommon.Hash {
+			if hash, ok := overrides.BlockHash[n]; ok {
+				return hash
+			}
+			return getHash(n)
+		}
+	}
+	return nil
+}
@@ -36,9 +36,12 @@ import (
 	"github.com/ledgerwatch/erigon/cmd/utils"
 	"github.com/ledgerwatch/erigon/core/rawdb"
 	"github.com/ledgerwatch/erigon/core/rawdb/blockio"
+	"github.com/ledgerwatch/erigon/core/state/temporal"
+	"github.com/ledgerwatch/erigon/core/systemcontracts"
 	"github.com/ledgerwatch/erigon/diagnostics"
 	"github.com/ledgerwatch/erigon/eth/ethconfig"
 	"github.com/ledgerwatch/erigon/eth/ethconfig/estimate"
+	"github.com/ledgerwatch/erigon/eth/ethconsensusconfig"
 	"github.com/ledgerwatch/erigon/eth/stagedsync/stages"
 	"github.com/ledgerwatch/erigon/params"
 	erigoncli "github.com/ledgerwatch/erigon/turbo/cli"
@@ -46,6 +49,7 @@ import (
 	"github.com/ledgerwatch/erigon/turbo/logging"
 	"github.com/ledgerwatch/erigon/turbo/node"
 	"github.com/ledgerwatch/erigon/turbo/snapshotsync/freezeblocks"
+	"github.com/ledgerwatch/erigon/turbo/transactions"
 )
 
 func joinFlags(lists ...[]cli.Flag) (res []cli.Flag) {
@@ -77,6 +81,12 @@ var snapshotCommand = cli.Command{
 				&SnapshotRebuildFlag,
 			}),
 		},
+		{
+			Name:   "logindex",
+			Action: doLogIndexCommand,
+			Usage:  "Build missed files of logs index of state history (logaddrs, logtopics) from receipts snapshots, or by re-execution on state history",
+			Flags:  joinFlags([]cli.Flag{&utils.DataDirFlag}),
+		},
 		{
 			Name:   "retire",
 			Action: doRetireCommand,
@@ -311,6 +321,68 @@ func doIndicesCommand(cliCtx *cli.Context) error {
 	return nil
 }
 
+func doLogIndexCommand(cliCtx *cli.Context) error {
+	logger, _, err := debug.Setup(cliCtx, true /* rootLogger */)
+	if err != nil {
+		return err
+	}
+	defer logger.Info("Done")
+	ctx := cliCtx.Context
+
+	dirs := datadir.New(cliCtx.String(utils.DataDirFlag.Name))
+	chainDB := mdbx.NewMDBX(logger).Label(kv.ChainDB).Path(dirs.Chaindata).MustOpen()
+	defer chainDB.Close()
+	if !kvcfg.HistoryV3.FromDB(chainDB) {
+		return fmt.Errorf("logs index of state history requires --history.v3")
+	}
+
+	dir.MustExist(dirs.SnapHistory)
+	chainConfig := fromdb.ChainConfig(chainDB)
+	cfg := ethconfig.NewSnapCfg(true, false, true)
+	blockSnaps, borSnaps, _, agg, err := openSnaps(ctx, cfg, dirs, snapcfg.KnownCfg(chainConfig.ChainName, 0).Version, chainDB, logger)
+	if err != nil {
+		return err
+	}
+	defer blockSnaps.Close()
+	defer borSnaps.Close()
+	defer agg.Close()
+	blockReader := freezeblocks.NewBlockReader(blockSnaps, borSnaps)
+	db, err := temporal.New(chainDB, agg, systemcontracts.SystemContractCodeLookup[chainConfig.ChainName])
+	if err != nil {
+		return err
+	}
+	engine := ethconsensusconfig.CreateConsensusEngineBareBones(ctx, chainConfig, logger)
+
+	steps := agg.LogIndexMissedSteps()
+	logger.Info("[snapshots] logs index", "missed_steps", len(steps))
+	for i, step := range steps {
+		if err := agg.BuildLogIndexFiles(ctx, step, func(txFrom, txTo uint64, add func(txNum uint64, addr []byte, topics [][]byte)) error {
+			return db.ViewTemporal(ctx, func(tx kv.TemporalTx) error {
+				err := blockReader.WalkLogsFromSnapshots(ctx, tx, txFrom, txTo, add)
+				if !errors.Is(err, freezeblocks.ErrReceiptsNotFrozen) {
+					return err
+				}
+				// re-execution on state history: logs, which are already walked, are added to index again - it's idempotent
+				logger.Debug("[snapshots] logs index: re-execution", "step", step, "reason", err)
+				return transactions.WalkLogsFromHistory(ctx, tx, chainConfig, engine, blockReader, txFrom, txTo, add)
+			})
+		}); err != nil {
+			return fmt.Errorf("logs index of step %d: %w", step, err)
+		}
+		logger.Info("[snapshots] logs index", "step", step, "progress", fmt.Sprintf("%d/%d", i+1, len(steps)))
+	}
+	if len(steps) == 0 {
+		return nil
+	}
+
+	if err = agg.MergeLoop(ctx, estimate.CompressSnapshot.Workers()); err != nil {
+		return err
+	}
+	return chainDB.Update(ctx, func(tx kv.RwTx) error {
+		return rawdb.WriteSnapshots(tx, blockSnaps.Files(), agg.Files())
+	})
+}
+
 func openSnaps(ctx context.Context, cfg ethconfig.BlocksFreezing, dirs datadir.Dirs, version uint8, chainDB kv.RwDB, logger log.Logger) (
 	blockSnaps *freezeblocks.RoSnapshots, borSnaps *freezeblocks.BorRoSnapshots, br *freezeblocks.BlockRetire, agg *libstate.AggregatorV3, err error,
 ) {
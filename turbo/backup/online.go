@@ -0,0 +1,233 @@
+package backup
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	libcommon "github.com/ledgerwatch/erigon-lib/common"
+	"github.com/ledgerwatch/erigon-lib/common/datadir"
+	"github.com/ledgerwatch/erigon-lib/kv"
+	"github.com/ledgerwatch/log/v3"
+
+	"github.com/ledgerwatch/erigon/core/rawdb"
+	"github.com/ledgerwatch/erigon/eth/stagedsync/stages"
+)
+
+const (
+	ManifestFileName = "manifest.json"
+	ManifestVersion  = 1
+
+	// layout of backup dir
+	ChaindataDirName = "chaindata"
+	SnapshotsDirName = "snapshots"
+)
+
+// Manifest - ties backup to the state of node at the moment of backup: progress of stages and set of snapshot files.
+// Written last: backup without manifest is incomplete.
+type Manifest struct {
+	Version   int       `json:"version"`
+	CreatedAt time.Time `json:"createdAt"`
+	Genesis   string    `json:"genesis"`
+	TxID      uint64    `json:"txId"` // id of read transaction which was copied
+
+	Stages           map[string]uint64 `json:"stages"`
+	Tables           map[string]uint64 `json:"tables"`           // table -> amount of entries
+	Snapshots        []string          `json:"snapshots"`        // block snapshots registered in db
+	SnapshotsHistory []string          `json:"snapshotsHistory"` // state history snapshots registered in db
+	Files            map[string]int64  `json:"files"`            // file in `snapshots` dir (relative path) -> size
+}
+
+func ReadManifest(backupDir string) (*Manifest, error) {
+	data, err := os.ReadFile(filepath.Join(backupDir, ManifestFileName))
+	if err != nil {
+		return nil, err
+	}
+	m := &Manifest{}
+	if err := json.Unmarshal(data, m); err != nil {
+		return nil, fmt.Errorf("%s: %w", ManifestFileName, err)
+	}
+	if m.Version != ManifestVersion {
+		return nil, fmt.Errorf("%s: unsupported version %d, expected %d", ManifestFileName, m.Version, ManifestVersion)
+	}
+	return m, nil
+}
+
+func writeManifest(backupDir string, m *Manifest) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmpPath := filepath.Join(backupDir, ManifestFileName+".tmp")
+	if err := os.WriteFile(tmpPath, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, filepath.Join(backupDir, ManifestFileName))
+}
+
+// Online - hot backup of running node to `backupDir`:
+//   - all tables are copied to `dst` from one read transaction - it's consistent snapshot of db (node continues to write)
+//   - snapshot files are immutable - they are hard-linked (copied if backupDir is on another disk)
+//
+// Long read transaction doesn't allow db to re-use pages - db may grow while backup is running.
+func Online(ctx context.Context, src kv.RoDB, dst kv.RwDB, dirs datadir.Dirs, backupDir string, readAheadThreads int, logger log.Logger) (*Manifest, error) {
+	if _, err := os.Stat(filepath.Join(backupDir, ManifestFileName)); err == nil {
+		return nil, fmt.Errorf("backup already exists: %s", backupDir)
+	}
+	srcTx, err := src.BeginRo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer srcTx.Rollback()
+
+	m := &Manifest{
+		Version:   ManifestVersion,
+		CreatedAt: time.Now().UTC(),
+		TxID:      srcTx.ViewID(),
+		Stages:    map[string]uint64{},
+		Tables:    map[string]uint64{},
+		Files:     map[string]int64{},
+	}
+	genesis, err := rawdb.ReadCanonicalHash(srcTx, 0)
+	if err != nil {
+		return nil, err
+	}
+	m.Genesis = genesis.Hex()
+	for _, stage := range stages.AllStages {
+		progress, err := stages.GetStageProgress(srcTx, stage)
+		if err != nil {
+			return nil, err
+		}
+		m.Stages[string(stage)] = progress
+	}
+	if m.Snapshots, m.SnapshotsHistory, err = rawdb.ReadSnapshots(srcTx); err != nil {
+		return nil, err
+	}
+
+	// files can be removed by merge of snapshots - link them asap
+	logger.Info("[backup] linking snapshot files", "from", dirs.Snap)
+	if err := linkSnapshots(ctx, dirs.Snap, filepath.Join(backupDir, SnapshotsDirName), m.Files); err != nil {
+		return nil, err
+	}
+	for _, name := range append(append([]string{}, m.Snapshots...), m.SnapshotsHistory...) {
+		if !hasFile(m.Files, name) {
+			return nil, fmt.Errorf("snapshot file %s registered in db, but not found (removed by merge?), please retry backup", name)
+		}
+	}
+
+	logEvery := time.NewTicker(20 * time.Second)
+	defer logEvery.Stop()
+	for name, cfg := range src.AllTables() {
+		if cfg.IsDeprecated {
+			continue
+		}
+		logger.Info("[backup] copy", "table", name, "txId", m.TxID)
+		if err := backupTable(ctx, src, srcTx, dst, name, readAheadThreads, logEvery, logger); err != nil {
+			return nil, fmt.Errorf("table %s: %w", name, err)
+		}
+		c, err := srcTx.Cursor(name)
+		if err != nil {
+			return nil, err
+		}
+		m.Tables[name], err = c.Count()
+		c.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := writeManifest(backupDir, m); err != nil {
+		return nil, err
+	}
+	logger.Info("[backup] done", "dir", backupDir, "txId", m.TxID, "files", len(m.Files), "stages", m.Stages[string(stages.Finish)])
+	return m, nil
+}
+
+// hasFile - `name` is file name (without dir), as it's stored in db
+func hasFile(files map[string]int64, name string) bool {
+	for path := range files {
+		if filepath.Base(path) == name {
+			return true
+		}
+	}
+	return false
+}
+
+// linkSnapshots - hard-links (or copies) all files of `from` dir recursively, except temporary files and downloader's db
+func linkSnapshots(ctx context.Context, from, to string, files map[string]int64) error {
+	return filepath.WalkDir(from, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if err := libcommon.Stopped(ctx.Done()); err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(from, path)
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if rel == "db" {
+				return filepath.SkipDir
+			}
+			return os.MkdirAll(filepath.Join(to, rel), 0755)
+		}
+		if !d.Type().IsRegular() || strings.HasSuffix(path, ".tmp") || strings.HasSuffix(path, ".lock") {
+			return nil
+		}
+		// not registered files may be removed by merge while we walk, registered files are checked by caller
+		info, err := d.Info()
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if err := linkOrCopy(path, filepath.Join(to, rel)); err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		files[filepath.ToSlash(rel)] = info.Size()
+		return nil
+	})
+}
+
+// linkOrCopy - hard-link is enough for immutable files. Falls back to copy if dirs are on different disks.
+func linkOrCopy(from, to string) error {
+	if err := os.Link(from, to); err == nil {
+		return nil
+	}
+	return copyFile(from, to)
+}
+
+func copyFile(from, to string) error {
+	r, err := os.Open(from)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+	w, err := os.Create(to + ".tmp")
+	if err != nil {
+		return err
+	}
+	defer w.Close()
+	if _, err = io.Copy(w, r); err != nil {
+		return err
+	}
+	if err = w.Sync(); err != nil {
+		return err
+	}
+	if err = w.Close(); err != nil {
+		return err
+	}
+	return os.Rename(to+".tmp", to)
+}
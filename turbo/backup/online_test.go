@@ -0,0 +1,128 @@
+package backup
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	libcommon "github.com/ledgerwatch/erigon-lib/common"
+	"github.com/ledgerwatch/erigon-lib/common/datadir"
+	"github.com/ledgerwatch/erigon-lib/kv"
+	mdbx2 "github.com/ledgerwatch/erigon-lib/kv/mdbx"
+	"github.com/ledgerwatch/log/v3"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ledgerwatch/erigon/core/rawdb"
+	"github.com/ledgerwatch/erigon/eth/stagedsync/stages"
+)
+
+const testSnapshot = "v1-000000-000500-headers.seg"
+
+// testDatadir - datadir with chaindata (genesis, stage progress, headers) and 1 registered snapshot file
+func testDatadir(t *testing.T, logger log.Logger) datadir.Dirs {
+	t.Helper()
+	dirs := datadir.New(t.TempDir())
+	db := mdbx2.NewMDBX(logger).Path(dirs.Chaindata).Label(kv.ChainDB).MustOpen()
+	defer db.Close()
+	require.NoError(t, db.Update(context.Background(), func(tx kv.RwTx) error {
+		if err := rawdb.WriteCanonicalHash(tx, libcommon.Hash{1}, 0); err != nil {
+			return err
+		}
+		if err := stages.SaveStageProgress(tx, stages.Headers, 10); err != nil {
+			return err
+		}
+		for i := byte(0); i < 10; i++ {
+			if err := tx.Put(kv.Headers, []byte{i}, []byte{i}); err != nil {
+				return err
+			}
+		}
+		return rawdb.WriteSnapshots(tx, []string{testSnapshot}, nil)
+	}))
+	require.NoError(t, os.WriteFile(filepath.Join(dirs.Snap, testSnapshot), make([]byte, 1024), 0644))
+	return dirs
+}
+
+func testBackup(t *testing.T, dirs datadir.Dirs, logger log.Logger) string {
+	t.Helper()
+	backupDir := t.TempDir()
+	src := mdbx2.NewMDBX(logger).Path(dirs.Chaindata).Label(kv.ChainDB).Readonly().MustOpen()
+	defer src.Close()
+	dst := mdbx2.NewMDBX(logger).Path(filepath.Join(backupDir, ChaindataDirName)).Label(kv.ChainDB).MustOpen()
+	defer dst.Close()
+	m, err := Online(context.Background(), src, dst, dirs, backupDir, 1, logger)
+	require.NoError(t, err)
+	require.Equal(t, libcommon.Hash{1}.Hex(), m.Genesis)
+	require.Equal(t, uint64(10), m.Stages[string(stages.Headers)])
+	require.Equal(t, uint64(10), m.Tables[kv.Headers])
+	require.Equal(t, map[string]int64{testSnapshot: 1024}, m.Files)
+	return backupDir
+}
+
+func TestBackupCorrupted(t *testing.T) {
+	ctx, logger := context.Background(), log.New()
+
+	t.Run("valid", func(t *testing.T) {
+		dirs := testDatadir(t, logger)
+		backupDir := testBackup(t, dirs, logger)
+		_, err := Validate(ctx, backupDir, logger)
+		require.NoError(t, err)
+		_, err = Restore(ctx, backupDir, dirs, logger)
+		require.NoError(t, err)
+		_, err = os.Stat(filepath.Join(dirs.Snap, testSnapshot))
+		require.NoError(t, err)
+	})
+
+	// Restore must reject backup and leave datadir untouched
+	restoreRejected := func(t *testing.T, dirs datadir.Dirs, backupDir, errContains string) {
+		t.Helper()
+		_, err := Validate(ctx, backupDir, logger)
+		require.ErrorContains(t, err, errContains)
+		_, err = Restore(ctx, backupDir, dirs, logger)
+		require.ErrorContains(t, err, errContains)
+		matches, err := filepath.Glob(filepath.Join(dirs.DataDir, "*", "*.before-restore-*"))
+		require.NoError(t, err)
+		require.Empty(t, matches)
+		_, err = os.Stat(dirs.Chaindata + ".restore")
+		require.ErrorIs(t, err, os.ErrNotExist)
+	}
+
+	t.Run("truncated snapshot file", func(t *testing.T) {
+		dirs := testDatadir(t, logger)
+		backupDir := testBackup(t, dirs, logger)
+		require.NoError(t, os.Truncate(filepath.Join(backupDir, SnapshotsDirName, testSnapshot), 512))
+		restoreRejected(t, dirs, backupDir, "snapshot file "+testSnapshot+": size 512, expected 1024")
+	})
+
+	t.Run("missing snapshot file", func(t *testing.T) {
+		dirs := testDatadir(t, logger)
+		backupDir := testBackup(t, dirs, logger)
+		require.NoError(t, os.Remove(filepath.Join(backupDir, SnapshotsDirName, testSnapshot)))
+		restoreRejected(t, dirs, backupDir, "snapshot file")
+	})
+
+	t.Run("chaindata lost entries", func(t *testing.T) {
+		dirs := testDatadir(t, logger)
+		backupDir := testBackup(t, dirs, logger)
+		db := mdbx2.NewMDBX(logger).Path(filepath.Join(backupDir, ChaindataDirName)).Label(kv.ChainDB).MustOpen()
+		require.NoError(t, db.Update(ctx, func(tx kv.RwTx) error { return tx.Delete(kv.Headers, []byte{5}) }))
+		db.Close()
+		restoreRejected(t, dirs, backupDir, "table Header: 9 entries, expected 10")
+	})
+
+	t.Run("chaindata overwritten", func(t *testing.T) {
+		dirs := testDatadir(t, logger)
+		backupDir := testBackup(t, dirs, logger)
+		f, err := os.OpenFile(filepath.Join(backupDir, ChaindataDirName, "mdbx.dat"), os.O_WRONLY, 0)
+		require.NoError(t, err)
+		_, err = f.WriteAt(make([]byte, 64*1024), 0) // meta pages
+		require.NoError(t, err)
+		require.NoError(t, f.Close())
+		_, err = Validate(ctx, backupDir, logger)
+		require.Error(t, err)
+		_, err = Restore(ctx, backupDir, dirs, logger)
+		require.Error(t, err)
+		_, err = os.Stat(dirs.Chaindata + ".restore")
+		require.ErrorIs(t, err, os.ErrNotExist)
+	})
+}
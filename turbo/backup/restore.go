@@ -0,0 +1,180 @@
+package backup
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/ledgerwatch/erigon-lib/common/datadir"
+	"github.com/ledgerwatch/erigon-lib/common/dir"
+	"github.com/ledgerwatch/erigon-lib/kv"
+	mdbx2 "github.com/ledgerwatch/erigon-lib/kv/mdbx"
+	"github.com/ledgerwatch/log/v3"
+	"golang.org/x/exp/slices"
+
+	"github.com/ledgerwatch/erigon/core/rawdb"
+	"github.com/ledgerwatch/erigon/eth/stagedsync/stages"
+)
+
+func openReadonly(ctx context.Context, path string, logger log.Logger) (kv.RwDB, error) {
+	return mdbx2.NewMDBX(logger).Path(path).Label(kv.ChainDB).Readonly().Open(ctx)
+}
+
+// Validate - checks that backup is complete and matches its manifest: snapshot files exist and have recorded sizes,
+// chaindata has same genesis, progress of stages, registered snapshots and amount of entries in tables.
+func Validate(ctx context.Context, backupDir string, logger log.Logger) (*Manifest, error) {
+	m, err := ReadManifest(backupDir)
+	if err != nil {
+		return nil, err
+	}
+	for rel, size := range m.Files {
+		info, err := os.Stat(filepath.Join(backupDir, SnapshotsDirName, filepath.FromSlash(rel)))
+		if err != nil {
+			return nil, fmt.Errorf("snapshot file: %w", err)
+		}
+		if info.Size() != size {
+			return nil, fmt.Errorf("snapshot file %s: size %d, expected %d", rel, info.Size(), size)
+		}
+	}
+	for _, name := range append(append([]string{}, m.Snapshots...), m.SnapshotsHistory...) {
+		if !hasFile(m.Files, name) {
+			return nil, fmt.Errorf("snapshot file %s is registered in manifest, but not in backup", name)
+		}
+	}
+
+	db, err := openReadonly(ctx, filepath.Join(backupDir, ChaindataDirName), logger)
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+	if err := db.View(ctx, func(tx kv.Tx) error {
+		genesis, err := rawdb.ReadCanonicalHash(tx, 0)
+		if err != nil {
+			return err
+		}
+		if genesis.Hex() != m.Genesis {
+			return fmt.Errorf("genesis %s, expected %s", genesis.Hex(), m.Genesis)
+		}
+		for stage, expected := range m.Stages {
+			progress, err := stages.GetStageProgress(tx, stages.SyncStage(stage))
+			if err != nil {
+				return err
+			}
+			if progress != expected {
+				return fmt.Errorf("stage %s: progress %d, expected %d", stage, progress, expected)
+			}
+		}
+		snapshots, snapshotsHistory, err := rawdb.ReadSnapshots(tx)
+		if err != nil {
+			return err
+		}
+		if !slices.Equal(snapshots, m.Snapshots) || !slices.Equal(snapshotsHistory, m.SnapshotsHistory) {
+			return fmt.Errorf("snapshots registered in db don't match manifest")
+		}
+		for table, expected := range m.Tables {
+			c, err := tx.Cursor(table)
+			if err != nil {
+				return err
+			}
+			count, err := c.Count()
+			c.Close()
+			if err != nil {
+				return err
+			}
+			if count != expected {
+				return fmt.Errorf("table %s: %d entries, expected %d", table, count, expected)
+			}
+		}
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("backup chaindata doesn't match manifest: %w", err)
+	}
+	return m, nil
+}
+
+// Restore - validates backup, then replaces chaindata and snapshots of datadir by backup's ones.
+// Node must be stopped. Replaced dirs are not removed: they are renamed with suffix `.before-restore-<unix time>`.
+func Restore(ctx context.Context, backupDir string, dirs datadir.Dirs, logger log.Logger) (*Manifest, error) {
+	m, err := Validate(ctx, backupDir, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	lock, locked, err := datadir.TryFlock(dirs)
+	if err != nil {
+		return nil, err
+	}
+	if !locked {
+		return nil, datadir.ErrDataDirLocked
+	}
+	defer lock.Unlock()
+
+	// don't mix chains
+	if dir.FileExist(filepath.Join(dirs.Chaindata, "mdbx.dat")) {
+		db, err := openReadonly(ctx, dirs.Chaindata, logger)
+		if err != nil {
+			return nil, err
+		}
+		var genesis string
+		err = db.View(ctx, func(tx kv.Tx) error {
+			h, err := rawdb.ReadCanonicalHash(tx, 0)
+			genesis = h.Hex()
+			return err
+		})
+		db.Close()
+		if err != nil {
+			return nil, err
+		}
+		if genesis != m.Genesis {
+			return nil, fmt.Errorf("datadir has genesis %s, but backup is of genesis %s", genesis, m.Genesis)
+		}
+	}
+
+	// prepare new dirs next to old ones, then swap them by renames
+	newChaindata, newSnap := dirs.Chaindata+".restore", dirs.Snap+".restore"
+	for _, d := range []string{newChaindata, newSnap} {
+		if err := os.RemoveAll(d); err != nil {
+			return nil, err
+		}
+	}
+	logger.Info("[backup] restore chaindata", "from", backupDir, "to", dirs.Chaindata)
+	if err := os.MkdirAll(newChaindata, 0755); err != nil {
+		return nil, err
+	}
+	// db is mutable - it must be copied, not linked
+	if err := copyFile(filepath.Join(backupDir, ChaindataDirName, "mdbx.dat"), filepath.Join(newChaindata, "mdbx.dat")); err != nil {
+		return nil, err
+	}
+	logger.Info("[backup] restore snapshots", "files", len(m.Files), "to", dirs.Snap)
+	for rel := range m.Files {
+		to := filepath.Join(newSnap, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(to), 0755); err != nil {
+			return nil, err
+		}
+		if err := linkOrCopy(filepath.Join(backupDir, SnapshotsDirName, filepath.FromSlash(rel)), to); err != nil {
+			return nil, err
+		}
+	}
+	// downloader's db is not a part of backup: keep it, it will re-check files
+	if dir.Exist(filepath.Join(dirs.Snap, "db")) {
+		if err := os.Rename(filepath.Join(dirs.Snap, "db"), filepath.Join(newSnap, "db")); err != nil {
+			return nil, err
+		}
+	}
+
+	suffix := fmt.Sprintf(".before-restore-%d", time.Now().Unix())
+	for from, to := range map[string]string{newChaindata: dirs.Chaindata, newSnap: dirs.Snap} {
+		if dir.Exist(to) {
+			if err := os.Rename(to, to+suffix); err != nil {
+				return nil, err
+			}
+		}
+		if err := os.Rename(from, to); err != nil {
+			return nil, err
+		}
+	}
+	logger.Info("[backup] restored", "txId", m.TxID, "createdAt", m.CreatedAt, "old", "*"+suffix)
+	return m, nil
+}
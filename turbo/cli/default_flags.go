@@ -41,13 +41,18 @@ var DefaultFlags = []cli.Flag{
 	&DatabaseVerbosityFlag,
 	&PrivateApiAddr,
 	&PrivateApiRateLimit,
+	&PrivateApiACL,
 	&EtlBufferSizeFlag,
+	&EtlCompressionFlag,
+	&EtlMergeWorkersFlag,
+	&EtlTmpdirQuotaFlag,
 	&TLSFlag,
 	&TLSCertFlag,
 	&TLSKeyFlag,
 	&TLSCACertFlag,
 	&StateStreamDisableFlag,
 	&SyncLoopThrottleFlag,
+	&SyncParallelExecWorkersFlag,
 	&BadBlockFlag,
 
 	&utils.HTTPEnabledFlag,
@@ -78,6 +83,7 @@ var DefaultFlags = []cli.Flag{
 	&utils.RpcReturnDataLimit,
 	&utils.AllowUnprotectedTxs,
 	&utils.RpcMaxGetProofRewindBlockCount,
+	&utils.RpcGetProofRecomputeLimit,
 	&utils.RPCGlobalTxFeeCapFlag,
 	&utils.TxpoolApiAddrFlag,
 	&utils.TraceMaxtracesFlag,
@@ -148,6 +154,7 @@ var DefaultFlags = []cli.Flag{
 	&HealthCheckFlag,
 	&utils.HeimdallURLFlag,
 	&utils.WebSeedsFlag,
+	&utils.ObjectStoreFlag,
 	&utils.WithoutHeimdallFlag,
 	&utils.HeimdallgRPCAddressFlag,
 	&utils.BorBlockPeriodFlag,
@@ -163,6 +170,7 @@ var DefaultFlags = []cli.Flag{
 	&utils.SentinelPortFlag,
 
 	&utils.OtsSearchMaxCapFlag,
+	&utils.DiagnosticsDbSizeIntervalFlag,
 
 	&utils.SilkwormExecutionFlag,
 	&utils.SilkwormRpcDaemonFlag,
@@ -44,6 +44,21 @@ var (
 		Usage: "Buffer size for ETL operations.",
 		Value: etl.BufferOptimalSize.String(),
 	}
+	EtlCompressionFlag = cli.StringFlag{
+		Name:  "etl.compression",
+		Usage: "Compression of ETL files in tmpdir: none, snappy, zstd. Reduces tmpdir usage by stages like Senders and TxLookup",
+		Value: etl.DefaultCompression.String(),
+	}
+	EtlMergeWorkersFlag = cli.IntFlag{
+		Name:  "etl.mergeWorkers",
+		Usage: "Amount of goroutines merging ETL files by key ranges before loading to DB",
+		Value: etl.DefaultMergeWorkers,
+	}
+	EtlTmpdirQuotaFlag = cli.StringFlag{
+		Name:  "etl.tmpdirQuota",
+		Usage: "Limit of total size of ETL files in tmpdir. Stage fails instead of filling the disk. 0 - unlimited",
+		Value: "0",
+	}
 	BodyCacheLimitFlag = cli.StringFlag{
 		Name:  "bodies.cache",
 		Usage: "Limit on the cache for block bodies",
@@ -56,6 +71,12 @@ var (
 		Value: "127.0.0.1:9090",
 	}
 
+	PrivateApiACL = cli.StringFlag{
+		Name:  "private.api.acl",
+		Usage: "Path to .toml file with per-client tables access policy of remote KV interface of private api (clients identified by token or by TLS client certificate)",
+		Value: "",
+	}
+
 	PrivateApiRateLimit = cli.IntFlag{
 		Name:  "private.api.ratelimit",
 		Usage: "Amount of requests server handle simultaneously - requests over this limit will wait. Increase it - if clients see 'request timeout' while server load is low - it means your 'hot data' is small or have much RAM. ",
@@ -167,6 +188,12 @@ var (
 		Value: 0, // unlimited
 	}
 
+	SyncParallelExecWorkersFlag = cli.IntFlag{
+		Name:  "sync.parallel.exec.workers",
+		Usage: "Sets the number of workers, which execute transactions of a block in parallel in the execution stage, when it runs in its own transaction (e.g. initial sync), 0 - sequential execution (not supported by Erigon3)",
+		Value: 0,
+	}
+
 	UploadLocationFlag = cli.StringFlag{
 		Name:  "upload.location",
 		Usage: "Location to upload snapshot segments to",
@@ -275,6 +302,23 @@ func ApplyFlagsForEthConfig(ctx *cli.Context, cfg *ethconfig.Config, logger log.
 		}
 		etl.BufferOptimalSize = *size
 	}
+	if ctx.IsSet(EtlCompressionFlag.Name) {
+		compression, err := etl.ParseCompression(ctx.String(EtlCompressionFlag.Name))
+		if err != nil {
+			utils.Fatalf("Invalid etl.compression provided: %v", err)
+		}
+		etl.DefaultCompression = compression
+	}
+	if ctx.IsSet(EtlMergeWorkersFlag.Name) {
+		etl.DefaultMergeWorkers = ctx.Int(EtlMergeWorkersFlag.Name)
+	}
+	if ctx.String(EtlTmpdirQuotaFlag.Name) != "" {
+		var quota datasize.ByteSize
+		if err := quota.UnmarshalText([]byte(ctx.String(EtlTmpdirQuotaFlag.Name))); err != nil {
+			utils.Fatalf("Invalid etl.tmpdirQuota provided: %v", err)
+		}
+		etl.DefaultTmpdirQuota.SetLimit(quota)
+	}
 
 	cfg.StateStream = !ctx.Bool(StateStreamDisableFlag.Name)
 	if ctx.String(BodyCacheLimitFlag.Name) != "" {
@@ -304,6 +348,10 @@ func ApplyFlagsForEthConfig(ctx *cli.Context, cfg *ethconfig.Config, logger log.
 		cfg.Sync.LoopBlockLimit = limit
 	}
 
+	if workers := ctx.Int(SyncParallelExecWorkersFlag.Name); workers > 0 {
+		cfg.Sync.ParallelExecWorkers = workers
+	}
+
 	if location := ctx.String(UploadLocationFlag.Name); len(location) > 0 {
 		cfg.Sync.UploadLocation = location
 	}
@@ -397,6 +445,23 @@ func ApplyFlagsForEthConfigCobra(f *pflag.FlagSet, cfg *ethconfig.Config) {
 		}
 		etl.BufferOptimalSize = *size
 	}
+	if v := f.String(EtlCompressionFlag.Name, EtlCompressionFlag.Value, EtlCompressionFlag.Usage); v != nil {
+		compression, err := etl.ParseCompression(*v)
+		if err != nil {
+			utils.Fatalf("Invalid etl.compression provided: %v", err)
+		}
+		etl.DefaultCompression = compression
+	}
+	if v := f.Int(EtlMergeWorkersFlag.Name, EtlMergeWorkersFlag.Value, EtlMergeWorkersFlag.Usage); v != nil {
+		etl.DefaultMergeWorkers = *v
+	}
+	if v := f.String(EtlTmpdirQuotaFlag.Name, EtlTmpdirQuotaFlag.Value, EtlTmpdirQuotaFlag.Usage); v != nil {
+		var quota datasize.ByteSize
+		if err := quota.UnmarshalText([]byte(*v)); err != nil {
+			utils.Fatalf("Invalid etl.tmpdirQuota provided: %v", err)
+		}
+		etl.DefaultTmpdirQuota.SetLimit(quota)
+	}
 
 	cfg.StateStream = true
 	if v := f.Bool(StateStreamDisableFlag.Name, false, StateStreamDisableFlag.Usage); v != nil {
@@ -468,6 +533,7 @@ func setEmbeddedRpcDaemon(ctx *cli.Context, cfg *nodecfg.Config, logger log.Logg
 		ReturnDataLimit:             ctx.Int(utils.RpcReturnDataLimit.Name),
 		AllowUnprotectedTxs:         ctx.Bool(utils.AllowUnprotectedTxs.Name),
 		MaxGetProofRewindBlockCount: ctx.Int(utils.RpcMaxGetProofRewindBlockCount.Name),
+		GetProofRecomputeLimit:      ctx.Uint64(utils.RpcGetProofRecomputeLimit.Name),
 
 		OtsMaxPageSize: ctx.Uint64(utils.OtsSearchMaxCapFlag.Name),
 
@@ -516,6 +582,7 @@ func setEmbeddedRpcDaemon(ctx *cli.Context, cfg *nodecfg.Config, logger log.Logg
 func setPrivateApi(ctx *cli.Context, cfg *nodecfg.Config) {
 	cfg.PrivateApiAddr = ctx.String(PrivateApiAddr.Name)
 	cfg.PrivateApiRateLimit = uint32(ctx.Uint64(PrivateApiRateLimit.Name))
+	cfg.PrivateApiACL = ctx.String(PrivateApiACL.Name)
 	maxRateLimit := uint32(kv.ReadersLimit - 128) // leave some readers for P2P
 	if cfg.PrivateApiRateLimit > maxRateLimit {
 		log.Warn("private.api.ratelimit is too big", "force", maxRateLimit)
@@ -0,0 +1,175 @@
+package engineapi
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/holiman/uint256"
+	"github.com/ledgerwatch/log/v3"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/ledgerwatch/erigon-lib/chain"
+	libcommon "github.com/ledgerwatch/erigon-lib/common"
+	"github.com/ledgerwatch/erigon-lib/common/hexutil"
+	"github.com/ledgerwatch/erigon-lib/common/hexutility"
+	"github.com/ledgerwatch/erigon-lib/gointerfaces"
+	"github.com/ledgerwatch/erigon-lib/gointerfaces/execution"
+	types2 "github.com/ledgerwatch/erigon-lib/gointerfaces/types"
+
+	"github.com/ledgerwatch/erigon/consensus/merge"
+	"github.com/ledgerwatch/erigon/core/types"
+	"github.com/ledgerwatch/erigon/rpc"
+	"github.com/ledgerwatch/erigon/turbo/engineapi/engine_types"
+)
+
+// assembledBlockClient serves GetAssembledBlock with the given data, other methods are not implemented
+type assembledBlockClient struct {
+	execution.ExecutionClient
+	data *execution.AssembledBlockData
+}
+
+func (c *assembledBlockClient) GetAssembledBlock(ctx context.Context, in *execution.GetAssembledBlockRequest, opts ...grpc.CallOption) (*execution.GetAssembledBlockResponse, error) {
+	return &execution.GetAssembledBlockResponse{Data: c.data}, nil
+}
+
+func pragueConfig() *chain.Config {
+	return &chain.Config{
+		ChainID:                 big.NewInt(1337),
+		TerminalTotalDifficulty: libcommon.Big0,
+		ShanghaiTime:            libcommon.Big0,
+		CancunTime:              libcommon.Big0,
+		PragueTime:              libcommon.Big0,
+	}
+}
+
+// pragueTestPayload returns a payload without transactions, its block hash commits to requests
+func pragueTestPayload(beaconRoot libcommon.Hash, requests types.FlatRequests) *engine_types.ExecutionPayload {
+	blobGas, excessBlobGas := hexutil.Uint64(0), hexutil.Uint64(0)
+	payload := &engine_types.ExecutionPayload{
+		ParentHash:    libcommon.Hash{1},
+		LogsBloom:     make(hexutility.Bytes, types.BloomByteLength),
+		BlockNumber:   1,
+		GasLimit:      30_000_000,
+		Timestamp:     1,
+		BaseFeePerGas: (*hexutil.Big)(big.NewInt(7)),
+		Transactions:  []hexutility.Bytes{},
+		Withdrawals:   []*types.Withdrawal{},
+		BlobGasUsed:   &blobGas,
+		ExcessBlobGas: &excessBlobGas,
+	}
+	wh := types.DeriveSha(types.Withdrawals(payload.Withdrawals))
+	header := types.Header{
+		ParentHash:            payload.ParentHash,
+		UncleHash:             types.EmptyUncleHash,
+		Difficulty:            merge.ProofOfStakeDifficulty,
+		Nonce:                 merge.ProofOfStakeNonce,
+		Number:                big.NewInt(1),
+		GasLimit:              uint64(payload.GasLimit),
+		Time:                  uint64(payload.Timestamp),
+		BaseFee:               big.NewInt(7),
+		TxHash:                types.DeriveSha(types.BinaryTransactions{}),
+		WithdrawalsHash:       &wh,
+		BlobGasUsed:           new(uint64),
+		ExcessBlobGas:         new(uint64),
+		ParentBeaconBlockRoot: &beaconRoot,
+		RequestsHash:          requests.Hash(),
+	}
+	payload.BlockHash = header.Hash()
+	return payload
+}
+
+func TestNewPayloadV4Requests(t *testing.T) {
+	ctx := context.Background()
+	beaconRoot := libcommon.Hash{2}
+	requests := types.FlatRequests{
+		{Type: types.DepositRequestType, RequestData: make([]byte, types.DepositRequestDataLen)},
+		{Type: 0x01, RequestData: []byte{1, 2, 3}},
+	}
+	encoded := []hexutility.Bytes{requests[0].Encode(), requests[1].Encode()}
+	payload := pragueTestPayload(beaconRoot, requests)
+
+	srv := NewEngineServer(ctx, log.New(), pragueConfig(), nil, nil, nil, false, false)
+
+	_, err := srv.NewPayloadV4(ctx, payload, []libcommon.Hash{}, &beaconRoot, nil)
+	require.Equal(t, &rpc.InvalidParamsError{Message: "nil executionRequests"}, err)
+
+	_, err = srv.NewPayloadV4(ctx, payload, []libcommon.Hash{}, &beaconRoot, []hexutility.Bytes{encoded[1], encoded[0]})
+	require.Equal(t, &rpc.InvalidParamsError{Message: "invalid executionRequests: requests not ordered by type at index 1"}, err)
+
+	_, err = srv.NewPayloadV4(ctx, payload, []libcommon.Hash{}, &beaconRoot, []hexutility.Bytes{{0x01}})
+	require.Equal(t, &rpc.InvalidParamsError{Message: "invalid executionRequests: empty request at index 0"}, err)
+
+	// the requests hash is a part of the block hash
+	status, err := srv.NewPayloadV4(ctx, payload, []libcommon.Hash{}, &beaconRoot, encoded[:1])
+	require.NoError(t, err)
+	require.Equal(t, engine_types.InvalidStatus, status.Status)
+	require.Equal(t, "invalid block hash", status.ValidationError.Error().Error())
+
+	// header download isn't set up, so the matching payload fails after the block hash check
+	_, err = srv.NewPayloadV4(ctx, payload, []libcommon.Hash{}, &beaconRoot, encoded)
+	require.EqualError(t, err, "headerdownload is nil")
+
+	// V3 is not supported after Prague, V4 is not supported before it
+	_, err = srv.NewPayloadV3(ctx, pragueTestPayload(beaconRoot, nil), []libcommon.Hash{}, &beaconRoot)
+	require.Equal(t, &rpc.UnsupportedForkError{Message: "Unsupported fork"}, err)
+	config := pragueConfig()
+	config.PragueTime = nil
+	preprague := NewEngineServer(ctx, log.New(), config, nil, nil, nil, false, false)
+	_, err = preprague.NewPayloadV4(ctx, payload, []libcommon.Hash{}, &beaconRoot, encoded)
+	require.Equal(t, &rpc.UnsupportedForkError{Message: "Unsupported fork"}, err)
+	// the fork is checked before requests are decoded
+	_, err = preprague.NewPayloadV4(ctx, payload, []libcommon.Hash{}, &beaconRoot, []hexutility.Bytes{{0x01}})
+	require.Equal(t, &rpc.UnsupportedForkError{Message: "Unsupported fork"}, err)
+}
+
+func TestGetPayloadV4Requests(t *testing.T) {
+	ctx := context.Background()
+	blobGas, excessBlobGas := uint64(0), uint64(0)
+	requests := [][]byte{{0x00, 1, 2}, {0x01, 3}}
+	zeroHash := gointerfaces.ConvertHashToH256(libcommon.Hash{})
+	client := &assembledBlockClient{data: &execution.AssembledBlockData{
+		ExecutionPayload: &types2.ExecutionPayload{
+			Version:       3,
+			ParentHash:    zeroHash,
+			Coinbase:      gointerfaces.ConvertAddressToH160(libcommon.Address{}),
+			StateRoot:     zeroHash,
+			ReceiptRoot:   zeroHash,
+			PrevRandao:    zeroHash,
+			BlockHash:     zeroHash,
+			Timestamp:     1,
+			BaseFeePerGas: gointerfaces.ConvertUint256IntToH256(new(uint256.Int)),
+			LogsBloom:     gointerfaces.ConvertBytesToH2048(make([]byte, types.BloomByteLength)),
+			BlobGasUsed:   &blobGas,
+			ExcessBlobGas: &excessBlobGas,
+		},
+		BlockValue: gointerfaces.ConvertUint256IntToH256(new(uint256.Int)),
+		Requests:   requests,
+	}}
+	payloadID := hexutility.Bytes{0, 0, 0, 0, 0, 0, 0, 1}
+
+	srv := NewEngineServer(ctx, log.New(), pragueConfig(), client, nil, nil, false, true)
+	resp, err := srv.GetPayloadV4(ctx, payloadID)
+	require.NoError(t, err)
+	require.Equal(t, []hexutility.Bytes{requests[0], requests[1]}, resp.ExecutionRequests)
+
+	_, err = srv.GetPayloadV3(ctx, payloadID)
+	require.Equal(t, &rpc.UnsupportedForkError{Message: "Unsupported fork"}, err)
+
+	// executionRequests are omitted from responses of earlier versions
+	config := pragueConfig()
+	config.PragueTime = nil
+	resp, err = NewEngineServer(ctx, log.New(), config, client, nil, nil, false, true).GetPayloadV3(ctx, payloadID)
+	require.NoError(t, err)
+	enc, err := json.Marshal(resp)
+	require.NoError(t, err)
+	require.NotContains(t, string(enc), "executionRequests")
+
+	// requests are returned by V4 only, even if there are none
+	client.data.Requests = nil
+	resp, err = srv.GetPayloadV4(ctx, payloadID)
+	require.NoError(t, err)
+	require.Equal(t, []hexutility.Bytes{}, resp.ExecutionRequests)
+}
@@ -196,11 +196,17 @@ func (e *EthereumExecutionModule) GetAssembledBlock(ctx context.Context, req *ex
 		}
 	}
 
+	var requests [][]byte
+	if header.RequestsHash != nil {
+		requests = block.Requests().Encode()
+	}
+
 	return &execution.GetAssembledBlockResponse{
 		Data: &execution.AssembledBlockData{
 			ExecutionPayload: payload,
 			BlockValue:       gointerfaces.ConvertUint256IntToH256(blockValue),
 			BlobsBundle:      blobsBundle,
+			Requests:         requests,
 		},
 		Busy: false,
 	}, nil
@@ -5,9 +5,14 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/ledgerwatch/erigon-lib/gointerfaces"
 	"github.com/ledgerwatch/erigon-lib/gointerfaces/remote"
-	"github.com/ledgerwatch/erigon/p2p"
+	"github.com/ledgerwatch/log/v3"
 
+	"github.com/ledgerwatch/erigon/common/debug"
+	"github.com/ledgerwatch/erigon/p2p"
+	"github.com/ledgerwatch/erigon/p2p/enode"
+	"github.com/ledgerwatch/erigon/rpc"
 	"github.com/ledgerwatch/erigon/turbo/rpchelper"
 )
 
@@ -22,6 +27,21 @@ type AdminAPI interface {
 
 	// AddPeer requests connecting to a remote node.
 	AddPeer(ctx context.Context, url string) (bool, error)
+
+	// RemovePeer disconnects from a remote node if the connection exists
+	// and stops reconnecting to it.
+	RemovePeer(ctx context.Context, url string) (bool, error)
+
+	// AddTrustedPeer allows a remote node to always connect, even if slots are full.
+	AddTrustedPeer(ctx context.Context, url string) (bool, error)
+
+	// RemoveTrustedPeer removes a remote node from the trusted peer set, but it
+	// does not disconnect it automatically.
+	RemoveTrustedPeer(ctx context.Context, url string) (bool, error)
+
+	// PeerEvents creates an RPC subscription which receives peer events from
+	// the node's p2p servers.
+	PeerEvents(ctx context.Context) (*rpc.Subscription, error)
 }
 
 // AdminAPIImpl data structure to store things needed for admin_* commands.
@@ -63,3 +83,78 @@ func (api *AdminAPIImpl) AddPeer(ctx context.Context, url string) (bool, error)
 	}
 	return result.Success, nil
 }
+
+func (api *AdminAPIImpl) RemovePeer(ctx context.Context, url string) (bool, error) {
+	result, err := api.ethBackend.RemovePeer(ctx, &remote.RemovePeerRequest{Url: url})
+	if err != nil {
+		return false, err
+	}
+	if result == nil {
+		return false, errors.New("nil removePeer response")
+	}
+	return result.Success, nil
+}
+
+func (api *AdminAPIImpl) AddTrustedPeer(ctx context.Context, url string) (bool, error) {
+	result, err := api.ethBackend.AddTrustedPeer(ctx, &remote.AddPeerRequest{Url: url})
+	if err != nil {
+		return false, err
+	}
+	if result == nil {
+		return false, errors.New("nil addTrustedPeer response")
+	}
+	return result.Success, nil
+}
+
+func (api *AdminAPIImpl) RemoveTrustedPeer(ctx context.Context, url string) (bool, error) {
+	result, err := api.ethBackend.RemoveTrustedPeer(ctx, &remote.RemovePeerRequest{Url: url})
+	if err != nil {
+		return false, err
+	}
+	if result == nil {
+		return false, errors.New("nil removeTrustedPeer response")
+	}
+	return result.Success, nil
+}
+
+func (api *AdminAPIImpl) PeerEvents(ctx context.Context) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+
+	rpcSub := notifier.CreateSubscription()
+
+	// The subscription outlives the request context, so it is cancelled on unsubscribe instead.
+	subCtx, cancel := context.WithCancel(context.Background())
+	go func() {
+		defer debug.LogPanic()
+		defer cancel()
+		<-rpcSub.Err()
+	}()
+	go func() {
+		defer debug.LogPanic()
+		err := api.ethBackend.PeerEvents(subCtx, func(event *remote.PeerEvent) {
+			if err := notifier.Notify(rpcSub.ID, peerEventToP2P(event)); err != nil {
+				log.Warn("[rpc] error while notifying subscription", "err", err)
+			}
+		})
+		if err != nil && subCtx.Err() == nil {
+			log.Warn("[rpc] peer events subscription closed", "err", err)
+		}
+	}()
+
+	return rpcSub, nil
+}
+
+// peerEventToP2P converts a sentry peer event into the p2p.PeerEvent reported by admin_peerEvents.
+func peerEventToP2P(event *remote.PeerEvent) *p2p.PeerEvent {
+	eventType := p2p.PeerEventTypeAdd
+	if event.EventId == remote.PeerEvent_Disconnect {
+		eventType = p2p.PeerEventTypeDrop
+	}
+	return &p2p.PeerEvent{
+		Type: eventType,
+		Peer: enode.PubkeyEncoded(gointerfaces.ConvertH512ToHash(event.PeerId)).ID(),
+	}
+}
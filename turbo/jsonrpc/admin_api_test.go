@@ -0,0 +1,29 @@
+package jsonrpc
+
+import (
+	"testing"
+
+	"github.com/ledgerwatch/erigon-lib/gointerfaces"
+	"github.com/ledgerwatch/erigon-lib/gointerfaces/remote"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ledgerwatch/erigon/crypto"
+	"github.com/ledgerwatch/erigon/p2p"
+	"github.com/ledgerwatch/erigon/p2p/discover/v4wire"
+	"github.com/ledgerwatch/erigon/p2p/enode"
+)
+
+func TestPeerEventToP2P(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	peerID := gointerfaces.ConvertHashToH512(v4wire.EncodePubkey(&key.PublicKey))
+
+	event := peerEventToP2P(&remote.PeerEvent{PeerId: peerID, EventId: remote.PeerEvent_Connect})
+	assert.Equal(t, p2p.PeerEventTypeAdd, event.Type)
+	assert.Equal(t, enode.PubkeyToIDV4(&key.PublicKey), event.Peer)
+
+	event = peerEventToP2P(&remote.PeerEvent{PeerId: peerID, EventId: remote.PeerEvent_Disconnect})
+	assert.Equal(t, p2p.PeerEventTypeDrop, event.Type)
+	assert.Equal(t, enode.PubkeyToIDV4(&key.PublicKey), event.Peer)
+}
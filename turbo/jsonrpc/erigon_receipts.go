@@ -11,6 +11,7 @@ import (
 	"github.com/ledgerwatch/erigon-lib/common/hexutility"
 	"github.com/ledgerwatch/erigon-lib/kv"
 	"github.com/ledgerwatch/erigon-lib/kv/bitmapdb"
+	"github.com/ledgerwatch/erigon-lib/kv/iter"
 
 	"github.com/ledgerwatch/erigon/core/rawdb"
 	"github.com/ledgerwatch/erigon/core/types"
@@ -101,6 +102,9 @@ func (api *ErigonImpl) GetLogs(ctx context.Context, crit filters.FilterCriteria)
 	if end > roaring.MaxUint32 {
 		return nil, fmt.Errorf("end (%d) > MaxUint32", end)
 	}
+	if api.historyV3(tx) {
+		return api.getErigonLogsV3(ctx, tx.(kv.TemporalTx), begin, end, crit)
+	}
 	blockNumbers := bitmapdb.NewBitmap()
 	defer bitmapdb.ReturnToPool(blockNumbers)
 	if err := applyFilters(blockNumbers, tx, begin, end, crit); err != nil {
@@ -195,6 +199,128 @@ func (api *ErigonImpl) GetLogs(ctx context.Context, crit filters.FilterCriteria)
 	return erigonLogs, nil
 }
 
+func (api *ErigonImpl) getErigonLogsV3(ctx context.Context, tx kv.TemporalTx, begin, end uint64, crit filters.FilterCriteria) (types.ErigonLogs, error) {
+	logs, err := api.getLogsV3(ctx, tx, begin, end, crit)
+	if err != nil {
+		return nil, err
+	}
+	erigonLogs := make(types.ErigonLogs, 0, len(logs))
+	var header *types.Header
+	for _, log := range logs {
+		if header == nil || header.Number.Uint64() != log.BlockNumber {
+			if header, err = api._blockReader.HeaderByNumber(ctx, tx, log.BlockNumber); err != nil {
+				return nil, err
+			}
+			if header == nil {
+				return nil, fmt.Errorf("block header not found: %d", log.BlockNumber)
+			}
+		}
+		erigonLogs = append(erigonLogs, &types.ErigonLog{
+			Address:     log.Address,
+			Topics:      log.Topics,
+			Data:        log.Data,
+			BlockNumber: log.BlockNumber,
+			TxHash:      log.TxHash,
+			TxIndex:     log.TxIndex,
+			BlockHash:   log.BlockHash,
+			Index:       log.Index,
+			Removed:     log.Removed,
+			Timestamp:   header.Time,
+		})
+	}
+	return erigonLogs, nil
+}
+
+// getLatestLogsV3 - Erigon3 doesn't store receipts: matching txs are re-executed, starting from the latest one,
+// until the count of logs or blocks is reached
+func (api *ErigonImpl) getLatestLogsV3(ctx context.Context, tx kv.TemporalTx, begin, end uint64, crit filters.FilterCriteria, logOptions filters.LogFilterOptions,
+	addrMap map[common.Address]struct{}, topicsMap map[common.Hash]struct{}) (types.ErigonLogs, error) {
+	erigonLogs := types.ErigonLogs{}
+	txNumbers, err := applyFiltersV3(tx, begin, end, crit)
+	if err != nil {
+		return erigonLogs, err
+	}
+	txNums, err := iter.ToArr[uint64](txNumbers)
+	if err != nil {
+		return erigonLogs, err
+	}
+
+	chainConfig, err := api.chainConfig(tx)
+	if err != nil {
+		return nil, err
+	}
+	exec := txnExecutor(tx, chainConfig, api.engine(), api._blockReader, nil)
+
+	var header *types.Header
+	var blockHash common.Hash
+	var logCount, blockCount uint64
+	it := MapDescendTxNum2BlockNum(tx, iter.ReverseArray(txNums))
+	for it.HasNext() {
+		if err = ctx.Err(); err != nil {
+			return nil, err
+		}
+		txNum, blockNum, txIndex, isFinalTxn, blockNumChanged, err := it.Next()
+		if err != nil {
+			return nil, err
+		}
+		if blockNumChanged {
+			if logOptions.BlockCount != 0 && logOptions.BlockCount <= blockCount {
+				return erigonLogs, nil
+			}
+			blockCount++
+			if header, err = api._blockReader.HeaderByNumber(ctx, tx, blockNum); err != nil {
+				return nil, err
+			}
+			if header == nil {
+				return nil, fmt.Errorf("block header not found: %d", blockNum)
+			}
+			blockHash = header.Hash()
+			exec.changeBlock(header)
+		}
+		if isFinalTxn {
+			continue
+		}
+
+		txn, err := api._txnReader.TxnByIdxInBlock(ctx, tx, blockNum, txIndex)
+		if err != nil {
+			return nil, err
+		}
+		if txn == nil {
+			continue
+		}
+		rawLogs, _, err := exec.execTx(txNum, txIndex, txn)
+		if err != nil {
+			return nil, err
+		}
+		var filtered types.Logs
+		if logOptions.IgnoreTopicsOrder {
+			filtered = types.Logs(rawLogs).CointainTopics(addrMap, topicsMap)
+		} else {
+			filtered = types.Logs(rawLogs).Filter(addrMap, crit.Topics)
+		}
+		for i := len(filtered) - 1; i >= 0; i-- {
+			log := filtered[i]
+			erigonLogs = append(erigonLogs, &types.ErigonLog{
+				Address:     log.Address,
+				Topics:      log.Topics,
+				Data:        log.Data,
+				BlockNumber: blockNum,
+				TxHash:      txn.Hash(),
+				TxIndex:     log.TxIndex,
+				BlockHash:   blockHash,
+				Index:       log.Index,
+				Removed:     log.Removed,
+				Timestamp:   header.Time,
+			})
+			logCount++
+		}
+		if logOptions.LogCount != 0 && logOptions.LogCount <= logCount {
+			return erigonLogs, nil
+		}
+	}
+	return erigonLogs, nil
+}
+
 // GetLatestLogs implements erigon_getLatestLogs.
 // Return specific number of logs or block matching a give filter objects by descend.
 // IgnoreTopicsOrder option provide a way to match the logs with addresses and topics without caring the topics's orders
@@ -260,15 +386,6 @@ func (api *ErigonImpl) GetLatestLogs(ctx context.Context, crit filters.FilterCri
 		return nil, fmt.Errorf("end (%d) < begin (%d)", end, begin)
 	}
 
-	blockNumbers := bitmapdb.NewBitmap()
-	defer bitmapdb.ReturnToPool(blockNumbers)
-	if err := applyFilters(blockNumbers, tx, begin, end, crit); err != nil {
-		return erigonLogs, err
-	}
-	if blockNumbers.IsEmpty() {
-		return erigonLogs, nil
-	}
-
 	addrMap := make(map[common.Address]struct{}, len(crit.Addresses))
 	for _, v := range crit.Addresses {
 		addrMap[v] = struct{}{}
@@ -279,6 +396,18 @@ func (api *ErigonImpl) GetLatestLogs(ctx context.Context, crit filters.FilterCri
 			topicsMap[crit.Topics[i][j]] = struct{}{}
 		}
 	}
+	if api.historyV3(tx) {
+		return api.getLatestLogsV3(ctx, tx.(kv.TemporalTx), begin, end, crit, logOptions, addrMap, topicsMap)
+	}
+
+	blockNumbers := bitmapdb.NewBitmap()
+	defer bitmapdb.ReturnToPool(blockNumbers)
+	if err := applyFilters(blockNumbers, tx, begin, end, crit); err != nil {
+		return erigonLogs, err
+	}
+	if blockNumbers.IsEmpty() {
+		return erigonLogs, nil
+	}
 
 	// latest logs that match the filter crit
 	iter := blockNumbers.ReverseIterator()
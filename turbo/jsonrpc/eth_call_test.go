@@ -26,6 +26,7 @@ import (
 	"github.com/ledgerwatch/erigon/core/state"
 	"github.com/ledgerwatch/erigon/core/types"
 	"github.com/ledgerwatch/erigon/crypto"
+	"github.com/ledgerwatch/erigon/eth/ethconfig"
 	"github.com/ledgerwatch/erigon/params"
 	"github.com/ledgerwatch/erigon/rpc"
 	"github.com/ledgerwatch/erigon/rpc/rpccfg"
@@ -49,7 +50,7 @@ func TestEstimateGas(t *testing.T) {
 	if _, err := api.EstimateGas(context.Background(), &ethapi.CallArgs{
 		From: &from,
 		To:   &to,
-	}, nil); err != nil {
+	}, nil, nil, nil); err != nil {
 		t.Errorf("calling EstimateGas: %v", err)
 	}
 }
@@ -64,7 +65,7 @@ func TestEthCallNonCanonical(t *testing.T) {
 	if _, err := api.Call(context.Background(), ethapi.CallArgs{
 		From: &from,
 		To:   &to,
-	}, rpc.BlockNumberOrHashWithHash(libcommon.HexToHash("0x3fcb7c0d4569fddc89cbea54b42f163e0c789351d98810a513895ab44b47020b"), true), nil); err != nil {
+	}, rpc.BlockNumberOrHashWithHash(libcommon.HexToHash("0x3fcb7c0d4569fddc89cbea54b42f163e0c789351d98810a513895ab44b47020b"), true), nil, nil); err != nil {
 		if fmt.Sprintf("%v", err) != "hash 3fcb7c0d4569fddc89cbea54b42f163e0c789351d98810a513895ab44b47020b is not currently canonical" {
 			t.Errorf("wrong error: %v", err)
 		}
@@ -86,19 +87,44 @@ func TestEthCallToPrunedBlock(t *testing.T) {
 		From: &bankAddress,
 		To:   &contractAddress,
 		Data: &callDataBytes,
-	}, rpc.BlockNumberOrHashWithNumber(ethCallBlockNumber), nil); err != nil {
+	}, rpc.BlockNumberOrHashWithNumber(ethCallBlockNumber), nil, nil); err != nil {
 		t.Errorf("unexpected error: %v", err)
 	}
 }
 
+func TestEthCallBlockOverrides(t *testing.T) {
+	m, bankAddress, _ := chainWithDeployedContract(t)
+	api := NewEthAPI(newBaseApiForTest(m), m.DB, nil, nil, nil, 5000000, 100_000, false, 100_000, log.New())
+
+	// returns TIMESTAMP, NUMBER and BLOCKHASH(5)
+	code := hexutility.Bytes(hexutil.MustDecode("0x426000524360205260054060405260606000f3"))
+	contract := libcommon.HexToAddress("0x1234")
+	overrides := ethapi.StateOverrides{contract: ethapi.Account{Code: &code}}
+	blockHash := libcommon.HexToHash("0xabcd")
+	timestamp := hexutil.Uint64(2_000_000_000)
+	blockOverrides := &ethapi.BlockOverrides{
+		Number:    (*hexutil.Big)(big.NewInt(10)),
+		Time:      &timestamp,
+		BlockHash: map[uint64]libcommon.Hash{5: blockHash},
+	}
+
+	result, err := api.Call(context.Background(), ethapi.CallArgs{
+		From: &bankAddress,
+		To:   &contract,
+	}, rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber), &overrides, blockOverrides)
+	require.NoError(t, err)
+	require.Len(t, result, 96)
+	assert.Equal(t, uint64(timestamp), new(big.Int).SetBytes(result[:32]).Uint64())
+	assert.Equal(t, uint64(10), new(big.Int).SetBytes(result[32:64]).Uint64())
+	assert.Equal(t, blockHash.Bytes(), []byte(result[64:]))
+}
+
 func TestGetProof(t *testing.T) {
 	var maxGetProofRewindBlockCount = 1 // Note, this is unsafe for parallel tests, but, this test is the only consumer for now
 
 	m, bankAddr, contractAddr := chainWithDeployedContract(t)
-	if m.HistoryV3 {
-		t.Skip("not supported by Erigon3")
-	}
 	api := NewEthAPI(newBaseApiForTest(m), m.DB, nil, nil, nil, 5000000, 100_000, false, maxGetProofRewindBlockCount, log.New())
+	api.GetProofRecomputeLimit = 100
 
 	key := func(b byte) libcommon.Hash {
 		result := libcommon.Hash{}
@@ -165,10 +191,23 @@ func TestGetProof(t *testing.T) {
 			stateVal:    1,
 		},
 		{
-			name:        "tooOldBlock",
+			name:        "oldBlockMissingState",
 			addr:        contractAddr,
 			blockNum:    1,
-			expectedErr: "requested block is too old, block must be within 1 blocks of the head block number (currently 3)",
+			storageKeys: []libcommon.Hash{key(1), key(5)},
+			stateVal:    0,
+		},
+		{
+			name:     "oldBlockEOA",
+			addr:     bankAddr,
+			blockNum: 1,
+		},
+		{
+			name:        "genesisBlockNoAccount",
+			addr:        contractAddr,
+			blockNum:    0,
+			storageKeys: []libcommon.Hash{key(1)},
+			stateVal:    0,
 		},
 	}
 
@@ -216,6 +255,52 @@ func TestGetProof(t *testing.T) {
 	}
 }
 
+func TestGetProofRecomputeLimit(t *testing.T) {
+	m, _, contractAddr := chainWithDeployedContract(t)
+	api := NewEthAPI(newBaseApiForTest(m), m.DB, nil, nil, nil, 5000000, 100_000, false, 1, log.New())
+	oldBlock := rpc.BlockNumberOrHashWithNumber(1)
+
+	// re-computation of the commitment is disabled by default, recent blocks are served from intermediate hashes
+	_, err := api.GetProof(context.Background(), contractAddr, nil, rpc.BlockNumberOrHashWithNumber(2))
+	require.NoError(t, err)
+	_, err = api.GetProof(context.Background(), contractAddr, nil, oldBlock)
+	require.EqualError(t, err, "requested block is too old, block must be within 1 blocks of the head block number (currently 3)")
+
+	api.GetProofRecomputeLimit = 1
+	_, err = api.GetProof(context.Background(), contractAddr, nil, oldBlock)
+	require.EqualError(t, err, "state as of block 1 has more than 1 items, see --rpc.getproof.recompute.limit")
+
+	api.GetProofRecomputeLimit = 100
+	_, err = api.GetProof(context.Background(), contractAddr, nil, oldBlock)
+	require.NoError(t, err)
+}
+
+func TestStorageRoot(t *testing.T) {
+	m, bankAddr, contractAddr := chainWithDeployedContract(t)
+	if ethconfig.EnableHistoryV4InTest {
+		t.Skip("Erigon4 doesn't have intermediate hashes")
+	}
+	api := NewEthAPI(newBaseApiForTest(m), m.DB, nil, nil, nil, 5000000, 100_000, false, 1, log.New())
+	ctx := context.Background()
+	tx, err := m.DB.BeginRo(ctx)
+	require.NoError(t, err)
+	defer tx.Rollback()
+
+	for _, addr := range []libcommon.Address{contractAddr, bankAddr} {
+		proof, err := api.GetProof(ctx, addr, nil, rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber))
+		require.NoError(t, err)
+		root, err := trie.StorageRoot(tx, addr)
+		require.NoError(t, err)
+		require.Equal(t, proof.StorageHash, root)
+	}
+	root, err := trie.StorageRoot(tx, contractAddr)
+	require.NoError(t, err)
+	require.NotEqual(t, trie.EmptyRoot, root)
+	root, err = trie.StorageRoot(tx, libcommon.HexToAddress("0xdeaddeaddeaddeaddeaddeaddeaddeaddeaddead0"))
+	require.NoError(t, err)
+	require.Equal(t, trie.EmptyRoot, root)
+}
+
 func TestGetBlockByTimestampLatestTime(t *testing.T) {
 	ctx := context.Background()
 	m, _, _ := rpcdaemontest.CreateTestSentry(t)
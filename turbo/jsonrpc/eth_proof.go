@@ -0,0 +1,278 @@
+package jsonrpc
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ledgerwatch/erigon-lib/commitment"
+	libcommon "github.com/ledgerwatch/erigon-lib/common"
+	"github.com/ledgerwatch/erigon-lib/common/hexutil"
+	"github.com/ledgerwatch/erigon-lib/common/hexutility"
+	"github.com/ledgerwatch/erigon-lib/common/length"
+	"github.com/ledgerwatch/erigon-lib/etl"
+	"github.com/ledgerwatch/erigon-lib/kv"
+	"github.com/ledgerwatch/erigon-lib/kv/order"
+	"github.com/ledgerwatch/erigon-lib/kv/rawdbv3"
+	"github.com/ledgerwatch/log/v3"
+
+	"github.com/ledgerwatch/erigon/core/state"
+	"github.com/ledgerwatch/erigon/core/types"
+	"github.com/ledgerwatch/erigon/core/types/accounts"
+	"github.com/ledgerwatch/erigon/crypto"
+	"github.com/ledgerwatch/erigon/turbo/rpchelper"
+)
+
+// getProofBatchSize is the number of state items fed into the commitment trie at once
+const getProofBatchSize = 100_000
+
+// getProofFromCommitment re-computes HexPatriciaHashed commitment over the whole state as of the
+// end of the given block and extracts proofs for the requested account and storage keys.
+// It does not depend on intermediate hashes, so works for any block with available history,
+// but requires full state traversal, which is aborted after GetProofRecomputeLimit state items.
+func (api *APIImpl) getProofFromCommitment(ctx context.Context, tx kv.Tx, address libcommon.Address, storageKeys []libcommon.Hash, header *types.Header) (*accounts.AccProofResult, error) {
+	blockNr := header.Number.Uint64()
+	chainConfig, err := api.chainConfig(tx)
+	if err != nil {
+		return nil, err
+	}
+	reader, err := rpchelper.CreateHistoryStateReader(tx, blockNr+1, 0, api.historyV3(tx), chainConfig.ChainName)
+	if err != nil {
+		return nil, err
+	}
+
+	retain := make([][]byte, 0, 1+len(storageKeys))
+	retain = append(retain, libcommon.Copy(address.Bytes()))
+	for _, key := range storageKeys {
+		retain = append(retain, append(libcommon.Copy(address.Bytes()), key.Bytes()...))
+	}
+	accountFn := func(plainKey []byte, cell *commitment.Cell) error {
+		acc, err := reader.ReadAccountData(libcommon.BytesToAddress(plainKey))
+		if err != nil {
+			return err
+		}
+		if acc == nil {
+			cell.Delete = true
+			return nil
+		}
+		cell.Nonce = acc.Nonce
+		cell.Balance.Set(&acc.Balance)
+		copy(cell.CodeHash[:], acc.CodeHash[:])
+		return nil
+	}
+	storageFn := func(plainKey []byte, cell *commitment.Cell) error {
+		addr := libcommon.BytesToAddress(plainKey[:length.Addr])
+		acc, err := reader.ReadAccountData(addr)
+		if err != nil {
+			return err
+		}
+		var v []byte
+		if acc != nil {
+			loc := libcommon.BytesToHash(plainKey[length.Addr:])
+			if v, err = reader.ReadAccountStorage(addr, acc.Incarnation, &loc); err != nil {
+				return err
+			}
+		}
+		if len(v) == 0 {
+			cell.Delete = true
+			return nil
+		}
+		cell.StorageLen = len(v)
+		copy(cell.Storage[:], v)
+		return nil
+	}
+	proofTrie, err := commitment.NewProofTrie(length.Addr, retain, getProofBatchSize, accountFn, storageFn)
+	if err != nil {
+		return nil, err
+	}
+
+	collector := etl.NewCollector("eth_getProof", api.dirs.Tmp, etl.NewSortableBuffer(etl.BufferOptimalSize), api.logger)
+	defer collector.Close()
+	collector.LogLvl(log.LvlDebug)
+	if err := collectProofState(ctx, tx, reader, blockNr, api.historyV3(tx), api.GetProofRecomputeLimit, collector); err != nil {
+		return nil, err
+	}
+	var update commitment.Update
+	if err := collector.Load(nil, "", func(k, v []byte, _ etl.CurrentTableReader, _ etl.LoadNextFunc) error {
+		plainKeyLen := length.Addr
+		if len(k) > length.Hash {
+			plainKeyLen += length.Hash
+		}
+		if _, err := update.Decode(v, plainKeyLen); err != nil {
+			return err
+		}
+		return proofTrie.Update(v[:plainKeyLen], update)
+	}, etl.TransformArgs{Quit: ctx.Done()}); err != nil {
+		return nil, err
+	}
+
+	root, err := proofTrie.RootHash()
+	if err != nil {
+		return nil, err
+	}
+	if libcommon.BytesToHash(root) != header.Root {
+		return nil, fmt.Errorf("mismatch in expected state root computed %x vs %x indicates bug in proof implementation", root, header.Root)
+	}
+
+	acc, err := reader.ReadAccountData(address)
+	if err != nil {
+		return nil, err
+	}
+	if acc == nil {
+		acc = &accounts.Account{}
+	}
+	accountProof, storageRoot, err := proofTrie.ProveAccount(address.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	result := &accounts.AccProofResult{
+		Address:      address,
+		AccountProof: toHexutilityBytes(accountProof),
+		Balance:      (*hexutil.Big)(acc.Balance.ToBig()),
+		Nonce:        hexutil.Uint64(acc.Nonce),
+		CodeHash:     acc.CodeHash,
+		StorageHash:  libcommon.BytesToHash(storageRoot),
+		StorageProof: make([]accounts.StorProofResult, len(storageKeys)),
+	}
+	for i, key := range storageKeys {
+		key := key
+		result.StorageProof[i].Key = key
+		value := new(big.Int)
+		if acc.Initialised {
+			v, err := reader.ReadAccountStorage(address, acc.Incarnation, &key)
+			if err != nil {
+				return nil, err
+			}
+			value.SetBytes(v)
+		}
+		result.StorageProof[i].Value = (*hexutil.Big)(value)
+		proof, err := proofTrie.ProveStorage(retain[i+1])
+		if err != nil {
+			return nil, err
+		}
+		result.StorageProof[i].Proof = toHexutilityBytes(proof)
+	}
+	return result, nil
+}
+
+// collectProofState puts every account and storage item of the state as of the end of the given
+// block into the collector. Keys are hashed, so that collector yields items in the trie order,
+// values are plain keys followed by encoded commitment.Update. An error is returned if the state
+// has more than limit items.
+func collectProofState(ctx context.Context, tx kv.Tx, reader state.StateReader, blockNr uint64, historyV3 bool, limit uint64, collector *etl.Collector) error {
+	var txNum uint64
+	if historyV3 {
+		var err error
+		if txNum, err = rawdbv3.TxNums.Min(tx, blockNr+1); err != nil {
+			return err
+		}
+	}
+	var numBuf [10]byte
+	var update commitment.Update
+	var count uint64
+	countItem := func() error {
+		if count++; count > limit {
+			return fmt.Errorf("state as of block %d has more than %d items, see --rpc.getproof.recompute.limit", blockNr, limit)
+		}
+		return nil
+	}
+	collectStorage := func(addrHash libcommon.Hash, addr, loc, v []byte) error {
+		if len(v) == 0 {
+			return nil // Skip deleted entries
+		}
+		if err := countItem(); err != nil {
+			return err
+		}
+		locHash, err := libcommon.HashData(loc)
+		if err != nil {
+			return err
+		}
+		update = commitment.Update{Flags: commitment.StorageUpdate, ValLength: len(v)}
+		copy(update.CodeHashOrStorage[:], v)
+		plainKey := append(append(make([]byte, 0, length.Addr+length.Hash+len(v)+3), addr...), loc...)
+		return collector.Collect(append(addrHash.Bytes(), locHash.Bytes()...), update.Encode(plainKey, numBuf[:]))
+	}
+	collectAccount := func(k []byte) error {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+		addr := libcommon.BytesToAddress(k)
+		acc, err := reader.ReadAccountData(addr)
+		if err != nil {
+			return err
+		}
+		if acc == nil {
+			return nil
+		}
+		if err := countItem(); err != nil {
+			return err
+		}
+		addrHash := crypto.Keccak256Hash(addr[:])
+		update = commitment.Update{Flags: commitment.BalanceUpdate | commitment.NonceUpdate | commitment.CodeUpdate, Nonce: acc.Nonce}
+		update.Balance.Set(&acc.Balance)
+		copy(update.CodeHashOrStorage[:], acc.CodeHash[:])
+		if err := collector.Collect(addrHash.Bytes(), update.Encode(libcommon.Copy(addr[:]), numBuf[:])); err != nil {
+			return err
+		}
+		if acc.Incarnation == 0 {
+			return nil
+		}
+
+		if historyV3 {
+			toKey, _ := kv.NextSubtree(addr[:])
+			it, err := tx.(kv.TemporalTx).DomainRange(kv.StorageDomain, addr[:], toKey, txNum, order.Asc, kv.Unlim)
+			if err != nil {
+				return err
+			}
+			for it.HasNext() {
+				k, v, err := it.Next()
+				if err != nil {
+					return err
+				}
+				if err := collectStorage(addrHash, addr[:], k[length.Addr:], v); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+		return state.WalkAsOfStorage(tx, addr, acc.Incarnation, libcommon.Hash{}, blockNr+1, func(_, loc, v []byte) (bool, error) {
+			return true, collectStorage(addrHash, addr[:], loc, v)
+		})
+	}
+
+	if historyV3 {
+		it, err := tx.(kv.TemporalTx).DomainRange(kv.AccountsDomain, nil, nil, txNum, order.Asc, kv.Unlim)
+		if err != nil {
+			return err
+		}
+		for it.HasNext() {
+			k, v, err := it.Next()
+			if err != nil {
+				return err
+			}
+			if len(v) == 0 {
+				continue
+			}
+			if err := collectAccount(k); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return state.WalkAsOfAccounts(tx, libcommon.Address{}, blockNr+1, func(k, _ []byte) (bool, error) {
+		if len(k) > length.Addr {
+			return true, nil
+		}
+		return true, collectAccount(k)
+	})
+}
+
+func toHexutilityBytes(in [][]byte) []hexutility.Bytes {
+	out := make([]hexutility.Bytes, len(in))
+	for i, b := range in {
+		out[i] = b
+	}
+	return out
+}
@@ -40,7 +40,11 @@ import (
 const PendingBlockNumber int64 = -2
 
 func (api *BaseAPI) getReceipts(ctx context.Context, tx kv.Tx, chainConfig *chain.Config, block *types.Block, senders []common.Address) (types.Receipts, error) {
-	if cached := rawdb.ReadReceipts(tx, block, senders); cached != nil {
+	cached, err := api._blockReader.Receipts(ctx, tx, block, senders)
+	if err != nil {
+		return nil, err
+	}
+	if cached != nil {
 		return cached, nil
 	}
 	engine := api.engine()
@@ -407,7 +411,7 @@ func applyFiltersV3(tx kv.TemporalTx, begin, end uint64, crit filters.FilterCrit
 	return out, nil
 }
 
-func (api *APIImpl) getLogsV3(ctx context.Context, tx kv.TemporalTx, begin, end uint64, crit filters.FilterCriteria) ([]*types.Log, error) {
+func (api *BaseAPI) getLogsV3(ctx context.Context, tx kv.TemporalTx, begin, end uint64, crit filters.FilterCriteria) ([]*types.Log, error) {
 	logs := []*types.Log{}
 
 	txNumbers, err := applyFiltersV3(tx, begin, end, crit)
@@ -0,0 +1,490 @@
+package jsonrpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/holiman/uint256"
+	"github.com/ledgerwatch/log/v3"
+
+	"github.com/ledgerwatch/erigon-lib/chain"
+	libcommon "github.com/ledgerwatch/erigon-lib/common"
+	"github.com/ledgerwatch/erigon-lib/common/hexutil"
+	"github.com/ledgerwatch/erigon-lib/common/hexutility"
+
+	"github.com/ledgerwatch/erigon/consensus/misc"
+	"github.com/ledgerwatch/erigon/core"
+	"github.com/ledgerwatch/erigon/core/state"
+	"github.com/ledgerwatch/erigon/core/types"
+	"github.com/ledgerwatch/erigon/core/vm"
+	"github.com/ledgerwatch/erigon/core/vm/evmtypes"
+	"github.com/ledgerwatch/erigon/rpc"
+	"github.com/ledgerwatch/erigon/turbo/adapter/ethapi"
+	"github.com/ledgerwatch/erigon/turbo/rpchelper"
+)
+
+const (
+	// maxSimulateBlocks limits the number of blocks (including the ones filling number gaps) in one eth_simulateV1 request
+	maxSimulateBlocks = 256
+	// simulateBlockTimeIncrement is the default time difference between consecutive simulated blocks
+	simulateBlockTimeIncrement = 12
+)
+
+// eth_simulateV1 error codes
+const (
+	simulateErrCodeNonceTooLow      = -38010
+	simulateErrCodeNonceTooHigh     = -38011
+	simulateErrCodeBaseFeeTooLow    = -38012
+	simulateErrCodeIntrinsicGas     = -38013
+	simulateErrCodeInsufficientFund = -38014
+	simulateErrCodeGasLimitReached  = -38015
+	simulateErrCodeInvalidNumber    = -38020
+	simulateErrCodeInvalidTimestamp = -38021
+	simulateErrCodeTooManyBlocks    = -38026
+	simulateErrCodeReverted         = 3
+	simulateErrCodeVMError          = -32015
+)
+
+var (
+	// transferLogAddress is the pseudo-address emitting ETH transfer logs, as proposed by ERC-7528
+	transferLogAddress = libcommon.HexToAddress("0xEeeeeEeeeEeEeeEeEeEeeEEEeeeeEeeeeeeeEEeE")
+	// transferLogTopic is keccak256("Transfer(address,address,uint256)"), same as for ERC-20 transfers
+	transferLogTopic = libcommon.HexToHash("0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef")
+)
+
+// SimulationOpts is the input of eth_simulateV1
+type SimulationOpts struct {
+	BlockStateCalls        []SimulatedBlock `json:"blockStateCalls"`
+	TraceTransfers         bool             `json:"traceTransfers"`
+	Validation             bool             `json:"validation"`
+	ReturnFullTransactions bool             `json:"returnFullTransactions"`
+}
+
+// SimulatedBlock is a block of calls executed on top of the previous simulated block
+type SimulatedBlock struct {
+	BlockOverrides *SimulatedBlockOverrides `json:"blockOverrides"`
+	StateOverrides *ethapi.StateOverrides   `json:"stateOverrides"`
+	Calls          []ethapi.CallArgs        `json:"calls"`
+}
+
+// SimulatedBlockOverrides replaces header fields of the simulated block
+type SimulatedBlockOverrides struct {
+	Number        *hexutil.Big       `json:"number"`
+	Difficulty    *hexutil.Big       `json:"difficulty"`
+	Time          *hexutil.Uint64    `json:"time"`
+	GasLimit      *hexutil.Uint64    `json:"gasLimit"`
+	FeeRecipient  *libcommon.Address `json:"feeRecipient"`
+	PrevRandao    *libcommon.Hash    `json:"prevRandao"`
+	BaseFeePerGas *hexutil.Big       `json:"baseFeePerGas"`
+	BlobBaseFee   *hexutil.Big       `json:"blobBaseFee"`
+}
+
+// SimulatedCallResult is the outcome of a single call of the simulated block
+type SimulatedCallResult struct {
+	ReturnValue hexutility.Bytes    `json:"returnData"`
+	Logs        []*types.Log        `json:"logs"`
+	GasUsed     hexutil.Uint64      `json:"gasUsed"`
+	Status      hexutil.Uint64      `json:"status"`
+	Error       *SimulatedCallError `json:"error,omitempty"`
+}
+
+// SimulatedCallError describes why the call has failed (reverted or hit an EVM error)
+type SimulatedCallError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	Data    string `json:"data,omitempty"`
+}
+
+// SimulateV1 implements eth_simulateV1. Executes a chain of blocks consisting of the given calls on top of
+// the given block, with optional block and state overrides for every simulated block.
+// Returns the simulated blocks together with the results of their calls. State root of the simulated
+// blocks is not computed.
+func (api *APIImpl) SimulateV1(ctx context.Context, opts SimulationOpts, blockNrOrHash *rpc.BlockNumberOrHash) ([]map[string]interface{}, error) {
+	if len(opts.BlockStateCalls) == 0 {
+		return nil, &rpc.InvalidParamsError{Message: "empty input"}
+	}
+	if len(opts.BlockStateCalls) > maxSimulateBlocks {
+		return nil, &rpc.CustomError{Code: simulateErrCodeTooManyBlocks, Message: fmt.Sprintf("too many blocks, maximum is %d", maxSimulateBlocks)}
+	}
+	bNrOrHash := rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
+	if blockNrOrHash != nil {
+		bNrOrHash = *blockNrOrHash
+	}
+
+	tx, err := api.db.BeginRo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	chainConfig, err := api.chainConfig(tx)
+	if err != nil {
+		return nil, err
+	}
+	blockNumber, hash, _, err := rpchelper.GetCanonicalBlockNumber(bNrOrHash, tx, api.filters)
+	if err != nil {
+		return nil, err
+	}
+	block, err := api.blockWithSenders(tx, hash, blockNumber)
+	if err != nil {
+		return nil, err
+	}
+	if block == nil {
+		return nil, fmt.Errorf("block %d(%x) not found", blockNumber, hash)
+	}
+	stateReader, err := rpchelper.CreateStateReader(ctx, tx, bNrOrHash, 0, api.filters, api.stateCache, api.historyV3(tx), chainConfig.ChainName)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func(start time.Time) { log.Trace("Executing EVM simulateV1 finished", "runtime", time.Since(start)) }(time.Now())
+
+	var cancel context.CancelFunc
+	if api.evmCallTimeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, api.evmCallTimeout)
+	} else {
+		ctx, cancel = context.WithCancel(ctx)
+	}
+	defer cancel()
+
+	headers, blocks, err := simulatedHeaders(chainConfig, block.HeaderNoCopy(), opts)
+	if err != nil {
+		return nil, err
+	}
+
+	sim := &simulator{
+		api:           api,
+		ctx:           ctx,
+		chainConfig:   chainConfig,
+		ibs:           state.New(stateReader),
+		opts:          opts,
+		base:          block.HeaderNoCopy(),
+		blockHashes:   make(map[uint64]libcommon.Hash),
+		canonicalHash: func(n uint64) (libcommon.Hash, error) { return api._blockReader.CanonicalHash(ctx, tx, n) },
+	}
+	sim.evm = vm.NewEVM(evmtypes.BlockContext{}, evmtypes.TxContext{}, sim.ibs, chainConfig, vm.Config{})
+	// Wait for the context to be done and cancel the evm. Even if the
+	// EVM has finished, cancelling may be done (repeatedly)
+	go func() {
+		<-ctx.Done()
+		sim.evm.Cancel()
+	}()
+
+	results := make([]map[string]interface{}, 0, len(headers))
+	for i, header := range headers {
+		result, err := sim.simulateBlock(header, blocks[i])
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// simulatedHeaders builds headers of the simulated blocks, filling gaps in numbers with empty blocks.
+// Returns requested block for every header, which is empty for the filler blocks.
+// Transactions, receipts and gas related fields are filled in during the simulation.
+func simulatedHeaders(chainConfig *chain.Config, base *types.Header, opts SimulationOpts) ([]*types.Header, []SimulatedBlock, error) {
+	headers := make([]*types.Header, 0, len(opts.BlockStateCalls))
+	blocks := make([]SimulatedBlock, 0, len(opts.BlockStateCalls))
+	prevNumber, prevTime := base.Number.Uint64(), base.Time
+	for i := range opts.BlockStateCalls {
+		overrides := opts.BlockStateCalls[i].BlockOverrides
+		if overrides == nil {
+			overrides = &SimulatedBlockOverrides{}
+		}
+		number := prevNumber + 1
+		if overrides.Number != nil {
+			if !overrides.Number.ToInt().IsUint64() || overrides.Number.ToInt().Uint64() <= prevNumber {
+				return nil, nil, &rpc.CustomError{Code: simulateErrCodeInvalidNumber, Message: fmt.Sprintf("block numbers must be in order: %d <= %d", overrides.Number.ToInt(), prevNumber)}
+			}
+			number = overrides.Number.ToInt().Uint64()
+		}
+		if number-base.Number.Uint64() > maxSimulateBlocks {
+			return nil, nil, &rpc.CustomError{Code: simulateErrCodeTooManyBlocks, Message: fmt.Sprintf("too many blocks, maximum is %d", maxSimulateBlocks)}
+		}
+		for prevNumber+1 < number {
+			prevNumber++
+			prevTime += simulateBlockTimeIncrement
+			headers = append(headers, simulatedHeader(base, prevNumber, prevTime, &SimulatedBlockOverrides{}))
+			blocks = append(blocks, SimulatedBlock{})
+		}
+		timestamp := prevTime + simulateBlockTimeIncrement
+		if overrides.Time != nil {
+			if uint64(*overrides.Time) <= prevTime {
+				return nil, nil, &rpc.CustomError{Code: simulateErrCodeInvalidTimestamp, Message: fmt.Sprintf("block timestamps must be in order: %d <= %d", uint64(*overrides.Time), prevTime)}
+			}
+			timestamp = uint64(*overrides.Time)
+		}
+		headers = append(headers, simulatedHeader(base, number, timestamp, overrides))
+		blocks = append(blocks, opts.BlockStateCalls[i])
+		prevNumber, prevTime = number, timestamp
+	}
+	for _, header := range headers {
+		if chainConfig.IsShanghai(header.Time) {
+			withdrawalsHash := types.EmptyRootHash
+			header.WithdrawalsHash = &withdrawalsHash
+		}
+		if chainConfig.IsCancun(header.Time) {
+			header.BlobGasUsed = new(uint64)
+			header.ExcessBlobGas = new(uint64)
+			header.ParentBeaconBlockRoot = new(libcommon.Hash)
+		}
+	}
+	return headers, blocks, nil
+}
+
+func simulatedHeader(base *types.Header, number, timestamp uint64, overrides *SimulatedBlockOverrides) *types.Header {
+	header := &types.Header{
+		UncleHash:  types.EmptyUncleHash,
+		Coinbase:   base.Coinbase,
+		Difficulty: new(big.Int).Set(base.Difficulty),
+		Number:     new(big.Int).SetUint64(number),
+		GasLimit:   base.GasLimit,
+		Time:       timestamp,
+		MixDigest:  base.MixDigest,
+	}
+	if overrides.Difficulty != nil {
+		header.Difficulty.Set(overrides.Difficulty.ToInt())
+	}
+	if overrides.GasLimit != nil {
+		header.GasLimit = uint64(*overrides.GasLimit)
+	}
+	if overrides.FeeRecipient != nil {
+		header.Coinbase = *overrides.FeeRecipient
+	}
+	if overrides.PrevRandao != nil {
+		header.MixDigest = *overrides.PrevRandao
+	}
+	if overrides.BaseFeePerGas != nil {
+		header.BaseFee = new(big.Int).Set(overrides.BaseFeePerGas.ToInt())
+	}
+	return header
+}
+
+// simulator keeps state shared by the consecutive simulated blocks
+type simulator struct {
+	api           *APIImpl
+	ctx           context.Context
+	chainConfig   *chain.Config
+	ibs           *state.IntraBlockState
+	evm           *vm.EVM
+	opts          SimulationOpts
+	parent        *types.Header
+	base          *types.Header
+	blockHashes   map[uint64]libcommon.Hash // hashes of already simulated blocks
+	canonicalHash func(n uint64) (libcommon.Hash, error)
+}
+
+func (s *simulator) getHash(n uint64) libcommon.Hash {
+	if hash, ok := s.blockHashes[n]; ok {
+		return hash
+	}
+	if n > s.base.Number.Uint64() {
+		return libcommon.Hash{}
+	}
+	hash, err := s.canonicalHash(n)
+	if err != nil {
+		log.Debug("Can't get block hash by number", "number", n, "only-canonical", true)
+	}
+	return hash
+}
+
+func (s *simulator) simulateBlock(header *types.Header, simBlock SimulatedBlock) (map[string]interface{}, error) {
+	parent := s.parent
+	if parent == nil {
+		parent = s.base
+	}
+	header.ParentHash = parent.Hash()
+	if header.BaseFee == nil && s.chainConfig.IsLondon(header.Number.Uint64()) {
+		if s.opts.Validation {
+			header.BaseFee = misc.CalcBaseFee(s.chainConfig, parent)
+		} else {
+			header.BaseFee = new(big.Int) // Allows zero gas price calls
+		}
+	}
+
+	if simBlock.StateOverrides != nil {
+		if err := simBlock.StateOverrides.Override(s.ibs); err != nil {
+			return nil, err
+		}
+	}
+
+	blockCtx := core.NewEVMBlockContext(header, s.getHash, s.api.engine(), &header.Coinbase)
+	if overrides := simBlock.BlockOverrides; overrides != nil && overrides.BlobBaseFee != nil {
+		blobBaseFee, overflow := uint256.FromBig(overrides.BlobBaseFee.ToInt())
+		if overflow {
+			return nil, fmt.Errorf("blob base fee override higher than 2^256-1")
+		}
+		blockCtx.BlobBaseFee = blobBaseFee
+	}
+	if s.opts.TraceTransfers {
+		blockCtx.Transfer = transferWithLogs(blockCtx.Transfer)
+	}
+	rules := s.chainConfig.Rules(header.Number.Uint64(), header.Time)
+	chainID, _ := uint256.FromBig(s.chainConfig.ChainID)
+	s.evm.ResetBetweenBlocks(blockCtx, evmtypes.TxContext{}, s.ibs, vm.Config{NoBaseFee: !s.opts.Validation}, rules)
+	gp := new(core.GasPool).AddGas(header.GasLimit).AddBlobGas(s.chainConfig.GetMaxBlobGasPerBlock())
+
+	txs := make(types.Transactions, 0, len(simBlock.Calls))
+	receipts := make(types.Receipts, 0, len(simBlock.Calls))
+	calls := make([]SimulatedCallResult, 0, len(simBlock.Calls))
+	for i, args := range simBlock.Calls {
+		// Reset of the EVM discards cancellation, so check the context explicitly
+		if s.ctx.Err() != nil {
+			return nil, fmt.Errorf("execution aborted (timeout = %v)", s.api.evmCallTimeout)
+		}
+		if args.Gas == nil {
+			gas := gp.Gas()
+			if s.api.GasCap < gas {
+				gas = s.api.GasCap
+			}
+			args.Gas = (*hexutil.Uint64)(&gas)
+		}
+		msg, err := args.ToMessage(s.api.GasCap, blockCtx.BaseFee)
+		if err != nil {
+			return nil, err
+		}
+		nonce := s.ibs.GetNonce(msg.From())
+		if args.Nonce != nil {
+			nonce = uint64(*args.Nonce)
+		}
+		msg = types.NewMessage(msg.From(), msg.To(), nonce, msg.Value(), msg.Gas(), msg.GasPrice(), msg.FeeCap(), msg.Tip(),
+			msg.Data(), msg.AccessList(), s.opts.Validation /* checkNonce */, false /* isFree */, msg.MaxFeePerBlobGas())
+
+		txn := simulatedTransaction(msg, chainID, header.BaseFee != nil)
+		txn.SetSender(msg.From())
+		txHash := txn.Hash()
+		s.ibs.SetTxContext(txHash, libcommon.Hash{}, i)
+		logsBefore := len(s.ibs.GetLogs(txHash)) // same calls have same hashes
+
+		s.evm.Reset(core.NewEVMTxContext(msg), s.ibs)
+		result, err := core.ApplyMessage(s.evm, msg, gp, true /* refunds */, false /* gasBailout */)
+		if err != nil {
+			return nil, simulateTxError(i, err)
+		}
+		if err = s.ibs.FinalizeTx(rules, state.NewNoopWriter()); err != nil {
+			return nil, err
+		}
+		// If the timer caused an abort, return an appropriate error message
+		if s.evm.Cancelled() || s.ctx.Err() != nil {
+			return nil, fmt.Errorf("execution aborted (timeout = %v)", s.api.evmCallTimeout)
+		}
+
+		header.GasUsed += result.UsedGas
+		logs := s.ibs.GetLogs(txHash)[logsBefore:]
+		receipt := &types.Receipt{
+			Type:              txn.Type(),
+			CumulativeGasUsed: header.GasUsed,
+			Logs:              logs,
+			TxHash:            txHash,
+			GasUsed:           result.UsedGas,
+			TransactionIndex:  uint(i),
+		}
+		callResult := SimulatedCallResult{
+			ReturnValue: result.Return(),
+			Logs:        logs,
+			GasUsed:     hexutil.Uint64(result.UsedGas),
+			Status:      hexutil.Uint64(types.ReceiptStatusSuccessful),
+		}
+		if result.Failed() {
+			receipt.Status = types.ReceiptStatusFailed
+			callResult.Status = hexutil.Uint64(types.ReceiptStatusFailed)
+			if len(result.Revert()) > 0 {
+				revertErr := ethapi.NewRevertError(result)
+				callResult.Error = &SimulatedCallError{Code: simulateErrCodeReverted, Message: revertErr.Error(), Data: hexutility.Encode(result.Revert())}
+			} else {
+				callResult.Error = &SimulatedCallError{Code: simulateErrCodeVMError, Message: result.Err.Error()}
+			}
+		} else {
+			receipt.Status = types.ReceiptStatusSuccessful
+		}
+		if callResult.Logs == nil {
+			callResult.Logs = []*types.Log{}
+		}
+		receipt.Bloom = types.CreateBloom(types.Receipts{receipt})
+		txs = append(txs, txn)
+		receipts = append(receipts, receipt)
+		calls = append(calls, callResult)
+	}
+
+	var withdrawals []*types.Withdrawal
+	if header.WithdrawalsHash != nil {
+		withdrawals = []*types.Withdrawal{}
+	}
+	block := types.NewBlock(header, txs, nil, receipts, withdrawals)
+	blockHash := block.Hash()
+	for i := range receipts {
+		for _, l := range receipts[i].Logs {
+			l.BlockHash = blockHash
+			l.BlockNumber = header.Number.Uint64()
+		}
+	}
+	s.blockHashes[header.Number.Uint64()] = blockHash
+	s.parent = block.Header()
+
+	return ethapi.RPCMarshalBlock(block, true, s.opts.ReturnFullTransactions, map[string]interface{}{"calls": calls})
+}
+
+// simulatedTransaction builds unsigned transaction corresponding to the simulated call
+func simulatedTransaction(msg types.Message, chainID *uint256.Int, london bool) types.Transaction {
+	commonTx := types.CommonTx{
+		Nonce: msg.Nonce(),
+		Gas:   msg.Gas(),
+		To:    msg.To(),
+		Value: msg.Value(),
+		Data:  msg.Data(),
+	}
+	if !london {
+		return &types.LegacyTx{CommonTx: commonTx, GasPrice: msg.GasPrice()}
+	}
+	return &types.DynamicFeeTransaction{
+		CommonTx:   commonTx,
+		ChainID:    chainID,
+		Tip:        msg.Tip(),
+		FeeCap:     msg.FeeCap(),
+		AccessList: msg.AccessList(),
+	}
+}
+
+// transferWithLogs wraps the transfer function to emit ERC-7528 logs for every ETH transfer.
+// Logs are added to the intra block state after the call snapshot, so they are discarded on revert.
+func transferWithLogs(transfer evmtypes.TransferFunc) evmtypes.TransferFunc {
+	return func(db evmtypes.IntraBlockState, sender, recipient libcommon.Address, amount *uint256.Int, bailout bool) {
+		transfer(db, sender, recipient, amount, bailout)
+		if amount.IsZero() {
+			return
+		}
+		value := amount.Bytes32()
+		db.AddLog(&types.Log{
+			Address: transferLogAddress,
+			Topics:  []libcommon.Hash{transferLogTopic, sender.Hash(), recipient.Hash()},
+			Data:    value[:],
+		})
+	}
+}
+
+// simulateTxError converts error of the invalid transaction into error with eth_simulateV1 code
+func simulateTxError(i int, err error) error {
+	code := rpc.CustomError{Message: fmt.Sprintf("call %d: %v", i, err)}
+	switch {
+	case errors.Is(err, core.ErrNonceTooLow):
+		code.Code = simulateErrCodeNonceTooLow
+	case errors.Is(err, core.ErrNonceTooHigh):
+		code.Code = simulateErrCodeNonceTooHigh
+	case errors.Is(err, core.ErrFeeCapTooLow):
+		code.Code = simulateErrCodeBaseFeeTooLow
+	case errors.Is(err, core.ErrIntrinsicGas):
+		code.Code = simulateErrCodeIntrinsicGas
+	case errors.Is(err, core.ErrInsufficientFunds):
+		code.Code = simulateErrCodeInsufficientFund
+	case errors.Is(err, core.ErrGasLimitReached):
+		code.Code = simulateErrCodeGasLimitReached
+	default:
+		return errors.New(code.Message)
+	}
+	return &code
+}
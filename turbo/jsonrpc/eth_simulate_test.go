@@ -0,0 +1,150 @@
+package jsonrpc
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/holiman/uint256"
+	"github.com/ledgerwatch/log/v3"
+	"github.com/stretchr/testify/require"
+
+	libcommon "github.com/ledgerwatch/erigon-lib/common"
+	"github.com/ledgerwatch/erigon-lib/common/datadir"
+	"github.com/ledgerwatch/erigon-lib/common/hexutil"
+	"github.com/ledgerwatch/erigon-lib/common/hexutility"
+	"github.com/ledgerwatch/erigon-lib/kv/kvcache"
+
+	"github.com/ledgerwatch/erigon/accounts/abi/bind/backends"
+	"github.com/ledgerwatch/erigon/core/types"
+	"github.com/ledgerwatch/erigon/crypto"
+	"github.com/ledgerwatch/erigon/params"
+	"github.com/ledgerwatch/erigon/rpc"
+	"github.com/ledgerwatch/erigon/rpc/rpccfg"
+	"github.com/ledgerwatch/erigon/turbo/adapter/ethapi"
+)
+
+func TestSimulateV1(t *testing.T) {
+	var (
+		key, _   = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		address  = crypto.PubkeyToAddress(key.PublicKey)
+		address1 = libcommon.HexToAddress("0x1111111111111111111111111111111111111111")
+		gspec    = &types.Genesis{
+			Config:   params.TestChainConfig,
+			Alloc:    types.GenesisAlloc{address: {Balance: big.NewInt(9000000000000000000)}},
+			GasLimit: 10000000,
+		}
+		ctx = context.Background()
+	)
+	contractBackend := backends.NewTestSimulatedBackendWithConfig(t, gspec.Alloc, gspec.Config, gspec.GasLimit)
+	defer contractBackend.Close()
+	contractBackend.Commit()
+
+	stateCache := kvcache.New(kvcache.DefaultCoherentConfig)
+	api := NewEthAPI(NewBaseApi(nil, stateCache, contractBackend.BlockReader(), contractBackend.Agg(), false, rpccfg.DefaultEvmCallTimeout, contractBackend.Engine(),
+		datadir.New(t.TempDir())), contractBackend.DB(), nil, nil, nil, 5000000, 100_000, false, 100_000, log.New())
+
+	value := (*hexutil.Big)(big.NewInt(1000))
+	number := (*hexutil.Big)(big.NewInt(5))
+	coinbase := libcommon.HexToAddress("0x2222222222222222222222222222222222222222")
+	latest := rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
+
+	t.Run("transfers", func(t *testing.T) {
+		res, err := api.SimulateV1(ctx, SimulationOpts{
+			TraceTransfers: true,
+			BlockStateCalls: []SimulatedBlock{
+				{Calls: []ethapi.CallArgs{{From: &address, To: &address1, Value: value}}},
+				{
+					BlockOverrides: &SimulatedBlockOverrides{Number: number, FeeRecipient: &coinbase},
+					Calls:          []ethapi.CallArgs{{From: &address, To: &address1, Value: value}},
+				},
+			},
+		}, &latest)
+		require.NoError(t, err)
+		// the second block is preceded by empty blocks filling the gap in numbers
+		require.Len(t, res, 4)
+		for i, block := range res {
+			require.Equal(t, (*hexutil.Big)(big.NewInt(int64(i+2))), block["number"])
+		}
+		require.Equal(t, res[0]["hash"], res[1]["parentHash"])
+		require.Equal(t, coinbase, res[3]["miner"])
+		require.Empty(t, res[1]["calls"])
+
+		for _, i := range []int{0, 3} {
+			calls := res[i]["calls"].([]SimulatedCallResult)
+			require.Len(t, calls, 1)
+			require.Equal(t, hexutil.Uint64(types.ReceiptStatusSuccessful), calls[0].Status)
+			require.Len(t, calls[0].Logs, 1)
+			transferLog := calls[0].Logs[0]
+			require.Equal(t, transferLogAddress, transferLog.Address)
+			require.Equal(t, []libcommon.Hash{transferLogTopic, address.Hash(), address1.Hash()}, transferLog.Topics)
+			amount := uint256.NewInt(1000).Bytes32()
+			require.Equal(t, amount[:], transferLog.Data)
+			require.Equal(t, res[i]["hash"], transferLog.BlockHash)
+		}
+	})
+
+	t.Run("invalidNumber", func(t *testing.T) {
+		_, err := api.SimulateV1(ctx, SimulationOpts{
+			BlockStateCalls: []SimulatedBlock{
+				{BlockOverrides: &SimulatedBlockOverrides{Number: number}},
+				{BlockOverrides: &SimulatedBlockOverrides{Number: number}},
+			},
+		}, &latest)
+		require.Error(t, err)
+		require.Equal(t, simulateErrCodeInvalidNumber, err.(*rpc.CustomError).Code)
+	})
+
+	t.Run("validation", func(t *testing.T) {
+		tooHigh := hexutil.Uint64(100)
+		_, err := api.SimulateV1(ctx, SimulationOpts{
+			Validation: true,
+			BlockStateCalls: []SimulatedBlock{
+				{Calls: []ethapi.CallArgs{{From: &address, To: &address1, Value: value, Nonce: &tooHigh}}},
+			},
+		}, &latest)
+		require.Error(t, err)
+		require.Equal(t, simulateErrCodeNonceTooHigh, err.(*rpc.CustomError).Code)
+	})
+}
+
+func TestSimulateV1BlobBaseFee(t *testing.T) {
+	var (
+		key, _  = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		address = crypto.PubkeyToAddress(key.PublicKey)
+		// BLOBBASEFEE PUSH1 0 MSTORE PUSH1 32 PUSH1 0 RETURN
+		blobBaseFeeCode = hexutility.Bytes{0x4a, 0x60, 0x00, 0x52, 0x60, 0x20, 0x60, 0x00, 0xf3}
+		contract        = libcommon.HexToAddress("0x1111111111111111111111111111111111111111")
+		ctx             = context.Background()
+	)
+	contractBackend := backends.NewTestSimulatedBackendWithConfig(t, types.GenesisAlloc{
+		address:  {Balance: big.NewInt(9000000000000000000)},
+		contract: {Balance: new(big.Int), Code: blobBaseFeeCode},
+	}, params.AllProtocolChanges, 10000000)
+	defer contractBackend.Close()
+
+	stateCache := kvcache.New(kvcache.DefaultCoherentConfig)
+	api := NewEthAPI(NewBaseApi(nil, stateCache, contractBackend.BlockReader(), contractBackend.Agg(), false, rpccfg.DefaultEvmCallTimeout, contractBackend.Engine(),
+		datadir.New(t.TempDir())), contractBackend.DB(), nil, nil, nil, 5000000, 100_000, false, 100_000, log.New())
+
+	blobBaseFee := (*hexutil.Big)(big.NewInt(7))
+	latest := rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
+	res, err := api.SimulateV1(ctx, SimulationOpts{
+		BlockStateCalls: []SimulatedBlock{
+			{
+				BlockOverrides: &SimulatedBlockOverrides{BlobBaseFee: blobBaseFee},
+				Calls:          []ethapi.CallArgs{{From: &address, To: &contract}},
+			},
+			// blob base fee of the next block is derived from its excess blob gas
+			{Calls: []ethapi.CallArgs{{From: &address, To: &contract}}},
+		},
+	}, &latest)
+	require.NoError(t, err)
+	require.Len(t, res, 2)
+	for i, want := range []uint64{7, 1} {
+		calls := res[i]["calls"].([]SimulatedCallResult)
+		require.Len(t, calls, 1)
+		require.Equal(t, hexutil.Uint64(types.ReceiptStatusSuccessful), calls[0].Status)
+		require.Equal(t, uint256.NewInt(want).PaddedBytes(32), []byte(calls[0].ReturnValue))
+	}
+}
@@ -1,43 +1,124 @@
 package jsonrpc
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
-	"github.com/ledgerwatch/erigon-lib/common/hexutil"
 	"math/big"
+	"sync"
+
+	"github.com/holiman/uint256"
 
+	"github.com/ledgerwatch/erigon-lib/chain"
 	"github.com/ledgerwatch/erigon-lib/common"
+	"github.com/ledgerwatch/erigon-lib/common/hexutil"
+	"github.com/ledgerwatch/erigon-lib/common/hexutility"
 	"github.com/ledgerwatch/erigon-lib/kv"
+	"github.com/ledgerwatch/erigon-lib/kv/bitmapdb"
+
 	"github.com/ledgerwatch/erigon/core/rawdb"
 	"github.com/ledgerwatch/erigon/core/types"
+	"github.com/ledgerwatch/erigon/eth/filters"
 	"github.com/ledgerwatch/erigon/rpc"
 	"github.com/ledgerwatch/erigon/turbo/adapter/ethapi"
 	"github.com/ledgerwatch/erigon/turbo/rpchelper"
+	"github.com/ledgerwatch/erigon/turbo/transactions"
 )
 
+// GraphQLAPI is the backend of the GraphQL resolvers. Each method reads everything it returns
+// within a single database transaction, so a GraphQL query needs far fewer round trips than
+// the equivalent JSON-RPC calls. Within the context returned by WithQueryTx all the methods
+// share one transaction, so the resolvers of a query read a consistent state.
 type GraphQLAPI interface {
+	WithQueryTx(ctx context.Context) (context.Context, func())
 	GetBlockDetails(ctx context.Context, number rpc.BlockNumber) (map[string]interface{}, error)
+	GetTransactionDetails(ctx context.Context, hash common.Hash) (map[string]interface{}, error)
+	GetLogsDetails(ctx context.Context, crit filters.FilterCriteria) ([]map[string]interface{}, error)
+	GetAccount(ctx context.Context, address common.Address, blockNrOrHash rpc.BlockNumberOrHash) (map[string]interface{}, error)
+	GetStorageAt(ctx context.Context, address common.Address, slot common.Hash, blockNrOrHash rpc.BlockNumberOrHash) (common.Hash, error)
+	Call(ctx context.Context, args ethapi.CallArgs, blockNrOrHash rpc.BlockNumberOrHash) (map[string]interface{}, error)
+	EstimateGas(ctx context.Context, args ethapi.CallArgs, blockNrOrHash rpc.BlockNumberOrHash) (hexutil.Uint64, error)
 	GetChainID(ctx context.Context) (*big.Int, error)
 }
 
 type GraphQLAPIImpl struct {
 	*BaseAPI
-	db kv.RoDB
+	db                kv.RoDB
+	eth               *APIImpl
+	logsMaxBlockRange uint64
 }
 
-func NewGraphQLAPI(base *BaseAPI, db kv.RoDB) *GraphQLAPIImpl {
+func NewGraphQLAPI(base *BaseAPI, db kv.RoDB, eth *APIImpl) *GraphQLAPIImpl {
 	return &GraphQLAPIImpl{
-		BaseAPI: base,
-		db:      db,
+		BaseAPI:           base,
+		db:                db,
+		eth:               eth,
+		logsMaxBlockRange: graphQLLogsMaxBlockRange,
+	}
+}
+
+// graphQLLogsMaxBlockRange is the maximum number of blocks searched for logs by a single query:
+// details of every matching block, including receipts, are read for the resolvers.
+const graphQLLogsMaxBlockRange = 10_000
+
+var errGraphQLQueryFinished = errors.New("graphql query is finished")
+
+type graphQLTxKey struct{}
+
+// graphQLTx is the read-only transaction shared by the resolvers of a query. It is opened on first use.
+// Resolvers run concurrently, while a transaction can't be used concurrently, so it is used under the lock.
+type graphQLTx struct {
+	mu       sync.Mutex
+	tx       kv.Tx
+	finished bool
+}
+
+// WithQueryTx returns the context of a query, in which the methods share a single transaction,
+// and the function to roll it back once the query is finished.
+func (api *GraphQLAPIImpl) WithQueryTx(ctx context.Context) (context.Context, func()) {
+	qtx := &graphQLTx{}
+	return context.WithValue(ctx, graphQLTxKey{}, qtx), func() {
+		qtx.mu.Lock()
+		defer qtx.mu.Unlock()
+		if qtx.tx != nil {
+			qtx.tx.Rollback()
+			qtx.tx = nil
+		}
+		qtx.finished = true
 	}
 }
 
+// queryTx returns the transaction of the query, locked until release is called, or a new transaction
+// if ctx is not a query context.
+func (api *GraphQLAPIImpl) queryTx(ctx context.Context) (tx kv.Tx, release func(), err error) {
+	qtx, ok := ctx.Value(graphQLTxKey{}).(*graphQLTx)
+	if !ok {
+		if tx, err = api.db.BeginRo(ctx); err != nil {
+			return nil, nil, err
+		}
+		return tx, tx.Rollback, nil
+	}
+	qtx.mu.Lock()
+	if qtx.finished {
+		qtx.mu.Unlock()
+		return nil, nil, errGraphQLQueryFinished
+	}
+	if qtx.tx == nil {
+		if qtx.tx, err = api.db.BeginRo(ctx); err != nil {
+			qtx.mu.Unlock()
+			return nil, nil, err
+		}
+	}
+	return qtx.tx, qtx.mu.Unlock, nil
+}
+
 func (api *GraphQLAPIImpl) GetChainID(ctx context.Context) (*big.Int, error) {
-	tx, err := api.db.BeginRo(ctx)
+	tx, release, err := api.queryTx(ctx)
 	if err != nil {
 		return nil, err
 	}
-	defer tx.Rollback()
+	defer release()
 
 	response, err := api.chainConfig(tx)
 	if err != nil {
@@ -48,11 +129,11 @@ func (api *GraphQLAPIImpl) GetChainID(ctx context.Context) (*big.Int, error) {
 }
 
 func (api *GraphQLAPIImpl) GetBlockDetails(ctx context.Context, blockNumber rpc.BlockNumber) (map[string]interface{}, error) {
-	tx, err := api.db.BeginRo(ctx)
+	tx, release, err := api.queryTx(ctx)
 	if err != nil {
 		return nil, err
 	}
-	defer tx.Rollback()
+	defer release()
 
 	block, senders, err := api.getBlockWithSenders(ctx, blockNumber, tx)
 	if err != nil {
@@ -62,40 +143,244 @@ func (api *GraphQLAPIImpl) GetBlockDetails(ctx context.Context, blockNumber rpc.
 		return nil, nil
 	}
 
-	getBlockRes, err := api.delegateGetBlockByNumber(tx, block, blockNumber, false)
+	chainConfig, err := api.chainConfig(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	return api.getBlockDetails(ctx, tx, chainConfig, block, senders, blockNumber)
+}
+
+// GetTransactionDetails returns the details of the block including the transaction, with the
+// position of the transaction in the block, or nil if the transaction is not mined.
+func (api *GraphQLAPIImpl) GetTransactionDetails(ctx context.Context, hash common.Hash) (map[string]interface{}, error) {
+	tx, release, err := api.queryTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	blockNum, ok, err := api.txnLookup(tx, hash)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, nil
+	}
+	block, err := api.blockByNumberWithSenders(tx, blockNum)
 	if err != nil {
 		return nil, err
 	}
+	if block == nil {
+		return nil, nil
+	}
+	txnIndex := -1
+	for idx, txn := range block.Transactions() {
+		if txn.Hash() == hash {
+			txnIndex = idx
+			break
+		}
+	}
+	if txnIndex < 0 {
+		return nil, nil
+	}
 
 	chainConfig, err := api.chainConfig(tx)
 	if err != nil {
 		return nil, err
 	}
 
-	receipts, err := api.getReceipts(ctx, tx, chainConfig, block, senders)
+	response, err := api.getBlockDetails(ctx, tx, chainConfig, block, block.Body().SendersFromTxs(), rpc.BlockNumber(blockNum))
 	if err != nil {
-		return nil, fmt.Errorf("getReceipts error: %w", err)
+		return nil, err
 	}
+	response["transactionIndex"] = txnIndex
+	return response, nil
+}
 
-	result := make([]map[string]interface{}, 0, len(receipts))
-	for _, receipt := range receipts {
-		txn := block.Transactions()[receipt.TransactionIndex]
+// GetLogsDetails returns the details of the blocks which may contain logs matching the filter
+// criteria according to the log indices. Filtering the logs themselves is up to the caller,
+// which gets the full transactions of the logs this way.
+func (api *GraphQLAPIImpl) GetLogsDetails(ctx context.Context, crit filters.FilterCriteria) ([]map[string]interface{}, error) {
+	tx, release, err := api.queryTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
 
-		transaction := marshalReceipt(receipt, txn, chainConfig, block.HeaderNoCopy(), txn.Hash(), true)
-		transaction["nonce"] = txn.GetNonce()
-		transaction["value"] = txn.GetValue()
-		transaction["data"] = txn.GetData()
-		transaction["logs"] = receipt.Logs
-		result = append(result, transaction)
+	latest, _, _, err := rpchelper.GetBlockNumber(rpc.BlockNumberOrHashWithNumber(rpc.LatestExecutedBlockNumber), tx, nil)
+	if err != nil {
+		return nil, err
+	}
+	begin, end := latest, latest
+	if crit.FromBlock != nil {
+		begin = crit.FromBlock.Uint64()
+	}
+	if crit.ToBlock != nil && crit.ToBlock.Uint64() < latest {
+		end = crit.ToBlock.Uint64()
+	}
+	if end < begin {
+		return nil, fmt.Errorf("end (%d) < begin (%d)", end, begin)
+	}
+	if end-begin >= api.logsMaxBlockRange {
+		return nil, fmt.Errorf("block range %d-%d exceeds the limit of %d blocks", begin, end, api.logsMaxBlockRange)
 	}
 
-	response := map[string]interface{}{}
-	response["block"] = getBlockRes
-	response["receipts"] = result
+	blockNums, err := api.getLogsBlockNumbers(tx, begin, end, crit)
+	if err != nil {
+		return nil, err
+	}
 
+	chainConfig, err := api.chainConfig(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]map[string]interface{}, 0, len(blockNums))
+	for _, blockNum := range blockNums {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		block, err := api.blockByNumberWithSenders(tx, blockNum)
+		if err != nil {
+			return nil, err
+		}
+		if block == nil {
+			return nil, fmt.Errorf("block not found %d", blockNum)
+		}
+		details, err := api.getBlockDetails(ctx, tx, chainConfig, block, block.Body().SendersFromTxs(), rpc.BlockNumber(blockNum))
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, details)
+	}
+	return result, nil
+}
+
+// GetAccount returns the balance, nonce and code of the account at the given block.
+func (api *GraphQLAPIImpl) GetAccount(ctx context.Context, address common.Address, blockNrOrHash rpc.BlockNumberOrHash) (map[string]interface{}, error) {
+	tx, release, err := api.queryTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	chainConfig, err := api.chainConfig(tx)
+	if err != nil {
+		return nil, err
+	}
+	reader, err := rpchelper.CreateStateReader(ctx, tx, blockNrOrHash, 0, api.filters, api.stateCache, api.historyV3(tx), chainConfig.ChainName)
+	if err != nil {
+		return nil, err
+	}
+
+	response := map[string]interface{}{
+		"balance": (*hexutil.Big)(big.NewInt(0)),
+		"nonce":   hexutil.Uint64(0),
+		"code":    hexutility.Bytes{},
+	}
+	acc, err := reader.ReadAccountData(address)
+	if err != nil {
+		return nil, fmt.Errorf("cant get account %x: %w", address, err)
+	}
+	if acc == nil {
+		// Special case - non-existent account is assumed to be empty
+		return response, nil
+	}
+	response["balance"] = (*hexutil.Big)(acc.Balance.ToBig())
+	response["nonce"] = hexutil.Uint64(acc.Nonce)
+	code, err := reader.ReadAccountCode(address, acc.Incarnation, acc.CodeHash)
+	if err != nil {
+		return nil, fmt.Errorf("cant get code of account %x: %w", address, err)
+	}
+	if code != nil {
+		response["code"] = hexutility.Bytes(code)
+	}
 	return response, nil
 }
 
+// GetStorageAt returns the value of the storage slot of the account at the given block.
+func (api *GraphQLAPIImpl) GetStorageAt(ctx context.Context, address common.Address, slot common.Hash, blockNrOrHash rpc.BlockNumberOrHash) (common.Hash, error) {
+	tx, release, err := api.queryTx(ctx)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	defer release()
+
+	reader, err := rpchelper.CreateStateReader(ctx, tx, blockNrOrHash, 0, api.filters, api.stateCache, api.historyV3(tx), "")
+	if err != nil {
+		return common.Hash{}, err
+	}
+	acc, err := reader.ReadAccountData(address)
+	if acc == nil || err != nil {
+		return common.Hash{}, err
+	}
+	res, err := reader.ReadAccountStorage(address, acc.Incarnation, &slot)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return common.BytesToHash(res), nil
+}
+
+// Call executes a message call on top of the given block, like eth_call, but also reports the
+// gas used and the status of the call instead of failing on reverts.
+func (api *GraphQLAPIImpl) Call(ctx context.Context, args ethapi.CallArgs, blockNrOrHash rpc.BlockNumberOrHash) (map[string]interface{}, error) {
+	tx, release, err := api.queryTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	chainConfig, err := api.chainConfig(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	if args.Gas == nil || uint64(*args.Gas) == 0 {
+		args.Gas = (*hexutil.Uint64)(&api.eth.GasCap)
+	}
+
+	blockNumber, hash, _, err := rpchelper.GetCanonicalBlockNumber(blockNrOrHash, tx, api.filters) // DoCall cannot be executed on non-canonical blocks
+	if err != nil {
+		return nil, err
+	}
+	block, err := api.blockWithSenders(tx, hash, blockNumber)
+	if err != nil {
+		return nil, err
+	}
+	if block == nil {
+		return nil, nil
+	}
+
+	stateReader, err := rpchelper.CreateStateReader(ctx, tx, blockNrOrHash, 0, api.filters, api.stateCache, api.historyV3(tx), chainConfig.ChainName)
+	if err != nil {
+		return nil, err
+	}
+	result, err := transactions.DoCall(ctx, api.engine(), args, tx, blockNrOrHash, block.HeaderNoCopy(), nil, nil, api.eth.GasCap, chainConfig, stateReader, api._blockReader, api.evmCallTimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(result.ReturnData) > api.eth.ReturnDataLimit {
+		return nil, fmt.Errorf("call returned result on length %d exceeding --rpc.returndata.limit %d", len(result.ReturnData), api.eth.ReturnDataLimit)
+	}
+
+	status := types.ReceiptStatusSuccessful
+	if result.Failed() {
+		status = types.ReceiptStatusFailed
+	}
+	return map[string]interface{}{
+		"data":    hexutility.Bytes(result.ReturnData),
+		"gasUsed": hexutil.Uint64(result.UsedGas),
+		"status":  hexutil.Uint64(status),
+	}, nil
+}
+
+// EstimateGas is served by eth_estimateGas, which reads the state in its own transaction.
+func (api *GraphQLAPIImpl) EstimateGas(ctx context.Context, args ethapi.CallArgs, blockNrOrHash rpc.BlockNumberOrHash) (hexutil.Uint64, error) {
+	return api.eth.EstimateGas(ctx, &args, &blockNrOrHash, nil, nil)
+}
+
 func (api *GraphQLAPIImpl) getBlockWithSenders(ctx context.Context, number rpc.BlockNumber, tx kv.Tx) (*types.Block, []common.Address, error) {
 	if number == rpc.PendingBlockNumber {
 		return api.pendingBlock(), nil, nil
@@ -110,6 +395,131 @@ func (api *GraphQLAPIImpl) getBlockWithSenders(ctx context.Context, number rpc.B
 	return block, senders, err
 }
 
+// getBlockDetails returns the block fields with its transactions and, unless the block is
+// pending, their receipts.
+func (api *GraphQLAPIImpl) getBlockDetails(ctx context.Context, tx kv.Tx, chainConfig *chain.Config, block *types.Block, senders []common.Address, number rpc.BlockNumber) (map[string]interface{}, error) {
+	getBlockRes, err := api.delegateGetBlockByNumber(tx, block, number, false)
+	if err != nil {
+		return nil, err
+	}
+
+	var receipts types.Receipts
+	if number != rpc.PendingBlockNumber {
+		receipts, err = api.getReceipts(ctx, tx, chainConfig, block, senders)
+		if err != nil {
+			return nil, fmt.Errorf("getReceipts error: %w", err)
+		}
+	}
+
+	header := block.HeaderNoCopy()
+	signer := types.MakeSigner(chainConfig, header.Number.Uint64(), header.Time)
+	result := make([]map[string]interface{}, 0, len(block.Transactions()))
+	for idx, txn := range block.Transactions() {
+		var transaction map[string]interface{}
+		if idx < len(receipts) {
+			receipt := receipts[idx].Copy()
+			receipt.Bloom = types.CreateBloom(types.Receipts{receipt})
+			transaction = marshalReceipt(receipt, txn, chainConfig, header, txn.Hash(), true)
+			transaction["logs"] = receipt.Logs
+
+			var rawReceipt bytes.Buffer
+			types.Receipts{receipt}.EncodeIndex(0, &rawReceipt)
+			transaction["rawReceipt"] = hexutility.Bytes(rawReceipt.Bytes())
+		} else {
+			from, err := txn.Sender(*signer)
+			if err != nil {
+				return nil, err
+			}
+			transaction = map[string]interface{}{
+				"transactionHash":  txn.Hash(),
+				"transactionIndex": hexutil.Uint64(idx),
+				"from":             from,
+				"to":               txn.GetTo(),
+				"type":             hexutil.Uint(txn.Type()),
+				"logs":             types.Logs{},
+			}
+		}
+		if err := marshalGraphQLTransaction(transaction, txn, header.BaseFee); err != nil {
+			return nil, err
+		}
+		result = append(result, transaction)
+	}
+
+	response := map[string]interface{}{}
+	response["block"] = getBlockRes
+	response["receipts"] = result
+
+	return response, nil
+}
+
+// marshalGraphQLTransaction adds the transaction fields, which are not part of the receipt, to the fields.
+func marshalGraphQLTransaction(fields map[string]interface{}, txn types.Transaction, baseFee *big.Int) error {
+	fields["nonce"] = txn.GetNonce()
+	fields["value"] = txn.GetValue()
+	fields["data"] = txn.GetData()
+	fields["gas"] = hexutil.Uint64(txn.GetGas())
+	gasPrice := txn.GetPrice().ToBig()
+	dynamicFee := txn.Type() >= types.DynamicFeeTxType
+	if dynamicFee {
+		fields["maxFeePerGas"] = (*hexutil.Big)(txn.GetFeeCap().ToBig())
+		fields["maxPriorityFeePerGas"] = (*hexutil.Big)(txn.GetTip().ToBig())
+		gasPrice = txn.GetFeeCap().ToBig()
+	}
+	if baseFee != nil {
+		tip := txn.GetEffectiveGasTip(uint256.MustFromBig(baseFee)).ToBig()
+		fields["effectiveTip"] = (*hexutil.Big)(tip)
+		if dynamicFee {
+			gasPrice = new(big.Int).Add(baseFee, tip)
+		}
+	}
+	fields["gasPrice"] = (*hexutil.Big)(gasPrice)
+	v, r, s := txn.RawSignatureValues()
+	fields["v"] = (*hexutil.Big)(v.ToBig())
+	fields["r"] = (*hexutil.Big)(r.ToBig())
+	fields["s"] = (*hexutil.Big)(s.ToBig())
+	fields["accessList"] = txn.GetAccessList()
+
+	var raw bytes.Buffer
+	if err := txn.MarshalBinary(&raw); err != nil {
+		return err
+	}
+	fields["raw"] = hexutility.Bytes(raw.Bytes())
+	return nil
+}
+
+// getLogsBlockNumbers returns the numbers of the blocks in [begin, end] which may contain logs
+// matching the filter criteria.
+func (api *GraphQLAPIImpl) getLogsBlockNumbers(tx kv.Tx, begin, end uint64, crit filters.FilterCriteria) ([]uint64, error) {
+	var blockNums []uint64
+	if api.historyV3(tx) {
+		txNumbers, err := applyFiltersV3(tx.(kv.TemporalTx), begin, end, crit)
+		if err != nil {
+			return nil, err
+		}
+		it := MapTxNum2BlockNum(tx, txNumbers)
+		for it.HasNext() {
+			_, blockNum, _, _, blockNumChanged, err := it.Next()
+			if err != nil {
+				return nil, err
+			}
+			if blockNumChanged {
+				blockNums = append(blockNums, blockNum)
+			}
+		}
+		return blockNums, nil
+	}
+
+	blockNumbers := bitmapdb.NewBitmap()
+	defer bitmapdb.ReturnToPool(blockNumbers)
+	if err := applyFilters(blockNumbers, tx, begin, end, crit); err != nil {
+		return nil, err
+	}
+	for it := blockNumbers.Iterator(); it.HasNext(); {
+		blockNums = append(blockNums, uint64(it.Next()))
+	}
+	return blockNums, nil
+}
+
 func (api *GraphQLAPIImpl) delegateGetBlockByNumber(tx kv.Tx, b *types.Block, number rpc.BlockNumber, inclTx bool) (map[string]interface{}, error) {
 	td, err := rawdb.ReadTd(tx, b.Hash(), b.NumberU64())
 	if err != nil {
@@ -0,0 +1,54 @@
+package jsonrpc
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	libcommon "github.com/ledgerwatch/erigon-lib/common"
+	"github.com/ledgerwatch/log/v3"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ledgerwatch/erigon/cmd/rpcdaemon/rpcdaemontest"
+	"github.com/ledgerwatch/erigon/eth/filters"
+	"github.com/ledgerwatch/erigon/rpc"
+)
+
+func TestGraphQLGetLogsDetailsRangeLimit(t *testing.T) {
+	m, _, _ := rpcdaemontest.CreateTestSentry(t)
+	base := newBaseApiForTest(m)
+	api := NewGraphQLAPI(base, m.DB, NewEthAPI(base, m.DB, nil, nil, nil, 5000000, 100_000, false, 100_000, log.New()))
+	api.logsMaxBlockRange = 4
+
+	crit := filters.FilterCriteria{FromBlock: big.NewInt(1), ToBlock: big.NewInt(4)}
+	res, err := api.GetLogsDetails(context.Background(), crit)
+	require.NoError(t, err)
+	require.Len(t, res, 4) // every block has a transaction from the sender
+
+	crit.ToBlock = big.NewInt(5)
+	_, err = api.GetLogsDetails(context.Background(), crit)
+	require.EqualError(t, err, "block range 1-5 exceeds the limit of 4 blocks")
+}
+
+func TestGraphQLQueryTx(t *testing.T) {
+	m, _, _ := rpcdaemontest.CreateTestSentry(t)
+	base := newBaseApiForTest(m)
+	api := NewGraphQLAPI(base, m.DB, NewEthAPI(base, m.DB, nil, nil, nil, 5000000, 100_000, false, 100_000, log.New()))
+
+	ctx, release := api.WithQueryTx(context.Background())
+	tx, unlock, err := api.queryTx(ctx)
+	require.NoError(t, err)
+	unlock()
+	tx2, unlock, err := api.queryTx(ctx)
+	require.NoError(t, err)
+	unlock()
+	require.Same(t, tx, tx2)
+
+	value, err := api.GetStorageAt(ctx, libcommon.Address{1}, libcommon.Hash{}, rpc.BlockNumberOrHashWithNumber(1))
+	require.NoError(t, err)
+	require.Equal(t, libcommon.Hash{}, value)
+
+	release()
+	_, err = api.GetStorageAt(ctx, libcommon.Address{1}, libcommon.Hash{}, rpc.BlockNumberOrHashWithNumber(1))
+	require.ErrorIs(t, err, errGraphQLQueryFinished)
+}
@@ -2,12 +2,15 @@ package jsonrpc
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"math/big"
 
 	"github.com/ledgerwatch/erigon-lib/common"
+	"github.com/ledgerwatch/erigon-lib/common/hexutil"
 	"github.com/ledgerwatch/erigon-lib/common/hexutility"
+	"github.com/ledgerwatch/erigon-lib/gointerfaces"
 	txPoolProto "github.com/ledgerwatch/erigon-lib/gointerfaces/txpool"
 
 	"github.com/ledgerwatch/erigon/core/types"
@@ -15,8 +18,103 @@ import (
 	"github.com/ledgerwatch/erigon/params"
 )
 
+// maxKnownAccountsCost limits the number of storage roots and slots a conditional transaction may check
+const maxKnownAccountsCost = 1000
+
+// KnownAccount is either the expected storage root of an account, or the expected values of some of its storage slots.
+type KnownAccount struct {
+	StorageRoot  *common.Hash
+	StorageSlots map[common.Hash]common.Hash
+}
+
+func (a *KnownAccount) UnmarshalJSON(data []byte) error {
+	var root common.Hash
+	if err := json.Unmarshal(data, &root); err == nil {
+		a.StorageRoot = &root
+		return nil
+	}
+	return json.Unmarshal(data, &a.StorageSlots)
+}
+
+func (a KnownAccount) MarshalJSON() ([]byte, error) {
+	if a.StorageRoot != nil {
+		return json.Marshal(a.StorageRoot)
+	}
+	return json.Marshal(a.StorageSlots)
+}
+
+// TransactionConditions are the preconditions of eth_sendRawTransactionConditional, the transaction
+// is only included into a block while all of them hold.
+type TransactionConditions struct {
+	KnownAccounts  map[common.Address]KnownAccount `json:"knownAccounts"`
+	BlockNumberMin *hexutil.Uint64                 `json:"blockNumberMin"`
+	BlockNumberMax *hexutil.Uint64                 `json:"blockNumberMax"`
+	TimestampMin   *hexutil.Uint64                 `json:"timestampMin"`
+	TimestampMax   *hexutil.Uint64                 `json:"timestampMax"`
+}
+
+func (c *TransactionConditions) knownAccountsCost() int {
+	cost := 0
+	for _, account := range c.KnownAccounts {
+		if account.StorageRoot != nil {
+			cost++
+		} else {
+			cost += len(account.StorageSlots)
+		}
+	}
+	return cost
+}
+
+// toProto converts the conditions, all of them are checked by the txpool
+func (c *TransactionConditions) toProto() *txPoolProto.TxConditions {
+	res := &txPoolProto.TxConditions{}
+	for addr, account := range c.KnownAccounts {
+		knownAccount := &txPoolProto.KnownAccount{Address: gointerfaces.ConvertAddressToH160(addr)}
+		if account.StorageRoot != nil {
+			knownAccount.StorageRoot = gointerfaces.ConvertHashToH256(*account.StorageRoot)
+			res.KnownAccounts = append(res.KnownAccounts, knownAccount)
+			continue
+		}
+		for key, value := range account.StorageSlots {
+			knownAccount.Slots = append(knownAccount.Slots, &txPoolProto.StorageSlot{
+				Key:   gointerfaces.ConvertHashToH256(key),
+				Value: gointerfaces.ConvertHashToH256(value),
+			})
+		}
+		res.KnownAccounts = append(res.KnownAccounts, knownAccount)
+	}
+	if c.BlockNumberMin != nil {
+		res.BlockNumberMin = uint64(*c.BlockNumberMin)
+	}
+	if c.BlockNumberMax != nil {
+		res.BlockNumberMax = uint64(*c.BlockNumberMax)
+	}
+	if c.TimestampMin != nil {
+		res.TimestampMin = uint64(*c.TimestampMin)
+	}
+	if c.TimestampMax != nil {
+		res.TimestampMax = uint64(*c.TimestampMax)
+	}
+	return res
+}
+
 // SendRawTransaction implements eth_sendRawTransaction. Creates new message call transaction or a contract creation for previously-signed transactions.
 func (api *APIImpl) SendRawTransaction(ctx context.Context, encodedTx hexutility.Bytes) (common.Hash, error) {
+	return api.sendRawTransaction(ctx, encodedTx, nil)
+}
+
+// SendRawTransactionConditional implements eth_sendRawTransactionConditional. Same as eth_sendRawTransaction, but
+// the transaction is only included into a block while the given conditions hold.
+// The conditions are enforced by the txpool both on admission and on block building. Storage roots
+// are only supported by the txpool of Erigon2 nodes, it rejects them otherwise.
+func (api *APIImpl) SendRawTransactionConditional(ctx context.Context, encodedTx hexutility.Bytes, conditions TransactionConditions) (common.Hash, error) {
+	if cost := conditions.knownAccountsCost(); cost > maxKnownAccountsCost {
+		return common.Hash{}, fmt.Errorf("too many known accounts storage roots and slots: %d, limit %d", cost, maxKnownAccountsCost)
+	}
+	return api.sendRawTransaction(ctx, encodedTx, conditions.toProto())
+}
+
+func (api *APIImpl) sendRawTransaction(ctx context.Context, encodedTx hexutility.Bytes, conditions *txPoolProto.TxConditions) (common.Hash, error) {
 	txn, err := types.DecodeWrappedTransaction(encodedTx)
 	if err != nil {
 		return common.Hash{}, err
@@ -54,7 +152,11 @@ func (api *APIImpl) SendRawTransaction(ctx context.Context, encodedTx hexutility
 	}
 
 	hash := txn.Hash()
-	res, err := api.txPool.Add(ctx, &txPoolProto.AddRequest{RlpTxs: [][]byte{encodedTx}})
+	req := &txPoolProto.AddRequest{RlpTxs: [][]byte{encodedTx}}
+	if conditions != nil {
+		req.Conditions = []*txPoolProto.TxConditions{conditions}
+	}
+	res, err := api.txPool.Add(ctx, req)
 	if err != nil {
 		return common.Hash{}, err
 	}
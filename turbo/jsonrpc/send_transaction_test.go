@@ -3,6 +3,7 @@ package jsonrpc_test
 import (
 	"bytes"
 	"crypto/ecdsa"
+	"encoding/json"
 	"math/big"
 	"testing"
 	"time"
@@ -166,6 +167,32 @@ func TestSendRawTransactionUnprotected(t *testing.T) {
 	}
 }
 
+func TestTransactionConditionsUnmarshal(t *testing.T) {
+	require := require.New(t)
+	var conditions jsonrpc.TransactionConditions
+	err := json.Unmarshal([]byte(`{
+		"knownAccounts": {
+			"0x000000000000000000000000000000000000aaaa": "0x00000000000000000000000000000000000000000000000000000000000000ff",
+			"0x000000000000000000000000000000000000bbbb": {
+				"0x0000000000000000000000000000000000000000000000000000000000000001": "0x0000000000000000000000000000000000000000000000000000000000000002"
+			}
+		},
+		"blockNumberMin": "0x10",
+		"timestampMax": "0x20"
+	}`), &conditions)
+	require.NoError(err)
+
+	root := conditions.KnownAccounts[common.HexToAddress("0xaaaa")]
+	require.NotNil(root.StorageRoot)
+	require.Equal(common.HexToHash("0xff"), *root.StorageRoot)
+	slots := conditions.KnownAccounts[common.HexToAddress("0xbbbb")]
+	require.Nil(slots.StorageRoot)
+	require.Equal(map[common.Hash]common.Hash{common.HexToHash("0x1"): common.HexToHash("0x2")}, slots.StorageSlots)
+	require.Equal(uint64(0x10), uint64(*conditions.BlockNumberMin))
+	require.Nil(conditions.BlockNumberMax)
+	require.Equal(uint64(0x20), uint64(*conditions.TimestampMax))
+}
+
 func transaction(nonce uint64, gaslimit uint64, key *ecdsa.PrivateKey) types.Transaction {
 	return pricedTransaction(nonce, gaslimit, u256.Num1, key)
 }
@@ -311,12 +311,19 @@ func (api *PrivateDebugAPIImpl) TraceCall(ctx context.Context, args ethapi.CallA
 			return fmt.Errorf("header.BaseFee uint256 overflow")
 		}
 	}
+	var blockOverrides *ethapi.BlockOverrides
+	if config != nil {
+		blockOverrides = config.BlockOverrides
+	}
+	blockCtx, baseFee, err := transactions.NewOverriddenEVMBlockContext(engine, header, baseFee, blockOverrides, blockNrOrHash.RequireCanonical, dbtx, api._blockReader)
+	if err != nil {
+		return fmt.Errorf("override block: %v", err)
+	}
 	msg, err := args.ToMessage(api.GasCap, baseFee)
 	if err != nil {
 		return fmt.Errorf("convert args to msg: %v", err)
 	}
 
-	blockCtx := transactions.NewEVMBlockContext(engine, header, blockNrOrHash.RequireCanonical, dbtx, api._blockReader)
 	txCtx := core.NewEVMTxContext(msg)
 	// Trace the transaction and return
 	return transactions.TraceTx(ctx, msg, blockCtx, txCtx, ibs, config, chainConfig, stream, api.evmCallTimeout)
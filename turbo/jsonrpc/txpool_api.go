@@ -19,6 +19,8 @@ import (
 type TxPoolAPI interface {
 	Content(ctx context.Context) (map[string]map[string]map[string]*RPCTransaction, error)
 	ContentFrom(ctx context.Context, addr libcommon.Address) (map[string]map[string]*RPCTransaction, error)
+	Inspect(ctx context.Context) (map[string]map[string]map[string]string, error)
+	Explain(ctx context.Context, hash libcommon.Hash) (*TxPoolExplanation, error)
 }
 
 // TxPoolAPIImpl data structure to store things needed for net_ commands
@@ -201,40 +203,83 @@ func (api *TxPoolAPIImpl) Status(ctx context.Context) (map[string]hexutil.Uint,
 	}, nil
 }
 
-/*
-
 // Inspect retrieves the content of the transaction pool and flattens it into an
 // easily inspectable list.
-func (s *PublicTxPoolAPI) Inspect() map[string]map[string]map[string]string {
+func (api *TxPoolAPIImpl) Inspect(ctx context.Context) (map[string]map[string]map[string]string, error) {
+	reply, err := api.pool.All(ctx, &proto_txpool.AllRequest{})
+	if err != nil {
+		return nil, err
+	}
+
 	content := map[string]map[string]map[string]string{
 		"pending": make(map[string]map[string]string),
+		"baseFee": make(map[string]map[string]string),
 		"queued":  make(map[string]map[string]string),
 	}
-	pending, queue := s.b.TxPoolContent()
 
 	// Define a formatter to flatten a transaction into a string
-	var format = func(tx *types.Transaction) string {
-		if to := tx.To(); to != nil {
-			return fmt.Sprintf("%s: %v wei + %v gas × %v wei", tx.To().Hex(), tx.Value(), tx.Gas(), tx.GasPrice())
+	format := func(txn types.Transaction) string {
+		if to := txn.GetTo(); to != nil {
+			return fmt.Sprintf("%s: %v wei + %v gas × %v wei", to.Hex(), txn.GetValue(), txn.GetGas(), txn.GetFeeCap())
 		}
-		return fmt.Sprintf("contract creation: %v wei + %v gas × %v wei", tx.Value(), tx.Gas(), tx.GasPrice())
+		return fmt.Sprintf("contract creation: %v wei + %v gas × %v wei", txn.GetValue(), txn.GetGas(), txn.GetFeeCap())
 	}
-	// Flatten the pending transactions
-	for account, txs := range pending {
-		dump := make(map[string]string)
-		for _, tx := range txs {
-			dump[fmt.Sprintf("%d", tx.Nonce())] = format(tx)
+	for i := range reply.Txs {
+		txn, err := types.DecodeWrappedTransaction(reply.Txs[i].RlpTx)
+		if err != nil {
+			return nil, fmt.Errorf("decoding transaction from: %x: %w", reply.Txs[i].RlpTx, err)
 		}
-		content["pending"][account.Hex()] = dump
+		subPool, ok := content[txPoolSubPoolName(reply.Txs[i].TxnType)]
+		if !ok {
+			continue
+		}
+		account := libcommon.Address(gointerfaces.ConvertH160toAddress(reply.Txs[i].Sender)).Hex()
+		if _, ok := subPool[account]; !ok {
+			subPool[account] = make(map[string]string)
+		}
+		subPool[account][fmt.Sprintf("%d", txn.GetNonce())] = format(txn)
 	}
-	// Flatten the queued transactions
-	for account, txs := range queue {
-		dump := make(map[string]string)
-		for _, tx := range txs {
-			dump[fmt.Sprintf("%d", tx.Nonce())] = format(tx)
+	return content, nil
+}
+
+// TxPoolExplanation tells which sub-pool a transaction is in and what keeps it from being pending,
+// or why it was discarded from the pool.
+type TxPoolExplanation struct {
+	SubPool        string   `json:"subPool,omitempty"`
+	SubPoolMarker  string   `json:"subPoolMarker,omitempty"`
+	MissingMarkers []string `json:"missingMarkers,omitempty"`
+	DiscardReason  string   `json:"discardReason,omitempty"`
+}
+
+// Explain returns the sub-pool of the transaction with the given hash and the sub-pool marker bits it
+// lacks to be pending. For a transaction that is no longer in the pool it returns the discard reason,
+// as long as the pool remembers it, and nil otherwise.
+func (api *TxPoolAPIImpl) Explain(ctx context.Context, hash libcommon.Hash) (*TxPoolExplanation, error) {
+	reply, err := api.pool.Explain(ctx, &proto_txpool.ExplainRequest{Hash: gointerfaces.ConvertHashToH256(hash)})
+	if err != nil {
+		return nil, err
+	}
+	if !reply.Found {
+		if reply.DiscardReason == "" {
+			return nil, nil
 		}
-		content["queued"][account.Hex()] = dump
+		return &TxPoolExplanation{DiscardReason: reply.DiscardReason}, nil
+	}
+	return &TxPoolExplanation{
+		SubPool:        txPoolSubPoolName(reply.TxnType),
+		SubPoolMarker:  fmt.Sprintf("0b%05b", reply.SubPoolMarker),
+		MissingMarkers: reply.MissingMarkers,
+	}, nil
+}
+
+func txPoolSubPoolName(t proto_txpool.AllReply_TxnType) string {
+	switch t {
+	case proto_txpool.AllReply_PENDING:
+		return "pending"
+	case proto_txpool.AllReply_BASE_FEE:
+		return "baseFee"
+	case proto_txpool.AllReply_QUEUED:
+		return "queued"
 	}
-	return content
+	return t.String()
 }
-*/
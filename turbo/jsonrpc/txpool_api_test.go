@@ -63,4 +63,18 @@ func TestTxPoolContent(t *testing.T) {
 	require.Len(status, 3)
 	require.Equal(status["pending"], hexutil.Uint(1))
 	require.Equal(status["queued"], hexutil.Uint(0))
+
+	inspect, err := api.Inspect(ctx)
+	require.NoError(err)
+	require.Equal(fmt.Sprintf("%s: 1234 wei + 21000 gas × 10000000000 wei", libcommon.Address{1}.Hex()), inspect["pending"][sender]["0"])
+
+	explanation, err := api.Explain(ctx, txn.Hash())
+	require.NoError(err)
+	require.Equal("pending", explanation.SubPool)
+	require.Empty(explanation.MissingMarkers)
+	require.Empty(explanation.DiscardReason)
+
+	explanation, err = api.Explain(ctx, libcommon.Hash{1})
+	require.NoError(err)
+	require.Nil(explanation)
 }
@@ -59,6 +59,11 @@ type TxnReader interface {
 	TxnByIdxInBlock(ctx context.Context, tx kv.Getter, blockNum uint64, i int) (txn types.Transaction, err error)
 	RawTransactions(ctx context.Context, tx kv.Getter, fromBlock, toBlock uint64) (txs [][]byte, err error)
 }
+type ReceiptReader interface {
+	// Receipts - returns receipts of canonical block with derived fields, or nil if they are not stored
+	Receipts(ctx context.Context, tx kv.Tx, block *types.Block, senders []common.Address) (types.Receipts, error)
+}
+
 type HeaderAndCanonicalReader interface {
 	HeaderReader
 	CanonicalReader
@@ -78,6 +83,7 @@ type FullBlockReader interface {
 	BorSpanReader
 	TxnReader
 	CanonicalReader
+	ReceiptReader
 
 	FrozenBlocks() uint64
 	FrozenBorBlocks() uint64
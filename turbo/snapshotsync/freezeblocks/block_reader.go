@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/binary"
+	"errors"
 	"fmt"
 	"math"
 	"sort"
@@ -14,6 +15,7 @@ import (
 	"github.com/ledgerwatch/erigon-lib/gointerfaces"
 	"github.com/ledgerwatch/erigon-lib/gointerfaces/remote"
 	"github.com/ledgerwatch/erigon-lib/kv"
+	"github.com/ledgerwatch/erigon-lib/kv/rawdbv3"
 	"github.com/ledgerwatch/erigon-lib/recsplit"
 	"github.com/ledgerwatch/erigon/consensus/bor/heimdall/span"
 	"github.com/ledgerwatch/erigon/core/rawdb"
@@ -235,6 +237,10 @@ func (r *RemoteBlockReader) EventsByBlock(ctx context.Context, tx kv.Tx, hash co
 	return result, nil
 }
 
+func (r *RemoteBlockReader) Receipts(ctx context.Context, tx kv.Tx, block *types.Block, senders []common.Address) (types.Receipts, error) {
+	return rawdb.ReadReceipts(tx, block, senders), nil
+}
+
 func (r *RemoteBlockReader) Span(ctx context.Context, tx kv.Getter, spanId uint64) ([]byte, error) {
 	return nil, nil
 }
@@ -666,6 +672,137 @@ func (r *BlockReader) bodyForStorageFromSnapshot(blockHeight uint64, sn *BodySeg
 	return b, buf, nil
 }
 
+// Receipts - frozen blocks are served by receipts segment if it exists. Otherwise receipts are read from db,
+// where they are only available if they were not pruned.
+func (r *BlockReader) Receipts(ctx context.Context, tx kv.Tx, block *types.Block, senders []common.Address) (types.Receipts, error) {
+	if block == nil {
+		return nil, nil
+	}
+	blockHeight := block.NumberU64()
+	maxBlockNumInFiles := r.sn.BlocksAvailable()
+	if maxBlockNumInFiles == 0 || blockHeight > maxBlockNumInFiles {
+		return rawdb.ReadReceipts(tx, block, senders), nil
+	}
+
+	view := r.sn.View()
+	defer view.Close()
+	seg, ok := view.ReceiptsSegment(blockHeight)
+	if !ok {
+		return rawdb.ReadReceipts(tx, block, senders), nil
+	}
+	receipts, _, err := r.receiptsFromSnapshot(blockHeight, seg, nil)
+	if err != nil {
+		return nil, err
+	}
+	if receipts == nil {
+		return rawdb.ReadReceipts(tx, block, senders), nil
+	}
+	if len(senders) > 0 {
+		block.SendersToTxs(senders)
+	} else {
+		senders = block.Body().SendersFromTxs()
+	}
+	if err := receipts.DeriveFields(block.Hash(), blockHeight, block.Transactions(), senders); err != nil {
+		return nil, fmt.Errorf("derive receipts fields: block_num=%d, %w", blockHeight, err)
+	}
+	return receipts, nil
+}
+
+// ErrReceiptsNotFrozen - receipts of block are not in receipts segments: Erigon3 doesn't produce them,
+// and merged segments have gaps for blocks which were pruned before they were frozen
+var ErrReceiptsNotFrozen = errors.New("receipts are not in snapshots")
+
+// WalkLogsFromSnapshots - calls `add` for every log of blocks which have txs in [txFrom, txTo). Logs are read from receipts segments
+// (no re-execution), txNum of log is txNum of its transaction. All these blocks must be frozen, otherwise ErrReceiptsNotFrozen
+// is returned - logs of previous blocks are already walked.
+func (r *BlockReader) WalkLogsFromSnapshots(ctx context.Context, tx kv.Tx, txFrom, txTo uint64, add func(txNum uint64, addr []byte, topics [][]byte)) error {
+	ok, blockNum, err := rawdbv3.TxNums.FindBlockNum(tx, txFrom)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("WalkLogsFromSnapshots: block of txNum=%d not found", txFrom)
+	}
+	lastBlockNum, _, err := rawdbv3.TxNums.Last(tx)
+	if err != nil {
+		return err
+	}
+
+	view := r.sn.View()
+	defer view.Close()
+	var buf []byte
+	var topics [][]byte
+	for ; blockNum <= lastBlockNum; blockNum++ {
+		minTxNum, err := rawdbv3.TxNums.Min(tx, blockNum)
+		if err != nil {
+			return err
+		}
+		if minTxNum >= txTo {
+			break
+		}
+		seg, ok := view.ReceiptsSegment(blockNum)
+		if !ok || seg.idxReceiptNumber == nil {
+			return fmt.Errorf("WalkLogsFromSnapshots: %w: block %d", ErrReceiptsNotFrozen, blockNum)
+		}
+		var receipts types.Receipts
+		if receipts, buf, err = r.receiptsFromSnapshot(blockNum, seg, buf); err != nil {
+			return err
+		}
+		if receipts == nil {
+			return fmt.Errorf("WalkLogsFromSnapshots: %w: block %d", ErrReceiptsNotFrozen, blockNum)
+		}
+		for txIndex, receipt := range receipts {
+			txNum := minTxNum + 1 + uint64(txIndex) // first txNum of block is system tx
+			for _, l := range receipt.Logs {
+				topics = topics[:0]
+				for i := range l.Topics {
+					topics = append(topics, l.Topics[i][:])
+				}
+				add(txNum, l.Address[:], topics)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+	}
+	return nil
+}
+
+func (r *BlockReader) receiptsFromSnapshot(blockHeight uint64, sn *ReceiptSegment, buf []byte) (types.Receipts, []byte, error) {
+	defer func() {
+		if rec := recover(); rec != nil {
+			panic(fmt.Errorf("%+v, snapshot: %d-%d, trace: %s", rec, sn.from, sn.to, dbg.Stack()))
+		}
+	}() // avoid crash because Erigon's core does many things
+
+	if sn.idxReceiptNumber == nil {
+		return nil, buf, nil
+	}
+	receiptsOffset := sn.idxReceiptNumber.OrdinalLookup(blockHeight - sn.idxReceiptNumber.BaseDataID())
+
+	gg := sn.seg.MakeGetter()
+	gg.Reset(receiptsOffset)
+	if !gg.HasNext() {
+		return nil, buf, nil
+	}
+	buf, _ = gg.Next(buf[:0])
+	if len(buf) == 0 { // block of a gap in merged segments, see Merger.mergeReceipts
+		return nil, buf, nil
+	}
+	var forStorage types.ReceiptsForStorage
+	if err := rlp.DecodeBytes(buf, &forStorage); err != nil {
+		return nil, buf, err
+	}
+	receipts := make(types.Receipts, len(forStorage))
+	for i, receipt := range forStorage {
+		receipts[i] = (*types.Receipt)(receipt)
+	}
+	return receipts, buf, nil
+}
+
 func (r *BlockReader) txsFromSnapshot(baseTxnID uint64, txsAmount uint32, txsSeg *TxnSegment, buf []byte) (txs []types.Transaction, senders []common.Address, err error) {
 	defer func() {
 		if rec := recover(); rec != nil {
@@ -3,18 +3,25 @@ package freezeblocks
 import (
 	"context"
 	"encoding/binary"
+	"math/big"
 	"os"
 	"path/filepath"
 	"testing"
 
+	"github.com/holiman/uint256"
 	"github.com/ledgerwatch/log/v3"
 	"github.com/stretchr/testify/require"
 
+	"github.com/ledgerwatch/erigon-lib/common"
 	"github.com/ledgerwatch/erigon-lib/common/length"
 	"github.com/ledgerwatch/erigon-lib/compress"
 	"github.com/ledgerwatch/erigon-lib/downloader/snaptype"
+	"github.com/ledgerwatch/erigon-lib/kv/memdb"
 	"github.com/ledgerwatch/erigon-lib/recsplit"
+	"github.com/ledgerwatch/erigon/core/types"
 	"github.com/ledgerwatch/erigon/eth/ethconfig"
+	"github.com/ledgerwatch/erigon/params"
+	"github.com/ledgerwatch/erigon/rlp"
 	"github.com/ledgerwatch/erigon/turbo/testlog"
 )
 
@@ -224,3 +231,143 @@ func createTestBorEventSegmentFile(t *testing.T, from, to, eventId uint64, dir s
 	err = idx.Build(context.Background())
 	require.NoError(t, err)
 }
+
+func TestBlockReaderReceiptsFromSnapshot(t *testing.T) {
+	t.Parallel()
+
+	logger := testlog.Logger(t, log.LvlInfo)
+	dir := t.TempDir()
+	for _, tp := range snaptype.BlockSnapshotTypes {
+		createTestSegmentFile(t, 0, 500_000, tp, dir, 1, logger)
+	}
+
+	receipt := &types.Receipt{
+		Status:            types.ReceiptStatusSuccessful,
+		CumulativeGasUsed: 21_000,
+		Logs: []*types.Log{{
+			Address: common.HexToAddress("0x1"),
+			Topics:  []common.Hash{common.HexToHash("0x2")},
+			Data:    []byte{3},
+		}},
+	}
+	segPath := filepath.Join(dir, snaptype.SegmentFileName(1, 0, 500_000, snaptype.Receipts))
+	compressor, err := compress.NewCompressor(context.Background(), "test", segPath, dir, 100, 1, log.LvlDebug, logger)
+	require.NoError(t, err)
+	defer compressor.Close()
+	compressor.DisableFsync()
+	for _, receipts := range []types.ReceiptsForStorage{{}, {(*types.ReceiptForStorage)(receipt)}} {
+		word, err := rlp.EncodeToBytes(receipts)
+		require.NoError(t, err)
+		require.NoError(t, compressor.AddWord(word))
+	}
+	require.NoError(t, compressor.Compress())
+	require.NoError(t, ReceiptsIdx(context.Background(), segPath, 0, dir, nil, log.LvlDebug, logger))
+
+	snapshots := NewRoSnapshots(ethconfig.BlocksFreezing{Enabled: true}, dir, 1, logger)
+	defer snapshots.Close()
+	require.NoError(t, snapshots.ReopenFolder())
+
+	view := snapshots.View()
+	defer view.Close()
+	seg, ok := view.ReceiptsSegment(1)
+	require.True(t, ok)
+
+	blockReader := &BlockReader{sn: snapshots}
+	receipts, _, err := blockReader.receiptsFromSnapshot(0, seg, nil)
+	require.NoError(t, err)
+	require.Empty(t, receipts)
+
+	receipts, _, err = blockReader.receiptsFromSnapshot(1, seg, nil)
+	require.NoError(t, err)
+	require.Len(t, receipts, 1)
+	require.Equal(t, receipt.Status, receipts[0].Status)
+	require.Equal(t, receipt.CumulativeGasUsed, receipts[0].CumulativeGasUsed)
+	require.Equal(t, receipt.Logs[0].Address, receipts[0].Logs[0].Address)
+	require.Equal(t, receipt.Logs[0].Topics, receipts[0].Logs[0].Topics)
+	require.Equal(t, receipt.Logs[0].Data, receipts[0].Logs[0].Data)
+}
+
+// createTestReceiptsSegmentFile - receipts segment with a word per block of [from, to), blocks without given receipts have no transactions
+func createTestReceiptsSegmentFile(t *testing.T, from, to uint64, receipts map[uint64]types.ReceiptsForStorage, dir string, logger log.Logger) {
+	segPath := filepath.Join(dir, snaptype.SegmentFileName(1, from, to, snaptype.Receipts))
+	compressor, err := compress.NewCompressor(context.Background(), "test", segPath, dir, 100, 1, log.LvlDebug, logger)
+	require.NoError(t, err)
+	defer compressor.Close()
+	compressor.DisableFsync()
+	for blockNum := from; blockNum < to; blockNum++ {
+		word, err := rlp.EncodeToBytes(receipts[blockNum])
+		require.NoError(t, err)
+		require.NoError(t, compressor.AddWord(word))
+	}
+	require.NoError(t, compressor.Compress())
+	require.NoError(t, ReceiptsIdx(context.Background(), segPath, from, dir, nil, log.LvlDebug, logger))
+}
+
+func TestBlockReaderReceiptsAfterMerge(t *testing.T) {
+	t.Parallel()
+
+	logger := testlog.Logger(t, log.LvlInfo)
+	dir := t.TempDir()
+	for i := uint64(0); i < 10; i++ {
+		for _, tp := range snaptype.BlockSnapshotTypes {
+			createTestSegmentFile(t, i*10_000, (i+1)*10_000, tp, dir, 1, logger)
+		}
+	}
+	receipt := func(data byte) types.ReceiptsForStorage {
+		return types.ReceiptsForStorage{{
+			Status:            types.ReceiptStatusSuccessful,
+			CumulativeGasUsed: 21_000,
+			Logs:              []*types.Log{{Address: common.HexToAddress("0x1"), Topics: []common.Hash{common.HexToHash("0x2")}, Data: []byte{data}}},
+		}}
+	}
+	// receipts of [10_000, 20_000) and [30_000, 100_000) were pruned from db before they were frozen
+	createTestReceiptsSegmentFile(t, 0, 10_000, map[uint64]types.ReceiptsForStorage{5: receipt(1)}, dir, logger)
+	createTestReceiptsSegmentFile(t, 20_000, 30_000, map[uint64]types.ReceiptsForStorage{20_005: receipt(2)}, dir, logger)
+
+	snapshots := NewRoSnapshots(ethconfig.BlocksFreezing{Enabled: true}, dir, 1, logger)
+	defer snapshots.Close()
+	require.NoError(t, snapshots.ReopenFolder())
+	merger := NewMerger(dir, 1, log.LvlInfo, nil, params.MainnetChainConfig, logger)
+	merger.DisableFsync()
+	ranges := merger.FindMergeRanges(snapshots.Ranges(), snapshots.SegmentsMax())
+	require.Equal(t, []Range{{from: 0, to: 100_000}}, ranges)
+	require.NoError(t, merger.Merge(context.Background(), snapshots, ranges, dir, false, nil, nil))
+
+	// receipts are merged alongside block segments, no orphan receipts segments are left
+	for _, r := range []Range{{0, 10_000}, {20_000, 30_000}} {
+		_, err := os.Stat(filepath.Join(dir, snaptype.SegmentFileName(1, r.from, r.to, snaptype.Receipts)))
+		require.True(t, os.IsNotExist(err))
+	}
+	mergedPath := filepath.Join(dir, snaptype.SegmentFileName(1, 0, 100_000, snaptype.Receipts))
+	require.NoError(t, ReceiptsIdx(context.Background(), mergedPath, 0, dir, nil, log.LvlDebug, logger))
+	// merged test block segments can't be indexed, replace them by indexed ones
+	for _, tp := range snaptype.BlockSnapshotTypes {
+		createTestSegmentFile(t, 0, 100_000, tp, dir, 1, logger)
+	}
+	require.NoError(t, snapshots.ReopenFolder())
+	require.Equal(t, uint64(100_000-1), snapshots.BlocksAvailable())
+
+	_, tx := memdb.NewTestTx(t)
+	blockReader := NewBlockReader(snapshots, nil)
+	block := func(num uint64) *types.Block {
+		txn := types.NewTransaction(0, common.HexToAddress("0x3"), uint256.NewInt(1), 21_000, uint256.NewInt(1), nil)
+		return types.NewBlock(&types.Header{Number: new(big.Int).SetUint64(num), Difficulty: new(big.Int)}, []types.Transaction{txn}, nil, nil, nil)
+	}
+	for i, blockNum := range []uint64{5, 20_005} {
+		b := block(blockNum)
+		receipts, err := blockReader.Receipts(context.Background(), tx, b, nil)
+		require.NoError(t, err)
+		require.Len(t, receipts, 1)
+		require.Equal(t, uint64(21_000), receipts[0].GasUsed)
+		require.Equal(t, b.Transactions()[0].Hash(), receipts[0].TxHash)
+		require.Equal(t, b.Hash(), receipts[0].BlockHash)
+		require.Equal(t, blockNum, receipts[0].BlockNumber.Uint64())
+		require.Equal(t, []byte{byte(i + 1)}, receipts[0].Logs[0].Data)
+		require.Equal(t, b.Hash(), receipts[0].Logs[0].BlockHash)
+		require.Equal(t, receipts[0].TxHash, receipts[0].Logs[0].TxHash)
+	}
+	// gaps are read from db, where receipts were pruned
+	receipts, err := blockReader.Receipts(context.Background(), tx, block(10_005), nil)
+	require.NoError(t, err)
+	require.Nil(t, receipts)
+}
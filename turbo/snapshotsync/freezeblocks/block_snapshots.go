@@ -67,6 +67,13 @@ type BodySegment struct {
 	version uint8
 }
 
+type ReceiptSegment struct {
+	seg              *compress.Decompressor // value: rlp(types.ReceiptsForStorage)
+	idxReceiptNumber *recsplit.Index        // block_num_u64     -> receipts_segment_offset
+	Range
+	version uint8
+}
+
 type TxnSegment struct {
 	Seg                 *compress.Decompressor // value: first_byte_of_transaction_hash + sender_address + transaction_rlp
 	IdxTxnHash          *recsplit.Index        // transaction_hash  -> transactions_segment_offset
@@ -215,6 +222,76 @@ func (sn *BodySegment) reopenIdx(dir string) (err error) {
 	return nil
 }
 
+func (sn *ReceiptSegment) closeSeg() {
+	if sn.seg != nil {
+		sn.seg.Close()
+		sn.seg = nil
+	}
+}
+func (sn *ReceiptSegment) closeIdx() {
+	if sn.idxReceiptNumber != nil {
+		sn.idxReceiptNumber.Close()
+		sn.idxReceiptNumber = nil
+	}
+}
+func (sn *ReceiptSegment) close() {
+	sn.closeSeg()
+	sn.closeIdx()
+}
+
+func (sn *ReceiptSegment) openFiles() []string {
+	var files []string
+
+	if sn.seg.IsOpen() {
+		files = append(files, sn.seg.FilePath())
+	}
+
+	if sn.idxReceiptNumber != nil {
+		files = append(files, sn.idxReceiptNumber.FilePath())
+	}
+
+	return files
+}
+
+func (sn *ReceiptSegment) reopenSeg(dir string) (err error) {
+	sn.closeSeg()
+	fileName := snaptype.SegmentFileName(sn.version, sn.from, sn.to, snaptype.Receipts)
+	sn.seg, err = compress.NewDecompressor(filepath.Join(dir, fileName))
+	if err != nil {
+		return fmt.Errorf("%w, fileName: %s", err, fileName)
+	}
+	return nil
+}
+func (sn *ReceiptSegment) reopenIdxIfNeed(dir string, optimistic bool) (err error) {
+	if sn.idxReceiptNumber != nil {
+		return nil
+	}
+	err = sn.reopenIdx(dir)
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			if optimistic {
+				log.Warn("[snapshots] open index", "err", err)
+			} else {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (sn *ReceiptSegment) reopenIdx(dir string) (err error) {
+	sn.closeIdx()
+	if sn.seg == nil {
+		return nil
+	}
+	fileName := snaptype.IdxFileName(sn.version, sn.from, sn.to, snaptype.Receipts.String())
+	sn.idxReceiptNumber, err = recsplit.OpenIndex(filepath.Join(dir, fileName))
+	if err != nil {
+		return fmt.Errorf("%w, fileName: %s", err, fileName)
+	}
+	return nil
+}
+
 func (sn *TxnSegment) closeIdx() {
 	if sn.IdxTxnHash != nil {
 		sn.IdxTxnHash.Close()
@@ -369,13 +446,36 @@ func (s *txnSegments) ViewSegment(blockNum uint64, f func(*TxnSegment) error) (f
 	return false, nil
 }
 
+type receiptSegments struct {
+	lock     sync.RWMutex
+	segments []*ReceiptSegment
+}
+
+func (s *receiptSegments) View(f func([]*ReceiptSegment) error) error {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return f(s.segments)
+}
+func (s *receiptSegments) ViewSegment(blockNum uint64, f func(*ReceiptSegment) error) (found bool, err error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	for _, seg := range s.segments {
+		if !(blockNum >= seg.from && blockNum < seg.to) {
+			continue
+		}
+		return true, f(seg)
+	}
+	return false, nil
+}
+
 type RoSnapshots struct {
 	indicesReady  atomic.Bool
 	segmentsReady atomic.Bool
 
-	Headers *headerSegments
-	Bodies  *bodySegments
-	Txs     *txnSegments
+	Headers  *headerSegments
+	Bodies   *bodySegments
+	Txs      *txnSegments
+	Receipts *receiptSegments // optional: blocks range is available even if receipts segment is missing
 
 	dir         string
 	segmentsMax atomic.Uint64 // all types of .seg files are available - up to this number
@@ -394,7 +494,7 @@ type RoSnapshots struct {
 //   - gaps are not allowed
 //   - segment have [from:to) semantic
 func NewRoSnapshots(cfg ethconfig.BlocksFreezing, snapDir string, version uint8, logger log.Logger) *RoSnapshots {
-	return &RoSnapshots{dir: snapDir, cfg: cfg, version: version, Headers: &headerSegments{}, Bodies: &bodySegments{}, Txs: &txnSegments{}, logger: logger}
+	return &RoSnapshots{dir: snapDir, cfg: cfg, version: version, Headers: &headerSegments{}, Bodies: &bodySegments{}, Txs: &txnSegments{}, Receipts: &receiptSegments{}, logger: logger}
 }
 
 func (s *RoSnapshots) Version() uint8                { return s.version }
@@ -431,6 +531,8 @@ func (s *RoSnapshots) DisableReadAhead() {
 	defer s.Bodies.lock.RUnlock()
 	s.Txs.lock.RLock()
 	defer s.Txs.lock.RUnlock()
+	s.Receipts.lock.RLock()
+	defer s.Receipts.lock.RUnlock()
 	for _, sn := range s.Headers.segments {
 		sn.seg.DisableReadAhead()
 	}
@@ -440,6 +542,9 @@ func (s *RoSnapshots) DisableReadAhead() {
 	for _, sn := range s.Txs.segments {
 		sn.Seg.DisableReadAhead()
 	}
+	for _, sn := range s.Receipts.segments {
+		sn.seg.DisableReadAhead()
+	}
 }
 func (s *RoSnapshots) EnableReadAhead() *RoSnapshots {
 	s.Headers.lock.RLock()
@@ -448,6 +553,8 @@ func (s *RoSnapshots) EnableReadAhead() *RoSnapshots {
 	defer s.Bodies.lock.RUnlock()
 	s.Txs.lock.RLock()
 	defer s.Txs.lock.RUnlock()
+	s.Receipts.lock.RLock()
+	defer s.Receipts.lock.RUnlock()
 	for _, sn := range s.Headers.segments {
 		sn.seg.EnableReadAhead()
 	}
@@ -457,6 +564,9 @@ func (s *RoSnapshots) EnableReadAhead() *RoSnapshots {
 	for _, sn := range s.Txs.segments {
 		sn.Seg.EnableReadAhead()
 	}
+	for _, sn := range s.Receipts.segments {
+		sn.seg.EnableReadAhead()
+	}
 	return s
 }
 func (s *RoSnapshots) EnableMadvWillNeed() *RoSnapshots {
@@ -466,6 +576,8 @@ func (s *RoSnapshots) EnableMadvWillNeed() *RoSnapshots {
 	defer s.Bodies.lock.RUnlock()
 	s.Txs.lock.RLock()
 	defer s.Txs.lock.RUnlock()
+	s.Receipts.lock.RLock()
+	defer s.Receipts.lock.RUnlock()
 	for _, sn := range s.Headers.segments {
 		sn.seg.EnableWillNeed()
 	}
@@ -475,6 +587,9 @@ func (s *RoSnapshots) EnableMadvWillNeed() *RoSnapshots {
 	for _, sn := range s.Txs.segments {
 		sn.Seg.EnableWillNeed()
 	}
+	for _, sn := range s.Receipts.segments {
+		sn.seg.EnableWillNeed()
+	}
 	return s
 }
 func (s *RoSnapshots) EnableMadvNormal() *RoSnapshots {
@@ -484,6 +599,8 @@ func (s *RoSnapshots) EnableMadvNormal() *RoSnapshots {
 	defer s.Bodies.lock.RUnlock()
 	s.Txs.lock.RLock()
 	defer s.Txs.lock.RUnlock()
+	s.Receipts.lock.RLock()
+	defer s.Receipts.lock.RUnlock()
 	for _, sn := range s.Headers.segments {
 		sn.seg.EnableMadvNormal()
 	}
@@ -493,6 +610,9 @@ func (s *RoSnapshots) EnableMadvNormal() *RoSnapshots {
 	for _, sn := range s.Txs.segments {
 		sn.Seg.EnableMadvNormal()
 	}
+	for _, sn := range s.Receipts.segments {
+		sn.seg.EnableMadvNormal()
+	}
 	return s
 }
 
@@ -539,6 +659,8 @@ func (s *RoSnapshots) Files() (list []string) {
 	defer s.Bodies.lock.RUnlock()
 	s.Txs.lock.RLock()
 	defer s.Txs.lock.RUnlock()
+	s.Receipts.lock.RLock()
+	defer s.Receipts.lock.RUnlock()
 	maxBlockNumInFiles := s.BlocksAvailable()
 	for _, seg := range s.Bodies.segments {
 		if seg.seg == nil {
@@ -570,6 +692,16 @@ func (s *RoSnapshots) Files() (list []string) {
 		_, fName := filepath.Split(seg.Seg.FilePath())
 		list = append(list, fName)
 	}
+	for _, seg := range s.Receipts.segments {
+		if seg.seg == nil {
+			continue
+		}
+		if seg.from > maxBlockNumInFiles {
+			continue
+		}
+		_, fName := filepath.Split(seg.seg.FilePath())
+		list = append(list, fName)
+	}
 	slices.Sort(list)
 	return list
 }
@@ -581,6 +713,8 @@ func (s *RoSnapshots) OpenFiles() (list []string) {
 	defer s.Bodies.lock.RUnlock()
 	s.Txs.lock.RLock()
 	defer s.Txs.lock.RUnlock()
+	s.Receipts.lock.RLock()
+	defer s.Receipts.lock.RUnlock()
 
 	for _, header := range s.Headers.segments {
 		list = append(list, header.openFiles()...)
@@ -594,6 +728,10 @@ func (s *RoSnapshots) OpenFiles() (list []string) {
 		list = append(list, txs.openFiles()...)
 	}
 
+	for _, receipts := range s.Receipts.segments {
+		list = append(list, receipts.openFiles()...)
+	}
+
 	return list
 }
 
@@ -613,6 +751,8 @@ func (s *RoSnapshots) rebuildSegments(fileNames []string, open bool, optimistic
 	defer s.Bodies.lock.Unlock()
 	s.Txs.lock.Lock()
 	defer s.Txs.lock.Unlock()
+	s.Receipts.lock.Lock()
+	defer s.Receipts.lock.Unlock()
 
 	s.closeWhatNotInList(fileNames)
 	var segmentsMax uint64
@@ -754,6 +894,43 @@ Loop:
 				s.Txs.segments = append(s.Txs.segments, sn)
 			}
 
+			if open {
+				if err := sn.reopenIdxIfNeed(s.dir, optimistic); err != nil {
+					return err
+				}
+			}
+		case snaptype.Receipts:
+			// receipts are optional: they don't affect segmentsMax
+			processed = false
+			var sn *ReceiptSegment
+			var exists bool
+			for _, sn2 := range s.Receipts.segments {
+				if sn2.seg == nil { // it's ok if some segment was not able to open
+					continue
+				}
+				if fName == sn2.seg.FileName() {
+					sn = sn2
+					exists = true
+					break
+				}
+			}
+			if !exists {
+				sn = &ReceiptSegment{version: f.Version, Range: Range{f.From, f.To}}
+			}
+
+			if open {
+				if err := sn.reopenSeg(s.dir); err != nil {
+					if optimistic || errors.Is(err, os.ErrNotExist) {
+						s.logger.Warn("[snapshots] open segment", "err", err)
+						continue Loop
+					}
+					return err
+				}
+			}
+			if !exists {
+				s.Receipts.segments = append(s.Receipts.segments, sn)
+			}
+
 			if open {
 				if err := sn.reopenIdxIfNeed(s.dir, optimistic); err != nil {
 					return err
@@ -834,6 +1011,8 @@ func (s *RoSnapshots) Close() {
 	defer s.Bodies.lock.Unlock()
 	s.Txs.lock.Lock()
 	defer s.Txs.lock.Unlock()
+	s.Receipts.lock.Lock()
+	defer s.Receipts.lock.Unlock()
 	s.closeWhatNotInList(nil)
 }
 
@@ -880,6 +1059,20 @@ Loop3:
 		sn.close()
 		s.Txs.segments[i] = nil
 	}
+Loop4:
+	for i, sn := range s.Receipts.segments {
+		if sn.seg == nil {
+			continue Loop4
+		}
+		_, name := filepath.Split(sn.seg.FilePath())
+		for _, fName := range l {
+			if fName == name {
+				continue Loop4
+			}
+		}
+		sn.close()
+		s.Receipts.segments[i] = nil
+	}
 	var i int
 	for i = 0; i < len(s.Headers.segments) && s.Headers.segments[i] != nil && s.Headers.segments[i].seg != nil; i++ {
 	}
@@ -913,6 +1106,17 @@ Loop3:
 			tailC[i] = nil
 		}
 	}
+
+	for i = 0; i < len(s.Receipts.segments) && s.Receipts.segments[i] != nil && s.Receipts.segments[i].seg != nil; i++ {
+	}
+	tailD := s.Receipts.segments[i:]
+	s.Receipts.segments = s.Receipts.segments[:i]
+	for i = 0; i < len(tailD); i++ {
+		if tailD[i] != nil {
+			tailD[i].close()
+			tailD[i] = nil
+		}
+	}
 }
 
 func (s *RoSnapshots) PrintDebug() {
@@ -922,6 +1126,8 @@ func (s *RoSnapshots) PrintDebug() {
 	defer s.Bodies.lock.RUnlock()
 	s.Txs.lock.RLock()
 	defer s.Txs.lock.RUnlock()
+	s.Receipts.lock.RLock()
+	defer s.Receipts.lock.RUnlock()
 	fmt.Println("    == Snapshots, Header")
 	for _, sn := range s.Headers.segments {
 		fmt.Printf("%d,  %t\n", sn.from, sn.idxHeaderHash == nil)
@@ -934,6 +1140,10 @@ func (s *RoSnapshots) PrintDebug() {
 	for _, sn := range s.Txs.segments {
 		fmt.Printf("%d,  %t, %t\n", sn.from, sn.IdxTxnHash == nil, sn.IdxTxnHash2BlockNum == nil)
 	}
+	fmt.Println("    == Snapshots, Receipts")
+	for _, sn := range s.Receipts.segments {
+		fmt.Printf("%d,  %t\n", sn.from, sn.idxReceiptNumber == nil)
+	}
 }
 
 func (s *RoSnapshots) AddSnapshotsToSilkworm(silkwormInstance *silkworm.Silkworm) error {
@@ -1001,6 +1211,10 @@ func buildIdx(ctx context.Context, sn snaptype.FileInfo, chainConfig *chain.Conf
 		if err := BodiesIdx(ctx, sn.Path, sn.From, tmpDir, p, lvl, logger); err != nil {
 			return err
 		}
+	case snaptype.Receipts:
+		if err := ReceiptsIdx(ctx, sn.Path, sn.From, tmpDir, p, lvl, logger); err != nil {
+			return err
+		}
 	case snaptype.Transactions:
 		dir, _ := filepath.Split(sn.Path)
 		if err := TransactionsIdx(ctx, chainConfig, sn.Version, sn.From, sn.To, dir, tmpDir, p, lvl, logger); err != nil {
@@ -1055,7 +1269,8 @@ func BuildMissedIndices(logPrefix string, ctx context.Context, dirs datadir.Dirs
 		}
 	}()
 
-	for _, t := range snaptype.BlockSnapshotTypes {
+	snapshotTypes := append(slices.Clone(snaptype.BlockSnapshotTypes), snaptype.OptionalBlockSnapshotTypes...)
+	for _, t := range snapshotTypes {
 		for index := range segments {
 			segment := segments[index]
 			if segment.T != t {
@@ -1297,6 +1512,20 @@ func segments(dir string, version uint8, minBlock uint64, segmentsTypeCheck func
 		l, _ = noGaps(noOverlaps(segmentsTypeCheck(dir, l)), minBlock)
 		res = append(res, l...)
 	}
+	{
+		// receipts are optional and may have gaps: only require block segments of same range
+		var l []snaptype.FileInfo
+		for _, f := range list {
+			if f.T != snaptype.Receipts {
+				continue
+			}
+			if f.To <= minBlock {
+				continue
+			}
+			l = append(l, f)
+		}
+		res = append(res, noOverlaps(segmentsTypeCheck(dir, l))...)
+	}
 
 	return res, missingSnapshots, nil
 }
@@ -1742,15 +1971,66 @@ func dumpBlocksRange(ctx context.Context, version uint8, blockFrom, blockTo uint
 		}
 	}
 
+	available, err := receiptsAvailable(ctx, chainDB, blockFrom, blockTo)
+	if err != nil {
+		return err
+	}
+	if available {
+		segName := snaptype.SegmentFileName(version, blockFrom, blockTo, snaptype.Receipts)
+		f, _ := snaptype.ParseFileName(snapDir, segName)
+
+		sn, err := compress.NewCompressor(ctx, "Snapshot Receipts", f.Path, tmpDir, compress.MinPatternScore, workers, log.LvlTrace, logger)
+		if err != nil {
+			return err
+		}
+		defer sn.Close()
+		if err := DumpReceipts(ctx, chainDB, blockFrom, blockTo, workers, lvl, logger, func(v []byte) error {
+			return sn.AddWord(v)
+		}); err != nil {
+			return fmt.Errorf("DumpReceipts: %w", err)
+		}
+		if err := sn.Compress(); err != nil {
+			return fmt.Errorf("compress: %w", err)
+		}
+
+		p := &background.Progress{}
+		if err := buildIdx(ctx, f, &chainConfig, tmpDir, p, lvl, logger); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
+// receiptsAvailable - receipts of [from, to) blocks are in db: they were produced by execution and not pruned.
+// Erigon3 doesn't write receipts to db: its logs index is built by re-execution on state history, see `snapshots logindex`
+func receiptsAvailable(ctx context.Context, db kv.RoDB, blockFrom, blockTo uint64) (available bool, err error) {
+	if err := db.View(ctx, func(tx kv.Tx) error {
+		executed, err := stages.GetStageProgress(tx, stages.Execution)
+		if err != nil {
+			return err
+		}
+		if executed+1 < blockTo {
+			return nil
+		}
+		availableFrom, err := rawdb.ReceiptsAvailableFrom(tx)
+		if err != nil {
+			return err
+		}
+		available = availableFrom <= blockFrom
+		return nil
+	}); err != nil {
+		return false, err
+	}
+	return available, nil
+}
+
 func hasIdxFile(sn snaptype.FileInfo, logger log.Logger) bool {
 	dir, _ := filepath.Split(sn.Path)
 	fName := snaptype.IdxFileName(sn.Version, sn.From, sn.To, sn.T.String())
 	var result = true
 	switch sn.T {
-	case snaptype.Headers, snaptype.Bodies, snaptype.BorEvents, snaptype.BorSpans, snaptype.BeaconBlocks:
+	case snaptype.Headers, snaptype.Bodies, snaptype.BorEvents, snaptype.BorSpans, snaptype.BeaconBlocks, snaptype.Receipts:
 		idx, err := recsplit.OpenIndex(filepath.Join(dir, fName))
 		if err != nil {
 			return false
@@ -2095,6 +2375,70 @@ func DumpBodies(ctx context.Context, db kv.RoDB, blockFrom, blockTo uint64, firs
 	return nil
 }
 
+// DumpReceipts - [from, to)
+// One word per block: rlp(types.ReceiptsForStorage). Fields which can be derived from block body are not stored.
+func DumpReceipts(ctx context.Context, db kv.RoDB, blockFrom, blockTo uint64, workers int, lvl log.Lvl, logger log.Logger, collect func([]byte) error) error {
+	logEvery := time.NewTicker(20 * time.Second)
+	defer logEvery.Stop()
+
+	key := make([]byte, 8+32)
+	from := hexutility.EncodeTs(blockFrom)
+	if err := kv.BigChunks(db, kv.HeaderCanonical, from, func(tx kv.Tx, k, v []byte) (bool, error) {
+		blockNum := binary.BigEndian.Uint64(k)
+		if blockNum >= blockTo {
+			return false, nil
+		}
+		copy(key, k)
+		copy(key[8:], v)
+
+		body, err := rawdb.ReadBodyForStorageByKey(tx, key)
+		if err != nil {
+			return false, err
+		}
+		if body == nil {
+			return false, fmt.Errorf("body missed in db: block_num=%d,  hash=%x", blockNum, v)
+		}
+		var txAmount int
+		if body.TxAmount >= 2 {
+			txAmount = int(body.TxAmount - 2) // system txs in the beginning and end of block
+		}
+
+		receipts := rawdb.ReadRawReceipts(tx, blockNum)
+		if len(receipts) != txAmount {
+			return false, fmt.Errorf("receipts missed in db: block_num=%d, hash=%x, expected: %d, got: %d", blockNum, v, txAmount, len(receipts))
+		}
+		forStorage := make(types.ReceiptsForStorage, len(receipts))
+		for i, r := range receipts {
+			forStorage[i] = (*types.ReceiptForStorage)(r)
+		}
+		dataRLP, err := rlp.EncodeToBytes(forStorage)
+		if err != nil {
+			return false, err
+		}
+		if err := collect(dataRLP); err != nil {
+			return false, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return false, ctx.Err()
+		case <-logEvery.C:
+			var m runtime.MemStats
+			if lvl >= log.LvlInfo {
+				dbg.ReadMemStats(&m)
+			}
+			logger.Log(lvl, "[snapshots] Dumping receipts", "block num", blockNum,
+				"alloc", common2.ByteCount(m.Alloc), "sys", common2.ByteCount(m.Sys),
+			)
+		default:
+		}
+		return true, nil
+	}); err != nil {
+		return err
+	}
+	return nil
+}
+
 var EmptyTxHash = common2.Hash{}
 
 func txsAmountBasedOnBodiesSnapshots(snapDir string, version uint8, blockFrom, blockTo uint64) (firstTxID uint64, expectedCount int, err error) {
@@ -2371,6 +2715,43 @@ func BodiesIdx(ctx context.Context, segmentFilePath string, firstBlockNumInSegme
 	return nil
 }
 
+func ReceiptsIdx(ctx context.Context, segmentFilePath string, firstBlockNumInSegment uint64, tmpDir string, p *background.Progress, lvl log.Lvl, logger log.Logger) (err error) {
+	defer func() {
+		if rec := recover(); rec != nil {
+			_, fName := filepath.Split(segmentFilePath)
+			err = fmt.Errorf("ReceiptsIdx: at=%s, %v, %s", fName, rec, dbg.Stack())
+		}
+	}()
+
+	num := make([]byte, 8)
+
+	d, err := compress.NewDecompressor(segmentFilePath)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+
+	if p != nil {
+		_, fname := filepath.Split(segmentFilePath)
+		p.Name.Store(&fname)
+		p.Total.Store(uint64(d.Count()))
+	}
+
+	if err := Idx(ctx, d, firstBlockNumInSegment, tmpDir, log.LvlDebug, func(idx *recsplit.RecSplit, i, offset uint64, word []byte) error {
+		if p != nil {
+			p.Processed.Add(1)
+		}
+		n := binary.PutUvarint(num, i)
+		if err := idx.AddKey(num[:n], offset); err != nil {
+			return err
+		}
+		return nil
+	}, logger); err != nil {
+		return fmt.Errorf("ReceiptNumberIdx: %w", err)
+	}
+	return nil
+}
+
 // Idx - iterate over segment and building .idx file
 func Idx(ctx context.Context, d *compress.Decompressor, firstDataID uint64, tmpDir string, lvl log.Lvl, walker func(idx *recsplit.RecSplit, i, offset uint64, word []byte) error, logger log.Logger) error {
 	segmentFileName := d.FilePath()
@@ -2517,6 +2898,7 @@ func (s *RoSnapshots) View() *View {
 	v.s.Headers.lock.RLock()
 	v.s.Bodies.lock.RLock()
 	v.s.Txs.lock.RLock()
+	v.s.Receipts.lock.RLock()
 	return v
 }
 
@@ -2528,10 +2910,12 @@ func (v *View) Close() {
 	v.s.Headers.lock.RUnlock()
 	v.s.Bodies.lock.RUnlock()
 	v.s.Txs.lock.RUnlock()
+	v.s.Receipts.lock.RUnlock()
 }
-func (v *View) Headers() []*HeaderSegment { return v.s.Headers.segments }
-func (v *View) Bodies() []*BodySegment    { return v.s.Bodies.segments }
-func (v *View) Txs() []*TxnSegment        { return v.s.Txs.segments }
+func (v *View) Headers() []*HeaderSegment   { return v.s.Headers.segments }
+func (v *View) Bodies() []*BodySegment      { return v.s.Bodies.segments }
+func (v *View) Txs() []*TxnSegment          { return v.s.Txs.segments }
+func (v *View) Receipts() []*ReceiptSegment { return v.s.Receipts.segments }
 func (v *View) HeadersSegment(blockNum uint64) (*HeaderSegment, bool) {
 	for _, seg := range v.Headers() {
 		if !(blockNum >= seg.from && blockNum < seg.to) {
@@ -2560,6 +2944,16 @@ func (v *View) TxsSegment(blockNum uint64) (*TxnSegment, bool) {
 	return nil, false
 }
 
+func (v *View) ReceiptsSegment(blockNum uint64) (*ReceiptSegment, bool) {
+	for _, seg := range v.Receipts() {
+		if !(blockNum >= seg.from && blockNum < seg.to) {
+			continue
+		}
+		return seg, true
+	}
+	return nil, false
+}
+
 func (m *Merger) filesByRange(snapshots *RoSnapshots, from, to uint64) (map[snaptype.Type][]string, error) {
 	toMerge := map[snaptype.Type][]string{}
 	view := snapshots.View()
@@ -2581,6 +2975,17 @@ func (m *Merger) filesByRange(snapshots *RoSnapshots, from, to uint64) (map[snap
 		toMerge[snaptype.Transactions] = append(toMerge[snaptype.Transactions], tSegments[i].Seg.FilePath())
 	}
 
+	// receipts may have gaps, they are merged anyway: see mergeReceipts
+	for _, sn := range view.Receipts() {
+		if sn.from < from {
+			continue
+		}
+		if sn.to > to {
+			break
+		}
+		toMerge[snaptype.Receipts] = append(toMerge[snaptype.Receipts], sn.seg.FilePath())
+	}
+
 	return toMerge, nil
 }
 
@@ -2591,20 +2996,29 @@ func (m *Merger) Merge(ctx context.Context, snapshots *RoSnapshots, mergeRanges
 	}
 	logEvery := time.NewTicker(30 * time.Second)
 	defer logEvery.Stop()
+	snapshotTypes := append(slices.Clone(snaptype.BlockSnapshotTypes), snaptype.OptionalBlockSnapshotTypes...)
 	for _, r := range mergeRanges {
 		toMerge, err := m.filesByRange(snapshots, r.from, r.to)
 		if err != nil {
 			return err
 		}
 
-		for _, t := range snaptype.BlockSnapshotTypes {
+		for _, t := range snapshotTypes {
+			if len(toMerge[t]) == 0 {
+				continue
+			}
 			segName := snaptype.SegmentFileName(snapshots.version, r.from, r.to, t)
 			f, ok := snaptype.ParseFileName(snapDir, segName)
 			if !ok {
 				continue
 			}
 
-			if err := m.merge(ctx, toMerge[t], f.Path, logEvery); err != nil {
+			if t == snaptype.Receipts {
+				err = m.mergeReceipts(ctx, toMerge[t], r, f.Path, logEvery)
+			} else {
+				err = m.merge(ctx, toMerge[t], f.Path, logEvery)
+			}
+			if err != nil {
 				return fmt.Errorf("mergeByAppendSegments: %w", err)
 			}
 			if doIndex {
@@ -2626,7 +3040,7 @@ func (m *Merger) Merge(ctx context.Context, snapshots *RoSnapshots, mergeRanges
 			}
 		}
 
-		for _, t := range snaptype.BlockSnapshotTypes {
+		for _, t := range snapshotTypes {
 			if len(toMerge[t]) == 0 {
 				continue
 			}
@@ -2643,8 +3057,34 @@ func (m *Merger) Merge(ctx context.Context, snapshots *RoSnapshots, mergeRanges
 }
 
 func (m *Merger) merge(ctx context.Context, toMerge []string, targetFile string, logEvery *time.Ticker) error {
+	return m.mergeWithGaps(ctx, toMerge, nil, targetFile, logEvery)
+}
+
+// mergeReceipts - receipts segments may have gaps: receipts of some blocks were pruned from db before they were frozen.
+// An empty word is written for every block of a gap, so that the merged segment has a word per block of the range,
+// and receipts of such blocks are read from db - same as when there is no receipts segment at all.
+func (m *Merger) mergeReceipts(ctx context.Context, toMerge []string, r Range, targetFile string, logEvery *time.Ticker) error {
+	gaps := make([]uint64, len(toMerge)+1) // gaps[i] - amount of blocks missing before toMerge[i], the last one - after all of them
+	from := r.from
+	for i, cFile := range toMerge {
+		dir, fName := filepath.Split(cFile)
+		f, ok := snaptype.ParseFileName(dir, fName)
+		if !ok {
+			return fmt.Errorf("can't parse file name: %s", fName)
+		}
+		gaps[i] = f.From - from
+		from = f.To
+	}
+	gaps[len(toMerge)] = r.to - from
+	return m.mergeWithGaps(ctx, toMerge, gaps, targetFile, logEvery)
+}
+
+func (m *Merger) mergeWithGaps(ctx context.Context, toMerge []string, gaps []uint64, targetFile string, logEvery *time.Ticker) error {
 	var word = make([]byte, 0, 4096)
 	var expectedTotal int
+	for _, gap := range gaps {
+		expectedTotal += int(gap)
+	}
 	cList := make([]*compress.Decompressor, len(toMerge))
 	for i, cFile := range toMerge {
 		d, err := compress.NewDecompressor(cFile)
@@ -2668,7 +3108,21 @@ func (m *Merger) merge(ctx context.Context, toMerge []string, targetFile string,
 	_, fName := filepath.Split(targetFile)
 	m.logger.Debug("[snapshots] merge", "file", fName)
 
-	for _, d := range cList {
+	addGap := func(i int) error {
+		if gaps == nil {
+			return nil
+		}
+		for j := uint64(0); j < gaps[i]; j++ {
+			if err := f.AddWord(nil); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	for i, d := range cList {
+		if err := addGap(i); err != nil {
+			return err
+		}
 		if err := d.WithReadAhead(func() error {
 			g := d.MakeGetter()
 			for g.HasNext() {
@@ -2682,6 +3136,9 @@ func (m *Merger) merge(ctx context.Context, toMerge []string, targetFile string,
 			return err
 		}
 	}
+	if err := addGap(len(cList)); err != nil {
+		return err
+	}
 	if f.Count() != expectedTotal {
 		return fmt.Errorf("unexpected amount after segments merge. got: %d, expected: %d", f.Count(), expectedTotal)
 	}
@@ -31,6 +31,7 @@ func DoCall(
 	blockNrOrHash rpc.BlockNumberOrHash,
 	header *types.Header,
 	overrides *ethapi2.StateOverrides,
+	blockOverrides *ethapi2.BlockOverrides,
 	gasCap uint64,
 	chainConfig *chain.Config,
 	stateReader state.StateReader,
@@ -76,11 +77,14 @@ func DoCall(
 			return nil, fmt.Errorf("header.BaseFee uint256 overflow")
 		}
 	}
+	blockCtx, baseFee, err := NewOverriddenEVMBlockContext(engine, header, baseFee, blockOverrides, blockNrOrHash.RequireCanonical, tx, headerReader)
+	if err != nil {
+		return nil, err
+	}
 	msg, err := args.ToMessage(gasCap, baseFee)
 	if err != nil {
 		return nil, err
 	}
-	blockCtx := NewEVMBlockContext(engine, header, blockNrOrHash.RequireCanonical, tx, headerReader)
 	txCtx := core.NewEVMTxContext(msg)
 
 	evm := vm.NewEVM(blockCtx, txCtx, state, chainConfig, vm.Config{NoBaseFee: true})
@@ -109,6 +113,23 @@ func NewEVMBlockContext(engine consensus.EngineReader, header *types.Header, req
 	return core.NewEVMBlockContext(header, MakeHeaderGetter(requireCanonical, tx, headerReader), engine, nil /* author */)
 }
 
+// NewOverriddenEVMBlockContext builds the block context of a call and applies the block overrides to it,
+// it returns the base fee for the call message, which may be overridden as well
+func NewOverriddenEVMBlockContext(engine consensus.EngineReader, header *types.Header, baseFee *uint256.Int, blockOverrides *ethapi2.BlockOverrides,
+	requireCanonical bool, tx kv.Tx, headerReader services.HeaderReader) (evmtypes.BlockContext, *uint256.Int, error) {
+	blockCtx := NewEVMBlockContext(engine, header, requireCanonical, tx, headerReader)
+	if blockOverrides == nil {
+		return blockCtx, baseFee, nil
+	}
+	if err := blockOverrides.Override(&blockCtx); err != nil {
+		return blockCtx, nil, err
+	}
+	if blockOverrides.BaseFee != nil {
+		baseFee = blockCtx.BaseFee
+	}
+	return blockCtx, baseFee, nil
+}
+
 func MakeHeaderGetter(requireCanonical bool, tx kv.Tx, headerReader services.HeaderReader) func(uint64) libcommon.Hash {
 	return func(n uint64) libcommon.Hash {
 		h, err := headerReader.HeaderByNumber(context.Background(), tx, n)
@@ -130,6 +151,7 @@ type ReusableCaller struct {
 	gasCap          uint64
 	baseFee         *uint256.Int
 	stateReader     state.StateReader
+	overrides       *ethapi2.StateOverrides
 	callTimeout     time.Duration
 	message         *types.Message
 }
@@ -154,6 +176,11 @@ func (r *ReusableCaller) DoCallWithNewGas(
 	// reset the EVM so that we can continue to use it with the new context
 	txCtx := core.NewEVMTxContext(r.message)
 	r.intraBlockState = state.New(r.stateReader)
+	if r.overrides != nil {
+		if err := r.overrides.Override(r.intraBlockState); err != nil {
+			return nil, err
+		}
+	}
 	r.evm.Reset(txCtx, r.intraBlockState)
 
 	timedOut := false
@@ -181,6 +208,7 @@ func NewReusableCaller(
 	engine consensus.EngineReader,
 	stateReader state.StateReader,
 	overrides *ethapi2.StateOverrides,
+	blockOverrides *ethapi2.BlockOverrides,
 	header *types.Header,
 	initialArgs ethapi2.CallArgs,
 	gasCap uint64,
@@ -207,12 +235,15 @@ func NewReusableCaller(
 		}
 	}
 
+	blockCtx, baseFee, err := NewOverriddenEVMBlockContext(engine, header, baseFee, blockOverrides, blockNrOrHash.RequireCanonical, tx, headerReader)
+	if err != nil {
+		return nil, err
+	}
 	msg, err := initialArgs.ToMessage(gasCap, baseFee)
 	if err != nil {
 		return nil, err
 	}
 
-	blockCtx := NewEVMBlockContext(engine, header, blockNrOrHash.RequireCanonical, tx, headerReader)
 	txCtx := core.NewEVMTxContext(msg)
 
 	evm := vm.NewEVM(blockCtx, txCtx, ibs, chainConfig, vm.Config{NoBaseFee: true})
@@ -224,6 +255,7 @@ func NewReusableCaller(
 		gasCap:          gasCap,
 		callTimeout:     callTimeout,
 		stateReader:     stateReader,
+		overrides:       overrides,
 		message:         &msg,
 	}, nil
 }
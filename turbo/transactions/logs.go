@@ -0,0 +1,97 @@
+package transactions
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ledgerwatch/erigon-lib/chain"
+	"github.com/ledgerwatch/erigon-lib/kv"
+	"github.com/ledgerwatch/erigon-lib/kv/rawdbv3"
+
+	"github.com/ledgerwatch/erigon/consensus"
+	"github.com/ledgerwatch/erigon/core"
+	"github.com/ledgerwatch/erigon/core/state"
+	"github.com/ledgerwatch/erigon/core/types"
+	"github.com/ledgerwatch/erigon/core/vm"
+	"github.com/ledgerwatch/erigon/core/vm/evmtypes"
+	"github.com/ledgerwatch/erigon/turbo/services"
+)
+
+// WalkLogsFromHistory - calls `add` for every log of txs in [txFrom, txTo). Logs are produced by re-execution of every tx
+// on state history as of its txNum (no receipts needed). Logs of system txs (first and last txNum of block) are not walked.
+func WalkLogsFromHistory(ctx context.Context, tx kv.TemporalTx, chainConfig *chain.Config, engine consensus.EngineReader, br services.FullBlockReader,
+	txFrom, txTo uint64, add func(txNum uint64, addr []byte, topics [][]byte)) error {
+	ok, blockNum, err := rawdbv3.TxNums.FindBlockNum(tx, txFrom)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("WalkLogsFromHistory: block of txNum=%d not found", txFrom)
+	}
+	lastBlockNum, _, err := rawdbv3.TxNums.Last(tx)
+	if err != nil {
+		return err
+	}
+
+	stateReader := state.NewHistoryReaderV3()
+	stateReader.SetTx(tx)
+	ibs := state.New(stateReader)
+	evm := vm.NewEVM(evmtypes.BlockContext{}, evmtypes.TxContext{}, nil, chainConfig, vm.Config{})
+	var topics [][]byte
+	for ; blockNum <= lastBlockNum; blockNum++ {
+		minTxNum, err := rawdbv3.TxNums.Min(tx, blockNum)
+		if err != nil {
+			return err
+		}
+		if minTxNum >= txTo {
+			break
+		}
+		block, err := br.BlockByNumber(ctx, tx, blockNum)
+		if err != nil {
+			return err
+		}
+		if block == nil {
+			return fmt.Errorf("WalkLogsFromHistory: block %d not found", blockNum)
+		}
+		header := block.Header()
+		blockCtx := NewEVMBlockContext(engine, header, true /* requireCanonical */, tx, br)
+		rules := chainConfig.Rules(blockNum, header.Time)
+		signer := types.MakeSigner(chainConfig, blockNum, header.Time)
+		vmConfig := vm.Config{SkipAnalysis: core.SkipAnalysis(chainConfig, blockNum)}
+		for txIndex, txn := range block.Transactions() {
+			txNum := minTxNum + 1 + uint64(txIndex) // first txNum of block is system tx
+			if txNum < txFrom {
+				continue
+			}
+			if txNum >= txTo {
+				break
+			}
+			msg, err := txn.AsMessage(*signer, header.BaseFee, rules)
+			if err != nil {
+				return err
+			}
+			stateReader.SetTxNum(txNum)
+			ibs.Reset()
+			ibs.SetTxContext(txn.Hash(), block.Hash(), txIndex)
+			evm.ResetBetweenBlocks(blockCtx, core.NewEVMTxContext(msg), ibs, vmConfig, rules)
+			gp := new(core.GasPool).AddGas(txn.GetGas()).AddBlobGas(txn.GetBlobGas())
+			if _, err = core.ApplyMessage(evm, msg, gp, true /* refunds */, false /* gasBailout */); err != nil {
+				return fmt.Errorf("%w: blockNum=%d, txNum=%d, %s", err, blockNum, txNum, ibs.Error())
+			}
+			for _, l := range ibs.GetLogs(txn.Hash()) {
+				topics = topics[:0]
+				for i := range l.Topics {
+					topics = append(topics, l.Topics[i][:])
+				}
+				add(txNum, l.Address[:], topics)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+	}
+	return nil
+}
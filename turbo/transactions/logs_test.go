@@ -0,0 +1,44 @@
+package transactions_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ledgerwatch/erigon-lib/kv"
+	"github.com/ledgerwatch/erigon-lib/kv/iter"
+	"github.com/ledgerwatch/erigon-lib/kv/order"
+	"github.com/ledgerwatch/erigon-lib/kv/rawdbv3"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ledgerwatch/erigon/cmd/rpcdaemon/rpcdaemontest"
+	"github.com/ledgerwatch/erigon/turbo/transactions"
+)
+
+// TestWalkLogsFromHistory - re-executed logs are the same as logs index produced by execution
+func TestWalkLogsFromHistory(t *testing.T) {
+	m, _, _ := rpcdaemontest.CreateTestSentry(t)
+	if !m.HistoryV3 {
+		t.Skip("requires Erigon3 state history")
+	}
+	ctx := context.Background()
+	tx, err := m.DB.BeginRo(ctx)
+	require.NoError(t, err)
+	defer tx.Rollback()
+	ttx := tx.(kv.TemporalTx)
+
+	_, lastTxNum, err := rawdbv3.TxNums.Last(tx)
+	require.NoError(t, err)
+	walked := map[string][]uint64{}
+	require.NoError(t, transactions.WalkLogsFromHistory(ctx, ttx, m.ChainConfig, m.Engine, m.BlockReader, 0, lastTxNum+1, func(txNum uint64, addr []byte, topics [][]byte) {
+		if txNums := walked[string(addr)]; len(txNums) == 0 || txNums[len(txNums)-1] != txNum {
+			walked[string(addr)] = append(txNums, txNum)
+		}
+	}))
+	require.NotEmpty(t, walked)
+
+	for addr, txNums := range walked {
+		it, err := ttx.IndexRange(kv.LogAddrIdx, []byte(addr), -1, -1, order.Asc, kv.Unlim)
+		require.NoError(t, err)
+		require.Equal(t, iter.ToArrU64Must(it), txNums, "address %x", addr)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"math/big"
 	"time"
 
 	jsoniter "github.com/json-iterator/go"
@@ -142,6 +143,7 @@ func TraceTx(
 		err    error
 	)
 	var streaming bool
+	streamField := "structLogs"
 	switch {
 	case config != nil && config.Tracer != nil:
 		// Define a meaningful timeout of a single transaction trace
@@ -157,9 +159,15 @@ func TraceTx(
 		if config != nil && config.TracerConfig != nil {
 			cfg = *config.TracerConfig
 		}
-		if tracer, err = tracers.New(*config.Tracer, &tracers.Context{
-			TxHash: txCtx.TxHash,
-		}, cfg); err != nil {
+		tracerCtx := &tracers.Context{
+			TxHash:      txCtx.TxHash,
+			BlockNumber: new(big.Int).SetUint64(blockCtx.BlockNumber),
+		}
+		if ibs, ok := ibs.(*state.IntraBlockState); ok {
+			tracerCtx.BlockHash = ibs.BlockHash()
+			tracerCtx.TxIndex = ibs.TxIndex()
+		}
+		if tracer, err = tracers.New(*config.Tracer, tracerCtx, cfg); err != nil {
 			stream.WriteNil()
 			return err
 		}
@@ -170,7 +178,11 @@ func TraceTx(
 			tracer.(tracers.Tracer).Stop(errors.New("execution timeout"))
 		}()
 		defer cancel()
-		streaming = false
+		// Streaming tracers write their entries while executing, like the struct logger
+		if streamingTracer, ok := tracer.(tracers.StreamingTracer); ok {
+			streamField = streamingTracer.SetStream(stream)
+			streaming = true
+		}
 
 	case config == nil:
 		tracer = logger.NewJsonStreamLogger(nil, ctx, stream)
@@ -188,7 +200,7 @@ func TraceTx(
 	}
 	if streaming {
 		stream.WriteObjectStart()
-		stream.WriteObjectField("structLogs")
+		stream.WriteObjectField(streamField)
 		stream.WriteArrayStart()
 	}
 
@@ -229,6 +241,12 @@ func TraceTx(
 		stream.WriteObjectField("returnValue")
 		stream.WriteString(returnVal)
 		stream.WriteObjectEnd()
+		if streamingTracer, ok := tracer.(tracers.StreamingTracer); ok {
+			// Result is already written, only check for interruption
+			if _, err := streamingTracer.GetResult(); err != nil {
+				return err
+			}
+		}
 	} else {
 		if r, err1 := tracer.(tracers.Tracer).GetResult(); err1 == nil {
 			stream.Write(r)
@@ -1532,6 +1532,37 @@ func CalcRoot(logPrefix string, tx kv.Tx) (libcommon.Hash, error) {
 	return h, nil
 }
 
+// StorageRoot computes the storage root of an account in the latest state with the help of intermediate hashes,
+// the same way as eth_getProof does. Absent accounts have the empty root.
+func StorageRoot(tx kv.Tx, addr libcommon.Address) (libcommon.Hash, error) {
+	enc, err := tx.GetOne(kv.PlainState, addr[:])
+	if err != nil {
+		return libcommon.Hash{}, err
+	}
+	if len(enc) == 0 {
+		return EmptyRoot, nil
+	}
+	var a accounts.Account
+	if err = a.DecodeForStorage(enc); err != nil {
+		return libcommon.Hash{}, err
+	}
+	rl := NewRetainList(0)
+	pr, err := NewProofRetainer(addr, &a, nil, rl)
+	if err != nil {
+		return libcommon.Hash{}, err
+	}
+	loader := NewFlatDBTrieLoader("storage_root", rl, nil, nil, false)
+	loader.SetProofRetainer(pr)
+	if _, err = loader.CalcTrieRoot(tx, nil); err != nil {
+		return libcommon.Hash{}, err
+	}
+	proof, err := pr.ProofResult()
+	if err != nil {
+		return libcommon.Hash{}, err
+	}
+	return proof.StorageHash, nil
+}
+
 func makeCurrentKeyStr(k []byte) string {
 	var currentKeyStr string
 	if k == nil {